@@ -0,0 +1,501 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/spf13/cobra"
+
+	"github.com/s0ders/go-semver-release/v6/internal/appcontext"
+	"github.com/s0ders/go-semver-release/v6/internal/branch"
+	"github.com/s0ders/go-semver-release/v6/internal/event"
+	"github.com/s0ders/go-semver-release/v6/internal/forge"
+	"github.com/s0ders/go-semver-release/v6/internal/policy"
+	"github.com/s0ders/go-semver-release/v6/internal/remote"
+	"github.com/s0ders/go-semver-release/v6/internal/render"
+)
+
+const (
+	checkPass = "PASS"
+	checkFail = "FAIL"
+	checkSkip = "SKIP"
+)
+
+const (
+	doctorFormatText   = "text"
+	doctorFormatGitHub = "github"
+	doctorFormatSARIF  = "sarif"
+)
+
+var (
+	ErrDoctorFailed        = errors.New("some checks failed")
+	ErrUnknownReportFormat = errors.New("unknown report format")
+)
+
+func NewDoctorCmd(ctx *appcontext.AppContext) *cobra.Command {
+	var format string
+
+	doctorCmd := &cobra.Command{
+		Use:   "doctor [REPOSITORY_PATH_OR_URL]",
+		Short: "Check the configuration and credentials before releasing",
+		Long:  "Check that the configuration parses, the access token authenticates against the remote, the branches exist, the GPG keys load and sign, and the GitHub output is writable, then print a pass or fail report",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			write, err := reportWriter(format)
+			if err != nil {
+				return err
+			}
+
+			report := &doctorReport{}
+
+			repositories := checkConfiguration(ctx, report, args)
+
+			for _, repository := range repositories {
+				checkRemote(ctx, report, repository)
+			}
+
+			checkGPGKeys(ctx, report)
+			checkGitHubOutput(report)
+
+			err = write(report, cmd.OutOrStdout())
+			if err != nil {
+				return fmt.Errorf("writing report: %w", err)
+			}
+
+			if report.Failed() {
+				return ErrDoctorFailed
+			}
+
+			return nil
+		},
+	}
+
+	doctorCmd.Flags().StringVar(&format, "format", doctorFormatText, "Format of the report, either \"text\", \"github\" for workflow command annotations or \"sarif\" for code scanning")
+
+	return doctorCmd
+}
+
+// reportWriter returns the function writing the report of the doctor command in the given format.
+func reportWriter(format string) (func(*doctorReport, io.Writer) error, error) {
+	switch format {
+	case doctorFormatText:
+		return (*doctorReport).Write, nil
+	case doctorFormatGitHub:
+		return (*doctorReport).WriteGitHub, nil
+	case doctorFormatSARIF:
+		return (*doctorReport).WriteSARIF, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownReportFormat, format)
+	}
+}
+
+// doctorCheck is the outcome of a check made by the doctor command. File, if set, is the file the check is about, e.g.
+// the configuration file, to which annotations are attached.
+type doctorCheck struct {
+	status string
+	name   string
+	detail string
+	file   string
+}
+
+// doctorReport is the outcome of every check made by the doctor command.
+type doctorReport struct {
+	checks []doctorCheck
+}
+
+func (r *doctorReport) pass(name, detail string) {
+	r.checks = append(r.checks, doctorCheck{status: checkPass, name: name, detail: detail})
+}
+
+func (r *doctorReport) fail(name string, err error) {
+	r.failIn(name, "", err)
+}
+
+// failIn records a failed check about the given file.
+func (r *doctorReport) failIn(name, file string, err error) {
+	r.checks = append(r.checks, doctorCheck{status: checkFail, name: name, detail: err.Error(), file: file})
+}
+
+func (r *doctorReport) skip(name, detail string) {
+	r.checks = append(r.checks, doctorCheck{status: checkSkip, name: name, detail: detail})
+}
+
+// Failed returns whether any check failed.
+func (r *doctorReport) Failed() bool {
+	return slices.ContainsFunc(r.checks, func(c doctorCheck) bool { return c.status == checkFail })
+}
+
+// Write writes the report as a table, followed by the number of checks that passed and failed.
+func (r *doctorReport) Write(out io.Writer) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "STATUS\tCHECK\tDETAIL")
+
+	var passed, failed int
+
+	for _, c := range r.checks {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", c.status, c.name, c.detail)
+
+		switch c.status {
+		case checkPass:
+			passed++
+		case checkFail:
+			failed++
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\n%d passed, %d failed\n", passed, failed)
+
+	return nil
+}
+
+// WriteGitHub writes the failed checks as GitHub Actions error workflow commands, so that they are shown as annotations
+// of the workflow run and, for those about a file of the repository, of the pull request.
+func (r *doctorReport) WriteGitHub(out io.Writer) error {
+	for _, c := range r.checks {
+		if c.status != checkFail {
+			continue
+		}
+
+		properties := "title=" + escapeWorkflowProperty(c.name)
+		if c.file != "" {
+			properties += ",file=" + escapeWorkflowProperty(c.file)
+		}
+
+		_, err := fmt.Fprintf(out, "::error %s::%s\n", properties, escapeWorkflowData(c.detail))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// escapeWorkflowData escapes the message of a workflow command, which must hold on a single line.
+func escapeWorkflowData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeWorkflowProperty escapes the value of a property of a workflow command, which cannot contain the characters
+// separating the properties.
+func escapeWorkflowProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
+// sarifLog is the subset of a SARIF 2.1.0 log written by the doctor command.
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Version        string      `json:"version,omitempty"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation struct {
+		ArtifactLocation struct {
+			URI string `json:"uri"`
+		} `json:"artifactLocation"`
+	} `json:"physicalLocation"`
+}
+
+// WriteSARIF writes the report as a SARIF log, e.g. to be uploaded to GitHub code scanning. Every check is a rule and
+// every failed check a result.
+func (r *doctorReport) WriteSARIF(out io.Writer) error {
+	driver := sarifDriver{
+		Name:           "go-semver-release",
+		InformationURI: "https://github.com/s0ders/go-semver-release",
+		Version:        readBuildInfo().Version,
+		Rules:          []sarifRule{},
+	}
+
+	results := []sarifResult{}
+
+	for _, c := range r.checks {
+		if !slices.ContainsFunc(driver.Rules, func(rule sarifRule) bool { return rule.ID == c.name }) {
+			driver.Rules = append(driver.Rules, sarifRule{ID: c.name})
+		}
+
+		if c.status != checkFail {
+			continue
+		}
+
+		result := sarifResult{RuleID: c.name, Level: "error", Message: sarifMessage{Text: c.detail}}
+
+		if c.file != "" {
+			var location sarifLocation
+			location.PhysicalLocation.ArtifactLocation.URI = c.file
+			result.Locations = append(result.Locations, location)
+		}
+
+		results = append(results, result)
+	}
+
+	log := sarifLog{
+		Version: "2.1.0",
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(log)
+}
+
+// doctorRepository is a repository to release and its configured branches.
+type doctorRepository struct {
+	path     string
+	branches []branch.Branch
+}
+
+// checkConfiguration checks that every section of the configuration parses, and returns the repositories to release
+// along with their branches, either the configured targets or the single repository.
+func checkConfiguration(ctx *appcontext.AppContext, report *doctorReport, args []string) []doctorRepository {
+	var (
+		repositories []doctorRepository
+		errs         []error
+		err          error
+	)
+
+	if ctx.Rules, err = configureRules(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("loading rules configuration: %w", err))
+	}
+
+	if ctx.Projects, err = configureProjects(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("loading projects configuration: %w", err))
+	}
+
+	if ctx.TagAliases, err = configureTagAliases(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("loading tag aliases configuration: %w", err))
+	}
+
+	if ctx.Policies, err = policy.Unmarshall(ctx.PolicyFlag); err != nil {
+		errs = append(errs, fmt.Errorf("loading policies configuration: %w", err))
+	}
+
+	if ctx.RenderFiles, err = render.UnmarshallFiles(ctx.RenderFlag); err != nil {
+		errs = append(errs, fmt.Errorf("loading render configuration: %w", err))
+	}
+
+	if _, err = event.Unmarshall(ctx.EventsFlag); err != nil {
+		errs = append(errs, fmt.Errorf("loading events configuration: %w", err))
+	}
+
+	if err = forge.ValidateKind(ctx.ForgeFlag); err != nil {
+		errs = append(errs, err)
+	}
+
+	targets, err := configureTargets(ctx, args)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("loading targets configuration: %w", err))
+	}
+
+	if len(targets) == 0 {
+		ctx.Branches, err = configureBranches(ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("loading branches configuration: %w", err))
+		}
+
+		repositoryPath, err := configureGitHubEnvironment(ctx, args)
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			repositories = append(repositories, doctorRepository{path: repositoryPath, branches: ctx.Branches})
+		}
+	}
+
+	for _, t := range targets {
+		branches, err := branch.Unmarshall(t.Branches)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("loading target %q branches configuration: %w", t.Repository, err))
+			continue
+		}
+
+		repositories = append(repositories, doctorRepository{path: t.Repository, branches: branches})
+	}
+
+	// The settings that do not depend on the repository would be reported once per target, only the first error is.
+	for _, repository := range repositories {
+		if _, err = validateConfiguration(ctx, repository.path); err != nil {
+			errs = append(errs, err)
+			break
+		}
+	}
+
+	if len(errs) > 0 {
+		report.failIn("configuration", repositoryRelativePath(ctx.Viper.ConfigFileUsed()), errors.Join(errs...))
+	} else {
+		report.pass("configuration", "configuration parsed")
+	}
+
+	return repositories
+}
+
+// repositoryRelativePath returns the given path relative to the working directory, which is the root of the repository
+// in a CI job, so that annotations can be attached to the file. Paths outside the working directory are kept as is.
+func repositoryRelativePath(path string) string {
+	if path == "" {
+		return ""
+	}
+
+	if !filepath.IsAbs(path) {
+		return filepath.ToSlash(filepath.Clean(path))
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+
+	rel, err := filepath.Rel(wd, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(path)
+	}
+
+	return filepath.ToSlash(rel)
+}
+
+// checkRemote checks that the access token authenticates against the remote of a repository, then that its branches
+// exist on the remote. Listing the references of the remote only requires read access, write access is not checked.
+func checkRemote(ctx *appcontext.AppContext, report *doctorReport, repository doctorRepository) {
+	refs, err := remote.New(ctx.RemoteNameFlag, ctx.AccessTokenFlag).List(repository.path)
+	if err != nil {
+		report.fail("remote", fmt.Errorf("%s: %w", repository.path, err))
+		report.skip("branches", repository.path+": remote unavailable")
+		return
+	}
+
+	report.pass("remote", fmt.Sprintf("%s: %d references listed", repository.path, len(refs)))
+
+	var missing []string
+
+	for _, b := range repository.branches {
+		found := slices.ContainsFunc(refs, func(ref *plumbing.Reference) bool {
+			return ref.Name() == plumbing.NewBranchReferenceName(b.Name)
+		})
+
+		if !found {
+			missing = append(missing, b.Name)
+		}
+	}
+
+	if len(missing) > 0 {
+		report.fail("branches", fmt.Errorf("%s: branches not found on the remote: %s", repository.path, strings.Join(missing, ", ")))
+		return
+	}
+
+	report.pass("branches", fmt.Sprintf("%s: %d branches found", repository.path, len(repository.branches)))
+}
+
+// checkGPGKeys checks that the GPG keys signing tags, the default one and those of the branches and projects, load
+// and can sign.
+func checkGPGKeys(ctx *appcontext.AppContext, report *doctorReport) {
+	entity, err := configureGPGKey(ctx)
+	if err != nil {
+		report.fail("gpg-key", err)
+		return
+	}
+
+	signKeys, err := configureSignKeys(ctx)
+	if err != nil {
+		report.fail("gpg-key", err)
+		return
+	}
+
+	keys := make(map[string]*openpgp.Entity)
+	for path, key := range signKeys {
+		keys[path] = key
+	}
+
+	if entity != nil {
+		keys[ctx.GPGKeyPathFlag] = entity
+	}
+
+	if len(keys) == 0 {
+		report.skip("gpg-key", "no key configured")
+		return
+	}
+
+	paths := make([]string, 0, len(keys))
+	for path := range keys {
+		paths = append(paths, path)
+	}
+
+	slices.Sort(paths)
+
+	for _, path := range paths {
+		err = openpgp.DetachSign(io.Discard, keys[path], strings.NewReader("go-semver-release doctor"), nil)
+		if err != nil {
+			report.fail("gpg-key", fmt.Errorf("%s: signing: %w", path, err))
+			continue
+		}
+
+		report.pass("gpg-key", path+": key loaded and signed")
+	}
+}
+
+// checkGitHubOutput checks that the GitHub output file, if any, can be written to. The file is created by the runner,
+// a missing file failing the check rather than being created by it.
+func checkGitHubOutput(report *doctorReport) {
+	path, ok := os.LookupEnv("GITHUB_OUTPUT")
+	if !ok {
+		report.skip("github-output", "GITHUB_OUTPUT not set")
+		return
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		report.fail("github-output", err)
+		return
+	}
+
+	if err = f.Close(); err != nil {
+		report.fail("github-output", err)
+		return
+	}
+
+	report.pass("github-output", path+" is writable")
+}
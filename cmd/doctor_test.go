@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestDoctorCmd(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	keyPath := filepath.Join(t.TempDir(), "key.asc")
+	writeGPGKey(t, keyPath)
+
+	outputPath := filepath.Join(t.TempDir(), "output")
+	writeFile(t, outputPath, "")
+	t.Setenv("GITHUB_OUTPUT", outputPath)
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{BranchesConfiguration: `[{"name": "master"}]`, GPGPathConfiguration: keyPath})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("doctor", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.Contains(string(out), "PASS    configuration", "configuration should parse")
+	assert.Contains(string(out), "PASS    remote", "remote should be listed")
+	assert.Contains(string(out), "1 branches found", "branch should be found")
+	assert.Contains(string(out), keyPath+": key loaded and signed", "GPG key should sign")
+	assert.Contains(string(out), outputPath+" is writable", "GitHub output should be writable")
+	assert.Contains(string(out), "5 passed, 0 failed", "every check should pass")
+}
+
+func TestDoctorCmd_Failures(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	outputPath := filepath.Join(t.TempDir(), "output")
+	t.Setenv("GITHUB_OUTPUT", outputPath)
+
+	th := NewTestHelper(t)
+	err := th.SetFlag(BranchesConfiguration, `[{"name": "master"}, {"name": "next"}]`)
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("doctor", testRepository.Path)
+	assert.ErrorIs(err, ErrDoctorFailed, "failed checks should fail the command")
+
+	assert.Contains(string(out), "branches not found on the remote: next", "missing branch should be reported")
+	assert.Contains(string(out), "SKIP    gpg-key", "GPG key check should be skipped")
+	assert.Contains(string(out), "FAIL    github-output", "missing GitHub output should be reported")
+	assert.NoFileExists(outputPath, "the GitHub output should not be created")
+	assert.Contains(string(out), "2 passed, 2 failed", "failed checks should be counted")
+}
+
+func TestDoctorCmd_InvalidConfiguration(t *testing.T) {
+	assert := assertion.New(t)
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{BranchesConfiguration: `[{"name": "master"}]`, PolicyConfiguration: `[{"name": "invalid", "expression": "release.type =="}]`})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("doctor", t.TempDir())
+	assert.ErrorIs(err, ErrDoctorFailed, "invalid configuration should fail the command")
+	assert.Contains(string(out), "loading policies configuration", "invalid policy should be reported")
+}
+
+func TestDoctorCmd_InvalidReleaseSettings(t *testing.T) {
+	assert := assertion.New(t)
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{BranchesConfiguration: `[{"name": "master"}]`, ChangelogFormatConfiguration: "bogus"})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("doctor", t.TempDir())
+	assert.ErrorIs(err, ErrDoctorFailed, "invalid changelog format should fail the command")
+	assert.Contains(string(out), "FAIL    configuration", "configuration check should fail")
+	assert.Contains(string(out), "validating changelog configuration", "invalid changelog format should be reported")
+}
+
+func TestDoctorCmd_GitHubFormat(t *testing.T) {
+	assert := assertion.New(t)
+
+	cfgPath := filepath.Join(t.TempDir(), ".semver.yaml")
+	writeFile(t, cfgPath, `
+branches:
+  - name: master
+policy:
+  - name: invalid
+    expression: "release.type =="
+`)
+
+	th := NewTestHelper(t)
+	err := th.SetFlag("config", cfgPath)
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("doctor", t.TempDir(), "--format", "github")
+	assert.ErrorIs(err, ErrDoctorFailed, "invalid configuration should fail the command")
+
+	assert.Contains(string(out), "::error title=configuration,file="+filepath.ToSlash(cfgPath)+"::", "the failure should be annotated on the configuration file")
+	assert.Contains(string(out), "loading policies configuration")
+	assert.NotContains(string(out), "PASS", "only failed checks should be annotated")
+}
+
+func TestDoctorCmd_SARIFFormat(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlag(BranchesConfiguration, `[{"name": "master"}, {"name": "next"}]`)
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("doctor", testRepository.Path, "--format", "sarif")
+	assert.ErrorIs(err, ErrDoctorFailed, "failed checks should fail the command")
+
+	// The error of the command follows the report in the output.
+	var log sarifLog
+
+	err = json.NewDecoder(bytes.NewReader(out)).Decode(&log)
+	checkErr(t, err, "decoding SARIF log")
+
+	assert.Equal("2.1.0", log.Version)
+
+	if assert.Len(log.Runs, 1) {
+		assert.Contains(log.Runs[0].Tool.Driver.Rules, sarifRule{ID: "remote"}, "every check should be a rule")
+
+		if assert.Len(log.Runs[0].Results, 1, "only failed checks should be results") {
+			result := log.Runs[0].Results[0]
+
+			assert.Equal("branches", result.RuleID)
+			assert.Equal("error", result.Level)
+			assert.Contains(result.Message.Text, "branches not found on the remote: next")
+		}
+	}
+}
+
+func TestDoctorCmd_UnknownFormat(t *testing.T) {
+	assert := assertion.New(t)
+
+	th := NewTestHelper(t)
+
+	_, err := th.ExecuteCommand("doctor", t.TempDir(), "--format", "junit")
+	assert.ErrorIs(err, ErrUnknownReportFormat)
+}
+
+func TestEscapeWorkflowProperty(t *testing.T) {
+	assert := assertion.New(t)
+
+	assert.Equal("C%3A\\a%2Cb%25%0A", escapeWorkflowProperty("C:\\a,b%\n"))
+	assert.Equal("C:\\a,b%25%0A", escapeWorkflowData("C:\\a,b%\n"))
+}
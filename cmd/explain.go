@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/s0ders/go-semver-release/v6/internal/appcontext"
+	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
+	"github.com/s0ders/go-semver-release/v6/internal/parser"
+	"github.com/s0ders/go-semver-release/v6/internal/remote"
+	"github.com/s0ders/go-semver-release/v6/internal/tag"
+)
+
+func NewExplainCmd(ctx *appcontext.AppContext) *cobra.Command {
+	explainCmd := &cobra.Command{
+		Use:   "explain <REPOSITORY_PATH_OR_URL> <TAG_OR_VERSION>",
+		Short: "Explain how an existing tag semantic version was produced",
+		Long:  "Print which commits made between an existing tag and the previous one contributed which bump to its semantic version according to the current rules",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			ctx.Rules, err = configureRules(ctx)
+			if err != nil {
+				return fmt.Errorf("loading rules configuration: %w", err)
+			}
+
+			ctx.Projects, err = configureProjects(ctx)
+			if err != nil {
+				return fmt.Errorf("loading projects configuration: %w", err)
+			}
+
+			ctx.TagAliases, err = configureTagAliases(ctx)
+			if err != nil {
+				return fmt.Errorf("loading tag aliases configuration: %w", err)
+			}
+
+			ctx.Workspace = configureWorkspace(ctx)
+			defer func() {
+				err = errors.Join(err, cleanupWorkspace(ctx))
+			}()
+
+			dir, err := ctx.Workspace.Create()
+			if err != nil {
+				return err
+			}
+
+			repository, err := remote.New(ctx.RemoteNameFlag, ctx.AccessTokenFlag).CloneTo(args[0], dir)
+			if err != nil {
+				return fmt.Errorf("cloning Git repository: %w", err)
+			}
+
+			tagName := args[1]
+			project := tagProject(ctx.Projects, tagName, tag.EpochNamespace(ctx.TagNamespaceFlag, ctx.EpochFlag))
+
+			explanation, err := parser.New(ctx).Explain(repository, tagName, project)
+			if err != nil {
+				return fmt.Errorf("explaining tag: %w", err)
+			}
+
+			matches, err := explanation.Matches()
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+
+			previousTag := "none"
+			if explanation.PreviousTag != nil {
+				previousTag = explanation.PreviousTag.Name
+			}
+
+			fmt.Fprintf(out, "Tag: %s\nPrevious tag: %s\n\n", explanation.Tag.Name, previousTag)
+
+			if len(explanation.Contributions) == 0 {
+				fmt.Fprintln(out, "No commit triggers a release under the current rules.")
+			} else {
+				w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+				fmt.Fprintln(w, "COMMIT\tRELEASE\tVERSION\tMESSAGE")
+				for _, contribution := range explanation.Contributions {
+					message, _, _ := strings.Cut(contribution.Commit.Message, "\n")
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", contribution.Commit.Hash.String()[:7], contribution.Release, contribution.Semver, message)
+				}
+
+				if err = w.Flush(); err != nil {
+					return fmt.Errorf("writing explanation: %w", err)
+				}
+			}
+
+			fmt.Fprintf(out, "\nComputed version: %s\n", explanation.Semver)
+
+			if !matches {
+				fmt.Fprintln(out, "The computed version differs from the tag, which was likely produced under different rules or created manually.")
+			}
+
+			return nil
+		},
+	}
+
+	return explainCmd
+}
+
+// tagProject returns the monorepo project a tag belongs to, based on the project name prefixing the tag name.
+func tagProject(projects []monorepo.Project, tagName, namespace string) monorepo.Project {
+	tagName, _ = tag.InNamespace(namespace, tagName)
+
+	for _, project := range projects {
+		if strings.HasPrefix(tagName, project.Name+"-") {
+			return project
+		}
+	}
+
+	return monorepo.Project{}
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestExplainCmd(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"fix", "feat"})
+
+	hash, err := testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit")
+
+	err = testRepository.AddTag("v0.1.1", hash)
+	checkErr(t, err, "adding tag")
+
+	_, err = testRepository.AddCommit("chore")
+	checkErr(t, err, "adding commit")
+
+	hash, err = testRepository.AddCommit("feat!")
+	checkErr(t, err, "adding commit")
+
+	err = testRepository.AddTag("v1.0.0", hash)
+	checkErr(t, err, "adding tag")
+
+	th := NewTestHelper(t)
+
+	out, err := th.ExecuteCommand("explain", testRepository.Path, "1.0.0")
+	checkErr(t, err, "executing command")
+
+	assert.Contains(string(out), "Previous tag: v0.1.1", "previous tag should be printed")
+	assert.Contains(string(out), "major", "breaking change should be listed")
+	assert.NotContains(string(out), "chore", "commit without release should not be listed")
+	assert.Contains(string(out), "Computed version: 1.0.0", "computed version should be printed")
+	assert.NotContains(string(out), "differs", "computed version should match the tag")
+}
+
+func TestExplainCmd_UnknownTag(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	th := NewTestHelper(t)
+
+	_, err := th.ExecuteCommand("explain", testRepository.Path, "v1.0.0")
+	assert.ErrorContains(err, "semver tag not found", "unknown tag should not be explained")
+}
@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// extendsKey is the configuration file key referencing a base configuration.
+const extendsKey = "extends"
+
+var (
+	ErrExtendsCycle = errors.New("configuration extends itself")
+	ErrLocalExtends = errors.New("remote configuration extends a local file")
+)
+
+var extendsHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// mergeExtendedConfig merges the base configuration referenced by the "extends" key of the configuration file, if any,
+// with the configuration file. Values of the configuration file take precedence, maps being merged recursively and
+// other values, including lists, being replaced as a whole. A base configuration can itself extend another one.
+func mergeExtendedConfig(v *viper.Viper) error {
+	extends := v.GetString(extendsKey)
+	if extends == "" {
+		return nil
+	}
+
+	location := resolveConfigLocation(v.ConfigFileUsed(), extends)
+
+	base, err := loadExtendedConfig(location, map[string]bool{v.ConfigFileUsed(): true})
+	if err != nil {
+		return fmt.Errorf("loading extended configuration %q: %w", extends, err)
+	}
+
+	override := v.AllSettings()
+	delete(override, extendsKey)
+
+	return v.MergeConfigMap(deepMerge(base, override))
+}
+
+// loadExtendedConfig reads a configuration file from a path or an HTTP(S) URL, along with the configuration it
+// extends. A configuration read from a URL can only extend another URL.
+func loadExtendedConfig(location string, visited map[string]bool) (map[string]any, error) {
+	if visited[location] {
+		return nil, fmt.Errorf("%w: %q", ErrExtendsCycle, location)
+	}
+
+	visited[location] = true
+
+	content, err := readConfigLocation(location)
+	if err != nil {
+		return nil, err
+	}
+
+	configType := strings.TrimPrefix(filepath.Ext(location), ".")
+	if !slices.Contains(viper.SupportedExts, configType) {
+		configType = configFileFormat
+	}
+
+	v := viper.New()
+	v.SetConfigType(configType)
+
+	if err = v.ReadConfig(bytes.NewReader(content)); err != nil {
+		return nil, fmt.Errorf("parsing %q: %w", location, err)
+	}
+
+	settings := v.AllSettings()
+
+	extends, ok := settings[extendsKey].(string)
+	if !ok || extends == "" {
+		return settings, nil
+	}
+
+	delete(settings, extendsKey)
+
+	// A configuration fetched from a URL must not pull in files of the machine it is loaded on.
+	resolved := resolveConfigLocation(location, extends)
+	if isURL(location) && !isURL(resolved) {
+		return nil, fmt.Errorf("%w: %q extends %q", ErrLocalExtends, location, extends)
+	}
+
+	base, err := loadExtendedConfig(resolved, visited)
+	if err != nil {
+		return nil, err
+	}
+
+	return deepMerge(base, settings), nil
+}
+
+func readConfigLocation(location string) ([]byte, error) {
+	if !isURL(location) {
+		content, err := os.ReadFile(location)
+		if err != nil {
+			return nil, fmt.Errorf("reading %q: %w", location, err)
+		}
+		return content, nil
+	}
+
+	resp, err := extendsHTTPClient.Get(location)
+	if err != nil {
+		return nil, fmt.Errorf("fetching %q: %w", location, err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching %q: unexpected status %q", location, resp.Status)
+	}
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading %q: %w", location, err)
+	}
+
+	return content, nil
+}
+
+// resolveConfigLocation resolves a configuration location relatively to the location of the configuration referencing
+// it, so that a base configuration can reference another one with a relative path.
+func resolveConfigLocation(from, location string) string {
+	if isURL(location) || filepath.IsAbs(location) {
+		return location
+	}
+
+	if isURL(from) {
+		base, err := url.Parse(from)
+		if err != nil {
+			return location
+		}
+
+		relative, err := url.Parse(location)
+		if err != nil {
+			return location
+		}
+
+		return base.ResolveReference(relative).String()
+	}
+
+	if from == "" {
+		return location
+	}
+
+	return filepath.Join(filepath.Dir(from), location)
+}
+
+func isURL(location string) bool {
+	return strings.HasPrefix(location, "https://") || strings.HasPrefix(location, "http://")
+}
+
+// deepMerge returns the merge of two configuration maps, values of override taking precedence over the ones of base.
+func deepMerge(base, override map[string]any) map[string]any {
+	merged := make(map[string]any, len(base)+len(override))
+
+	for key, value := range base {
+		merged[key] = value
+	}
+
+	for key, value := range override {
+		baseMap, baseIsMap := merged[key].(map[string]any)
+		overrideMap, overrideIsMap := value.(map[string]any)
+
+		if baseIsMap && overrideIsMap {
+			merged[key] = deepMerge(baseMap, overrideMap)
+			continue
+		}
+
+		merged[key] = value
+	}
+
+	return merged
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestExtends_FileAndURL(t *testing.T) {
+	assert := assertion.New(t)
+
+	// Organization-wide base configuration, itself extending a remote one.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("git-email: platform@acme.com\ngit-name: Platform Robot\n"))
+	}))
+	t.Cleanup(server.Close)
+
+	cfgDir := t.TempDir()
+
+	writeFile(t, filepath.Join(cfgDir, "base", "org.yaml"), `
+extends: `+server.URL+`/remote.yaml
+git-name: Org Robot
+rules:
+  minor:
+    - feat
+  patch:
+    - fix
+branches:
+  - name: main
+`)
+
+	cfgPath := filepath.Join(cfgDir, ".semver.yaml")
+	writeFile(t, cfgPath, `
+extends: ./base/org.yaml
+rules:
+  patch:
+    - fix
+    - perf
+branches:
+  - name: master
+`)
+
+	testRepository := NewTestRepository(t, []string{"perf"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{"config": cfgPath, DryRunConfiguration: "true"})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.Equal("platform@acme.com", th.Ctx.GitEmailFlag, "value only defined remotely should be inherited")
+	assert.Equal("Org Robot", th.Ctx.GitNameFlag, "closest base configuration should take precedence")
+	assert.Equal([]string{"feat"}, th.Ctx.RulesFlag["minor"], "maps should be merged")
+	assert.Equal([]string{"fix", "perf"}, th.Ctx.RulesFlag["patch"], "lists should be replaced by the repository configuration")
+	assert.Equal("master", th.Ctx.Branches[0].Name, "repository branches should take precedence")
+	assert.Len(th.Ctx.Branches, 1)
+}
+
+func TestExtends_Cycle(t *testing.T) {
+	assert := assertion.New(t)
+
+	cfgDir := t.TempDir()
+
+	writeFile(t, filepath.Join(cfgDir, "a.yaml"), "extends: b.yaml\n")
+	writeFile(t, filepath.Join(cfgDir, "b.yaml"), "extends: a.yaml\n")
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlag("config", filepath.Join(cfgDir, "a.yaml"))
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, ErrExtendsCycle)
+}
+
+func TestExtends_RemoteExtendsLocalFile(t *testing.T) {
+	assert := assertion.New(t)
+
+	secretPath := filepath.Join(t.TempDir(), "secret.yaml")
+	writeFile(t, secretPath, "git-name: Local Robot\n")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("extends: " + secretPath + "\n"))
+	}))
+	t.Cleanup(server.Close)
+
+	cfgPath := filepath.Join(t.TempDir(), ".semver.yaml")
+	writeFile(t, cfgPath, "extends: "+server.URL+"/remote.yaml\n")
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlag("config", cfgPath)
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, ErrLocalExtends, "a remote configuration should not extend a local file")
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	err := os.MkdirAll(filepath.Dir(path), 0o755)
+	checkErr(t, err, "creating directory")
+
+	err = os.WriteFile(path, []byte(content), 0o644)
+	checkErr(t, err, "writing file")
+}
@@ -0,0 +1,384 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/s0ders/go-semver-release/v6/internal/appcontext"
+)
+
+var ErrConfigExists = errors.New("configuration file already exists")
+
+var (
+	// stableBranchNames are the usual names of a repository main branch, by order of preference.
+	stableBranchNames = []string{"main", "master", "trunk"}
+	// prereleaseBranchNames are the usual names of branches producing prereleases.
+	prereleaseBranchNames = []string{"alpha", "beta", "canary", "next", "preview", "rc"}
+	// projectManifests are the files marking the root of a project in a monorepo.
+	projectManifests = []string{"Cargo.toml", "build.gradle", "composer.json", "go.mod", "package.json", "pom.xml", "pyproject.toml"}
+	// ignoredDirectories are never inspected when looking for projects.
+	ignoredDirectories = []string{"node_modules", "testdata", "third_party", "vendor"}
+)
+
+var tagVersionRegex = regexp.MustCompile(`(\d+)\.(\d+)\.(\d+)`)
+
+// initConfig is the starter configuration written by the init command.
+type initConfig struct {
+	Rules     map[string][]string `yaml:"rules"`
+	Branches  []initBranch        `yaml:"branches"`
+	TagPrefix string              `yaml:"tag-prefix"`
+	Monorepo  []initProject       `yaml:"monorepo,omitempty"`
+}
+
+type initBranch struct {
+	Name       string `yaml:"name"`
+	Prerelease bool   `yaml:"prerelease,omitempty"`
+}
+
+type initProject struct {
+	Name string `yaml:"name"`
+	Path string `yaml:"path"`
+}
+
+func NewInitCmd(ctx *appcontext.AppContext) *cobra.Command {
+	var (
+		output      string
+		force       bool
+		interactive bool
+	)
+
+	initCmd := &cobra.Command{
+		Use:   "init [REPOSITORY_PATH]",
+		Short: "Write a starter configuration file for a repository",
+		Long:  "Inspect the branches, existing tags and project layout of a local repository and write a starter configuration file, optionally asking to confirm each detected value",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			repositoryPath := "."
+			if len(args) == 1 {
+				repositoryPath = args[0]
+			}
+
+			if output == "" {
+				output = filepath.Join(repositoryPath, defaultConfigFile+"."+configFileFormat)
+			}
+
+			if _, err := os.Stat(output); err == nil && !force {
+				return fmt.Errorf("%w: %q", ErrConfigExists, output)
+			}
+
+			repository, err := git.PlainOpen(repositoryPath)
+			if err != nil {
+				return fmt.Errorf("opening Git repository: %w", err)
+			}
+
+			config, err := detectConfig(repository, repositoryPath)
+			if err != nil {
+				return err
+			}
+
+			if interactive {
+				config, err = promptConfig(cmd.InOrStdin(), cmd.OutOrStdout(), config)
+				if err != nil {
+					return fmt.Errorf("reading answers: %w", err)
+				}
+			}
+
+			content, err := marshalInitConfig(config)
+			if err != nil {
+				return err
+			}
+
+			if err = os.WriteFile(output, content, 0o644); err != nil {
+				return fmt.Errorf("writing configuration file: %w", err)
+			}
+
+			ctx.Logger.Info().Str("path", output).Msg("configuration file written")
+
+			return nil
+		},
+	}
+
+	initCmd.Flags().StringVarP(&output, "output", "o", "", "Path of the written configuration file (default \"<REPOSITORY_PATH>/"+defaultConfigFile+"."+configFileFormat+"\")")
+	initCmd.Flags().BoolVar(&force, "force", false, "Overwrite an existing configuration file")
+	initCmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Ask to confirm or change each detected value")
+
+	return initCmd
+}
+
+// detectConfig builds a starter configuration from the branches, tags and files of a repository.
+func detectConfig(repository *git.Repository, path string) (initConfig, error) {
+	config := initConfig{
+		Rules: map[string][]string{
+			"minor": {"feat"},
+			"patch": {"fix", "perf", "revert"},
+		},
+	}
+
+	branches, err := detectBranches(repository)
+	if err != nil {
+		return config, fmt.Errorf("detecting branches: %w", err)
+	}
+
+	config.Branches = branches
+
+	projects, err := detectProjects(path)
+	if err != nil {
+		return config, fmt.Errorf("detecting projects: %w", err)
+	}
+
+	config.Monorepo = projects
+
+	config.TagPrefix, err = detectTagPrefix(repository, projects)
+	if err != nil {
+		return config, fmt.Errorf("detecting tag prefix: %w", err)
+	}
+
+	return config, nil
+}
+
+// detectBranches returns the stable branch of the repository, along with the branches whose name is commonly used
+// for prereleases. Local and remote-tracking branches are both considered.
+func detectBranches(repository *git.Repository) ([]initBranch, error) {
+	names := make(map[string]bool)
+
+	refs, err := repository.References()
+	if err != nil {
+		return nil, err
+	}
+
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		switch {
+		case ref.Name().IsBranch():
+			names[ref.Name().Short()] = true
+		case ref.Name().IsRemote():
+			_, name, _ := strings.Cut(ref.Name().Short(), "/")
+			if name != "HEAD" {
+				names[name] = true
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var branches []initBranch
+
+	for _, name := range stableBranchNames {
+		if names[name] {
+			branches = append(branches, initBranch{Name: name})
+			break
+		}
+	}
+
+	if len(branches) == 0 {
+		head, err := repository.Head()
+		if err != nil {
+			return nil, fmt.Errorf("reading HEAD: %w", err)
+		}
+		branches = append(branches, initBranch{Name: head.Name().Short()})
+	}
+
+	for _, name := range prereleaseBranchNames {
+		if names[name] {
+			branches = append(branches, initBranch{Name: name, Prerelease: true})
+		}
+	}
+
+	return branches, nil
+}
+
+// detectProjects returns the directories, up to two levels below the repository root, containing a project manifest.
+// A repository is only considered a monorepo if it contains at least two such projects.
+func detectProjects(root string) ([]initProject, error) {
+	var projects []initProject
+
+	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.IsDir() || path == root {
+			return nil
+		}
+
+		if strings.HasPrefix(d.Name(), ".") || slices.Contains(ignoredDirectories, d.Name()) {
+			return filepath.SkipDir
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		for _, manifest := range projectManifests {
+			if _, err = os.Stat(filepath.Join(path, manifest)); err == nil {
+				projects = append(projects, initProject{Name: d.Name(), Path: filepath.ToSlash(rel)})
+				return filepath.SkipDir
+			}
+		}
+
+		if strings.Count(filepath.ToSlash(rel), "/") >= 1 {
+			return filepath.SkipDir
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(projects) < 2 {
+		return nil, nil
+	}
+
+	return projects, nil
+}
+
+// detectTagPrefix returns the most common prefix among the existing version tags, ignoring the project name of
+// monorepo tags, or "v" if the repository has no version tag.
+func detectTagPrefix(repository *git.Repository, projects []initProject) (string, error) {
+	counts := make(map[string]int)
+
+	tags, err := repository.Tags()
+	if err != nil {
+		return "", err
+	}
+
+	err = tags.ForEach(func(ref *plumbing.Reference) error {
+		name := ref.Name().Short()
+
+		for _, project := range projects {
+			name = strings.TrimPrefix(name, project.Name+"-")
+		}
+
+		loc := tagVersionRegex.FindStringIndex(name)
+		if loc == nil {
+			return nil
+		}
+
+		counts[name[:loc[0]]]++
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if len(counts) == 0 {
+		return "v", nil
+	}
+
+	prefixes := make([]string, 0, len(counts))
+	for prefix := range counts {
+		prefixes = append(prefixes, prefix)
+	}
+
+	sort.Slice(prefixes, func(i, j int) bool {
+		if counts[prefixes[i]] != counts[prefixes[j]] {
+			return counts[prefixes[i]] > counts[prefixes[j]]
+		}
+		return prefixes[i] < prefixes[j]
+	})
+
+	return prefixes[0], nil
+}
+
+// promptConfig asks to confirm or change each detected value, an empty answer keeping the detected one.
+func promptConfig(in io.Reader, out io.Writer, config initConfig) (initConfig, error) {
+	scanner := bufio.NewScanner(in)
+
+	ask := func(question, current string) (string, error) {
+		fmt.Fprintf(out, "%s [%s]: ", question, current)
+
+		if !scanner.Scan() {
+			return current, scanner.Err()
+		}
+
+		answer := strings.TrimSpace(scanner.Text())
+		if answer == "" {
+			return current, nil
+		}
+
+		return answer, nil
+	}
+
+	stable, err := ask("Release branch", config.Branches[0].Name)
+	if err != nil {
+		return config, err
+	}
+
+	var prereleases []string
+	for _, b := range config.Branches[1:] {
+		prereleases = append(prereleases, b.Name)
+	}
+
+	answer, err := ask("Prerelease branches, comma separated, \"-\" for none", strings.Join(prereleases, ","))
+	if err != nil {
+		return config, err
+	}
+
+	config.Branches = []initBranch{{Name: stable}}
+
+	if answer != "-" {
+		for _, name := range strings.Split(answer, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				config.Branches = append(config.Branches, initBranch{Name: name, Prerelease: true})
+			}
+		}
+	}
+
+	config.TagPrefix, err = ask("Tag prefix", config.TagPrefix)
+	if err != nil {
+		return config, err
+	}
+
+	if len(config.Monorepo) > 0 {
+		var paths []string
+		for _, project := range config.Monorepo {
+			paths = append(paths, project.Path)
+		}
+
+		fmt.Fprintf(out, "Detected projects: %s\n", strings.Join(paths, ", "))
+
+		answer, err = ask("Configure as a monorepo (yes/no)", "yes")
+		if err != nil {
+			return config, err
+		}
+
+		if strings.HasPrefix(strings.ToLower(answer), "n") {
+			config.Monorepo = nil
+		}
+	}
+
+	return config, nil
+}
+
+func marshalInitConfig(config initConfig) ([]byte, error) {
+	var sb strings.Builder
+
+	encoder := yaml.NewEncoder(&sb)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(config); err != nil {
+		return nil, fmt.Errorf("marshaling configuration: %w", err)
+	}
+
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("marshaling configuration: %w", err)
+	}
+
+	return []byte(sb.String()), nil
+}
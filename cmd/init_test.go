@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestInitCmd(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	_, err := testRepository.AddCommitWithContent("feat", "services/foo/go.mod", "module foo")
+	checkErr(t, err, "adding commit")
+
+	hash, err := testRepository.AddCommitWithContent("feat", "bar/package.json", "{}")
+	checkErr(t, err, "adding commit")
+
+	err = testRepository.AddTag("foo-release-1.0.0", hash)
+	checkErr(t, err, "adding tag")
+
+	err = testRepository.AddTag("bar-release-0.1.0", hash)
+	checkErr(t, err, "adding tag")
+
+	err = testRepository.AddTag("v0.0.1", hash)
+	checkErr(t, err, "adding tag")
+
+	err = testRepository.Storer.SetReference(plumbing.NewHashReference("refs/heads/rc", hash))
+	checkErr(t, err, "creating branch")
+
+	th := NewTestHelper(t)
+
+	_, err = th.ExecuteCommand("init", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	content, err := os.ReadFile(filepath.Join(testRepository.Path, ".semver.yaml"))
+	checkErr(t, err, "reading configuration file")
+
+	expected := `rules:
+  minor:
+    - feat
+  patch:
+    - fix
+    - perf
+    - revert
+branches:
+  - name: master
+  - name: rc
+    prerelease: true
+tag-prefix: release-
+monorepo:
+  - name: bar
+    path: bar
+  - name: foo
+    path: services/foo
+`
+
+	assert.Equal(expected, string(content))
+
+	_, err = th.ExecuteCommand("init", testRepository.Path)
+	assert.ErrorIs(err, ErrConfigExists, "existing configuration should not be overwritten")
+}
+
+func TestInitCmd_Interactive(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	output := filepath.Join(t.TempDir(), "config.yaml")
+
+	th := NewTestHelper(t)
+	th.Cmd.SetIn(strings.NewReader("main\nbeta, alpha\n\n"))
+
+	out, err := th.ExecuteCommand("init", testRepository.Path, "--interactive", "--output", output)
+	checkErr(t, err, "executing command")
+
+	assert.Contains(string(out), "Release branch [master]")
+
+	content, err := os.ReadFile(output)
+	checkErr(t, err, "reading configuration file")
+
+	assert.Contains(string(content), "branches:\n  - name: main\n  - name: beta\n    prerelease: true\n  - name: alpha\n    prerelease: true\n")
+	assert.Contains(string(content), "tag-prefix: v\n", "detected tag prefix should be kept")
+	assert.NotContains(string(content), "monorepo")
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/spf13/viper"
+)
+
+var ErrUndefinedVariable = errors.New("undefined environment variable")
+
+// variableRegex matches the "${NAME}" and "${NAME:-default}" references to environment variables, and the "$$" escape
+// sequence written as a literal "$".
+var variableRegex = regexp.MustCompile(`\$\$|\$\{([A-Za-z_][A-Za-z0-9_]*)(?::-([^}]*))?\}`)
+
+// interpolateConfig replaces the references to environment variables in the values of the configuration file, so that
+// a single file can serve several environments, e.g. "build-metadata: ${BUILD_NUMBER}". An undefined variable without
+// default value is replaced by an empty string, or rejected in strict mode.
+func interpolateConfig(v *viper.Viper, strict bool) error {
+	interpolated := make(map[string]any)
+
+	for key, value := range v.AllSettings() {
+		result, changed, err := interpolate(key, value, strict)
+		if err != nil {
+			return err
+		}
+
+		if changed {
+			interpolated[key] = result
+		}
+	}
+
+	if len(interpolated) == 0 {
+		return nil
+	}
+
+	return v.MergeConfigMap(interpolated)
+}
+
+// interpolate returns a copy of a configuration value in which references to environment variables are replaced by
+// their value, along with whether any reference was replaced.
+func interpolate(key string, value any, strict bool) (any, bool, error) {
+	switch typed := value.(type) {
+	case string:
+		if !variableRegex.MatchString(typed) {
+			return typed, false, nil
+		}
+
+		var err error
+
+		result := variableRegex.ReplaceAllStringFunc(typed, func(reference string) string {
+			if reference == "$$" {
+				return "$"
+			}
+
+			// The default value is used if the variable is unset or empty, as in shells.
+			match := variableRegex.FindStringSubmatchIndex(reference)
+			name := reference[match[2]:match[3]]
+			hasDefault := match[4] >= 0
+
+			if env, ok := os.LookupEnv(name); ok && (env != "" || !hasDefault) {
+				return env
+			}
+
+			if hasDefault {
+				return reference[match[4]:match[5]]
+			}
+
+			if strict && err == nil {
+				err = fmt.Errorf("interpolating %q: %w: %q", key, ErrUndefinedVariable, name)
+			}
+
+			return ""
+		})
+		if err != nil {
+			return nil, false, err
+		}
+
+		return result, true, nil
+	case map[string]any:
+		result := make(map[string]any, len(typed))
+		changed := false
+
+		for k, item := range typed {
+			interpolated, itemChanged, err := interpolate(key+"."+k, item, strict)
+			if err != nil {
+				return nil, false, err
+			}
+
+			result[k] = interpolated
+			changed = changed || itemChanged
+		}
+
+		return result, changed, nil
+	case []any:
+		result := make([]any, len(typed))
+		changed := false
+
+		for i, item := range typed {
+			interpolated, itemChanged, err := interpolate(fmt.Sprintf("%s[%d]", key, i), item, strict)
+			if err != nil {
+				return nil, false, err
+			}
+
+			result[i] = interpolated
+			changed = changed || itemChanged
+		}
+
+		return result, changed, nil
+	default:
+		return value, false, nil
+	}
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestInterpolate_Config(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Setenv("CI_ROBOT", "Robot")
+	t.Setenv("CI_BUILD", "42")
+	t.Setenv("CI_EMPTY", "")
+	t.Setenv("GO_SEMVER_RELEASE_ACCESS_TOKEN", "")
+
+	cfgPath := filepath.Join(t.TempDir(), ".semver.yaml")
+	writeFile(t, cfgPath, `
+git-name: ${CI_ROBOT}
+git-email: ${CI_EMPTY:-robot@example.com}
+build-metadata: build.${CI_BUILD}
+tag-prefix: $${CI_BUILD}
+branches:
+  - name: ${CI_BRANCH:-master}
+`)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{"config": cfgPath, DryRunConfiguration: "true"})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.Equal("Robot", th.Ctx.GitNameFlag)
+	assert.Equal("robot@example.com", th.Ctx.GitEmailFlag, "default value should be used for empty variables")
+	assert.Equal("build.42", th.Ctx.BuildMetadataFlag)
+	assert.Equal("${CI_BUILD}", th.Ctx.TagPrefixFlag, "escaped references should not be interpolated")
+	assert.Equal("master", th.Ctx.Branches[0].Name, "nested values should be interpolated")
+}
+
+func TestInterpolate_Config_Strict(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Setenv("GO_SEMVER_RELEASE_ACCESS_TOKEN", "")
+
+	cfgPath := filepath.Join(t.TempDir(), ".semver.yaml")
+	writeFile(t, cfgPath, `
+git-name: Robot ${CI_UNDEFINED}
+branches:
+  - name: master
+`)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{"config": cfgPath, DryRunConfiguration: "true"})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.Equal("Robot ", th.Ctx.GitNameFlag, "undefined variables should be empty")
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{"config": cfgPath, DryRunConfiguration: "true", StrictEnvConfiguration: "true"})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, ErrUndefinedVariable)
+	assert.ErrorContains(err, `"git-name"`)
+}
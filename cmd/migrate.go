@@ -0,0 +1,248 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/s0ders/go-semver-release/v6/internal/appcontext"
+)
+
+// Keys of legacy configuration formats.
+const (
+	legacyReleaseRulesKey  = "releaseRules"
+	legacyRulesPathKey     = "rules-path"
+	legacyReleaseBranchKey = "release-branch"
+)
+
+// migrateKnownKeys are the keys of the current configuration format that are not flags.
+var migrateKnownKeys = []string{extendsKey}
+
+func NewMigrateConfigCmd(ctx *appcontext.AppContext) *cobra.Command {
+	var output string
+
+	migrateCmd := &cobra.Command{
+		Use:   "migrate-config <CONFIG_FILE_PATH>",
+		Short: "Rewrite a configuration file from a previous format to the current one",
+		Long:  "Convert the release rules and branches of a configuration file written for a previous major version to the current format, reporting the constructs that cannot be converted",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			content, err := os.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("reading configuration file: %w", err)
+			}
+
+			var config map[string]any
+
+			if err = yaml.Unmarshal(content, &config); err != nil {
+				return fmt.Errorf("parsing configuration file: %w", err)
+			}
+
+			m := &configMigration{dir: filepath.Dir(args[0]), isKnownKey: func(key string) bool {
+				return cmd.Root().PersistentFlags().Lookup(key) != nil
+			}}
+
+			migrated, err := m.migrate(config)
+			if err != nil {
+				return fmt.Errorf("migrating configuration file: %w", err)
+			}
+
+			for _, report := range m.reports {
+				ctx.Logger.Warn().Msg(report)
+			}
+
+			if !m.changed {
+				ctx.Logger.Info().Msg("configuration file already uses the current format")
+			}
+
+			buf := &bytes.Buffer{}
+
+			encoder := yaml.NewEncoder(buf)
+			encoder.SetIndent(2)
+
+			if err = encoder.Encode(migrated); err != nil {
+				return fmt.Errorf("marshaling configuration: %w", err)
+			}
+
+			if err = encoder.Close(); err != nil {
+				return fmt.Errorf("marshaling configuration: %w", err)
+			}
+
+			if output == "" {
+				_, err = cmd.OutOrStdout().Write(buf.Bytes())
+				return err
+			}
+
+			if err = os.WriteFile(output, buf.Bytes(), 0o644); err != nil {
+				return fmt.Errorf("writing configuration file: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	migrateCmd.Flags().StringVarP(&output, "output", "o", "", "Path of the migrated configuration file, which may be the original one (default to standard output)")
+
+	return migrateCmd
+}
+
+// configMigration converts a configuration map to the current format, recording what was changed and what could not
+// be converted.
+type configMigration struct {
+	dir        string
+	isKnownKey func(string) bool
+	changed    bool
+	reports    []string
+}
+
+func (m *configMigration) report(format string, a ...any) {
+	m.reports = append(m.reports, fmt.Sprintf(format, a...))
+}
+
+func (m *configMigration) migrate(config map[string]any) (map[string]any, error) {
+	migrated := make(map[string]any, len(config))
+
+	rules, err := m.migrateRules(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if rules != nil {
+		migrated[RulesConfiguration] = rules
+	}
+
+	if branches := m.migrateBranches(config); branches != nil {
+		migrated[BranchesConfiguration] = branches
+	}
+
+	keys := make([]string, 0, len(config))
+	for key := range config {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		switch key {
+		case RulesConfiguration, BranchesConfiguration, legacyReleaseRulesKey, legacyRulesPathKey, legacyReleaseBranchKey:
+			continue
+		}
+
+		if !m.isKnownKey(key) && !slices.Contains(migrateKnownKeys, key) {
+			m.report("unknown key %q was removed", key)
+			m.changed = true
+			continue
+		}
+
+		migrated[key] = config[key]
+	}
+
+	return migrated, nil
+}
+
+// migrateRules converts the legacy list of {"type": "feat", "release": "minor"} release rules, defined inline or in a
+// separate JSON file, to the current map of release types to commit types.
+func (m *configMigration) migrateRules(config map[string]any) (any, error) {
+	rules := config[RulesConfiguration]
+
+	if path, ok := config[legacyRulesPathKey].(string); ok {
+		rules = path
+	}
+
+	if path, ok := rules.(string); ok {
+		m.changed = true
+
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(m.dir, path)
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading rules file: %w", err)
+		}
+
+		if err = yaml.Unmarshal(content, &rules); err != nil {
+			return nil, fmt.Errorf("parsing rules file: %w", err)
+		}
+	}
+
+	legacy, ok := config[legacyReleaseRulesKey]
+	if !ok {
+		if rulesMap, isMap := rules.(map[string]any); isMap {
+			legacy, ok = rulesMap[legacyReleaseRulesKey]
+		}
+	}
+
+	if !ok {
+		return rules, nil
+	}
+
+	m.changed = true
+
+	list, ok := legacy.([]any)
+	if !ok {
+		m.report("%q is not a list and was removed", legacyReleaseRulesKey)
+		return nil, nil
+	}
+
+	migrated := make(map[string][]string)
+
+	for _, item := range list {
+		rule, _ := item.(map[string]any)
+
+		commitType, _ := rule["type"].(string)
+		release, _ := rule["release"].(string)
+
+		switch release {
+		case "minor", "patch":
+			migrated[release] = append(migrated[release], commitType)
+		case "major":
+			m.report("rule %q releasing a major version was removed, breaking changes are detected from the \"!\" marker and the \"BREAKING CHANGE\" footer", commitType)
+		default:
+			m.report("rule %q with unknown release type %q was removed", commitType, release)
+		}
+	}
+
+	return migrated, nil
+}
+
+// migrateBranches converts the legacy single release branch and the list of branch names to the current list of
+// branch objects.
+func (m *configMigration) migrateBranches(config map[string]any) any {
+	branches, ok := config[BranchesConfiguration]
+
+	if releaseBranch, isString := config[legacyReleaseBranchKey].(string); isString {
+		m.changed = true
+
+		if ok {
+			m.report("%q was removed since %q is defined", legacyReleaseBranchKey, BranchesConfiguration)
+		} else {
+			return []any{map[string]any{"name": releaseBranch}}
+		}
+	}
+
+	list, isList := branches.([]any)
+	if !isList {
+		return branches
+	}
+
+	migrated := make([]any, 0, len(list))
+
+	for _, item := range list {
+		if name, isString := item.(string); isString {
+			m.changed = true
+			migrated = append(migrated, map[string]any{"name": name})
+			continue
+		}
+
+		migrated = append(migrated, item)
+	}
+
+	return migrated
+}
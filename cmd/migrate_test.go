@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestMigrateConfigCmd(t *testing.T) {
+	assert := assertion.New(t)
+
+	cfgDir := t.TempDir()
+
+	writeFile(t, filepath.Join(cfgDir, "rules.json"), `{
+  "releaseRules": [
+    {"type": "feat", "release": "minor"},
+    {"type": "fix", "release": "patch"},
+    {"type": "perf", "release": "patch"},
+    {"type": "refactor", "release": "major"}
+  ]
+}`)
+
+	cfgPath := filepath.Join(cfgDir, ".semver.yaml")
+	writeFile(t, cfgPath, `
+rules-path: rules.json
+release-branch: main
+tag-prefix: v
+verbosity: debug
+`)
+
+	th := NewTestHelper(t)
+
+	out, err := th.ExecuteCommand("migrate-config", cfgPath, "--output", cfgPath)
+	checkErr(t, err, "executing command")
+
+	assert.Contains(string(out), `rule \"refactor\" releasing a major version was removed`)
+	assert.Contains(string(out), `unknown key \"verbosity\" was removed`)
+
+	content, err := os.ReadFile(cfgPath)
+	checkErr(t, err, "reading migrated configuration")
+
+	expected := `branches:
+  - name: main
+rules:
+  minor:
+    - feat
+  patch:
+    - fix
+    - perf
+tag-prefix: v
+`
+
+	assert.Equal(expected, string(content))
+}
+
+func TestMigrateConfigCmd_InlineRulesAndBranchNames(t *testing.T) {
+	assert := assertion.New(t)
+
+	cfgPath := filepath.Join(t.TempDir(), ".semver.yaml")
+	writeFile(t, cfgPath, `
+rules:
+  releaseRules:
+    - type: feat
+      release: minor
+branches:
+  - main
+  - name: rc
+    prerelease: true
+`)
+
+	th := NewTestHelper(t)
+
+	out, err := th.ExecuteCommand("migrate-config", cfgPath)
+	checkErr(t, err, "executing command")
+
+	assert.Contains(string(out), "rules:\n  minor:\n    - feat\n")
+	assert.Contains(string(out), "branches:\n  - name: main\n  - name: rc\n    prerelease: true\n")
+}
+
+func TestMigrateConfigCmd_Current(t *testing.T) {
+	assert := assertion.New(t)
+
+	cfgPath := filepath.Join(t.TempDir(), ".semver.yaml")
+	writeFile(t, cfgPath, "rules:\n  minor:\n    - feat\nbranches:\n  - name: main\n")
+
+	th := NewTestHelper(t)
+
+	out, err := th.ExecuteCommand("migrate-config", cfgPath)
+	checkErr(t, err, "executing command")
+
+	assert.Contains(string(out), "already uses the current format")
+}
@@ -3,128 +3,2136 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"maps"
 	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/ProtonMail/go-crypto/openpgp"
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/rs/zerolog"
 	"github.com/spf13/cobra"
 
+	"github.com/s0ders/go-semver-release/v6/internal/apidiff"
 	"github.com/s0ders/go-semver-release/v6/internal/appcontext"
+	"github.com/s0ders/go-semver-release/v6/internal/artifact"
+	"github.com/s0ders/go-semver-release/v6/internal/audit"
 	"github.com/s0ders/go-semver-release/v6/internal/branch"
+	"github.com/s0ders/go-semver-release/v6/internal/changelog"
 	"github.com/s0ders/go-semver-release/v6/internal/ci"
+	"github.com/s0ders/go-semver-release/v6/internal/dryrun"
+	"github.com/s0ders/go-semver-release/v6/internal/event"
+	"github.com/s0ders/go-semver-release/v6/internal/forge"
 	"github.com/s0ders/go-semver-release/v6/internal/gpg"
+	"github.com/s0ders/go-semver-release/v6/internal/helm"
+	"github.com/s0ders/go-semver-release/v6/internal/keychain"
 	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
 	"github.com/s0ders/go-semver-release/v6/internal/parser"
+	"github.com/s0ders/go-semver-release/v6/internal/policy"
+	"github.com/s0ders/go-semver-release/v6/internal/progress"
+	"github.com/s0ders/go-semver-release/v6/internal/provenance"
 	"github.com/s0ders/go-semver-release/v6/internal/remote"
+	"github.com/s0ders/go-semver-release/v6/internal/render"
 	"github.com/s0ders/go-semver-release/v6/internal/rule"
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
 	"github.com/s0ders/go-semver-release/v6/internal/tag"
+	"github.com/s0ders/go-semver-release/v6/internal/target"
+	"github.com/s0ders/go-semver-release/v6/internal/terraform"
+	"github.com/s0ders/go-semver-release/v6/internal/vcs"
+	"github.com/s0ders/go-semver-release/v6/internal/workspace"
+	"github.com/s0ders/go-semver-release/v6/pkg/sink"
+)
+
+// ReleaseOutputSchemaVersion is the version of the schema of the release command output. It is incremented whenever a
+// key is removed, renamed or changes meaning, but not when a key is added.
+const ReleaseOutputSchemaVersion = 1
+
+var (
+	ErrSummaryBranches      = errors.New("release summary spans several branches")
+	ErrRepositoryAndTargets = errors.New("a repository cannot be given along with targets")
+	ErrPartialRelease       = errors.New("some releases failed")
+	ErrRootPathAndMonorepo  = errors.New("a root path cannot be given along with monorepo projects")
 )
 
 func NewReleaseCmd(ctx *appcontext.AppContext) *cobra.Command {
 	releaseCmd := &cobra.Command{
-		Use:   "release <REPOSITORY_PATH_OR_URL>",
+		Use:   "release [REPOSITORY_PATH_OR_URL]",
 		Short: "Version a Git repository according the the given configuration",
 		Long:  "Tag a Git repository with the new semantic version number if a new release is found on the given release branches and projects if executed in a monorepo",
-		Args:  cobra.ExactArgs(1),
-		RunE: func(cmd *cobra.Command, args []string) (err error) {
-			var (
-				repository *git.Repository
-				origin     *remote.Remote
-			)
-
-			entity, err := configureGPGKey(ctx)
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			targets, err := configureTargets(ctx, args)
+			if err != nil {
+				return fmt.Errorf("loading targets configuration: %w", err)
+			}
+
+			if len(targets) == 0 {
+				repositoryPath, err := configureGitHubEnvironment(ctx, args)
+				if err != nil {
+					return err
+				}
+
+				return releaseRepository(ctx, repositoryPath)
+			}
+
+			logger := ctx.Logger
+			defer func() {
+				ctx.Logger = logger
+			}()
+
+			// With --continue-on-error, a failed target does not prevent the next ones from being released.
+			var errs []error
+
+			for _, t := range targets {
+				ctx.BranchesFlag = t.Branches
+				ctx.MonorepositoryFlag = t.Projects
+				ctx.Logger = logger.With().Str("repository", t.Repository).Logger()
+
+				err = releaseRepository(ctx, t.Repository)
+				if err == nil {
+					continue
+				}
+
+				err = fmt.Errorf("releasing target %q: %w", t.Repository, err)
+				if !ctx.ContinueOnErrorFlag {
+					return err
+				}
+
+				errs = append(errs, err)
+			}
+
+			return errors.Join(errs...)
+		},
+	}
+
+	return releaseCmd
+}
+
+// releaseRepository computes the next versions of the configured branches and projects of a repository, and tags and
+// pushes the new releases.
+func releaseRepository(ctx *appcontext.AppContext, repositoryPath string) (err error) {
+	var locks []*remote.Lock
+
+	if ctx.AsOfFlag != "" && ctx.AtFlag != "" {
+		return fmt.Errorf("computing versions as of %q: --%s and --%s are mutually exclusive", ctx.AsOfFlag, AsOfConfiguration, AtConfiguration)
+	}
+
+	// Versions computed as of a past point of the history are only reported, never released. The dry-run is forced
+	// before anything is configured, since configuring e.g. the forge or artifacts depends on the suppressed effects.
+	if ctx.AsOfFlag != "" {
+		err = ctx.DryRunFlag.Set(dryrun.All)
+		if err != nil {
+			return err
+		}
+	}
+
+	entity, err := configureGPGKey(ctx)
+	if err != nil {
+		return fmt.Errorf("configuring GPG key: %w", err)
+	}
+
+	backend, err := vcs.Get(ctx.VCSFlag)
+	if err != nil {
+		return fmt.Errorf("configuring VCS backend: %w", err)
+	}
+
+	renderer, err := validateConfiguration(ctx, repositoryPath)
+	if err != nil {
+		return err
+	}
+
+	ctx.Rules, err = configureRules(ctx)
+	if err != nil {
+		return fmt.Errorf("loading rules configuration: %w", err)
+	}
+
+	ctx.Branches, err = configureBranches(ctx)
+	if err != nil {
+		return fmt.Errorf("loading branches configuration: %w", err)
+	}
+
+	ctx.Projects, err = configureProjects(ctx)
+	if err != nil {
+		return fmt.Errorf("loading projects configuration: %w", err)
+	}
+
+	ctx.TagAliases, err = configureTagAliases(ctx)
+	if err != nil {
+		return fmt.Errorf("loading tag aliases configuration: %w", err)
+	}
+
+	ctx.Policies, err = policy.Unmarshall(ctx.PolicyFlag)
+	if err != nil {
+		return fmt.Errorf("loading policies configuration: %w", err)
+	}
+
+	ctx.RenderFiles, err = render.UnmarshallFiles(ctx.RenderFlag)
+	if err != nil {
+		return fmt.Errorf("loading render configuration: %w", err)
+	}
+
+	signKeys, err := configureSignKeys(ctx)
+	if err != nil {
+		return fmt.Errorf("configuring GPG keys: %w", err)
+	}
+
+	ctx.Forge, err = configureForge(ctx, repositoryPath)
+	if err != nil {
+		return fmt.Errorf("configuring forge client: %w", err)
+	}
+
+	ctx.ArtifactsBucket, ctx.ArtifactStore, err = configureArtifacts(ctx)
+	if err != nil {
+		return fmt.Errorf("configuring artifacts bucket: %w", err)
+	}
+
+	ctx.EventPublishers, err = configureEvents(ctx)
+	if err != nil {
+		return fmt.Errorf("configuring events: %w", err)
+	}
+
+	writers, err := outputWriters(ctx)
+	if err != nil {
+		return fmt.Errorf("configuring outputs: %w", err)
+	}
+
+	// Local files describe the current versions, those computed as of the past must not overwrite them.
+	writeFiles := ctx.AsOfFlag == ""
+
+	// Files written for a release are committed with it only if inside the worktree of the repository released.
+	root := worktreeRoot(repositoryPath)
+	if !writeFiles {
+		writers = nil
+	}
+
+	if ctx.AtFlag != "" && len(ctx.Branches) != 1 {
+		return fmt.Errorf("analyzing commit %q: exactly one branch must be configured, got %d", ctx.AtFlag, len(ctx.Branches))
+	}
+
+	tagger := tag.NewTagger(ctx.GitNameFlag, ctx.GitEmailFlag, tag.WithTagPrefix(ctx.TagPrefixFlag), tag.WithNamespace(tag.EpochNamespace(ctx.TagNamespaceFlag, ctx.EpochFlag)), tag.WithSignKey(entity))
+
+	ctx.Workspace = configureWorkspace(ctx)
+	defer func() {
+		err = errors.Join(err, cleanupWorkspace(ctx))
+	}()
+
+	repository, err := backend.Clone(repositoryPath, vcs.Options{
+		RemoteName: ctx.RemoteNameFlag,
+		Token:      ctx.AccessTokenFlag,
+		Tagger:     tagger,
+		CacheDir:   ctx.CacheDirFlag,
+		Workspace:  ctx.Workspace,
+		Branches:   branchNames(ctx.Branches),
+		Progress:   progressWriter(ctx, "cloning repository"),
+	})
+	if err != nil {
+		return fmt.Errorf("cloning Git repository: %w", err)
+	}
+
+	// The commit history is only analyzed on Git repositories, releasing from another backend is not supported yet.
+	gitRepository, ok := repository.(*vcs.GitRepository)
+	if !ok {
+		return fmt.Errorf("analyzing %s repository: %w", backend.Name(), vcs.ErrUnsupported)
+	}
+
+	err = discoverProjects(ctx, gitRepository)
+	if err != nil {
+		return fmt.Errorf("discovering projects: %w", err)
+	}
+
+	origin := gitRepository.Remote()
+
+	if ctx.AtFlag != "" {
+		err = fetchAtCommit(ctx, gitRepository.Git(), origin)
+		if err != nil {
+			return err
+		}
+	}
+
+	if ctx.LockFlag && !ctx.DryRunFlag.Suppresses(dryrun.Lock) {
+		locks, err = acquireLocks(ctx, origin)
+		if err != nil {
+			return err
+		}
+
+		defer func() {
+			for _, lock := range locks {
+				err = errors.Join(err, lock.Release())
+			}
+		}()
+	}
+
+	outputs, err := parser.New(ctx).Run(context.Background(), gitRepository.Git())
+	if err != nil {
+		return fmt.Errorf("computing new semver: %w", err)
+	}
+
+	if ctx.PushCheckFlag {
+		err = checkPush(ctx, origin, tagger, outputs)
+		if err != nil {
+			return err
+		}
+	}
+
+	var auditLogger *audit.Logger
+	if ctx.AuditLogFlag != "" {
+		auditLogger = audit.New(ctx.AuditLogFlag, audit.WithSignKey(entity))
+	}
+
+	counts := render.ReleaseCounts{}
+	summary := render.ReleaseSummaryData{Date: time.Now().UTC(), Preview: ctx.DryRunFlag.Suppresses(dryrun.Push), Counts: &counts}
+	handles := make(map[string]string)
+	pulls := make(map[string][]forge.PullRequest)
+	heads := make(map[string]plumbing.Hash)
+	failed := make(map[releaseKey]bool)
+
+	var (
+		results      releaseResults
+		attestations []provenance.Envelope
+	)
+
+	for _, output := range outputs {
+		if output.Error != nil {
+			ctx.Logger.Error().Err(output.Error).Str("branch", output.Branch).Str("project", output.Project.Name).Msg("computing new semver failed")
+
+			failed[releaseKey{output.Branch, output.Project.Name}] = true
+			results.errs = append(results.errs, fmt.Errorf("computing new semver: %w", output.Error))
+			counts.Failed++
+			continue
+		}
+
+		if output.NewRelease && !output.Skipped {
+			held, err := evaluatePolicies(ctx, output)
+			if err != nil {
+				return err
+			}
+
+			// A release denied or held by a policy is not made, nor are the releases of the projects depending on it, and
+			// the latest version is reported as when no commit triggers a release.
+			if held {
+				previous := *output.PreviousSemver
+				previous.Metadata = output.Semver.Metadata
+
+				output.Semver = &previous
+				output.NewRelease = false
+				failed[releaseKey{output.Branch, output.Project.Name}] = true
+			}
+		}
+
+		semver := output.Semver
+		release := output.NewRelease
+		project := output.Project.Name
+
+		for _, writer := range writers {
+			err = writer.Write(sink.Release{
+				Version:     semver.String(),
+				Branch:      output.Branch,
+				TagPrefix:   output.TagPrefix,
+				Project:     project,
+				Environment: output.Environment,
+				NewRelease:  release,
+			}, sink.Options{Dir: ctx.ChannelsDirFlag})
+			if err != nil {
+				return fmt.Errorf("generating %s output: %w", writer.Name(), err)
+			}
+		}
+
+		if release && !output.Skipped {
+			output.Changes = pullRequestChanges(ctx, output.Changes, pulls)
+		}
+
+		notes := changelogRelease(ctx, output)
+		if release && !output.Skipped {
+			resolveContributorHandles(ctx, &notes, handles)
+		}
+
+		if writeFiles && ctx.ChangelogDirFlag != "" && release && !output.Skipped {
+			err = writeChangelog(ctx, renderer, output, notes)
+			if err != nil {
+				return fmt.Errorf("generating changelog: %w", err)
+			}
+		}
+
+		logEvent := ctx.Logger.Info()
+		logEvent.Int("schema-version", ReleaseOutputSchemaVersion)
+		logEvent.Bool("new-release", release)
+		logEvent.Str("version", semver.String())
+		logEvent.Str("branch", output.Branch)
+
+		if output.Environment != "" {
+			logEvent.Str("environment", output.Environment)
+		}
+
+		if output.Forced {
+			logEvent.Bool("forced-release", true)
+		}
+
+		if project != "" {
+			logEvent.Str("project", project)
+
+			tagger.SetProjectName(project)
+		}
+
+		if ctx.CascadeBumpsFlag && project != "" {
+			logEvent.Int("release-order", output.ReleaseOrder)
+
+			if release && !output.Skipped {
+				logEvent.Str("bump-reason", output.BumpReason)
+			}
+
+			if len(output.BumpedBy) > 0 {
+				logEvent.Strs("bumped-by", output.BumpedBy)
+			}
+		}
+
+		if deprecations := notes.Deprecations(); release && !output.Skipped && len(deprecations) > 0 {
+			logEvent.Strs("deprecations", deprecations)
+		}
+
+		if output.ChangedPaths != nil {
+			logEvent.Strs("changed-paths", output.ChangedPaths)
+		}
+
+		if output.RuleStats != nil {
+			logEvent.Interface("rule-stats", output.RuleStats)
+		}
+
+		tagger.SetTagPrefix(output.TagPrefix)
+		signKey := selectSignKey(ctx, signKeys, entity, output)
+		tagger.SetSignKey(signKey)
+		tagger.SetIdentity(selectIdentity(ctx, output))
+
+		var (
+			entry *render.ReleaseSummaryEntry
+			files releaseFiles
+		)
+
+		if release && !output.Skipped {
+			data := notes.Data()
+
+			entry = &render.ReleaseSummaryEntry{
+				Project:      project,
+				Branch:       output.Branch,
+				Version:      semver.String(),
+				Tag:          tagger.Format(semver),
+				Sections:     data.Sections,
+				Deprecations: data.Deprecations,
+				Contributors: data.Contributors,
+			}
+
+			if writeFiles {
+				files.rendered, err = renderFiles(ctx, root, output, tagger.Format(semver))
+				if err != nil {
+					return fmt.Errorf("rendering files: %w", err)
+				}
+
+				files.changelog, err = insertChangelog(ctx, renderer, root, output, notes, tagger.Format(semver))
+				if err != nil {
+					return fmt.Errorf("updating changelog file: %w", err)
+				}
+
+				files.notes = notes
+			}
+		}
+
+		switch {
+		case output.Skipped:
+			counts.Skipped++
+			logEvent.Msg("release skipped by commit marker")
+		case !release:
+			counts.Unreleased++
+
+			// The outcome is still reported to the output writers, only the command output is left out.
+			if ctx.SuppressNoReleaseFlag {
+				logEvent.Discard()
+			}
+
+			logEvent.Msg("no new release")
+		case release && ctx.DryRunFlag.Suppresses(dryrun.Tag):
+			counts.Released++
+			logEvent.Msg("dry-run enabled, next release found")
+		default:
+			message := "new release found"
+			if output.Forced {
+				message = "forced release found"
+			}
+
+			if dependency := failedDependency(output, failed); dependency != "" {
+				logEvent.Msg(message)
+				ctx.Logger.Warn().Str("project", project).Str("branch", output.Branch).Str("dependency", dependency).Msg("release skipped, a dependency failed to be released")
+
+				failed[releaseKey{output.Branch, project}] = true
+				results.skipped = append(results.skipped, tagger.Format(semver))
+				counts.Skipped++
+				continue
+			}
+
+			output.CommitHash, err = tagRelease(ctx, repository, tagger, renderer, auditLogger, repositoryPath, output, files, heads)
+			if err != nil {
+				logEvent.Msg(message)
+				ctx.Logger.Error().Err(err).Str("project", project).Str("branch", output.Branch).Msg("release failed")
+
+				failed[releaseKey{output.Branch, project}] = true
+				results.failed = append(results.failed, tagger.Format(semver))
+				results.errs = append(results.errs, fmt.Errorf("releasing %s: %w", tagger.Format(semver), err))
+				counts.Failed++
+				continue
+			}
+
+			results.released = append(results.released, tagger.Format(semver))
+			counts.Released++
+
+			// A made release is output once tagged, so that the output describes the created tag.
+			err = describeTag(repository, tagger.Format(semver), logEvent)
+			logEvent.Msg(message)
+
+			if err != nil {
+				ctx.Logger.Error().Err(err).Str("project", project).Str("branch", output.Branch).Msg("tag description failed")
+
+				results.errs = append(results.errs, fmt.Errorf("describing tag %s: %w", tagger.Format(semver), err))
+			}
+
+			attestation, err := attestProvenance(ctx, repository, tagger, signKey, repositoryPath, output)
+			if err != nil {
+				ctx.Logger.Error().Err(err).Str("project", project).Str("branch", output.Branch).Msg("provenance attestation failed")
+
+				results.errs = append(results.errs, fmt.Errorf("attesting provenance of %s: %w", tagger.Format(semver), err))
+			} else if attestation != nil {
+				attestations = append(attestations, *attestation)
+			}
+
+			err = uploadArtifacts(ctx, renderer, output, notes, tagger.Format(semver))
+			if err != nil {
+				ctx.Logger.Error().Err(err).Str("project", project).Str("branch", output.Branch).Msg("artifacts upload failed")
+
+				results.errs = append(results.errs, fmt.Errorf("uploading artifacts of %s: %w", tagger.Format(semver), err))
+			}
+
+			err = publishTerraformModule(ctx, output)
+			if err != nil {
+				ctx.Logger.Error().Err(err).Str("project", project).Str("branch", output.Branch).Msg("Terraform module publication failed")
+
+				results.errs = append(results.errs, fmt.Errorf("publishing Terraform module of %s: %w", tagger.Format(semver), err))
+			}
+
+			err = publishEvents(ctx, output, tagger.Format(semver))
+			if err != nil {
+				ctx.Logger.Error().Err(err).Str("project", project).Str("branch", output.Branch).Msg("events publication failed")
+
+				results.errs = append(results.errs, fmt.Errorf("publishing events of %s: %w", tagger.Format(semver), err))
+			}
+		}
+
+		// Badges only show released versions, a release previewed by a dry-run keeping the badge of the latest one.
+		if writeFiles && ctx.BadgesDirFlag != "" && !(release && ctx.DryRunFlag.Suppresses(dryrun.Push)) {
+			err = ci.WriteBadgeFile(ctx.BadgesDirFlag, semver, output.Branch, project)
 			if err != nil {
-				return fmt.Errorf("configuring GPG key: %w", err)
+				return fmt.Errorf("generating badge: %w", err)
 			}
+		}
+
+		if entry != nil {
+			summary.Releases = append(summary.Releases, *entry)
+		}
+	}
+
+	// Without the outputs of the branches and projects without new release, the counts tell what the run analyzed.
+	if ctx.SuppressNoReleaseFlag {
+		ctx.Logger.Info().Int("schema-version", ReleaseOutputSchemaVersion).Int("released", counts.Released).Int("unreleased", counts.Unreleased).Int("skipped", counts.Skipped).Int("failed", counts.Failed).Msg("release counts")
+	}
+
+	if writeFiles {
+		err = publishReleaseSummary(ctx, renderer, summary, attestations)
+		if err != nil {
+			return fmt.Errorf("publishing release summary: %w", err)
+		}
+	}
+
+	// Releases are already tagged at this point, a failure to report them being a partial release.
+	err = publishCheckRuns(ctx, renderer, gitRepository, summary)
+	if err != nil {
+		ctx.Logger.Error().Err(err).Msg("check runs publication failed")
+
+		results.errs = append(results.errs, fmt.Errorf("publishing check runs: %w", err))
+	}
+
+	if len(results.errs) > 0 {
+		ctx.Logger.Error().Strs("released", results.released).Strs("failed", results.failed).Strs("skipped", results.skipped).Msg("partial release")
+
+		return errors.Join(append([]error{ErrPartialRelease}, results.errs...)...)
+	}
+
+	return nil
+}
+
+// validateConfiguration checks the settings validated before releasing a repository, so that the doctor command rejects
+// the same configurations as the release command. The trusted keys and the changelog links of the repository are set
+// in the context, and the renderer of the configured templates is returned.
+func validateConfiguration(ctx *appcontext.AppContext, repositoryPath string) (*render.Renderer, error) {
+	err := parser.ValidateReleaseType(ctx.ForceBumpFlag)
+	if err != nil {
+		return nil, fmt.Errorf("validating forced release configuration: %w", err)
+	}
+
+	err = tag.ValidateEpoch(ctx.EpochFlag)
+	if err != nil {
+		return nil, fmt.Errorf("validating epoch configuration: %w", err)
+	}
+
+	err = parser.ValidateReleaseType(ctx.MaxBumpPerRunFlag)
+	if err != nil {
+		return nil, fmt.Errorf("validating maximum bump configuration: %w", err)
+	}
+
+	err = changelog.ValidateFormat(ctx.ChangelogFormatFlag)
+	if err != nil {
+		return nil, fmt.Errorf("validating changelog configuration: %w", err)
+	}
+
+	err = apidiff.ValidateMode(ctx.APIDiffFlag)
+	if err != nil {
+		return nil, fmt.Errorf("validating API diff configuration: %w", err)
+	}
+
+	err = parser.ValidateAuthorDomains(ctx.AuthorDomainsFlag)
+	if err != nil {
+		return nil, fmt.Errorf("validating author domains configuration: %w", err)
+	}
+
+	ctx.TrustedKeys, err = configureTrustedKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading trusted keys: %w", err)
+	}
+
+	renderer, err := render.New(ctx.TemplatesDirFlag)
+	if err != nil {
+		return nil, fmt.Errorf("loading templates: %w", err)
+	}
+
+	ctx.Links, err = configureLinks(ctx, repositoryPath)
+	if err != nil {
+		return nil, fmt.Errorf("configuring changelog links: %w", err)
+	}
+
+	return renderer, nil
+}
+
+// evaluatePolicies evaluates the configured policies against the new release of an output and reports whether a
+// policy denies it or holds it until approved, in which case the policy is logged.
+func evaluatePolicies(ctx *appcontext.AppContext, output parser.ComputeNewSemverOutput) (bool, error) {
+	release := policy.Release{
+		Project:     output.Project.Name,
+		Branch:      output.Branch,
+		Environment: output.Environment,
+		Version:     output.Semver,
+		Previous:    output.PreviousSemver,
+		Forced:      output.Forced,
+	}
+
+	matched, err := policy.Evaluate(ctx.Policies, release, time.Now().UTC(), ctx.ApproveFlag)
+	if err != nil {
+		return false, fmt.Errorf("evaluating policies: %w", err)
+	}
+
+	if matched == nil {
+		return false, nil
+	}
+
+	logEvent := ctx.Logger.Warn().Str("policy", matched.Name).Str("version", output.Semver.String()).Str("branch", output.Branch)
+
+	if output.Project.Name != "" {
+		logEvent.Str("project", output.Project.Name)
+	}
+
+	if matched.Message != "" {
+		logEvent.Str("reason", matched.Message)
+	}
+
+	if matched.Action == policy.ActionRequireApproval {
+		logEvent.Msg("release held until the policy is approved")
+	} else {
+		logEvent.Msg("release denied by policy")
+	}
+
+	return true, nil
+}
+
+// progressWriter returns a writer logging the progress of an operation if progress reporting is enabled, nil otherwise.
+func progressWriter(ctx *appcontext.AppContext, message string) io.Writer {
+	if !ctx.ProgressFlag {
+		return nil
+	}
+
+	return progress.NewWriter(ctx.Logger, message, progress.Interval)
+}
+
+// branchNames returns the names of the given branches, which are the only ones cloned.
+func branchNames(branches []branch.Branch) []string {
+	names := make([]string, len(branches))
+
+	for i, b := range branches {
+		names[i] = b.Name
+	}
+
+	return names
+}
+
+// releaseKey identifies the release of a project on a branch.
+type releaseKey struct {
+	branch  string
+	project string
+}
+
+// releaseResults are the tags released, failed to be released and skipped because of a failed dependency by a run,
+// along with the errors of the failed releases and of the branches and projects whose new semver was not computed.
+type releaseResults struct {
+	released []string
+	failed   []string
+	skipped  []string
+	errs     []error
+}
+
+// failedDependency returns the name of a project the output's project depends on whose release failed or was skipped
+// on the same branch, if any.
+func failedDependency(output parser.ComputeNewSemverOutput, failed map[releaseKey]bool) string {
+	for _, dependency := range output.Project.DependsOn {
+		if failed[releaseKey{output.Branch, dependency}] {
+			return dependency
+		}
+	}
+
+	return ""
+}
+
+// tagRelease creates and pushes the tag of a release, recording it in the audit log, if any, and as a deployment of
+// its environment. The files of the release are committed first, the tag being created on that commit. Releases are
+// tagged serially, in the order of the outputs of the parser.
+func tagRelease(ctx *appcontext.AppContext, repository vcs.Repository, tagger *tag.Tagger, renderer *render.Renderer, auditLogger *audit.Logger, repositoryPath string, output parser.ComputeNewSemverOutput, files releaseFiles, heads map[string]plumbing.Hash) (plumbing.Hash, error) {
+	semver := output.Semver
+	commitHash := output.CommitHash
+	project := output.Project.Name
+
+	err := checkTerraformVersion(tagger, output)
+	if err != nil {
+		return commitHash, err
+	}
+
+	if len(ctx.RequireChecksFlag) > 0 && !ctx.DryRunFlag.Suppresses(dryrun.Checks) {
+		err = requireChecks(ctx, ctx.Forge, commitHash.String())
+		if err != nil {
+			return commitHash, fmt.Errorf("checking release commit status: %w", err)
+		}
+	}
+
+	commitHash, err = commitReleaseFiles(repository, renderer, output, files, tagger.Format(semver), heads)
+	if err != nil {
+		return output.CommitHash, fmt.Errorf("committing release files: %w", err)
+	}
+
+	bumped := commitHash != output.CommitHash
+
+	message, err := renderer.String(render.TagMessage, render.TagMessageData{
+		Tag:     tagger.Format(semver),
+		Version: semver.String(),
+		Branch:  output.Branch,
+		Project: project,
+		Commit:  commitHash.String(),
+	})
+	if err != nil {
+		return commitHash, fmt.Errorf("rendering tag message: %w", err)
+	}
+
+	tagger.SetMessage(message)
+
+	source, sourceTag, err := tagProjectSource(ctx, repository, tagger, output, commitHash)
+	if err != nil {
+		return commitHash, fmt.Errorf("tagging project tag source: %w", err)
+	}
+
+	err = repository.CreateTag(tagger.Format(semver), commitHash.String())
+	if err != nil {
+		return commitHash, fmt.Errorf("tagging repository: %w", err)
+	}
+
+	ctx.Logger.Debug().Str("tag", tagger.Format(semver)).Msg("new tag added to repository")
+
+	record := audit.Record{
+		Repository: repositoryPath,
+		Branch:     output.Branch,
+		Project:    project,
+		Version:    semver.String(),
+		Tag:        tagger.Format(semver),
+		Commit:     commitHash.String(),
+		Actor:      audit.Actor(),
+	}
+
+	err = appendAuditRecord(auditLogger, audit.ActionTag, record)
+	if err != nil {
+		return commitHash, err
+	}
+
+	floating, err := moveFloatingTags(ctx, repository, tagger, semver, commitHash)
+	if err != nil {
+		return commitHash, fmt.Errorf("moving floating tags: %w", err)
+	}
+
+	latest, previous, err := updateLatestRef(ctx, repository, tagger, output, commitHash)
+	if err != nil {
+		return commitHash, fmt.Errorf("updating latest reference: %w", err)
+	}
+
+	if ctx.DryRunFlag.Suppresses(dryrun.Push) {
+		if bumped {
+			heads[output.Branch] = commitHash
+		}
+
+		ctx.Logger.Debug().Str("tag", tagger.Format(semver)).Msg("dry-run enabled, tag not pushed")
+		return commitHash, nil
+	}
+
+	// The commit of the release files is pushed before the tag, so that the tag is never pushed without the commit it
+	// references being reachable from the branch.
+	if bumped {
+		err = repository.(vcs.Committer).PushBranch(output.Branch, commitHash.String())
+		if err != nil {
+			return commitHash, fmt.Errorf("pushing release files commit to remote: %w", err)
+		}
+
+		heads[output.Branch] = commitHash
+	}
+
+	err = repository.PushTag(tagger.Format(semver))
+	if err != nil {
+		return commitHash, fmt.Errorf("pushing tag to remote: %w", err)
+	}
+
+	if source != nil {
+		err = source.PushTag(sourceTag)
+		if err != nil {
+			return commitHash, fmt.Errorf("pushing tag to project tag source: %w", err)
+		}
+	}
+
+	err = appendAuditRecord(auditLogger, audit.ActionPush, record)
+	if err != nil {
+		return commitHash, err
+	}
+
+	for _, moved := range floating {
+		err = repository.(vcs.TagMover).ForcePushTag(moved.name, moved.previous)
+		if err != nil {
+			return commitHash, fmt.Errorf("pushing floating tag to remote: %w", err)
+		}
+	}
+
+	if latest != "" {
+		err = repository.(vcs.ReferenceUpdater).PushReference(latest, previous)
+		if err != nil {
+			return commitHash, fmt.Errorf("pushing latest reference to remote: %w", err)
+		}
+	}
+
+	err = recordDeployment(ctx, tagger.Format(semver), output.Environment)
+	if err != nil {
+		return commitHash, fmt.Errorf("recording deployment: %w", err)
+	}
+
+	return commitHash, nil
+}
+
+// tagProjectSource creates the tag of the release of a project with a tag source in a clone of that tag source, so that
+// the next run reads the version just released. The tag is not prefixed by the project name and is created on the
+// commit split from the release commit, which must therefore be split to the tag source before releasing. The remote
+// to which the tag must be pushed is returned, nil if the project has no tag source.
+func tagProjectSource(ctx *appcontext.AppContext, repository vcs.Repository, tagger *tag.Tagger, output parser.ComputeNewSemverOutput, commitHash plumbing.Hash) (*remote.Remote, string, error) {
+	project := output.Project
+	if project.TagSource == "" {
+		return nil, "", nil
+	}
+
+	// Splitting the release commit relies on Git trees.
+	gitRepository, ok := repository.(*vcs.GitRepository)
+	if !ok {
+		return nil, "", vcs.ErrUnsupported
+	}
+
+	origin := remote.New(ctx.RemoteNameFlag, ctx.AccessTokenFlag)
+
+	var (
+		source *git.Repository
+		err    error
+	)
+
+	if ctx.Workspace != nil {
+		var dir string
+
+		dir, err = ctx.Workspace.Create()
+		if err != nil {
+			return nil, "", err
+		}
+
+		source, err = origin.CloneTo(project.TagSource, dir)
+	} else {
+		source, err = origin.Clone(project.TagSource)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("cloning tag source: %w", err)
+	}
+
+	commit, err := gitRepository.Git().CommitObject(commitHash)
+	if err != nil {
+		return nil, "", fmt.Errorf("fetching release commit: %w", err)
+	}
+
+	split, err := parser.SplitCommit(source, commit, project.Path)
+	if err != nil {
+		return nil, "", err
+	}
+
+	sourceTagger := *tagger
+	sourceTagger.SetProjectName("")
+
+	name := sourceTagger.Format(output.Semver)
+
+	err = sourceTagger.CreateTag(source, name, split)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return origin, name, nil
+}
+
+// describeTag adds the tag created for a new release to its output: the hash of the tag object, whether it is signed
+// and the fingerprint of its signer, so that verification jobs do not have to open the repository. Only the name of
+// the tag is added for repositories whose tags are not objects.
+func describeTag(repository vcs.Repository, name string, logEvent *zerolog.Event) error {
+	logEvent.Str("tag", name)
+
+	describer, ok := repository.(vcs.TagDescriber)
+	if !ok {
+		return nil
+	}
+
+	described, err := describer.DescribeTag(name)
+	if err != nil {
+		return err
+	}
+
+	logEvent.Str("tag-hash", described.Hash).Bool("signed", described.Signed)
+
+	if described.Signed {
+		logEvent.Str("signer-fingerprint", described.Signer)
+	}
+
+	return nil
+}
+
+// outputWriters returns the configured output writers, along with the channels writer when a channels directory is
+// configured.
+func outputWriters(ctx *appcontext.AppContext) ([]sink.Writer, error) {
+	names := slices.Clone(ctx.OutputsFlag)
+	if ctx.ChannelsDirFlag != "" && !slices.Contains(names, sink.ChannelsWriter{}.Name()) {
+		names = append(names, sink.ChannelsWriter{}.Name())
+	}
+
+	writers := make([]sink.Writer, 0, len(names))
+
+	for _, name := range names {
+		writer, err := sink.Get(name)
+		if err != nil {
+			return nil, err
+		}
+
+		writers = append(writers, writer)
+	}
+
+	return writers, nil
+}
+
+// floatingTag is a floating tag moved to a new release, previous being the hash of the reference of the tag it
+// replaced, empty if it did not exist, for it to be force pushed with lease.
+type floatingTag struct {
+	name     string
+	previous string
+}
+
+// moveFloatingTags moves the floating tags tracking the latest release of the major and minor versions of a stable
+// release, e.g. "v1" and "v1.4" for "v1.4.2", to its commit. A floating tag is left untouched if a higher release of
+// its series already exists, e.g. when releasing a fix of an older minor version.
+func moveFloatingTags(ctx *appcontext.AppContext, repository vcs.Repository, tagger *tag.Tagger, version *semver.Version, commitHash plumbing.Hash) ([]floatingTag, error) {
+	if !ctx.FloatingTagsFlag || version.Prerelease != "" {
+		return nil, nil
+	}
+
+	mover, ok := repository.(vcs.TagMover)
+	if !ok {
+		return nil, vcs.ErrUnsupported
+	}
+
+	tags, err := repository.Tags()
+	if err != nil {
+		return nil, err
+	}
+
+	releaseTag := tagger.Format(version)
+
+	var moved []floatingTag
+
+	for i, name := range tagger.FloatingTags(version) {
+		// The first floating tag tracks the major version, the second one the minor version.
+		inSeries := func(v *semver.Version) bool {
+			return v.Major == version.Major && (i == 0 || v.Minor == version.Minor)
+		}
+
+		if latest := latestInSeries(tagger, tags, version, inSeries); latest.Compare(version) > 0 {
+			ctx.Logger.Debug().Str("tag", name).Str("latest", latest.String()).Msg("floating tag not moved, a higher release exists")
+			continue
+		}
+
+		if slices.ContainsFunc(tags, func(t vcs.Tag) bool { return t.Name == name && t.Commit == commitHash.String() }) {
+			continue
+		}
+
+		tagger.SetMessage(fmt.Sprintf("%s\n\nTracks the latest release: %s.\n", name, releaseTag))
+
+		previous, err := mover.MoveTag(name, commitHash.String())
+		if err != nil {
+			return nil, fmt.Errorf("moving tag %q: %w", name, err)
+		}
+
+		ctx.Logger.Debug().Str("tag", name).Str("release", releaseTag).Msg("floating tag moved")
+
+		moved = append(moved, floatingTag{name: name, previous: previous})
+	}
+
+	return moved, nil
+}
+
+// latestInSeries returns the highest stable version among the given one and the release tags whose version belongs to
+// its series, e.g. of the same major version.
+func latestInSeries(tagger *tag.Tagger, tags []vcs.Tag, version *semver.Version, inSeries func(*semver.Version) bool) *semver.Version {
+	latest := version
+
+	for _, t := range tags {
+		v, ok := tagger.Version(t.Name)
+		if !ok || v.Prerelease != "" || !inSeries(v) {
+			continue
+		}
+
+		if v.Compare(latest) > 0 {
+			latest = v
+		}
+	}
+
+	return latest
+}
+
+// latestRef returns the full name of the reference tracking the latest release, the configured branch name or
+// reference, followed by the project name in a monorepo.
+func latestRef(ctx *appcontext.AppContext, project string) string {
+	ref := ctx.LatestRefFlag
+	if !strings.HasPrefix(ref, "refs/") {
+		ref = plumbing.NewBranchReferenceName(ref).String()
+	}
+
+	if project != "" {
+		ref += "/" + project
+	}
+
+	return ref
+}
+
+// updateLatestRef points the reference tracking the latest release to the commit of a stable release, unless a higher
+// release already exists, and returns the hash it previously pointed to for it to be pushed with lease. A branch is
+// only fast-forwarded, a release whose commit does not descend from it leaving it untouched.
+func updateLatestRef(ctx *appcontext.AppContext, repository vcs.Repository, tagger *tag.Tagger, output parser.ComputeNewSemverOutput, commitHash plumbing.Hash) (ref string, previous string, err error) {
+	if ctx.LatestRefFlag == "" || output.Semver.Prerelease != "" {
+		return "", "", nil
+	}
+
+	updater, ok := repository.(vcs.ReferenceUpdater)
+	if !ok {
+		return "", "", vcs.ErrUnsupported
+	}
+
+	ref = latestRef(ctx, output.Project.Name)
+
+	tags, err := repository.Tags()
+	if err != nil {
+		return "", "", err
+	}
+
+	if latest := latestInSeries(tagger, tags, output.Semver, func(*semver.Version) bool { return true }); latest.Compare(output.Semver) > 0 {
+		ctx.Logger.Debug().Str("ref", ref).Str("latest", latest.String()).Msg("latest reference not updated, a higher release exists")
+		return "", "", nil
+	}
+
+	previous, err = updater.UpdateReference(ref, commitHash.String())
+	if errors.Is(err, vcs.ErrNotFastForward) {
+		ctx.Logger.Warn().Err(err).Str("ref", ref).Msg("latest reference not updated")
+		return "", "", nil
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("updating %q: %w", ref, err)
+	}
+
+	ctx.Logger.Debug().Str("ref", ref).Str("commit", commitHash.String()).Msg("latest reference updated")
+
+	return ref, previous, nil
+}
+
+// releaseFiles are the files written for a release, committed with it so that its tag contains them.
+type releaseFiles struct {
+	// rendered are the contents of the rendered files, by path relative to the root of the repository.
+	rendered map[string][]byte
+	// changelog, if set, is the path of the changelog file, relative to the root of the repository, in which notes are
+	// inserted.
+	changelog string
+	notes     changelog.Release
+}
+
+// commitReleaseFiles commits the files of a release on top of the head of its branch and returns the commit to tag:
+// the chart of a Helm chart project bumped to the released version, the rendered files and the changelog file with the
+// changelog of the release inserted. Files that are already up to date are left out, the release commit being returned
+// if no file changed.
+func commitReleaseFiles(repository vcs.Repository, renderer *render.Renderer, output parser.ComputeNewSemverOutput, files releaseFiles, tagName string, heads map[string]plumbing.Hash) (plumbing.Hash, error) {
+	helmChart := output.Project.Type == monorepo.TypeHelmChart
+
+	if !helmChart && len(files.rendered) == 0 && files.changelog == "" {
+		return output.CommitHash, nil
+	}
+
+	committer, ok := repository.(vcs.Committer)
+	if !ok {
+		return plumbing.ZeroHash, vcs.ErrUnsupported
+	}
+
+	parent, ok := heads[output.Branch]
+	if !ok {
+		head, err := committer.Head(output.Branch)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+
+		parent = plumbing.NewHash(head)
+	}
+
+	contents := maps.Clone(files.rendered)
+	if contents == nil {
+		contents = make(map[string][]byte)
+	}
+
+	message := fmt.Sprintf("chore(release): update files of %s", tagName)
+
+	if helmChart {
+		chartPath := path.Join(filepath.ToSlash(output.Project.Path), helm.ChartFile)
+
+		content, err := committer.ReadFile(parent.String(), chartPath)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+
+		contents[chartPath], err = helm.Bump(content, output.Semver.String())
+		if err != nil {
+			return plumbing.ZeroHash, fmt.Errorf("bumping %q: %w", chartPath, err)
+		}
+
+		message = fmt.Sprintf("chore(release): bump %s chart to %s", output.Project.Name, output.Semver.String())
+	}
+
+	if files.changelog != "" {
+		content, err := repositoryFile(committer, parent, files.changelog)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+
+		content, _, err = changelog.InsertContent(content, renderer, files.notes)
+		if err != nil {
+			return plumbing.ZeroHash, fmt.Errorf("updating %q: %w", files.changelog, err)
+		}
+
+		contents[files.changelog] = content
+	}
+
+	for name, content := range contents {
+		current, err := repositoryFile(committer, parent, name)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+
+		if current != nil && bytes.Equal(current, content) {
+			delete(contents, name)
+		}
+	}
+
+	if len(contents) == 0 {
+		return output.CommitHash, nil
+	}
+
+	commit, err := committer.CommitFiles(parent.String(), contents, message)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	return plumbing.NewHash(commit), nil
+}
+
+// repositoryFile returns the content of the file at the given path of a commit, or nil if it does not exist.
+func repositoryFile(committer vcs.Committer, commit plumbing.Hash, name string) ([]byte, error) {
+	content, err := committer.ReadFile(commit.String(), name)
+	if errors.Is(err, object.ErrFileNotFound) {
+		return nil, nil
+	}
+
+	return content, err
+}
+
+// changelogRelease returns the changelog of a new release, made of the changes that triggered it and crediting the
+// authors of every commit it contains.
+func changelogRelease(ctx *appcontext.AppContext, output parser.ComputeNewSemverOutput) changelog.Release {
+	release := changelog.Release{
+		Version: output.Semver.String(),
+		Date:    time.Now().UTC(),
+		Links:   ctx.Links,
+	}
+
+	for _, change := range output.Changes {
+		commit, ok := changelog.ParseCommit(change.Hash.String(), change.Message)
+		if ok {
+			author := changelog.Author{Name: change.Author.Name, Email: change.Author.Email}
+			commit.Authors = append([]changelog.Author{author}, commit.Authors...)
+
+			release.Commits = append(release.Commits, commit)
+		}
+	}
+
+	for _, commit := range output.Commits {
+		release.Authors = append(release.Authors, changelog.Author{Name: commit.Author.Name, Email: commit.Author.Email})
+		release.Authors = append(release.Authors, changelog.CoAuthors(commit.Message)...)
+	}
+
+	return release
+}
+
+// pullRequestChanges returns the changes of a release described by their merged pull request, if pull request notes
+// are enabled: the change of the commit a pull request was squashed or merged into takes its title and description,
+// suffixed by its number so that changelogs link to it, a pull request being listed once. Pull requests are cached by
+// commit across the releases of a run. A change whose pull requests cannot be fetched, whose commit is not the merge
+// commit of a pull request, e.g. one of the commits of a pull request merged with a merge commit, or whose pull
+// request title is not conventional, keeps its commit message.
+func pullRequestChanges(ctx *appcontext.AppContext, changes []parser.Change, pulls map[string][]forge.PullRequest) []parser.Change {
+	if !ctx.PullRequestNotesFlag {
+		return changes
+	}
+
+	described := make([]parser.Change, 0, len(changes))
+	seen := make(map[int]bool)
+
+	for _, change := range changes {
+		hash := change.Hash.String()
+
+		pullRequests, ok := pulls[hash]
+		if !ok {
+			var err error
+
+			pullRequests, err = ctx.Forge.CommitPullRequests(context.Background(), hash)
+			if err != nil {
+				ctx.Logger.Warn().Err(err).Str("commit", hash).Msg("failed to fetch commit pull requests")
+			}
+
+			pulls[hash] = pullRequests
+		}
+
+		pullRequest, ok := mergedPullRequest(pullRequests, hash)
+		if !ok {
+			described = append(described, change)
+			continue
+		}
+
+		message := pullRequestMessage(pullRequest)
+		if _, ok = changelog.ParseCommit(hash, message); !ok {
+			ctx.Logger.Debug().Int("pull-request", pullRequest.Number).Msg("pull request title is not conventional, keeping commit message")
+			described = append(described, change)
+			continue
+		}
+
+		if seen[pullRequest.Number] {
+			continue
+		}
+
+		seen[pullRequest.Number] = true
+		change.Message = message
+		described = append(described, change)
+	}
+
+	return described
+}
+
+// mergedPullRequest returns the merged pull request whose merge commit is the given commit, if any. The commits of a
+// pull request merged with a merge commit are not described by it, since they are changes of their own.
+func mergedPullRequest(pullRequests []forge.PullRequest, commit string) (forge.PullRequest, bool) {
+	for _, pullRequest := range pullRequests {
+		if pullRequest.Merged && pullRequest.MergeCommit == commit {
+			return pullRequest, true
+		}
+	}
+
+	return forge.PullRequest{}, false
+}
+
+// pullRequestMessage returns the commit message describing a pull request: its title, suffixed by its number unless
+// it already is, followed by its description.
+func pullRequestMessage(pullRequest forge.PullRequest) string {
+	title := strings.TrimSpace(pullRequest.Title)
+
+	suffix := fmt.Sprintf("(#%d)", pullRequest.Number)
+	if !strings.HasSuffix(title, suffix) {
+		title += " " + suffix
+	}
+
+	body := strings.TrimSpace(strings.ReplaceAll(pullRequest.Body, "\r\n", "\n"))
+	if body == "" {
+		return title
+	}
+
+	return title + "\n\n" + body
+}
+
+// resolveContributorHandles sets the forge handle of the authors of a changelog, if contributor handles are enabled.
+// Handles are cached by email across the releases of a run. An author whose handle cannot be fetched is listed by name.
+func resolveContributorHandles(ctx *appcontext.AppContext, release *changelog.Release, handles map[string]string) {
+	if !ctx.ContributorHandlesFlag {
+		return
+	}
+
+	resolve := func(authors []changelog.Author) {
+		for i := range authors {
+			author := &authors[i]
+			if author.Email == "" {
+				continue
+			}
+
+			key := strings.ToLower(author.Email)
+
+			handle, ok := handles[key]
+			if !ok {
+				var err error
+
+				handle, err = ctx.Forge.UserHandle(context.Background(), author.Email)
+				if err != nil {
+					ctx.Logger.Warn().Err(err).Str("email", author.Email).Msg("failed to fetch contributor handle")
+				}
+
+				handles[key] = handle
+			}
+
+			author.Handle = handle
+		}
+	}
+
+	resolve(release.Authors)
+
+	for i := range release.Commits {
+		resolve(release.Commits[i].Authors)
+	}
+}
+
+// writeChangelog writes the changelog of a new release to a file named after its release channel inside the changelog
+// directory.
+func writeChangelog(ctx *appcontext.AppContext, renderer *render.Renderer, output parser.ComputeNewSemverOutput, release changelog.Release) error {
+	name := ci.ChannelName(output.Branch, output.Project.Name) + changelog.Extension(ctx.ChangelogFormatFlag)
+
+	return changelog.WriteFile(filepath.Join(ctx.ChangelogDirFlag, name), renderer, ctx.ChangelogFormatFlag, release)
+}
+
+// configureArtifacts parses the bucket to which the artifacts of the releases are uploaded, if any, and returns a store
+// of it. No store is returned if the upload is suppressed by a dry-run, so that previews need no cloud credentials.
+func configureArtifacts(ctx *appcontext.AppContext) (artifact.Bucket, artifact.Store, error) {
+	if ctx.ArtifactsBucketFlag == "" {
+		return artifact.Bucket{}, nil, nil
+	}
+
+	bucket, err := artifact.ParseBucket(ctx.ArtifactsBucketFlag)
+	if err != nil {
+		return bucket, nil, err
+	}
+
+	if ctx.DryRunFlag.Suppresses(dryrun.Push) || ctx.DryRunFlag.Suppresses(dryrun.Artifacts) {
+		return bucket, nil, nil
+	}
+
+	store, err := artifact.New(context.Background(), bucket)
+
+	return bucket, store, err
+}
+
+// releaseArtifact is the result of a release uploaded as an artifact, holding the same fields as its log output.
+type releaseArtifact struct {
+	SchemaVersion   int    `json:"schema-version"`
+	NewRelease      bool   `json:"new-release"`
+	Version         string `json:"version"`
+	PreviousVersion string `json:"previous-version,omitempty"`
+	Branch          string `json:"branch"`
+	Environment     string `json:"environment,omitempty"`
+	Project         string `json:"project,omitempty"`
+	Tag             string `json:"tag"`
+	Commit          string `json:"commit"`
+}
+
+// uploadArtifacts uploads the result and the changelog of a new release to the configured bucket, followed by the
+// manifest listing them.
+func uploadArtifacts(ctx *appcontext.AppContext, renderer *render.Renderer, output parser.ComputeNewSemverOutput, notes changelog.Release, tagName string) error {
+	if ctx.ArtifactStore == nil {
+		return nil
+	}
+
+	result := releaseArtifact{
+		SchemaVersion: ReleaseOutputSchemaVersion,
+		NewRelease:    true,
+		Version:       output.Semver.String(),
+		Branch:        output.Branch,
+		Environment:   output.Environment,
+		Project:       output.Project.Name,
+		Tag:           tagName,
+		Commit:        output.CommitHash.String(),
+	}
+
+	if output.PreviousSemver != nil {
+		result.PreviousVersion = output.PreviousSemver.String()
+	}
+
+	resultContent, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding release result: %w", err)
+	}
+
+	var changelogContent bytes.Buffer
+
+	err = changelog.Render(&changelogContent, renderer, ctx.ChangelogFormatFlag, notes)
+	if err != nil {
+		return fmt.Errorf("rendering changelog: %w", err)
+	}
+
+	changelogType := "text/markdown"
+	if ctx.ChangelogFormatFlag == changelog.FormatConventionalJSON {
+		changelogType = "application/json"
+	}
+
+	manifest := artifact.Manifest{
+		Tag:     tagName,
+		Version: result.Version,
+		Branch:  result.Branch,
+		Project: result.Project,
+		Commit:  result.Commit,
+		Date:    notes.Date,
+	}
+
+	key, err := artifact.Upload(context.Background(), ctx.ArtifactStore, ctx.ArtifactsBucket, manifest, []artifact.Artifact{
+		{Name: "release.json", ContentType: "application/json", Content: resultContent},
+		{Name: "changelog" + changelog.Extension(ctx.ChangelogFormatFlag), ContentType: changelogType, Content: changelogContent.Bytes()},
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx.Logger.Debug().Str("bucket", ctx.ArtifactsBucket.String()).Str("manifest", key).Msg("artifacts uploaded")
+
+	return nil
+}
+
+// configureEvents returns the publishers of the configured event sinks. None is returned if the publication is
+// suppressed by a dry-run, so that previews need no cloud credentials.
+func configureEvents(ctx *appcontext.AppContext) ([]event.Publisher, error) {
+	sinks, err := event.Unmarshall(ctx.EventsFlag)
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx.DryRunFlag.Suppresses(dryrun.Push) || ctx.DryRunFlag.Suppresses(dryrun.Events) {
+		return nil, nil
+	}
+
+	publishers := make([]event.Publisher, 0, len(sinks))
+
+	for _, sink := range sinks {
+		publisher, err := sink.Publisher(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("configuring %s: %w", sink, err)
+		}
+
+		publishers = append(publishers, publisher)
+	}
+
+	return publishers, nil
+}
+
+// publishEvents publishes the event of a new release to every configured sink, a failing sink not preventing the
+// publication to the others.
+func publishEvents(ctx *appcontext.AppContext, output parser.ComputeNewSemverOutput, tagName string) error {
+	if len(ctx.EventPublishers) == 0 {
+		return nil
+	}
+
+	e := event.Event{
+		SchemaVersion: event.SchemaVersion,
+		Type:          event.ReleaseType,
+		Tag:           tagName,
+		Version:       output.Semver.String(),
+		Branch:        output.Branch,
+		Environment:   output.Environment,
+		Project:       output.Project.Name,
+		Commit:        output.CommitHash.String(),
+		Date:          time.Now().UTC(),
+	}
+
+	if output.PreviousSemver != nil {
+		e.PreviousVersion = output.PreviousSemver.String()
+	}
+
+	var errs []error
+
+	for _, publisher := range ctx.EventPublishers {
+		if err := publisher.Publish(context.Background(), e); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// checkTerraformVersion returns an error if the release of a project publishing a Terraform module would not be
+// accepted by its registry: registries publishing modules from their tags require tags made of a bare version, which
+// only the tags of a project's tag source are, monorepo tags being prefixed by the project name, and versions
+// published with the API must not carry build metadata.
+func checkTerraformVersion(tagger *tag.Tagger, output parser.ComputeNewSemverOutput) error {
+	module := output.Project.TerraformModule
+	if module == nil {
+		return nil
+	}
+
+	version := output.Semver.String()
+	if output.Project.TerraformPublish == terraform.PublishTag {
+		version = tagger.Format(output.Semver)
+
+		if output.Project.TagSource != "" {
+			sourceTagger := *tagger
+			sourceTagger.SetProjectName("")
+			version = sourceTagger.Format(output.Semver)
+		}
+	}
+
+	if err := terraform.ValidateTag(version); err != nil {
+		return fmt.Errorf("checking Terraform module %s: %w", module, err)
+	}
+
+	return nil
+}
+
+// publishTerraformModule publishes the new version of a project to the private registry of its Terraform module if
+// the module is published with the API of the registry.
+func publishTerraformModule(ctx *appcontext.AppContext, output parser.ComputeNewSemverOutput) error {
+	module := output.Project.TerraformModule
+	if module == nil || output.Project.TerraformPublish != terraform.PublishAPI {
+		return nil
+	}
+
+	if ctx.DryRunFlag.Suppresses(dryrun.Push) || ctx.DryRunFlag.Suppresses(dryrun.Registry) {
+		return nil
+	}
+
+	client := terraform.NewClient(terraform.APIURL(module.Host), terraform.TokenFromEnv(module.Host))
+
+	err := client.PublishVersion(context.Background(), *module, output.Semver.String(), output.CommitHash.String())
+	if err != nil {
+		return err
+	}
+
+	ctx.Logger.Debug().Str("module", module.String()).Str("version", output.Semver.String()).Msg("Terraform module version published")
+
+	return nil
+}
+
+// renderFiles renders the configured template files matching the project of a new release with its version, and
+// returns the contents of those inside the worktree of the repository, by path relative to its root, to be committed
+// with the release.
+func renderFiles(ctx *appcontext.AppContext, root string, output parser.ComputeNewSemverOutput, tagName string) (map[string][]byte, error) {
+	data := fileData(output, tagName)
+	rendered := make(map[string][]byte)
+
+	for _, file := range ctx.RenderFiles {
+		if !file.Matches(output.Project.Name) {
+			continue
+		}
+
+		path, content, err := file.Write(data)
+		if err != nil {
+			return nil, err
+		}
+
+		ctx.Logger.Debug().Str("template", file.Template).Str("output", path).Msg("file rendered")
+
+		name, ok := worktreePath(root, path)
+		if !ok {
+			ctx.Logger.Debug().Str("output", path).Msg("rendered file outside of the repository, not committed")
+			continue
+		}
+
+		rendered[name] = content
+	}
+
+	return rendered, nil
+}
+
+// insertChangelog inserts the changelog of a new release in the changelog file, whose path can be a template using
+// the render.FileData, e.g. "{{.Project}}/CHANGELOG.md" in monorepo mode. It returns the path of the changelog file
+// relative to the root of the worktree of the repository, to be committed with the release, or an empty path if it is
+// not configured or is outside of the worktree.
+func insertChangelog(ctx *appcontext.AppContext, renderer *render.Renderer, root string, output parser.ComputeNewSemverOutput, release changelog.Release, tagName string) (string, error) {
+	if ctx.ChangelogFileFlag == "" {
+		return "", nil
+	}
+
+	path, err := render.Inline(ctx.ChangelogFileFlag, fileData(output, tagName))
+	if err != nil {
+		return "", fmt.Errorf("rendering changelog file path: %w", err)
+	}
+
+	written, err := changelog.Insert(path, renderer, release)
+	if err != nil {
+		return "", err
+	}
+
+	if !written {
+		ctx.Logger.Debug().Str("path", path).Str("version", release.Version).Msg("release already in changelog file")
+	}
+
+	name, ok := worktreePath(root, path)
+	if !ok {
+		ctx.Logger.Debug().Str("path", path).Msg("changelog file outside of the repository, not committed")
+		return "", nil
+	}
+
+	return name, nil
+}
+
+// worktreeRoot returns the root of the worktree of the repository at the given path, empty if it has none, e.g. a URL
+// or a bare repository, in which case no file written locally belongs to it.
+func worktreeRoot(repositoryPath string) string {
+	if _, err := os.Stat(repositoryPath); err != nil {
+		return ""
+	}
+
+	repository, err := git.PlainOpen(repositoryPath)
+	if err != nil {
+		return ""
+	}
+
+	worktree, err := repository.Worktree()
+	if err != nil {
+		return ""
+	}
+
+	root, err := filepath.EvalSymlinks(worktree.Filesystem.Root())
+	if err != nil {
+		return ""
+	}
+
+	return root
+}
+
+// worktreePath returns the path of a file written locally, which is relative to the working directory, relative to the
+// given worktree root instead, and whether the file is inside that worktree.
+func worktreePath(root, path string) (string, bool) {
+	if root == "" {
+		return "", false
+	}
+
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return "", false
+	}
+
+	path, err = filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", false
+	}
+
+	name, err := filepath.Rel(root, path)
+	if err != nil || !filepath.IsLocal(name) {
+		return "", false
+	}
+
+	return filepath.ToSlash(name), true
+}
+
+// fileData returns the data of the files rendered for a new release.
+func fileData(output parser.ComputeNewSemverOutput, tagName string) render.FileData {
+	semver := output.Semver
+
+	return render.FileData{
+		Tag:        tagName,
+		Version:    semver.String(),
+		Major:      semver.Major,
+		Minor:      semver.Minor,
+		Patch:      semver.Patch,
+		Prerelease: semver.Prerelease,
+		Branch:     output.Branch,
+		Project:    output.Project.Name,
+		Commit:     output.CommitHash.String(),
+	}
+}
+
+// publishCheckRuns reports a check run on the analyzed commit of every branch, i.e. its head or the commit given by
+// --at, summarizing the releases the branch leads to, so that the reviewers of a pull request see them among its checks.
+// Branches without new release get a check run too, telling that nothing will be released.
+func publishCheckRuns(ctx *appcontext.AppContext, renderer *render.Renderer, repository *vcs.GitRepository, summary render.ReleaseSummaryData) error {
+	if ctx.CheckRunFlag == "" || ctx.DryRunFlag.Suppresses(dryrun.CheckRun) {
+		return nil
+	}
+
+	for _, b := range ctx.Branches {
+		commit, err := analyzedCommit(ctx, repository, b.Name)
+		if err != nil {
+			return err
+		}
+
+		// The counts of the run span every branch.
+		data := summary
+		data.Counts = nil
+		data.Releases = slices.DeleteFunc(slices.Clone(summary.Releases), func(release render.ReleaseSummaryEntry) bool {
+			return release.Branch != b.Name
+		})
+
+		content, err := renderer.String(render.ReleaseSummary, data)
+		if err != nil {
+			return err
+		}
+
+		title := fmt.Sprintf("%d new releases", len(data.Releases))
+		switch len(data.Releases) {
+		case 0:
+			title = "No new release"
+		case 1:
+			title = "New release " + data.Releases[0].Tag
+		}
+
+		err = ctx.Forge.CreateCheckRun(context.Background(), forge.CheckRun{Name: ctx.CheckRunFlag, Commit: commit, Title: title, Summary: content})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// analyzedCommit returns the hash of the commit of a branch whose history was analyzed.
+func analyzedCommit(ctx *appcontext.AppContext, repository *vcs.GitRepository, branch string) (string, error) {
+	if ctx.AtFlag == "" {
+		return repository.Head(branch)
+	}
 
-			ctx.Rules, err = configureRules(ctx)
-			if err != nil {
-				return fmt.Errorf("loading rules configuration: %w", err)
-			}
+	hash, err := repository.Git().ResolveRevision(plumbing.Revision(ctx.AtFlag))
+	if err != nil {
+		return "", fmt.Errorf("resolving commit %q: %w", ctx.AtFlag, err)
+	}
 
-			ctx.Branches, err = configureBranches(ctx)
-			if err != nil {
-				return fmt.Errorf("loading branches configuration: %w", err)
-			}
+	return hash.String(), nil
+}
 
-			ctx.Projects, err = configureProjects(ctx)
-			if err != nil {
-				return fmt.Errorf("loading projects configuration: %w", err)
-			}
+// publishReleaseSummary writes the summary of the new releases of a run to the configured file, posts it on the
+// configured pull request, and publishes it as a forge release if a release tag is configured. A forge release is
+// created on a single branch, the releases of the run must therefore all be made on the same branch, as in a monorepo
+// released from its main branch.
+func publishReleaseSummary(ctx *appcontext.AppContext, renderer *render.Renderer, summary render.ReleaseSummaryData, attestations []provenance.Envelope) error {
+	if ctx.ReleaseSummaryFlag == "" && ctx.ReleaseSummaryTagFlag == "" && ctx.ReleaseSummaryCommentFlag == 0 {
+		return nil
+	}
 
-			origin = remote.New(ctx.RemoteNameFlag, ctx.AccessTokenFlag)
+	content, err := renderer.String(render.ReleaseSummary, summary)
+	if err != nil {
+		return err
+	}
 
-			repository, err = origin.Clone(args[0])
-			if err != nil {
-				return fmt.Errorf("cloning Git repository: %w", err)
-			}
+	if ctx.ReleaseSummaryFlag != "" {
+		if err = os.MkdirAll(filepath.Dir(ctx.ReleaseSummaryFlag), 0o755); err != nil {
+			return fmt.Errorf("creating release summary directory: %w", err)
+		}
 
-			outputs, err := parser.New(ctx).Run(context.Background(), repository)
-			if err != nil {
-				return fmt.Errorf("computing new semver: %w", err)
+		if err = os.WriteFile(ctx.ReleaseSummaryFlag, []byte(content), 0o644); err != nil {
+			return fmt.Errorf("writing release summary: %w", err)
+		}
+	}
+
+	// The summary file is written even without new release, so that automation can rely on its counts, but there is
+	// nothing to comment or publish.
+	if len(summary.Releases) == 0 {
+		return nil
+	}
+
+	if ctx.ReleaseSummaryCommentFlag > 0 && !ctx.DryRunFlag.Suppresses(dryrun.Comment) {
+		err = ctx.Forge.CommentPullRequest(context.Background(), ctx.ReleaseSummaryCommentFlag, content)
+		if err != nil {
+			return err
+		}
+	}
+
+	if ctx.ReleaseSummaryTagFlag == "" || ctx.DryRunFlag.Suppresses(dryrun.Release) {
+		return nil
+	}
+
+	branchName := summary.Releases[0].Branch
+
+	for _, release := range summary.Releases {
+		if release.Branch != branchName {
+			return fmt.Errorf("%w: %q and %q", ErrSummaryBranches, branchName, release.Branch)
+		}
+	}
+
+	tagName, err := render.Inline(ctx.ReleaseSummaryTagFlag, summary)
+	if err != nil {
+		return fmt.Errorf("rendering release summary tag: %w", err)
+	}
+
+	release := forge.Release{
+		Tag:    tagName,
+		Target: branchName,
+		Name:   tagName,
+		Body:   content,
+	}
+
+	if len(attestations) > 0 {
+		bundle, err := provenance.Bundle(attestations)
+		if err != nil {
+			return err
+		}
+
+		release.Assets = append(release.Assets, forge.Asset{Name: provenance.BundleName, ContentType: provenance.BundleContentType, Content: bundle})
+	}
+
+	return ctx.Forge.CreateRelease(context.Background(), release)
+}
+
+// attestProvenance appends the provenance attestation of a pushed release, signed with the key of its tag if any, to
+// the provenance file, if one is configured, and returns it. Releases previewed by a dry-run are not attested.
+func attestProvenance(ctx *appcontext.AppContext, repository vcs.Repository, tagger *tag.Tagger, signKey *openpgp.Entity, repositoryPath string, output parser.ComputeNewSemverOutput) (*provenance.Envelope, error) {
+	if ctx.ProvenanceFileFlag == "" || ctx.DryRunFlag.Suppresses(dryrun.Push) {
+		return nil, nil
+	}
+
+	release := provenance.Release{
+		Repository: remoteURL(ctx, repositoryPath),
+		Branch:     output.Branch,
+		Project:    output.Project.Name,
+		Version:    output.Semver.String(),
+		Tag:        tagger.Format(output.Semver),
+		Commit:     output.CommitHash.String(),
+	}
+
+	if output.PreviousSemver != nil {
+		previous := tagger.Format(output.PreviousSemver)
+
+		tags, err := repository.Tags()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, t := range tags {
+			if t.Name == previous {
+				release.PreviousTag, release.PreviousCommit = t.Name, t.Commit
+				break
 			}
+		}
+	}
+
+	envelope, err := provenance.Seal(provenance.New(release, provenance.Run(time.Now())), signKey)
+	if err != nil {
+		return nil, err
+	}
 
-			tagger := tag.NewTagger(ctx.GitNameFlag, ctx.GitEmailFlag, tag.WithTagPrefix(ctx.TagPrefixFlag), tag.WithSignKey(entity))
+	if err = provenance.Append(ctx.ProvenanceFileFlag, envelope); err != nil {
+		return nil, err
+	}
 
-			for _, output := range outputs {
-				semver := output.Semver
-				release := output.NewRelease
-				commitHash := output.CommitHash
-				project := output.Project.Name
+	return &envelope, nil
+}
 
-				err = ci.GenerateGitHubOutput(semver, output.Branch, ci.WithNewRelease(release), ci.WithTagPrefix(ctx.TagPrefixFlag), ci.WithProject(project))
-				if err != nil {
-					return fmt.Errorf("generating github output: %w", err)
-				}
+// recordDeployment records the deployment of a new tag to the environment of its branch on the forge, so that CD systems
+// can be triggered by it, if deployments are enabled and the branch has an environment.
+func recordDeployment(ctx *appcontext.AppContext, tagName, environment string) error {
+	if !ctx.DeploymentsFlag || environment == "" || ctx.DryRunFlag.Suppresses(dryrun.Deployment) {
+		return nil
+	}
 
-				logEvent := ctx.Logger.Info()
-				logEvent.Bool("new-release", release)
-				logEvent.Str("version", semver.String())
-				logEvent.Str("branch", output.Branch)
+	return ctx.Forge.CreateDeployment(context.Background(), forge.Deployment{
+		Ref:         tagName,
+		Environment: environment,
+		Description: "Release " + tagName,
+	})
+}
 
-				if project != "" {
-					logEvent.Str("project", project)
+// configureForge returns a client of the forge hosting the repository when a feature relying on its API is enabled, nil
+// otherwise. The forge is the configured one, or the one inferred from the repository URL. The GitHub API URL can be
+// overridden with the GITHUB_API_URL environment variable, which is set on GitHub Actions runners, so that GitHub
+// Enterprise Server instances are supported. Merge queue commits can be expanded without the API, so the forge is only
+// required by the other features.
+func configureForge(ctx *appcontext.AppContext, repositoryPath string) (forge.Client, error) {
+	if err := forge.ValidateKind(ctx.ForgeFlag); err != nil {
+		return nil, err
+	}
 
-					tagger.SetProjectName(project)
-				}
+	required := len(ctx.RequireChecksFlag) > 0 || ctx.ReleaseSummaryTagFlag != "" || ctx.ReleaseSummaryCommentFlag > 0 || ctx.CheckRunFlag != "" || ctx.DeploymentsFlag || ctx.ContributorHandlesFlag || ctx.PullRequestNotesFlag
 
-				switch {
-				case !release:
-					logEvent.Msg("no new release")
-					return nil
-				case release && ctx.DryRunFlag:
-					logEvent.Msg("dry-run enabled, next release found")
-					return nil
-				default:
-					logEvent.Msg("new release found")
-
-					err = tagger.TagRepository(repository, semver, commitHash)
-					if err != nil {
-						return fmt.Errorf("tagging repository: %w", err)
-					}
-
-					ctx.Logger.Debug().Str("tag", tagger.Format(semver)).Msg("new tag added to repository")
-
-					err = origin.PushTag(tagger.Format(semver))
-					if err != nil {
-						return fmt.Errorf("pushing tag to remote: %w", err)
-					}
-				}
+	if !required && !ctx.MergeQueueFlag {
+		return nil, nil
+	}
+
+	client, err := forge.New(repositoryPath, ctx.AccessTokenFlag, ctx.ForgeFlag, os.Getenv("GITHUB_API_URL"))
+	if err != nil && !required {
+		ctx.Logger.Debug().Err(err).Msg("forge API unavailable, merge queue commits are expanded from their message")
+		return nil, nil
+	}
+
+	return client, err
+}
+
+// configureLinks returns the links of the changelogs to the commit and pull request pages of the repository, derived
+// from its URL, or from the URL of its remote if it is a local path, unless their URL templates are configured.
+func configureLinks(ctx *appcontext.AppContext, repositoryPath string) (forge.Links, error) {
+	links := forge.DefaultLinks(remoteURL(ctx, repositoryPath))
+
+	if ctx.CommitURLTemplateFlag != "" {
+		links.Commit = ctx.CommitURLTemplateFlag
+	}
+
+	if ctx.PullRequestURLTemplateFlag != "" {
+		links.PullRequest = ctx.PullRequestURLTemplateFlag
+	}
+
+	if err := links.Validate(); err != nil {
+		return forge.Links{}, err
+	}
+
+	return links, nil
+}
+
+// remoteURL returns the URL of the configured remote of a local repository, or the given path if it is not one of a
+// local repository with such a remote, e.g. a URL.
+func remoteURL(ctx *appcontext.AppContext, repositoryPath string) string {
+	if _, err := os.Stat(repositoryPath); err != nil {
+		return repositoryPath
+	}
+
+	repository, err := git.PlainOpen(repositoryPath)
+	if err != nil {
+		return repositoryPath
+	}
+
+	r, err := repository.Remote(ctx.RemoteNameFlag)
+	if err != nil || len(r.Config().URLs) == 0 {
+		return repositoryPath
+	}
+
+	return r.Config().URLs[0]
+}
+
+// configureWorkspace returns the manager of the temporary directories of the run, after sweeping the ones left behind
+// by previous runs. Failing to sweep them does not prevent the run.
+func configureWorkspace(ctx *appcontext.AppContext) *workspace.Manager {
+	manager := workspace.New(workspace.WithKeep(ctx.KeepWorkspaceFlag), workspace.WithTTL(ctx.WorkspaceTTLFlag))
+
+	swept, err := manager.Sweep()
+	if err != nil {
+		ctx.Logger.Warn().Err(err).Msg("failed to sweep stale workspaces")
+	}
+
+	for _, dir := range swept {
+		ctx.Logger.Debug().Str("path", dir).Msg("stale workspace removed")
+	}
+
+	return manager
+}
+
+// cleanupWorkspace removes the temporary directories of the run, or logs their path when they are kept.
+func cleanupWorkspace(ctx *appcontext.AppContext) error {
+	if ctx.KeepWorkspaceFlag {
+		for _, dir := range ctx.Workspace.Dirs() {
+			ctx.Logger.Info().Str("path", dir).Msg("workspace kept")
+		}
+	}
+
+	return ctx.Workspace.Cleanup()
+}
+
+// requireChecks refuses to release a commit whose required checks are failing or pending.
+func requireChecks(ctx *appcontext.AppContext, client forge.Client, commit string) error {
+	checks, err := client.Checks(context.Background(), commit)
+	if err != nil {
+		return err
+	}
+
+	for _, check := range checks {
+		ctx.Logger.Debug().Str("commit", commit).Str("check", check.Name).Str("state", string(check.State)).Msg("check reported")
+	}
+
+	return forge.RequireChecks(checks, ctx.RequireChecksFlag)
+}
+
+// configureGitHubEnvironment returns the path or URL of the repository to release and, when running on a GitHub
+// Actions runner, fills the configuration values that were not set with the ones inferred from the runner environment.
+func configureGitHubEnvironment(ctx *appcontext.AppContext, args []string) (string, error) {
+	var repositoryPath string
+
+	if len(args) > 0 {
+		repositoryPath = args[0]
+	} else {
+		repositoryPath = ctx.RepositoryFlag
+	}
+
+	environment, ok := ci.DetectGitHubEnvironment()
+	if !ok {
+		if repositoryPath == "" {
+			return "", errors.New("a repository path or URL is required when not running on GitHub Actions")
+		}
+		return repositoryPath, nil
+	}
+
+	if repositoryPath == "" {
+		if environment.RepositoryURL == "" {
+			return "", errors.New("inferring repository URL: GITHUB_SERVER_URL or GITHUB_REPOSITORY is not set")
+		}
+
+		repositoryPath = environment.RepositoryURL
+		ctx.Logger.Debug().Str("url", repositoryPath).Msg("repository inferred from GitHub Actions environment")
+	}
+
+	if ctx.AccessTokenFlag == "" && environment.Token != "" {
+		ctx.AccessTokenFlag = environment.Token
+		ctx.Logger.Debug().Msg("access token inferred from GitHub Actions environment")
+	}
+
+	if len(ctx.BranchesFlag) == 0 && environment.Branch != "" {
+		ctx.BranchesFlag = branch.Flag{{"name": environment.Branch}}
+		ctx.Logger.Debug().Str("branch", environment.Branch).Msg("branch inferred from GitHub Actions environment")
+	}
+
+	return repositoryPath, nil
+}
+
+// fetchAtCommit makes sure the commit to analyze is available in the cloned repository. A commit that is not reachable
+// from any branch or tag is not cloned and has to be fetched explicitly, which requires its full SHA-1.
+func fetchAtCommit(ctx *appcontext.AppContext, repository *git.Repository, origin *remote.Remote) error {
+	_, err := repository.ResolveRevision(plumbing.Revision(ctx.AtFlag))
+	if err == nil {
+		return nil
+	}
+
+	if !plumbing.IsHash(ctx.AtFlag) {
+		return fmt.Errorf("resolving commit %q: %w", ctx.AtFlag, err)
+	}
+
+	return origin.FetchCommit(plumbing.NewHash(ctx.AtFlag))
+}
+
+// acquireLocks acquires the release lock of every configured branch and refreshes the repository, since other
+// processes may have released these branches since the repository was cloned.
+func acquireLocks(ctx *appcontext.AppContext, origin *remote.Remote) ([]*remote.Lock, error) {
+	var locks []*remote.Lock
+
+	for _, b := range ctx.Branches {
+		lock, err := origin.Lock(b.Name, audit.Actor(), ctx.LockTTLFlag)
+		if err != nil {
+			for _, acquired := range locks {
+				err = errors.Join(err, acquired.Release())
 			}
+			return nil, fmt.Errorf("locking branch %q: %w", b.Name, err)
+		}
 
-			return nil
-		},
+		ctx.Logger.Debug().Str("branch", b.Name).Msg("release lock acquired")
+
+		locks = append(locks, lock)
 	}
 
-	return releaseCmd
+	err := origin.Refresh()
+	if err != nil {
+		for _, lock := range locks {
+			err = errors.Join(err, lock.Release())
+		}
+		return nil, fmt.Errorf("refreshing repository: %w", err)
+	}
+
+	return locks, nil
+}
+
+// checkPush checks that the remote accepts the tags of the new releases before any of them is made, so that missing
+// permissions and tags already pushed by another process fail the run before anything is released. Nothing is pushed,
+// so the check is made in dry-run mode too.
+func checkPush(ctx *appcontext.AppContext, origin *remote.Remote, tagger *tag.Tagger, outputs []parser.ComputeNewSemverOutput) error {
+	if origin == nil {
+		return errors.New("checking push: repository has no remote")
+	}
+
+	var refNames []plumbing.ReferenceName
+
+	for _, output := range outputs {
+		if output.Error != nil || !output.NewRelease || output.Skipped {
+			continue
+		}
+
+		tagger.SetProjectName(output.Project.Name)
+		tagger.SetTagPrefix(output.TagPrefix)
+
+		refNames = append(refNames, plumbing.NewTagReferenceName(tagger.Format(output.Semver)))
+	}
+
+	tagger.SetProjectName("")
+
+	err := origin.CheckPush(refNames...)
+	if err != nil {
+		return fmt.Errorf("checking push: %w", err)
+	}
+
+	ctx.Logger.Debug().Int("tags", len(refNames)).Msg("remote accepts push")
+
+	return nil
+}
+
+// appendAuditRecord writes a record of the given action to the audit log, if one is configured.
+func appendAuditRecord(logger *audit.Logger, action string, record audit.Record) error {
+	if logger == nil {
+		return nil
+	}
+
+	record.Action = action
+
+	if err := logger.Append(record); err != nil {
+		return fmt.Errorf("writing %s action to audit log: %w", action, err)
+	}
+
+	return nil
 }
 
 func configureRules(ctx *appcontext.AppContext) (rule.Rules, error) {
 	flag := ctx.RulesFlag
 
+	order, err := rule.ParseOrder(ctx.RuleOrderFlag)
+	if err != nil {
+		return rule.Rules{}, fmt.Errorf("parsing rule order: %w", err)
+	}
+
 	if flag.String() == "{}" {
-		return rule.Default, nil
+		rules := rule.Default
+		rules.Order = order
+
+		return rules, nil
 	}
 
 	rulesJSON := map[string][]string(flag)
@@ -134,9 +2142,30 @@ func configureRules(ctx *appcontext.AppContext) (rule.Rules, error) {
 		return unmarshalledRules, fmt.Errorf("parsing rules configuration: %w", err)
 	}
 
+	unmarshalledRules.Order = order
+
 	return unmarshalledRules, nil
 }
 
+// configureTargets returns the repositories to release when several are configured, each with its own branches and
+// projects. A single repository is given by the positional argument or the repository flag instead.
+func configureTargets(ctx *appcontext.AppContext, args []string) ([]target.Target, error) {
+	if len(ctx.TargetsFlag) == 0 {
+		return nil, nil
+	}
+
+	if len(args) > 0 || ctx.RepositoryFlag != "" {
+		return nil, ErrRepositoryAndTargets
+	}
+
+	targets, err := target.Unmarshall(ctx.TargetsFlag)
+	if err != nil {
+		return nil, fmt.Errorf("parsing targets configuration: %w", err)
+	}
+
+	return targets, nil
+}
+
 func configureBranches(ctx *appcontext.AppContext) ([]branch.Branch, error) {
 	branchesJSON := []map[string]any(ctx.BranchesFlag)
 
@@ -151,6 +2180,20 @@ func configureBranches(ctx *appcontext.AppContext) ([]branch.Branch, error) {
 func configureProjects(ctx *appcontext.AppContext) ([]monorepo.Project, error) {
 	flag := ctx.MonorepositoryFlag
 
+	// A repository released from a subdirectory is handled as a monorepo made of a single project.
+	if ctx.RootPathFlag != "" {
+		if flag.String() != "[]" || ctx.DiscoverProjectsFlag {
+			return nil, ErrRootPathAndMonorepo
+		}
+
+		project, err := monorepo.RootProject(ctx.RootPathFlag)
+		if err != nil {
+			return nil, fmt.Errorf("parsing root path: %w", err)
+		}
+
+		return []monorepo.Project{project}, nil
+	}
+
 	if flag.String() == "[]" {
 		return nil, nil
 	}
@@ -165,6 +2208,82 @@ func configureProjects(ctx *appcontext.AppContext) ([]monorepo.Project, error) {
 	return projects, nil
 }
 
+// discoverProjects adds the projects defined by the workspaces of the repository, as of the tip of the first configured
+// branch, to the configured ones, which take precedence over the discovered projects of the same name.
+func discoverProjects(ctx *appcontext.AppContext, repository *vcs.GitRepository) error {
+	if !ctx.DiscoverProjectsFlag || len(ctx.Branches) == 0 {
+		return nil
+	}
+
+	head, err := repository.Head(ctx.Branches[0].Name)
+	if err != nil {
+		return err
+	}
+
+	commit, err := repository.Git().CommitObject(plumbing.NewHash(head))
+	if err != nil {
+		return fmt.Errorf("fetching commit %s: %w", head, err)
+	}
+
+	files, err := commit.Files()
+	if err != nil {
+		return fmt.Errorf("listing files: %w", err)
+	}
+
+	manifests := make(map[string][]byte)
+
+	err = files.ForEach(func(file *object.File) error {
+		if !monorepo.IsWorkspaceManifest(file.Name) {
+			return nil
+		}
+
+		content, err := file.Contents()
+		if err != nil {
+			return fmt.Errorf("reading %q: %w", file.Name, err)
+		}
+
+		manifests[file.Name] = []byte(content)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	discovered, err := monorepo.Discover(manifests)
+	if err != nil {
+		return err
+	}
+
+	projects := monorepo.Merge(discovered, ctx.Projects)
+
+	if _, err = monorepo.Order(projects); err != nil {
+		return err
+	}
+
+	for _, project := range discovered {
+		ctx.Logger.Debug().Str("project", project.Name).Str("path", project.Path).Strs("depends-on", project.DependsOn).Msg("project discovered")
+	}
+
+	ctx.Projects = projects
+
+	return nil
+}
+
+func configureTagAliases(ctx *appcontext.AppContext) (map[string]*semver.Version, error) {
+	aliases := make(map[string]*semver.Version, len(ctx.TagAliasesFlag))
+
+	for tagName, version := range ctx.TagAliasesFlag {
+		parsed, err := semver.NewFromString(version)
+		if err != nil {
+			return nil, fmt.Errorf("parsing version of tag %q: %w", tagName, err)
+		}
+
+		aliases[strings.ToLower(tagName)] = parsed
+	}
+
+	return aliases, nil
+}
+
 func configureGPGKey(ctx *appcontext.AppContext) (*openpgp.Entity, error) {
 	flag := ctx.GPGKeyPathFlag
 
@@ -172,17 +2291,153 @@ func configureGPGKey(ctx *appcontext.AppContext) (*openpgp.Entity, error) {
 		return nil, nil
 	}
 
-	ctx.Logger.Debug().Str("path", ctx.GPGKeyPathFlag).Msg("using the following armored key for signing")
+	return loadGPGKey(ctx, flag)
+}
+
+// configureSignKeys loads the GPG keys of the branches and projects that override the default one, indexed by path so
+// that a key shared by several branches or projects is only loaded once.
+func configureSignKeys(ctx *appcontext.AppContext) (map[string]*openpgp.Entity, error) {
+	keys := make(map[string]*openpgp.Entity)
+
+	var paths []string
+
+	for _, b := range ctx.Branches {
+		paths = append(paths, b.GPGKeyPath)
+	}
+
+	for _, project := range ctx.Projects {
+		paths = append(paths, project.GPGKeyPath)
+	}
+
+	for _, path := range paths {
+		if _, ok := keys[path]; ok || path == "" {
+			continue
+		}
+
+		entity, err := loadGPGKey(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+
+		keys[path] = entity
+	}
+
+	return keys, nil
+}
+
+// selectIdentity returns the name and email of the tagger of the tags of a given output. Each one is the one of its
+// project if any, otherwise the one of its branch if any, otherwise the default one.
+func selectIdentity(ctx *appcontext.AppContext, output parser.ComputeNewSemverOutput) (string, string) {
+	name, email := ctx.GitNameFlag, ctx.GitEmailFlag
+
+	for _, b := range ctx.Branches {
+		if b.Name != output.Branch {
+			continue
+		}
+
+		if b.GitName != "" {
+			name = b.GitName
+		}
+
+		if b.GitEmail != "" {
+			email = b.GitEmail
+		}
+	}
+
+	if output.Project.GitName != "" {
+		name = output.Project.GitName
+	}
+
+	if output.Project.GitEmail != "" {
+		email = output.Project.GitEmail
+	}
+
+	return name, email
+}
+
+// selectSignKey returns the key signing the tags of a given output: the one of its project if any, otherwise the one
+// of its branch if any, otherwise the default one.
+func selectSignKey(ctx *appcontext.AppContext, keys map[string]*openpgp.Entity, defaultKey *openpgp.Entity, output parser.ComputeNewSemverOutput) *openpgp.Entity {
+	if key, ok := keys[output.Project.GPGKeyPath]; ok {
+		return key
+	}
+
+	for _, b := range ctx.Branches {
+		if b.Name != output.Branch {
+			continue
+		}
 
-	armoredKeyFile, err := os.ReadFile(ctx.GPGKeyPathFlag)
+		if key, ok := keys[b.GPGKeyPath]; ok {
+			return key
+		}
+	}
+
+	return defaultKey
+}
+
+// configureTrustedKeys loads the public keys trusted to sign tags and validates the policy applied to untrusted tags.
+func configureTrustedKeys(ctx *appcontext.AppContext) (openpgp.EntityList, error) {
+	err := parser.ValidateUntrustedTagsPolicy(ctx.UntrustedTagsFlag)
 	if err != nil {
-		return nil, fmt.Errorf("reading armored key: %w", err)
+		return nil, err
+	}
+
+	var keyring openpgp.EntityList
+
+	for _, path := range ctx.TrustedKeysFlag {
+		armoredKeyRing, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading trusted key: %w", err)
+		}
+
+		entities, err := gpg.KeyRingFromArmored(bytes.NewReader(armoredKeyRing))
+		if err != nil {
+			return nil, fmt.Errorf("loading trusted key %q: %w", path, err)
+		}
+
+		keyring = append(keyring, entities...)
+	}
+
+	return keyring, nil
+}
+
+func loadGPGKey(ctx *appcontext.AppContext, path string) (*openpgp.Entity, error) {
+	var armoredKey []byte
+
+	if keychain.IsReference(path) {
+		ctx.Logger.Debug().Str("reference", path).Msg("using the following keychain armored key for signing")
+
+		secret, err := keychain.Get(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading armored key: %w", err)
+		}
+
+		armoredKey = []byte(secret)
+	} else {
+		ctx.Logger.Debug().Str("path", path).Msg("using the following armored key for signing")
+
+		armoredKeyFile, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading armored key: %w", err)
+		}
+
+		armoredKey = armoredKeyFile
 	}
 
-	entity, err := gpg.FromArmored(bytes.NewReader(armoredKeyFile))
+	entity, err := gpg.FromArmored(bytes.NewReader(armoredKey))
 	if err != nil {
 		return nil, fmt.Errorf("loading armored key: %w", err)
 	}
 
+	passphrase, err := keychain.Resolve(ctx.GPGPassphraseFlag)
+	if err != nil {
+		return nil, fmt.Errorf("reading armored key passphrase: %w", err)
+	}
+
+	err = gpg.Unlock(entity, []byte(passphrase))
+	if err != nil {
+		return nil, fmt.Errorf("unlocking armored key: %w", err)
+	}
+
 	return entity, nil
 }
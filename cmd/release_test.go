@@ -3,23 +3,55 @@ package cmd
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	assertion "github.com/stretchr/testify/assert"
+	"github.com/zalando/go-keyring"
 
 	"github.com/s0ders/go-semver-release/v6/internal/appcontext"
+	"github.com/s0ders/go-semver-release/v6/internal/audit"
 	"github.com/s0ders/go-semver-release/v6/internal/branch"
-	"github.com/s0ders/go-semver-release/v6/internal/gittest"
+	"github.com/s0ders/go-semver-release/v6/internal/changelog"
+	"github.com/s0ders/go-semver-release/v6/internal/ci"
+	"github.com/s0ders/go-semver-release/v6/internal/dryrun"
+	"github.com/s0ders/go-semver-release/v6/internal/event"
+	"github.com/s0ders/go-semver-release/v6/internal/forge"
+	"github.com/s0ders/go-semver-release/v6/internal/gpg"
 	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
+	"github.com/s0ders/go-semver-release/v6/internal/parser"
+	"github.com/s0ders/go-semver-release/v6/internal/provenance"
+	"github.com/s0ders/go-semver-release/v6/internal/remote"
+	"github.com/s0ders/go-semver-release/v6/internal/render"
 	"github.com/s0ders/go-semver-release/v6/internal/rule"
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
 	"github.com/s0ders/go-semver-release/v6/internal/tag"
+	"github.com/s0ders/go-semver-release/v6/internal/terraform"
+	"github.com/s0ders/go-semver-release/v6/internal/vcs"
+	"github.com/s0ders/go-semver-release/v6/pkg/gittest"
+	"github.com/s0ders/go-semver-release/v6/pkg/sink"
 )
 
 type cmdOutput struct {
@@ -49,6 +81,126 @@ func TestReleaseCmd_ConfigurationAsEnvironmentVariable(t *testing.T) {
 	assert.Equal(accessToken, th.Ctx.AccessTokenFlag, "access token flag value should be equal to environment variable value")
 }
 
+func TestReleaseCmd_BranchesShorthand(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	t.Setenv("GO_SEMVER_RELEASE_BRANCHES", "master:environment=production")
+
+	th := NewTestHelper(t)
+
+	_, err := th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.Equal(branch.Flag{{"name": "master", "environment": "production"}}, th.Ctx.BranchesFlag)
+
+	_, err = testRepository.Tag("v0.1.0")
+	assert.NoError(err, "tag should have been created on the shorthand branch")
+}
+
+func TestReleaseCmd_RepositoryFlag(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{BranchesConfiguration: "master", RepositoryConfiguration: testRepository.Path})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release")
+	checkErr(t, err, "executing command")
+
+	_, err = testRepository.Tag("v0.1.0")
+	assert.NoError(err, "repository given by flag should have been released")
+}
+
+func TestReleaseCmd_Targets(t *testing.T) {
+	assert := assertion.New(t)
+
+	fooRepository := NewTestRepository(t, []string{"feat"})
+	barRepository := NewTestRepository(t, []string{"fix"})
+
+	_, err := barRepository.AddCommitWithSpecificFile("feat", "./api/api.txt")
+	checkErr(t, err, "adding commit")
+
+	t.Setenv("GO_SEMVER_RELEASE_ACCESS_TOKEN", "")
+
+	cfgPath := filepath.Join(t.TempDir(), ".semver.yaml")
+	writeFile(t, cfgPath, `
+targets:
+  - repository: `+fooRepository.Path+`
+    branches: master
+  - repository: `+barRepository.Path+`
+    branches:
+      - name: master
+    monorepo:
+      - name: api
+        path: api
+`)
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{"config": cfgPath})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("release")
+	checkErr(t, err, "executing command")
+
+	assert.Contains(string(out), `"repository":"`+fooRepository.Path+`"`, "outputs should tell their target apart")
+
+	_, err = fooRepository.Tag("v0.1.0")
+	assert.NoError(err, "first target should have been released")
+
+	_, err = barRepository.Tag("api-v0.1.0")
+	assert.NoError(err, "second target should have been released with its own projects")
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{"config": cfgPath})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", fooRepository.Path)
+	assert.ErrorIs(err, ErrRepositoryAndTargets)
+}
+
+func TestReleaseCmd_Targets_ContinueOnError(t *testing.T) {
+	assert := assertion.New(t)
+
+	barRepository := NewTestRepository(t, []string{"feat"})
+
+	missing := filepath.Join(t.TempDir(), "missing")
+
+	t.Setenv("GO_SEMVER_RELEASE_ACCESS_TOKEN", "")
+
+	cfgPath := filepath.Join(t.TempDir(), ".semver.yaml")
+	writeFile(t, cfgPath, `
+targets:
+  - repository: `+missing+`
+    branches: master
+  - repository: `+barRepository.Path+`
+    branches: master
+`)
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{"config": cfgPath})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release")
+	assert.ErrorContains(err, missing)
+
+	_, err = barRepository.Tag("v0.1.0")
+	assert.Error(err, "no target should be released after a failure by default")
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{"config": cfgPath, ContinueOnErrorConfiguration: "true"})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release")
+	assert.ErrorContains(err, missing, "the run should still fail")
+
+	_, err = barRepository.Tag("v0.1.0")
+	assert.NoError(err, "the target following the failed one should be released")
+}
+
 func TestReleaseCmd_ConfigurationAsFile(t *testing.T) {
 	assert := assertion.New(t)
 
@@ -347,6 +499,183 @@ func TestReleaseCmd_RemoteRelease(t *testing.T) {
 	assert.Equal(true, exists, "tag not found")
 }
 
+func TestReleaseCmd_BareRepository(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"fix", "feat"})
+
+	bareRepository, err := testRepository.BareClone()
+	checkErr(t, err, "cloning bare repository")
+
+	t.Cleanup(func() {
+		_ = bareRepository.Remove()
+	})
+
+	th := NewTestHelper(t)
+	err = th.SetFlag(BranchesConfiguration, `[{"name": "master"}]`)
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("release", bareRepository.Path)
+	checkErr(t, err, "executing command")
+
+	expectedOut := cmdOutput{
+		Message:    "new release found",
+		Version:    "0.1.0",
+		NewRelease: true,
+		Branch:     "master",
+	}
+	actualOut := cmdOutput{}
+
+	err = json.Unmarshal(out, &actualOut)
+	checkErr(t, err, "unmarshalling output")
+
+	assert.Equal(expectedOut, actualOut, "releaseCmd output should be equal")
+
+	exists, err := tag.Exists(bareRepository.Repository, "v0.1.0")
+	checkErr(t, err, "checking if tag exists")
+
+	assert.Equal(true, exists, "tag should have been pushed to the bare repository")
+}
+
+func TestReleaseCmd_CacheDir(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"fix", "feat"})
+	cacheDir := t.TempDir()
+
+	release := func() cmdOutput {
+		th := NewTestHelper(t)
+		err := th.SetFlags(map[string]string{
+			BranchesConfiguration:   `[{"name": "master"}]`,
+			RemoteNameConfiguration: "origin",
+			CacheDirConfiguration:   cacheDir,
+		})
+		checkErr(t, err, "setting flags")
+
+		out, err := th.ExecuteCommand("release", testRepository.Path)
+		checkErr(t, err, "executing command")
+
+		actualOut := cmdOutput{}
+
+		err = json.Unmarshal(out, &actualOut)
+		checkErr(t, err, "unmarshalling output")
+
+		return actualOut
+	}
+
+	assert.Equal("0.1.0", release().Version)
+
+	_, err := testRepository.AddCommit("feat")
+	checkErr(t, err, "adding commit")
+
+	assert.Equal("0.2.0", release().Version, "the cached clone should be updated with the new commits and tags")
+
+	entries, err := os.ReadDir(cacheDir)
+	checkErr(t, err, "reading cache directory")
+
+	assert.Len(entries, 1, "the repository should be cloned once")
+
+	exists, err := tag.Exists(testRepository.Repository, "v0.2.0")
+	checkErr(t, err, "checking if tag exists")
+
+	assert.Equal(true, exists, "tag not found")
+}
+
+func TestReleaseCmd_Workspace(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"fix", "feat"})
+
+	for _, keep := range []bool{false, true} {
+		tempDir := t.TempDir()
+		t.Setenv("TMPDIR", tempDir)
+
+		th := NewTestHelper(t)
+		err := th.SetFlags(map[string]string{
+			BranchesConfiguration:      `[{"name": "master"}]`,
+			DryRunConfiguration:        "true",
+			KeepWorkspaceConfiguration: strconv.FormatBool(keep),
+		})
+		checkErr(t, err, "setting flags")
+
+		_, err = th.ExecuteCommand("release", testRepository.Path)
+		checkErr(t, err, "executing command")
+
+		entries, err := os.ReadDir(tempDir)
+		checkErr(t, err, "reading temporary directory")
+
+		if keep {
+			assert.Len(entries, 1, "the workspace should be kept")
+		} else {
+			assert.Empty(entries, "the workspace should be removed")
+		}
+	}
+}
+
+func TestReleaseCmd_TagNamespace(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"fix", "feat"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration:     `[{"name": "master"}]`,
+		TagNamespaceConfiguration: "releases",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	exists, err := tag.Exists(testRepository.Repository, "releases/v0.1.0")
+	checkErr(t, err, "checking if tag exists")
+
+	assert.True(exists, "tag should have been pushed under the namespace")
+
+	exists, err = tag.Exists(testRepository.Repository, "v0.1.0")
+	checkErr(t, err, "checking if tag exists")
+
+	assert.False(exists, "tag should not have been pushed outside the namespace")
+}
+
+func TestReleaseCmd_Epoch(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"fix", "feat"})
+
+	head, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	err = testRepository.AddTag("v9.4.0", head.Hash())
+	checkErr(t, err, "adding tag")
+
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit")
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration:     `[{"name": "master"}]`,
+		TagNamespaceConfiguration: "releases",
+		EpochConfiguration:        "1",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	exists, err := tag.Exists(testRepository.Repository, "releases/epoch1/v0.1.1")
+	checkErr(t, err, "checking if tag exists")
+
+	assert.True(exists, "tag should have been pushed under the epoch namespace, ignoring the tags of other epochs")
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{BranchesConfiguration: `[{"name": "master"}]`, EpochConfiguration: "-1"})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, tag.ErrInvalidEpoch)
+}
+
 func TestReleaseCmd_MultiBranchRelease(t *testing.T) {
 	assert := assertion.New(t)
 
@@ -572,6 +901,80 @@ func TestReleaseCmd_DryRunRelease(t *testing.T) {
 	assert.Equal(false, exists, "tag should not exist, running in dry-run mode")
 }
 
+func TestReleaseCmd_DryRunSideEffects(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+	summaryPath := filepath.Join(t.TempDir(), "summary.md")
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration:       `[{"name": "master"}]`,
+		ReleaseSummaryConfiguration: summaryPath,
+		DryRunConfiguration:         "push",
+	})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.Contains(string(out), `"message":"new release found"`, "the release should be tagged")
+	assert.Equal(dryrun.Flag{dryrun.Push, dryrun.Deployment}, th.Ctx.DryRunFlag)
+
+	exists, err := tag.Exists(testRepository.Repository, "0.1.0")
+	checkErr(t, err, "checking if tag exists")
+	assert.False(exists, "tag should not have been pushed")
+
+	summary, err := os.ReadFile(summaryPath)
+	checkErr(t, err, "reading release summary")
+	assert.Contains(string(summary), "**Preview:**", "the summary should be marked as a preview")
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{BranchesConfiguration: `[{"name": "master"}]`, DryRunConfiguration: "commit"})
+	assert.ErrorContains(err, dryrun.ErrUnknownEffect.Error())
+}
+
+func TestReleaseCmd_Policies(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	policies := `[{"name": "minor-approval", "expression": "release.type == 'minor' && release.previous == '0.0.0'", "action": "require-approval", "message": "first release"}]`
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{BranchesConfiguration: "master", PolicyConfiguration: policies})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.Contains(string(out), `"message":"release held until the policy is approved"`)
+	assert.Contains(string(out), `"reason":"first release"`)
+	assert.Contains(string(out), `"new-release":false,"version":"0.0.0"`, "the latest version should be reported")
+
+	_, err = testRepository.Tag("v0.1.0")
+	assert.Error(err, "a held release should not be tagged")
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{BranchesConfiguration: "master", PolicyConfiguration: policies, ApproveConfiguration: "minor-approval"})
+	checkErr(t, err, "setting flags")
+
+	out, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.Contains(string(out), `"message":"new release found"`)
+
+	_, err = testRepository.Tag("v0.1.0")
+	assert.NoError(err, "an approved release should be tagged")
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{BranchesConfiguration: "master", PolicyConfiguration: `[{"name": "invalid", "expression": "release.type =="}]`})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorContains(err, "loading policies configuration")
+}
+
 func TestReleaseCmd_ReleaseNoNewVersion(t *testing.T) {
 	assert := assertion.New(t)
 
@@ -598,375 +1001,2974 @@ func TestReleaseCmd_ReleaseNoNewVersion(t *testing.T) {
 	assert.Equal(expectedOut, actualOut, "releaseCmd output should be equal")
 }
 
-func TestReleaseCmd_ReadOnlyGitHubOutput(t *testing.T) {
+func TestReleaseCmd_SkipMarker(t *testing.T) {
 	assert := assertion.New(t)
 
-	outputDir, err := os.MkdirTemp("./", "output-*")
-	checkErr(t, err, "creating output directory")
+	testRepository := NewTestRepository(t, []string{"feat"})
 
-	defer func() {
-		err = os.RemoveAll(outputDir)
-		checkErr(t, err, "removing output directory")
-	}()
+	_, err := testRepository.AddCommitWithMessage("fix: fix foo [skip release]")
+	checkErr(t, err, "adding commit")
 
-	outputFilePath := filepath.Join(outputDir, "output")
+	th := NewTestHelper(t)
+	err = th.SetFlag(BranchesConfiguration, `[{"name": "master"}]`)
+	checkErr(t, err, "setting flags")
 
-	outputFile, err := os.OpenFile(outputFilePath, os.O_RDONLY|os.O_CREATE, 0o444)
-	checkErr(t, err, "creating output file")
+	out, err := th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
 
-	defer func() {
-		err = outputFile.Close()
-		checkErr(t, err, "closing output file")
-	}()
+	expectedOut := cmdOutput{
+		Message:    "release skipped by commit marker",
+		NewRelease: false,
+		Branch:     "master",
+		Version:    "0.0.0",
+	}
+	actualOut := cmdOutput{}
 
-	outputPath := filepath.Join(outputDir, "output")
+	err = json.Unmarshal(out, &actualOut)
+	checkErr(t, err, "unmarshalling output")
 
-	err = os.Setenv("GITHUB_OUTPUT", outputPath)
-	checkErr(t, err, "setting GITHUB_OUTPUT environment variable")
+	assert.Equal(expectedOut, actualOut, "releaseCmd output should be equal")
 
-	defer func() {
-		err = os.Unsetenv("GITHUB_OUTPUT")
-		checkErr(t, err, "unsetting GITHUB_OUTPUT environment variable")
-	}()
+	exists, err := tag.Exists(testRepository.Repository, "v0.1.0")
+	checkErr(t, err, "checking if tag exists")
 
-	testRepository := NewTestRepository(t, []string{})
+	assert.False(exists, "no tag should have been created")
+}
+
+func TestReleaseCmd_ForceBump(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"chore"})
 
 	th := NewTestHelper(t)
-	err = th.SetFlag(BranchesConfiguration, `[{"name": "master"}]`)
+	err := th.SetFlags(map[string]string{BranchesConfiguration: `[{"name": "master"}]`, ForceBumpConfiguration: "patch"})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.Contains(string(out), `"forced-release":true`)
+	assert.Contains(string(out), `"message":"forced release found"`)
+
+	exists, err := tag.Exists(testRepository.Repository, "v0.0.1")
+	checkErr(t, err, "checking if tag exists")
+
+	assert.True(exists, "forced release should have been tagged")
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{BranchesConfiguration: `[{"name": "master"}]`, ForceBumpConfiguration: "huge"})
 	checkErr(t, err, "setting flags")
 
 	_, err = th.ExecuteCommand("release", testRepository.Path)
-	assert.ErrorContains(err, "opening ci file", "should have failed trying to write GitHub output to read-only file")
+	assert.ErrorIs(err, parser.ErrInvalidReleaseType)
 }
 
-func TestReleaseCmd_InvalidRepositoryPath(t *testing.T) {
+func TestReleaseCmd_ConfirmMajor(t *testing.T) {
 	assert := assertion.New(t)
 
+	testRepository := NewTestRepository(t, []string{"feat!"})
+
 	th := NewTestHelper(t)
-	_ = th.SetFlag(BranchesConfiguration, `[{"name": "master"}]`)
-	_, err := th.ExecuteCommand("release", "./does/not/exist")
+	err := th.SetFlags(map[string]string{BranchesConfiguration: `[{"name": "master"}]`, MaxVersionSkipConfiguration: "1"})
+	checkErr(t, err, "setting flags")
 
-	assert.ErrorContains(err, "cloning Git repository", "should have failed trying to open inexisting Git repository")
-}
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, parser.ErrUnconfirmedMajor)
 
-func TestReleaseCmd_RepositoryWithNoHead(t *testing.T) {
-	assert := assertion.New(t)
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{BranchesConfiguration: `[{"name": "master"}]`, MaxVersionSkipConfiguration: "1", ConfirmMajorConfiguration: "true"})
+	checkErr(t, err, "setting flags")
 
-	tempDirPath, err := os.MkdirTemp("", "tag-*")
-	if err != nil {
-		t.Fatalf("creating temp dir: %v", err)
-	}
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
 
-	defer func() {
-		err = os.RemoveAll(tempDirPath)
-		if err != nil {
-			t.Fatalf("removing temp dir: %v", err)
-		}
-	}()
+	exists, err := tag.Exists(testRepository.Repository, "v1.0.0")
+	checkErr(t, err, "checking if tag exists")
 
-	_, err = git.PlainInit(tempDirPath, false)
-	if err != nil {
-		t.Fatalf("initializing repository: %v", err)
-	}
+	assert.True(exists, "confirmed major release should have been tagged")
 
-	th := NewTestHelper(t)
-	err = th.SetFlag(BranchesConfiguration, `[{"name": "master"}]`)
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{BranchesConfiguration: `[{"name": "master"}]`, MaxBumpPerRunConfiguration: "huge"})
 	checkErr(t, err, "setting flags")
 
-	_, err = th.ExecuteCommand("release", tempDirPath)
-
-	assert.Error(err, "should have failed trying to compute new semver of repository with no HEAD")
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, parser.ErrInvalidReleaseType)
 }
 
-func TestReleaseCmd_CustomRules(t *testing.T) {
+func TestReleaseCmd_MergeQueue(t *testing.T) {
 	assert := assertion.New(t)
 
-	commits := []string{
-		"fix",  // 0.1.0 (with custom rule)
-		"feat", // 0.2.0
-	}
+	testRepository, err := gittest.New(gittest.CommitMessage("Merge #12 #13\n\n12: feat: add foo r=alice\n\n13: fix: fix bar r=alice"))
+	checkErr(t, err, "creating repository")
 
-	testRepository := NewTestRepository(t, commits)
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
 
 	th := NewTestHelper(t)
-	err := th.SetFlags(map[string]string{
-		BranchesConfiguration: `[{"name": "master"}]`,
-		RulesConfiguration:    `{"minor": ["feat", "fix"]}`,
-	})
+	err = th.SetFlags(map[string]string{BranchesConfiguration: `[{"name": "master"}]`, MergeQueueConfiguration: "true"})
 	checkErr(t, err, "setting flags")
 
 	out, err := th.ExecuteCommand("release", testRepository.Path)
 	checkErr(t, err, "executing command")
 
-	expectedVersion := "0.2.0"
-	expectedTag := "v" + expectedVersion
-	expectedOut := cmdOutput{
-		Message:    "new release found",
-		Version:    expectedVersion,
-		NewRelease: true,
-		Branch:     "master",
-	}
-	actualOut := cmdOutput{}
-
-	err = json.Unmarshal(out, &actualOut)
-	assert.NoError(err, "failed to unmarshal json")
-
-	// Check that the JSON output is correct
-	assert.Equal(expectedOut, actualOut, "releaseCmd output should be equal")
-
-	// Check that the tag was actually created on the repository
-	exists, err := tag.Exists(testRepository.Repository, expectedTag)
-	assert.NoError(err, "failed to check if tag exists")
-
-	assert.Equal(true, exists, "tag should exist")
+	assert.Contains(string(out), `"version":"0.1.1"`, "bundled pull requests should be analyzed from the commit message")
 }
 
-func TestReleaseCmd_Monorepo(t *testing.T) {
+func TestReleaseCmd_Changelog(t *testing.T) {
 	assert := assertion.New(t)
 
-	testRepository, err := gittest.NewRepository()
-	checkErr(t, err, "creating sample repository")
-
-	defer func() {
-		err = testRepository.Remove()
-		checkErr(t, err, "removing repository")
-	}()
-
-	// "foo" commits
-	_, err = testRepository.AddCommitWithSpecificFile("feat", "./foo/foo.txt")
-	checkErr(t, err, "adding commit")
-	_, err = testRepository.AddCommitWithSpecificFile("fix", "./foo/foo2.txt")
-	checkErr(t, err, "adding commit")
+	testRepository := NewTestRepository(t, []string{"feat", "fix", "chore"})
 
-	// "bar" commits
-	_, err = testRepository.AddCommitWithSpecificFile("feat!", "./bar/foo.txt")
-	checkErr(t, err, "adding commit")
-	_, err = testRepository.AddCommitWithSpecificFile("fix", "./bar/foo2.txt")
-	checkErr(t, err, "adding commit")
-	_, err = testRepository.AddCommitWithSpecificFile("fix", "./bar/foo2.txt")
-	checkErr(t, err, "adding commit")
+	dir := t.TempDir()
 
 	th := NewTestHelper(t)
-	err = th.SetFlags(map[string]string{
-		BranchesConfiguration: `[{"name": "master"}]`,
-		MonorepoConfiguration: `[{"name": "foo", "path": "foo"}, {"name": "bar", "path": "bar"}]`,
-	})
+	err := th.SetFlags(map[string]string{BranchesConfiguration: `[{"name": "master"}]`, ChangelogDirConfiguration: dir, DryRunConfiguration: "true"})
 	checkErr(t, err, "setting flags")
 
-	out, err := th.ExecuteCommand("release", testRepository.Path)
+	_, err = th.ExecuteCommand("release", testRepository.Path)
 	checkErr(t, err, "executing command")
 
-	i := 0
-	expectedOutputs := []cmdOutput{
-		{
-			Message:    "new release found",
-			Version:    "0.1.1",
-			NewRelease: true,
-			Branch:     "master",
-			Project:    "foo",
-		},
-		{
-			Message:    "new release found",
-			Version:    "1.0.2",
-			NewRelease: true,
-			Branch:     "master",
-			Project:    "bar",
-		},
-	}
+	content, err := os.ReadFile(filepath.Join(dir, "master.md"))
+	checkErr(t, err, "reading changelog")
 
-	scanner := bufio.NewScanner(bytes.NewReader(out))
+	assert.Contains(string(content), "## [0.1.1] - ")
+	assert.Contains(string(content), "### Added\n\n- this a test commit")
+	assert.Contains(string(content), "### Fixed\n\n- this a test commit")
+	assert.Contains(string(content), "### Contributors\n\n- Go Semver Release\n")
 
-	for scanner.Scan() {
-		rawOutput := scanner.Bytes()
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{BranchesConfiguration: `[{"name": "master"}]`, ChangelogDirConfiguration: dir, ChangelogFormatConfiguration: "conventional-json", DryRunConfiguration: "true"})
+	checkErr(t, err, "setting flags")
 
-		actualOutput := cmdOutput{}
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
 
-		err = json.Unmarshal(rawOutput, &actualOutput)
-		checkErr(t, err, "unmarshalling output")
+	content, err = os.ReadFile(filepath.Join(dir, "master.json"))
+	checkErr(t, err, "reading changelog")
 
-		assert.Equal(expectedOutputs[i], actualOutput)
-		i++
-	}
-	err = scanner.Err()
-	checkErr(t, err, "scanning error")
+	assert.Contains(string(content), `"type": "feat"`)
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{BranchesConfiguration: `[{"name": "master"}]`, ChangelogFormatConfiguration: "html"})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, changelog.ErrUnknownFormat)
 }
 
-func TestReleaseCmd_ConfigureRules_DefaultRules(t *testing.T) {
+func TestReleaseCmd_ChangelogFile(t *testing.T) {
 	assert := assertion.New(t)
-	ctx := NewAppContext()
 
-	rules, err := configureRules(ctx)
-	checkErr(t, err, "configuring rules")
+	testRepository := NewTestRepository(t, []string{"feat", "fix"})
 
-	assert.Equal(rule.Default, rules)
+	path := filepath.Join(t.TempDir(), "{{ .Branch }}", "CHANGELOG.md")
+
+	for range 2 {
+		th := NewTestHelper(t)
+		err := th.SetFlags(map[string]string{BranchesConfiguration: `[{"name": "master"}]`, ChangelogFileConfiguration: path, DryRunConfiguration: "true"})
+		checkErr(t, err, "setting flags")
+
+		_, err = th.ExecuteCommand("release", testRepository.Path)
+		checkErr(t, err, "executing command")
+	}
+
+	content, err := os.ReadFile(filepath.Join(filepath.Dir(filepath.Dir(path)), "master", "CHANGELOG.md"))
+	checkErr(t, err, "reading changelog file")
+
+	assert.True(strings.HasPrefix(string(content), "# Changelog\n\n"+changelog.Marker+"\n\n## [0.1.1] - "))
+	assert.Equal(1, strings.Count(string(content), "## [0.1.1]"), "running a release again should not duplicate its section")
 }
 
-func TestReleaseCmd_ConfigureBranches_NoBranches(t *testing.T) {
+func TestReleaseCmd_ChangelogReleaseAuthors(t *testing.T) {
 	assert := assertion.New(t)
-	ctx := NewAppContext()
 
-	_, err := configureBranches(ctx)
-	assert.ErrorIs(err, branch.ErrNoBranch)
+	feat := parser.Change{Hash: plumbing.NewHash("1111111111111111111111111111111111111111"), Message: "feat: add v2 endpoints", Author: object.Signature{Name: "Jane", Email: "jane@example.com"}}
+	docs := parser.Change{Hash: plumbing.NewHash("2222222222222222222222222222222222222222"), Message: "docs: fix typo\n\nCo-authored-by: John <john@example.com>", Author: object.Signature{Name: "Alice", Email: "alice@example.com"}}
+
+	release := changelogRelease(NewAppContext(), parser.ComputeNewSemverOutput{
+		Semver:  &semver.Version{Minor: 1},
+		Changes: []parser.Change{feat},
+		Commits: []parser.Change{feat, docs},
+	})
+
+	assert.Len(release.Commits, 1, "only the changes triggering the release should be listed")
+	assert.Equal([]changelog.Author{{Name: "Jane", Email: "jane@example.com"}, {Name: "Alice", Email: "alice@example.com"}, {Name: "John", Email: "john@example.com"}}, release.Contributors(), "the authors of every commit should be credited")
 }
 
-func TestReleaseCmd_ConfigureProjects_NoProjects(t *testing.T) {
+func TestReleaseCmd_ResolveContributorHandles(t *testing.T) {
 	assert := assertion.New(t)
+
+	release := changelog.Release{
+		Commits: []changelog.Commit{
+			{Authors: []changelog.Author{{Name: "Jane", Email: "jane@example.com"}, {Name: "John", Email: "john@example.com"}}},
+			{Authors: []changelog.Author{{Name: "Jane", Email: "Jane@example.com"}}},
+		},
+	}
+
 	ctx := NewAppContext()
+	ctx.Forge = fakeForge{handles: map[string]string{"jane@example.com": "jane"}}
 
-	projects, err := configureProjects(ctx)
-	checkErr(t, err, "configuring projects")
+	handles := make(map[string]string)
 
-	assert.Nil(projects, "no monorepo configuration, should have gotten nil")
+	resolveContributorHandles(ctx, &release, handles)
+	assert.Empty(release.Commits[0].Authors[0].Handle, "handles should not be resolved unless enabled")
+
+	ctx.ContributorHandlesFlag = true
+
+	resolveContributorHandles(ctx, &release, handles)
+	assert.Equal("jane", release.Commits[0].Authors[0].Handle)
+	assert.Empty(release.Commits[0].Authors[1].Handle, "contributors without account should be kept without handle")
+	assert.Equal("jane", release.Commits[1].Authors[0].Handle, "handles should be cached case-insensitively")
+	assert.Equal(map[string]string{"jane@example.com": "jane", "john@example.com": ""}, handles)
 }
 
-func TestReleaseCmd_InvalidCustomRules(t *testing.T) {
+func TestReleaseCmd_PullRequestChanges(t *testing.T) {
 	assert := assertion.New(t)
-	ctx := NewAppContext()
 
-	ctx.RulesFlag = map[string][]string{
-		"minor": {"feat"},
-		"patch": {"feat"},
+	squash := plumbing.NewHash("1111111111111111111111111111111111111111")
+	first := plumbing.NewHash("2222222222222222222222222222222222222222")
+	second := plumbing.NewHash("3333333333333333333333333333333333333333")
+	untitled := plumbing.NewHash("4444444444444444444444444444444444444444")
+	direct := plumbing.NewHash("5555555555555555555555555555555555555555")
+
+	changes := []parser.Change{
+		{Hash: squash, Message: "feat: add stuff"},
+		{Hash: first, Message: "fix: typo"},
+		{Hash: second, Message: "feat: part two"},
+		{Hash: untitled, Message: "fix: keep me"},
+		{Hash: direct, Message: "fix: pushed directly"},
 	}
 
-	_, err := configureRules(ctx)
-	assert.ErrorIs(err, rule.ErrDuplicateReleaseRule, "should have failed parsing invalid custom rule")
-}
+	merge := "6666666666666666666666666666666666666666"
 
-func TestReleaseCmd_InvalidBranch(t *testing.T) {
-	assert := assertion.New(t)
 	ctx := NewAppContext()
+	ctx.Forge = fakeForge{pulls: map[string][]forge.PullRequest{
+		squash.String():   {{Number: 7, Title: "feat(api): add v2 endpoints", Body: "Adds the v2 endpoints.\r\n\r\nBREAKING CHANGE: v1 is removed", Merged: true, MergeCommit: squash.String()}},
+		first.String():    {{Number: 8, Title: "fix: open pull request"}, {Number: 9, Title: "feat: two parts (#9)", Merged: true, MergeCommit: merge}},
+		second.String():   {{Number: 9, Title: "feat: two parts (#9)", Merged: true, MergeCommit: merge}},
+		untitled.String(): {{Number: 10, Title: "Update things", Merged: true, MergeCommit: untitled.String()}},
+	}}
 
-	ctx.BranchesFlag = []map[string]any{{"prerelease": true}}
+	pulls := make(map[string][]forge.PullRequest)
 
-	_, err := configureBranches(ctx)
-	assert.ErrorIs(err, branch.ErrNoName, "should have failed parsing branch with no name")
-}
+	assert.Equal(changes, pullRequestChanges(ctx, changes, pulls), "changes should be kept unless pull request notes are enabled")
 
-func TestReleaseCmd_InvalidMonorepoProjects(t *testing.T) {
-	assert := assertion.New(t)
-	ctx := NewAppContext()
+	ctx.PullRequestNotesFlag = true
 
-	ctx.MonorepositoryFlag = []map[string]string{{"path": "foo"}}
+	got := pullRequestChanges(ctx, changes, pulls)
 
-	_, err := configureProjects(ctx)
-	assert.ErrorIs(err, monorepo.ErrNoName, "should have failed parsing project with no name")
+	assert.Equal([]parser.Change{
+		{Hash: squash, Message: "feat(api): add v2 endpoints (#7)\n\nAdds the v2 endpoints.\n\nBREAKING CHANGE: v1 is removed"},
+		{Hash: first, Message: "fix: typo"},
+		{Hash: second, Message: "feat: part two"},
+		{Hash: untitled, Message: "fix: keep me"},
+		{Hash: direct, Message: "fix: pushed directly"},
+	}, got)
+	assert.Len(pulls, 5, "pull requests should be cached by commit")
+
+	bundled := []parser.Change{{Hash: squash, Message: "feat: add stuff"}, {Hash: squash, Message: "fix: more stuff"}}
+	assert.Equal([]parser.Change{got[0]}, pullRequestChanges(ctx, bundled, pulls), "a pull request should be listed once")
+
+	commit, ok := changelog.ParseCommit(squash.String(), got[0].Message)
+	assert.True(ok)
+	assert.True(commit.Breaking(), "breaking changes of the description should be kept")
 }
 
-func TestReleaseCmd_InvalidArmoredKeyPath(t *testing.T) {
+func TestReleaseCmd_TemplatesDir(t *testing.T) {
 	assert := assertion.New(t)
-	ctx := NewAppContext()
 
-	ctx.GPGKeyPathFlag = "./does/not/exist"
+	testRepository := NewTestRepository(t, []string{"feat"})
 
-	_, err := configureGPGKey(ctx)
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "tag-message.tmpl"), "Release {{ .Version }} from {{ .Branch }}")
 
-	assert.ErrorContains(err, "reading armored key", "should have failed trying to open non existing armored GPG key")
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{BranchesConfiguration: `[{"name": "master"}]`, TemplatesDirConfiguration: dir})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	reference, err := testRepository.Tag("v0.1.0")
+	checkErr(t, err, "fetching tag reference")
+
+	tagObject, err := testRepository.TagObject(reference.Hash())
+	checkErr(t, err, "fetching tag object")
+
+	assert.Equal("Release 0.1.0 from master\n", tagObject.Message)
+
+	writeFile(t, filepath.Join(dir, "tag.tmpl"), "{{ .Tag }}")
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{BranchesConfiguration: `[{"name": "master"}]`, TemplatesDirConfiguration: dir})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, render.ErrUnknownTemplate)
 }
 
-func TestReleaseCmd_InvalidArmoredKeyContent(t *testing.T) {
+func TestReleaseCmd_ReleaseSummary(t *testing.T) {
 	assert := assertion.New(t)
-	ctx := NewAppContext()
 
-	gpgKeyDir, err := os.MkdirTemp("./", "gpg-*")
-	if err != nil {
-		t.Fatalf("failed to create temporary directory: %s", err)
-	}
+	testRepository := NewTestRepository(t, []string{"chore"})
 
-	defer func() {
-		err = os.RemoveAll(gpgKeyDir)
-		if err != nil {
-			t.Fatalf("failed to remove temporary directory: %s", err)
-		}
-	}()
+	_, err := testRepository.AddCommitWithSpecificFile("feat", "./api/api.txt")
+	checkErr(t, err, "adding commit")
 
-	keyFilePath := filepath.Join(gpgKeyDir, "key.asc")
+	_, err = testRepository.AddCommitWithSpecificFile("fix", "./web/web.txt")
+	checkErr(t, err, "adding commit")
 
-	keyFile, err := os.Create(keyFilePath)
-	if err != nil {
-		t.Fatalf("failed to create output file: %s", err)
-	}
+	summaryPath := filepath.Join(t.TempDir(), "out", "summary.md")
 
-	defer func() {
-		err = keyFile.Close()
-		if err != nil {
-			t.Fatalf("failed to create temporary directory: %s", err)
-		}
-	}()
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration:       `[{"name": "master"}]`,
+		MonorepoConfiguration:       `[{"name": "api", "path": "api"}, {"name": "web", "path": "web"}, {"name": "cli", "path": "cli"}]`,
+		ReleaseSummaryConfiguration: summaryPath,
+		DryRunConfiguration:         "true",
+	})
+	checkErr(t, err, "setting flags")
 
-	ctx.GPGKeyPathFlag = keyFilePath
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
 
-	_, err = configureGPGKey(ctx)
-	assert.ErrorContains(err, "loading armored key", "should have failed trying to read armored key ring from empty file")
+	content, err := os.ReadFile(summaryPath)
+	checkErr(t, err, "reading release summary")
+
+	assert.Contains(string(content), "| api | master | 0.1.0 | api-v0.1.0 |")
+	assert.Contains(string(content), "| web | master | 0.0.1 | web-v0.0.1 |")
+	assert.NotContains(string(content), "cli", "projects without release should not be listed")
+	assert.Contains(string(content), "## api 0.1.0\n\n### Added\n\n- this a test commit")
+	assert.Contains(string(content), "## web 0.0.1\n\n### Fixed\n\n- this a test commit")
 }
 
-// Test utilities
-func NewTestRepository(t *testing.T, commits []string) *gittest.TestRepository {
-	testRepository, err := gittest.NewRepository()
-	checkErr(t, err, "creating sample repository")
+func TestReleaseCmd_SuppressNoRelease(t *testing.T) {
+	assert := assertion.New(t)
 
-	for _, commit := range commits {
-		_, err = testRepository.AddCommit(commit)
-		checkErr(t, err, "creating sample commit")
-	}
+	testRepository := NewTestRepository(t, []string{"chore"})
 
-	t.Cleanup(func() {
-		os.RemoveAll(testRepository.Path)
+	_, err := testRepository.AddCommitWithSpecificFile("feat", "./api/api.txt")
+	checkErr(t, err, "adding commit")
+
+	channelsDir := t.TempDir()
+	summaryPath := filepath.Join(t.TempDir(), "summary.md")
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration:          `[{"name": "master"}]`,
+		MonorepoConfiguration:          `[{"name": "api", "path": "api"}, {"name": "web", "path": "web"}, {"name": "cli", "path": "cli"}]`,
+		ChannelsDirConfiguration:       channelsDir,
+		ReleaseSummaryConfiguration:    summaryPath,
+		SuppressNoReleaseConfiguration: "true",
+		DryRunConfiguration:            "true",
 	})
+	checkErr(t, err, "setting flags")
 
-	return testRepository
-}
+	out, err := th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
 
-type TestHelper struct {
-	Ctx *appcontext.AppContext
-	Cmd *cobra.Command
-}
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
 
-// NewTestHelper creates a new TestHelper with a fresh AppContext and Command
-func NewTestHelper(t *testing.T) *TestHelper {
-	ctx := &appcontext.AppContext{
-		Viper: viper.New(),
+	if assert.Len(lines, 2, "only the new release and the counts should be output") {
+		assert.Contains(lines[0], `"project":"api"`)
+		assert.Contains(lines[1], `"released":1,"unreleased":2,"skipped":0,"failed":0,"message":"release counts"`)
 	}
-	cmd := NewRootCommand(ctx)
-	return &TestHelper{
-		Ctx: ctx,
-		Cmd: cmd,
+
+	for _, project := range []string{"api", "web", "cli"} {
+		assert.FileExists(filepath.Join(channelsDir, project+"-master"), "every project should still be reported to the output writers")
 	}
+
+	content, err := os.ReadFile(summaryPath)
+	checkErr(t, err, "reading release summary")
+
+	assert.Contains(string(content), "**Released:** 1, **without new release:** 2, **skipped:** 0, **failed:** 0")
 }
 
-// SetFlag sets a flag value for the test
-func (th *TestHelper) SetFlag(name string, value string) error {
-	return th.Cmd.PersistentFlags().Set(name, value)
+func TestReleaseCmd_ReleaseSummaryWithoutRelease(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"chore"})
+
+	summaryPath := filepath.Join(t.TempDir(), "summary.md")
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{BranchesConfiguration: `[{"name": "master"}]`, ReleaseSummaryConfiguration: summaryPath})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	content, err := os.ReadFile(summaryPath)
+	checkErr(t, err, "reading release summary")
+
+	assert.Contains(string(content), "**Released:** 0, **without new release:** 1, **skipped:** 0, **failed:** 0", "the counts should be written without new release")
 }
 
-// SetFlags sets multiple flag values for the test
-func (th *TestHelper) SetFlags(flags map[string]string) error {
-	for name, value := range flags {
-		if err := th.SetFlag(name, value); err != nil {
-			return err
-		}
+func TestReleaseCmd_PublishReleaseSummary(t *testing.T) {
+	assert := assertion.New(t)
+
+	var releases []forge.Release
+
+	ctx := NewAppContext()
+	ctx.ReleaseSummaryTagFlag = `release-{{ .Date.Format "20060102" }}`
+	ctx.Forge = fakeForge{releases: &releases}
+
+	renderer, err := render.New("")
+	checkErr(t, err, "creating renderer")
+
+	summary := render.ReleaseSummaryData{
+		Date: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		Releases: []render.ReleaseSummaryEntry{
+			{Project: "api", Branch: "main", Version: "1.0.0", Tag: "api-v1.0.0"},
+			{Project: "web", Branch: "main", Version: "2.1.0", Tag: "web-v2.1.0"},
+		},
 	}
-	return nil
+
+	err = publishReleaseSummary(ctx, renderer, summary, nil)
+	checkErr(t, err, "publishing release summary")
+
+	assert.Len(releases, 1)
+	assert.Equal("release-20240301", releases[0].Tag)
+	assert.Equal("main", releases[0].Target)
+	assert.Contains(releases[0].Body, "| web | main | 2.1.0 | web-v2.1.0 |")
+	assert.Empty(releases[0].Assets, "no asset should be attached without attestations")
+
+	attestation, err := provenance.Seal(provenance.New(provenance.Release{Tag: "api-v1.0.0", Commit: "3f1c2a9"}, provenance.RunDetails{}), nil)
+	checkErr(t, err, "sealing attestation")
+
+	err = publishReleaseSummary(ctx, renderer, summary, []provenance.Envelope{attestation})
+	checkErr(t, err, "publishing release summary")
+
+	bundle, err := provenance.Bundle([]provenance.Envelope{attestation})
+	checkErr(t, err, "bundling attestation")
+
+	assert.Equal([]forge.Asset{{Name: provenance.BundleName, ContentType: provenance.BundleContentType, Content: bundle}}, releases[1].Assets)
+
+	releases = releases[:1]
+	ctx.DryRunFlag = dryrun.Flag{dryrun.Release}
+
+	err = publishReleaseSummary(ctx, renderer, summary, nil)
+	checkErr(t, err, "publishing release summary")
+
+	assert.Len(releases, 1, "nothing should be published in dry-run mode")
+
+	ctx.DryRunFlag = nil
+	summary.Releases[1].Branch = "rc"
+
+	err = publishReleaseSummary(ctx, renderer, summary, nil)
+	assert.ErrorIs(err, ErrSummaryBranches)
 }
 
-// ExecuteCommand executes the command with given arguments
-func (th *TestHelper) ExecuteCommand(args ...string) ([]byte, error) {
-	th.Cmd.SetArgs(args)
-	return ExecuteCommand(th.Cmd, args...)
+func TestReleaseCmd_CommentReleaseSummary(t *testing.T) {
+	assert := assertion.New(t)
+
+	comments := make(map[int][]string)
+
+	ctx := NewAppContext()
+	ctx.ReleaseSummaryCommentFlag = 42
+	ctx.DryRunFlag = dryrun.Flag{dryrun.Tag, dryrun.Push, dryrun.Deployment}
+	ctx.Forge = fakeForge{comments: comments}
+
+	renderer, err := render.New("")
+	checkErr(t, err, "creating renderer")
+
+	summary := render.ReleaseSummaryData{
+		Date:     time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		Releases: []render.ReleaseSummaryEntry{{Project: "api", Branch: "main", Version: "1.0.0", Tag: "api-v1.0.0"}},
+	}
+
+	err = publishReleaseSummary(ctx, renderer, summary, nil)
+	checkErr(t, err, "publishing release summary")
+
+	assert.Len(comments[42], 1, "the summary should be posted when only tags are suppressed")
+	assert.Contains(comments[42][0], "| api | main | 1.0.0 | api-v1.0.0 |")
+
+	ctx.DryRunFlag = dryrun.Flag{dryrun.Comment}
+
+	err = publishReleaseSummary(ctx, renderer, summary, nil)
+	checkErr(t, err, "publishing release summary")
+
+	assert.Len(comments[42], 1, "nothing should be posted when comments are suppressed")
 }
 
-// ExecuteCommand is a helper function to execute a command and capture its output
-func ExecuteCommand(cmd *cobra.Command, args ...string) ([]byte, error) {
-	output := new(bytes.Buffer)
-	cmd.SetOut(output)
-	cmd.SetErr(output)
-	cmd.SetArgs(args)
-	err := cmd.Execute()
-	return output.Bytes(), err
+func TestReleaseCmd_PublishCheckRuns(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(gittest.Commit("feat"), gittest.Branch("rc"), gittest.Commit("fix"))
+	checkErr(t, err, "creating repository")
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	master, err := testRepository.Reference(plumbing.NewBranchReferenceName("master"), true)
+	checkErr(t, err, "fetching master branch")
+
+	rc, err := testRepository.Reference(plumbing.NewBranchReferenceName("rc"), true)
+	checkErr(t, err, "fetching rc branch")
+
+	var checkRuns []forge.CheckRun
+
+	ctx := NewAppContext()
+	ctx.CheckRunFlag = "release"
+	ctx.Branches = []branch.Branch{{Name: "master"}, {Name: "rc"}}
+	ctx.DryRunFlag = dryrun.Flag{dryrun.Tag, dryrun.Push, dryrun.Deployment}
+	ctx.Forge = fakeForge{checkRuns: &checkRuns}
+
+	renderer, err := render.New("")
+	checkErr(t, err, "creating renderer")
+
+	repository := vcs.NewGitRepository(testRepository.Repository, nil, vcs.Options{})
+
+	summary := render.ReleaseSummaryData{
+		Date:     time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		Releases: []render.ReleaseSummaryEntry{{Branch: "master", Version: "0.1.1", Tag: "v0.1.1"}},
+		Preview:  true,
+	}
+
+	err = publishCheckRuns(ctx, renderer, repository, summary)
+	checkErr(t, err, "publishing check runs")
+
+	if assert.Len(checkRuns, 2, "every branch should get a check run") {
+		assert.Equal("release", checkRuns[0].Name)
+		assert.Equal(master.Hash().String(), checkRuns[0].Commit)
+		assert.Equal("New release v0.1.1", checkRuns[0].Title)
+		assert.Contains(checkRuns[0].Summary, "| - | master | 0.1.1 | v0.1.1 |")
+
+		assert.Equal(rc.Hash().String(), checkRuns[1].Commit)
+		assert.Equal("No new release", checkRuns[1].Title)
+		assert.NotContains(checkRuns[1].Summary, "v0.1.1", "releases of other branches should not be summarized")
+	}
+
+	checkRuns = nil
+	ctx.Branches = ctx.Branches[1:]
+	ctx.AtFlag = master.Hash().String()
+
+	err = publishCheckRuns(ctx, renderer, repository, summary)
+	checkErr(t, err, "publishing check runs")
+
+	if assert.Len(checkRuns, 1) {
+		assert.Equal(master.Hash().String(), checkRuns[0].Commit, "the check run should be reported on the analyzed commit")
+	}
+
+	checkRuns = nil
+	ctx.DryRunFlag = dryrun.Flag{dryrun.CheckRun}
+
+	err = publishCheckRuns(ctx, renderer, repository, summary)
+	checkErr(t, err, "publishing check runs")
+
+	assert.Empty(checkRuns, "nothing should be reported when check runs are suppressed")
 }
 
-func checkErr(t *testing.T, err error, message string) {
-	t.Helper()
+func TestReleaseCmd_CheckRunFailure(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(gittest.Commit("feat"), gittest.Serve())
+	checkErr(t, err, "creating test repository")
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	remoteURL, err := url.Parse(testRepository.RemoteURL)
+	checkErr(t, err, "parsing remote URL")
+
+	// The repository is served under an owner and a name for the forge to be derived from its URL, the API failing to
+	// create check runs.
+	mux := http.NewServeMux()
+	mux.Handle("/acme/app.git/", &httputil.ReverseProxy{
+		Rewrite: func(r *httputil.ProxyRequest) {
+			r.SetURL(remoteURL)
+			r.Out.URL.Path = strings.Replace(r.In.URL.Path, "/acme/app.git", remoteURL.Path, 1)
+		},
+	})
+	mux.HandleFunc("POST /repos/acme/app/check-runs", func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	t.Setenv("GITHUB_API_URL", server.URL)
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration: `[{"name": "master"}]`,
+		ForgeConfiguration:    forge.KindGitHub,
+		CheckRunConfiguration: "release",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", server.URL+"/acme/app.git")
+	assert.ErrorIs(err, ErrPartialRelease, "a failed check run should make the release partial")
+	assert.ErrorContains(err, "publishing check runs")
+
+	_, err = testRepository.Origin.Tag("v0.1.0")
+	assert.NoError(err, "the tag should still be pushed")
+}
+
+func TestReleaseCmd_Environment(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{BranchesConfiguration: `[{"name": "master", "environment": "production"}]`, DryRunConfiguration: "true"})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.Contains(string(out), `"branch":"master","environment":"production"`)
+}
+
+func TestReleaseCmd_RecordDeployment(t *testing.T) {
+	assert := assertion.New(t)
+
+	var deployments []forge.Deployment
+
+	ctx := NewAppContext()
+	ctx.Forge = fakeForge{deployments: &deployments}
+
+	err := recordDeployment(ctx, "v1.0.0", "production")
+	checkErr(t, err, "recording deployment")
+
+	assert.Empty(deployments, "deployments should only be recorded when enabled")
+
+	ctx.DeploymentsFlag = true
+
+	err = recordDeployment(ctx, "v1.0.0", "")
+	checkErr(t, err, "recording deployment")
+
+	assert.Empty(deployments, "branches without environment should not be deployed")
+
+	err = recordDeployment(ctx, "v1.0.0", "production")
+	checkErr(t, err, "recording deployment")
+
+	assert.Equal([]forge.Deployment{{Ref: "v1.0.0", Environment: "production", Description: "Release v1.0.0"}}, deployments)
+}
+
+func TestReleaseCmd_ReadOnlyGitHubOutput(t *testing.T) {
+	assert := assertion.New(t)
+
+	outputDir, err := os.MkdirTemp("./", "output-*")
+	checkErr(t, err, "creating output directory")
+
+	defer func() {
+		err = os.RemoveAll(outputDir)
+		checkErr(t, err, "removing output directory")
+	}()
+
+	outputFilePath := filepath.Join(outputDir, "output")
+
+	outputFile, err := os.OpenFile(outputFilePath, os.O_RDONLY|os.O_CREATE, 0o444)
+	checkErr(t, err, "creating output file")
+
+	defer func() {
+		err = outputFile.Close()
+		checkErr(t, err, "closing output file")
+	}()
+
+	outputPath := filepath.Join(outputDir, "output")
+
+	err = os.Setenv("GITHUB_OUTPUT", outputPath)
+	checkErr(t, err, "setting GITHUB_OUTPUT environment variable")
+
+	defer func() {
+		err = os.Unsetenv("GITHUB_OUTPUT")
+		checkErr(t, err, "unsetting GITHUB_OUTPUT environment variable")
+	}()
+
+	testRepository := NewTestRepository(t, []string{})
+
+	th := NewTestHelper(t)
+	err = th.SetFlag(BranchesConfiguration, `[{"name": "master"}]`)
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorContains(err, "opening ci file", "should have failed trying to write GitHub output to read-only file")
+}
+
+func TestReleaseCmd_InvalidRepositoryPath(t *testing.T) {
+	assert := assertion.New(t)
+
+	th := NewTestHelper(t)
+	_ = th.SetFlag(BranchesConfiguration, `[{"name": "master"}]`)
+	_, err := th.ExecuteCommand("release", "./does/not/exist")
+
+	assert.ErrorContains(err, "cloning Git repository", "should have failed trying to open inexisting Git repository")
+}
+
+func TestReleaseCmd_UnknownVCS(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{})
+
+	th := NewTestHelper(t)
+	err := th.SetFlag(BranchesConfiguration, `[{"name": "master"}]`)
+	checkErr(t, err, "setting flags")
+
+	err = th.SetFlag(VCSConfiguration, "hg")
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, vcs.ErrUnknownBackend, "should have failed with an unknown VCS backend")
+}
+
+func TestReleaseCmd_RepositoryWithNoHead(t *testing.T) {
+	assert := assertion.New(t)
+
+	tempDirPath, err := os.MkdirTemp("", "tag-*")
 	if err != nil {
-		t.Fatalf("%s: %s", message, err)
+		t.Fatalf("creating temp dir: %v", err)
+	}
+
+	defer func() {
+		err = os.RemoveAll(tempDirPath)
+		if err != nil {
+			t.Fatalf("removing temp dir: %v", err)
+		}
+	}()
+
+	_, err = git.PlainInit(tempDirPath, false)
+	if err != nil {
+		t.Fatalf("initializing repository: %v", err)
+	}
+
+	th := NewTestHelper(t)
+	err = th.SetFlag(BranchesConfiguration, `[{"name": "master"}]`)
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", tempDirPath)
+
+	assert.Error(err, "should have failed trying to compute new semver of repository with no HEAD")
+}
+
+func TestReleaseCmd_CustomRules(t *testing.T) {
+	assert := assertion.New(t)
+
+	commits := []string{
+		"fix",  // 0.1.0 (with custom rule)
+		"feat", // 0.2.0
 	}
+
+	testRepository := NewTestRepository(t, commits)
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration: `[{"name": "master"}]`,
+		RulesConfiguration:    `{"minor": ["feat", "fix"]}`,
+	})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	expectedVersion := "0.2.0"
+	expectedTag := "v" + expectedVersion
+	expectedOut := cmdOutput{
+		Message:    "new release found",
+		Version:    expectedVersion,
+		NewRelease: true,
+		Branch:     "master",
+	}
+	actualOut := cmdOutput{}
+
+	err = json.Unmarshal(out, &actualOut)
+	assert.NoError(err, "failed to unmarshal json")
+
+	// Check that the JSON output is correct
+	assert.Equal(expectedOut, actualOut, "releaseCmd output should be equal")
+
+	// Check that the tag was actually created on the repository
+	exists, err := tag.Exists(testRepository.Repository, expectedTag)
+	assert.NoError(err, "failed to check if tag exists")
+
+	assert.Equal(true, exists, "tag should exist")
+}
+
+func TestReleaseCmd_Monorepo(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, err, "creating sample repository")
+
+	defer func() {
+		err = testRepository.Remove()
+		checkErr(t, err, "removing repository")
+	}()
+
+	// "foo" commits
+	_, err = testRepository.AddCommitWithSpecificFile("feat", "./foo/foo.txt")
+	checkErr(t, err, "adding commit")
+	_, err = testRepository.AddCommitWithSpecificFile("fix", "./foo/foo2.txt")
+	checkErr(t, err, "adding commit")
+
+	// "bar" commits
+	_, err = testRepository.AddCommitWithSpecificFile("feat!", "./bar/foo.txt")
+	checkErr(t, err, "adding commit")
+	_, err = testRepository.AddCommitWithSpecificFile("fix", "./bar/foo2.txt")
+	checkErr(t, err, "adding commit")
+	_, err = testRepository.AddCommitWithSpecificFile("fix", "./bar/foo2.txt")
+	checkErr(t, err, "adding commit")
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration: `[{"name": "master"}]`,
+		MonorepoConfiguration: `[{"name": "foo", "path": "foo"}, {"name": "bar", "path": "bar"}]`,
+	})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	i := 0
+	expectedOutputs := []cmdOutput{
+		{
+			Message:    "new release found",
+			Version:    "0.1.1",
+			NewRelease: true,
+			Branch:     "master",
+			Project:    "foo",
+		},
+		{
+			Message:    "new release found",
+			Version:    "1.0.2",
+			NewRelease: true,
+			Branch:     "master",
+			Project:    "bar",
+		},
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+
+	for scanner.Scan() {
+		rawOutput := scanner.Bytes()
+
+		actualOutput := cmdOutput{}
+
+		err = json.Unmarshal(rawOutput, &actualOutput)
+		checkErr(t, err, "unmarshalling output")
+
+		assert.Equal(expectedOutputs[i], actualOutput)
+		i++
+	}
+	err = scanner.Err()
+	checkErr(t, err, "scanning error")
+}
+
+func TestReleaseCmd_ProjectTagSource(t *testing.T) {
+	assert := assertion.New(t)
+
+	splitRepository, err := gittest.NewRepository()
+	checkErr(t, err, "creating split repository")
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, err, "creating repository")
+
+	t.Cleanup(func() {
+		_ = splitRepository.Remove()
+		_ = testRepository.Remove()
+	})
+
+	splitHash, err := splitRepository.AddCommitWithContent("feat", "foo.txt", "foo")
+	checkErr(t, err, "adding split repository commit")
+
+	err = splitRepository.AddTag("v1.0.0", splitHash)
+	checkErr(t, err, "tagging split repository")
+
+	// The project directory mirrors the tree of the split repository, sample file of its first commit included.
+	_, err = testRepository.AddCommitWithContent("chore", "./foo/sample.txt", "...")
+	checkErr(t, err, "adding commit")
+
+	_, err = testRepository.AddCommitWithContent("fix", "./foo/foo.txt", "bar")
+	checkErr(t, err, "adding commit")
+
+	flags := map[string]string{
+		BranchesConfiguration: `[{"name": "master"}]`,
+		MonorepoConfiguration: fmt.Sprintf(`[{"name": "foo", "path": "foo", "tag-source": %q}]`, splitRepository.Path),
+	}
+
+	th := NewTestHelper(t)
+	checkErr(t, th.SetFlags(flags), "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, parser.ErrSplitCommitNotFound, "a release commit that was not split should not be released")
+
+	_, err = testRepository.Tag("foo-v1.0.1")
+	assert.Error(err, "a release commit that was not split should not be tagged")
+
+	_, err = splitRepository.AddCommitWithContent("fix", "foo.txt", "bar")
+	checkErr(t, err, "splitting release commit")
+
+	for i, expected := range []string{`"new-release":true,"version":"1.0.1"`, `"new-release":false,"version":"1.0.1"`} {
+		th = NewTestHelper(t)
+		checkErr(t, th.SetFlags(flags), "setting flags")
+
+		out, err := th.ExecuteCommand("release", testRepository.Path)
+		checkErr(t, err, "executing command")
+
+		assert.Contains(string(out), expected, "run %d", i+1)
+	}
+
+	_, err = testRepository.Tag("foo-v1.0.1")
+	assert.NoError(err, "the monorepo should be tagged")
+
+	_, err = splitRepository.Tag("v1.0.1")
+	assert.NoError(err, "the tag source should be tagged")
+}
+
+func TestReleaseCmd_RootPath(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(
+		gittest.CommitFile("feat!", "main.go", "main"),
+		gittest.CommitFile("feat", "vendor-products/acme/acme.go", "acme"),
+		gittest.CommitFile("fix", "vendor-products/acme/acme.go", "acme fix"),
+	)
+	checkErr(t, err, "creating repository")
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration: `[{"name": "master"}]`,
+		RootPathConfiguration: "vendor-products/acme",
+	})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	var output cmdOutput
+
+	err = json.Unmarshal(bytes.TrimSpace(out), &output)
+	checkErr(t, err, "unmarshalling output")
+
+	assert.Equal("0.1.1", output.Version, "only the commits of the root path should be analyzed")
+	assert.Equal("acme", output.Project)
+
+	exists, err := tag.Exists(testRepository.Repository, "acme-v0.1.1")
+	checkErr(t, err, "checking if tag exists")
+	assert.True(exists, "tag should be prefixed by the root path name")
+}
+
+func TestReleaseCmd_MonorepoFailureIsolation(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, err, "creating sample repository")
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	for _, path := range []string{"./web/web.txt", "./api/api.txt", "./lib/lib.txt", "./docs/docs.txt"} {
+		_, err = testRepository.AddCommitWithSpecificFile("feat", path)
+		checkErr(t, err, "adding commit")
+	}
+
+	// A tag nested under the name of the next "lib" tag makes pushing the latter fail.
+	head, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	err = testRepository.AddTag("lib-v0.1.0/blocked", head.Hash())
+	checkErr(t, err, "adding blocking tag")
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration: `[{"name": "master"}]`,
+		MonorepoConfiguration: `[{"name": "web", "path": "web", "depends-on": "api"}, {"name": "api", "path": "api", "depends-on": "lib"}, {"name": "lib", "path": "lib"}, {"name": "docs", "path": "docs"}]`,
+	})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, ErrPartialRelease)
+
+	assert.Contains(string(out), `"released":["docs-v0.1.0"],"failed":["lib-v0.1.0"],"skipped":["api-v0.1.0","web-v0.1.0"]`)
+
+	_, err = testRepository.Tag("docs-v0.1.0")
+	assert.NoError(err, "projects independent from the failed one should be released")
+
+	for _, name := range []string{"api-v0.1.0", "web-v0.1.0"} {
+		_, err = testRepository.Tag(name)
+		assert.Error(err, "projects depending on the failed one should not be released")
+	}
+}
+
+func TestReleaseCmd_ContinueOnError(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, err, "creating sample repository")
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	for _, path := range []string{"./foo/foo.txt", "./bar/bar.txt", "./baz/baz.txt"} {
+		_, err = testRepository.AddCommitWithSpecificFile("feat", path)
+		checkErr(t, err, "adding commit")
+	}
+
+	projects := `[{"name": "foo", "path": "foo", "tag-source": "` + filepath.ToSlash(filepath.Join(t.TempDir(), "missing")) + `"}, {"name": "bar", "path": "bar"}, {"name": "baz", "path": "baz", "depends-on": "foo"}]`
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration: `[{"name": "master"}]`,
+		MonorepoConfiguration: projects,
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.Error(err)
+	assert.NotErrorIs(err, ErrPartialRelease, "an error should abort the run by default")
+
+	_, err = testRepository.Tag("bar-v0.1.0")
+	assert.Error(err, "no project should be released when the run is aborted")
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration:        `[{"name": "master"}]`,
+		MonorepoConfiguration:        projects,
+		ContinueOnErrorConfiguration: "true",
+	})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, ErrPartialRelease, "the run should still fail")
+
+	assert.Contains(string(out), `"message":"computing new semver failed"`)
+
+	_, err = testRepository.Tag("bar-v0.1.0")
+	assert.NoError(err, "other projects should be released")
+
+	_, err = testRepository.Tag("baz-v0.1.0")
+	assert.Error(err, "projects depending on the failed one should not be released")
+}
+
+func TestReleaseCmd_ContinueOnError_TagCommitLookup(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, err, "creating sample repository")
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	var head plumbing.Hash
+
+	for _, path := range []string{"./foo/foo.txt", "./bar/bar.txt"} {
+		head, err = testRepository.AddCommitWithSpecificFile("feat", path)
+		checkErr(t, err, "adding commit")
+	}
+
+	commit, err := testRepository.CommitObject(head)
+	checkErr(t, err, "fetching head commit")
+
+	// The latest tag of the first project points to a tree, so that looking up its commit fails.
+	_, err = testRepository.CreateTag("foo-v1.0.0", commit.TreeHash, &git.CreateTagOptions{
+		Message: "foo-v1.0.0",
+		Tagger:  &object.Signature{Name: "Go Semver Release", Email: "go-semver@release.ci", When: commit.Committer.When},
+	})
+	checkErr(t, err, "creating tag")
+
+	// Branches are analyzed one after the other, so that the second one needs the parser not to be left locked by the
+	// failure on the first one.
+	err = testRepository.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName("rc"), head))
+	checkErr(t, err, "creating branch")
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration:        `[{"name": "master"}, {"name": "rc", "prerelease": true}]`,
+		MonorepoConfiguration:        `[{"name": "foo", "path": "foo"}, {"name": "bar", "path": "bar"}]`,
+		ContinueOnErrorConfiguration: "true",
+	})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, ErrPartialRelease, "the run should still fail")
+	assert.ErrorContains(err, "fetching latest semver tag commit")
+
+	assert.Contains(string(out), `"message":"computing new semver failed"`)
+
+	_, err = testRepository.Tag("bar-v0.1.0")
+	assert.NoError(err, "the project analyzed along the failed one should be released")
+
+	_, err = testRepository.Tag("bar-v0.1.0-rc")
+	assert.NoError(err, "the project should be released on the branch analyzed after the failure")
+}
+
+func TestReleaseCmd_OutputOrder(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"chore"})
+
+	_, err := testRepository.AddCommitWithSpecificFile("feat", "./bar/bar.txt")
+	checkErr(t, err, "adding commit")
+	_, err = testRepository.AddCommitWithSpecificFile("fix", "./baz/baz.txt")
+	checkErr(t, err, "adding commit")
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration: `[{"name": "master"}]`,
+		MonorepoConfiguration: `[{"name": "foo", "path": "foo"}, {"name": "bar", "path": "bar"}, {"name": "baz", "path": "baz"}]`,
+		DryRunConfiguration:   "true",
+	})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+
+	expected := []string{
+		`{"level":"info","schema-version":1,"new-release":false,"version":"0.0.0","branch":"master","project":"foo","message":"no new release"}`,
+		`{"level":"info","schema-version":1,"new-release":true,"version":"0.1.0","branch":"master","project":"bar","message":"dry-run enabled, next release found"}`,
+		`{"level":"info","schema-version":1,"new-release":true,"version":"0.0.1","branch":"master","project":"baz","message":"dry-run enabled, next release found"}`,
+	}
+
+	assert.Equal(expected, lines, "every project should be output in the configured order with a stable key order")
+}
+
+func TestReleaseCmd_ChannelsDir(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	_, err := testRepository.AddCommitWithSpecificFile("fix", "./api/api.txt")
+	checkErr(t, err, "adding commit")
+
+	channelsDir := filepath.Join(t.TempDir(), "out")
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration:    `[{"name": "master"}]`,
+		MonorepoConfiguration:    `[{"name": "api", "path": "api"}, {"name": "web", "path": "web"}]`,
+		ChannelsDirConfiguration: channelsDir,
+		DryRunConfiguration:      "true",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	content, err := os.ReadFile(filepath.Join(channelsDir, "api-master"))
+	checkErr(t, err, "reading channel file")
+	assert.Equal("0.0.1\n", string(content))
+
+	content, err = os.ReadFile(filepath.Join(channelsDir, "web-master"))
+	checkErr(t, err, "reading channel file")
+	assert.Equal("0.0.0\n", string(content), "channels without release should be written too")
+}
+
+func TestReleaseCmd_Progress(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat", "fix"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{BranchesConfiguration: "master", ProgressConfiguration: "true"})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.Contains(string(out), `"message":"scanning commits"`)
+	assert.Contains(string(out), `"count":1,"total":1,"message":"analyzing branches and projects"`)
+}
+
+func TestReleaseCmd_BadgesDir(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	_, err := testRepository.AddCommitWithSpecificFile("fix", "./api/api.txt")
+	checkErr(t, err, "adding commit")
+
+	badgesDir := filepath.Join(t.TempDir(), "badges")
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration:  `[{"name": "master"}]`,
+		MonorepoConfiguration:  `[{"name": "api", "path": "api"}, {"name": "web", "path": "web"}]`,
+		BadgesDirConfiguration: badgesDir,
+		DryRunConfiguration:    "true",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.NoFileExists(filepath.Join(badgesDir, "api-master", ci.BadgeFileName), "a release previewed by a dry-run should not have a badge")
+
+	content, err := os.ReadFile(filepath.Join(badgesDir, "web-master", ci.BadgeFileName))
+	checkErr(t, err, "reading badge file")
+	assert.JSONEq(`{"schemaVersion": 1, "label": "web", "message": "0.0.0", "color": "blue"}`, string(content))
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration:  `[{"name": "master"}]`,
+		MonorepoConfiguration:  `[{"name": "api", "path": "api"}, {"name": "web", "path": "web"}]`,
+		BadgesDirConfiguration: badgesDir,
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	content, err = os.ReadFile(filepath.Join(badgesDir, "api-master", ci.BadgeFileName))
+	checkErr(t, err, "reading badge file")
+	assert.JSONEq(`{"schemaVersion": 1, "label": "api", "message": "0.0.1", "color": "blue"}`, string(content))
+}
+
+func TestReleaseCmd_BranchTagPrefix(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	hash, err := testRepository.AddCommit("feat")
+	checkErr(t, err, "adding commit")
+
+	err = testRepository.AddTag("nightly-2.0.0", hash)
+	checkErr(t, err, "adding tag")
+
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit")
+
+	head, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	err = testRepository.Storer.SetReference(plumbing.NewHashReference("refs/heads/nightly", head.Hash()))
+	checkErr(t, err, "creating branch")
+
+	th := NewTestHelper(t)
+	err = th.SetFlag(BranchesConfiguration, `[{"name": "master"}, {"name": "nightly", "tag-prefix": "nightly-"}]`)
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	for _, tagName := range []string{"v0.2.1", "nightly-2.0.1"} {
+		exists, err := tag.Exists(testRepository.Repository, tagName)
+		checkErr(t, err, "checking if tag exists")
+
+		assert.True(exists, "tag %q should exist", tagName)
+	}
+}
+
+func TestReleaseCmd_TagAliases(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	hash, err := testRepository.AddCommit("feat")
+	checkErr(t, err, "adding commit")
+
+	err = testRepository.AddTag("RELEASE_2020_07", hash)
+	checkErr(t, err, "adding tag")
+
+	_, err = testRepository.AddCommit("feat")
+	checkErr(t, err, "adding commit")
+
+	cfgPath := filepath.Join(t.TempDir(), ".semver.yaml")
+	writeFile(t, cfgPath, "tag-aliases:\n  RELEASE_2020_07: 3.5.0\nbranches:\n  - name: master\n")
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{"config": cfgPath, DryRunConfiguration: "true"})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	actualOut := cmdOutput{}
+
+	err = json.Unmarshal(out, &actualOut)
+	checkErr(t, err, "unmarshalling output")
+
+	assert.Equal("3.6.0", actualOut.Version)
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{TagAliasesConfiguration: "RELEASE_2020_07=foo", BranchesConfiguration: `[{"name": "master"}]`})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, semver.ErrInvalidVersion)
+}
+
+func TestReleaseCmd_RequireChecks_LocalRepository(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	cfgPath := filepath.Join(t.TempDir(), ".semver.yaml")
+	writeFile(t, cfgPath, "require-checks:\n  - build\n  - test\nbranches:\n  - name: master\n")
+
+	th := NewTestHelper(t)
+	err := th.SetFlag("config", cfgPath)
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorContains(err, "configuring forge client", "should have failed since a local repository has no forge")
+	assert.Equal([]string{"build", "test"}, th.Ctx.RequireChecksFlag)
+}
+
+type fakeForge struct {
+	checks      []forge.Check
+	releases    *[]forge.Release
+	deployments *[]forge.Deployment
+	comments    map[int][]string
+	checkRuns   *[]forge.CheckRun
+	handles     map[string]string
+	pulls       map[string][]forge.PullRequest
+}
+
+func (f fakeForge) Checks(_ context.Context, _ string) ([]forge.Check, error) {
+	return f.checks, nil
+}
+
+func (f fakeForge) PullRequestTitle(_ context.Context, _ int) (string, error) {
+	return "", nil
+}
+
+func (f fakeForge) CommitPullRequests(_ context.Context, commit string) ([]forge.PullRequest, error) {
+	return f.pulls[commit], nil
+}
+
+func (f fakeForge) CommentPullRequest(_ context.Context, number int, body string) error {
+	f.comments[number] = append(f.comments[number], body)
+	return nil
+}
+
+func (f fakeForge) CreateCheckRun(_ context.Context, run forge.CheckRun) error {
+	*f.checkRuns = append(*f.checkRuns, run)
+	return nil
+}
+
+func (f fakeForge) CreateDeployment(_ context.Context, deployment forge.Deployment) error {
+	*f.deployments = append(*f.deployments, deployment)
+	return nil
+}
+
+func (f fakeForge) CreateRelease(_ context.Context, release forge.Release) error {
+	*f.releases = append(*f.releases, release)
+	return nil
+}
+
+func (f fakeForge) DeleteRelease(_ context.Context, tag string) error {
+	*f.releases = slices.DeleteFunc(*f.releases, func(release forge.Release) bool {
+		return release.Tag == tag
+	})
+	return nil
+}
+
+func (f fakeForge) UserHandle(_ context.Context, email string) (string, error) {
+	return f.handles[email], nil
+}
+
+func TestReleaseCmd_RequireChecks(t *testing.T) {
+	assert := assertion.New(t)
+
+	ctx := NewAppContext()
+	ctx.RequireChecksFlag = []string{"build", "test"}
+
+	client := fakeForge{checks: []forge.Check{{Name: "build", State: forge.CheckSuccess}, {Name: "test", State: forge.CheckPending}}}
+
+	err := requireChecks(ctx, client, "abc")
+	assert.ErrorIs(err, forge.ErrChecksNotPassed)
+
+	client.checks[1].State = forge.CheckSuccess
+
+	err = requireChecks(ctx, client, "abc")
+	assert.NoError(err)
+}
+
+func TestReleaseCmd_AuditLog(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	auditLogPath := filepath.Join(t.TempDir(), "audit.log")
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration: `[{"name": "master"}]`,
+		AuditLogConfiguration: auditLogPath,
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	auditLog, err := os.ReadFile(auditLogPath)
+	checkErr(t, err, "reading audit log")
+
+	var records []audit.Record
+
+	scanner := bufio.NewScanner(bytes.NewReader(auditLog))
+	for scanner.Scan() {
+		var record audit.Record
+
+		err = json.Unmarshal(scanner.Bytes(), &record)
+		checkErr(t, err, "unmarshalling audit record")
+
+		records = append(records, record)
+	}
+
+	assert.Len(records, 2, "audit log should contain a tag and a push record")
+	assert.Equal(audit.ActionTag, records[0].Action)
+	assert.Equal(audit.ActionPush, records[1].Action)
+	assert.Equal("v0.1.0", records[1].Tag)
+	assert.NoError(audit.Verify(bytes.NewReader(auditLog), nil), "audit log should be valid")
+}
+
+func TestReleaseCmd_Lock(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	otherReleaser := remote.New("origin", "")
+	_, err := otherReleaser.Clone(testRepository.Path)
+	checkErr(t, err, "cloning repository")
+
+	lock, err := otherReleaser.Lock("master", "other", time.Minute)
+	checkErr(t, err, "acquiring lock")
+
+	flags := map[string]string{
+		BranchesConfiguration: `[{"name": "master"}]`,
+		LockConfiguration:     "true",
+	}
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(flags)
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, remote.ErrLocked, "release should not proceed while the branch is locked")
+
+	exists, err := tag.Exists(testRepository.Repository, "v0.1.0")
+	checkErr(t, err, "checking if tag exists")
+	assert.False(exists, "tag should not have been pushed")
+
+	checkErr(t, lock.Release(), "releasing lock")
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(flags)
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	exists, err = tag.Exists(testRepository.Repository, "v0.1.0")
+	checkErr(t, err, "checking if tag exists")
+	assert.True(exists, "tag should have been pushed")
+
+	_, err = testRepository.Reference("refs/semver-release/lock/master", false)
+	assert.ErrorIs(err, plumbing.ErrReferenceNotFound, "lock should have been released")
+}
+
+func TestReleaseCmd_PushCheck(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(gittest.Commit("feat"), gittest.Serve(gittest.WithReadOnly()))
+	checkErr(t, err, "creating test repository")
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	flags := map[string]string{
+		BranchesConfiguration:  `[{"name": "master"}]`,
+		PushCheckConfiguration: "true",
+		DryRunConfiguration:    "true",
+	}
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(flags)
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.RemoteURL)
+	assert.ErrorIs(err, transport.ErrAuthorizationFailed, "a remote rejecting pushes should fail the check in dry-run mode")
+
+	writableRepository := NewTestRepository(t, []string{"feat"})
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration:  `[{"name": "master"}]`,
+		PushCheckConfiguration: "true",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", writableRepository.Path)
+	checkErr(t, err, "executing command")
+
+	exists, err := tag.Exists(writableRepository.Repository, "v0.1.0")
+	checkErr(t, err, "checking if tag exists")
+	assert.True(exists, "tag should have been pushed once the check passed")
+}
+
+func TestReleaseCmd_AtDetachedCommit(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"fix"})
+
+	head, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	detachedHash, err := testRepository.AddCommit("feat")
+	checkErr(t, err, "adding commit")
+
+	// Moving the branch back so that the last commit is only reachable by its hash, as a pull request commit would be
+	err = testRepository.Storer.SetReference(plumbing.NewHashReference(head.Name(), head.Hash()))
+	checkErr(t, err, "resetting branch")
+
+	cfg, err := testRepository.Config()
+	checkErr(t, err, "reading repository configuration")
+
+	cfg.Raw.Section("uploadpack").SetOption("allowAnySHA1InWant", "true")
+
+	err = testRepository.SetConfig(cfg)
+	checkErr(t, err, "writing repository configuration")
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration: `[{"name": "master"}]`,
+		AtConfiguration:       detachedHash.String(),
+	})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	var output cmdOutput
+	err = json.Unmarshal(out, &output)
+	checkErr(t, err, "unmarshalling output")
+
+	assert.Equal("0.1.0", output.Version, "detached commit should have been analyzed")
+
+	tagRef, err := testRepository.Tag("v0.1.0")
+	checkErr(t, err, "fetching tag")
+
+	tagObject, err := testRepository.TagObject(tagRef.Hash())
+	checkErr(t, err, "fetching tag object")
+
+	assert.Equal(detachedHash, tagObject.Target, "detached commit should have been tagged")
+}
+
+func TestReleaseCmd_AtMultipleBranches(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"fix"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration: `[{"name": "master"}, {"name": "rc", "prerelease": true}]`,
+		AtConfiguration:       "HEAD",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorContains(err, "exactly one branch must be configured")
+}
+
+func TestReleaseCmd_AsOf(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	fix, err := testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit")
+
+	_, err = testRepository.AddCommit("feat")
+	checkErr(t, err, "adding commit")
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration: `[{"name": "master"}]`,
+		AsOfConfiguration:     fix.String(),
+	})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	var output cmdOutput
+	err = json.Unmarshal(out, &output)
+	checkErr(t, err, "unmarshalling output")
+
+	assert.Equal("0.1.1", output.Version, "the version should be computed as of the given commit")
+
+	_, err = testRepository.Tag("v0.1.1")
+	assert.ErrorIs(err, git.ErrTagNotFound, "versions computed as of a past commit should not be released")
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration: `[{"name": "master"}]`,
+		AsOfConfiguration:     "2000-01-01",
+		AtConfiguration:       "HEAD",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorContains(err, "mutually exclusive")
+}
+
+func TestReleaseCmd_AsOf_NoLocalFiles(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	fix, err := testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit")
+
+	dir := t.TempDir()
+
+	outputPath := filepath.Join(dir, "github-output")
+	checkErr(t, os.WriteFile(outputPath, nil, 0o644), "creating GitHub output file")
+
+	changelogPath := filepath.Join(dir, "CHANGELOG.md")
+	checkErr(t, os.WriteFile(changelogPath, []byte("# Changelog\n\n"+changelog.Marker+"\n"), 0o644), "creating changelog file")
+
+	templatePath := filepath.Join(dir, "version.tmpl")
+	checkErr(t, os.WriteFile(templatePath, []byte("{{ .Version }}"), 0o644), "writing template")
+
+	t.Setenv("GITHUB_OUTPUT", outputPath)
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration:       `[{"name": "master"}]`,
+		AsOfConfiguration:           fix.String(),
+		ChannelsDirConfiguration:    filepath.Join(dir, "channels"),
+		BadgesDirConfiguration:      filepath.Join(dir, "badges"),
+		ChangelogDirConfiguration:   filepath.Join(dir, "changelogs"),
+		ChangelogFileConfiguration:  changelogPath,
+		ReleaseSummaryConfiguration: filepath.Join(dir, "summary.md"),
+		RenderConfiguration:         fmt.Sprintf(`[{"template": %q, "output": %q}]`, templatePath, filepath.Join(dir, "VERSION")),
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	entries, err := os.ReadDir(dir)
+	checkErr(t, err, "reading directory")
+
+	names := make([]string, len(entries))
+	for i, entry := range entries {
+		names[i] = entry.Name()
+	}
+
+	assert.Equal([]string{"CHANGELOG.md", "github-output", "version.tmpl"}, names, "no file should be created")
+
+	content, err := os.ReadFile(outputPath)
+	checkErr(t, err, "reading GitHub output file")
+	assert.Empty(content, "the GitHub output should not be written")
+
+	content, err = os.ReadFile(changelogPath)
+	checkErr(t, err, "reading changelog file")
+	assert.Equal("# Changelog\n\n"+changelog.Marker+"\n", string(content), "the changelog file should not be updated")
+}
+
+func TestReleaseCmd_GitHubActionsEnvironment(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	// The repository URL is inferred with a ".git" suffix, which bare repositories directories are named with.
+	bareRepository, err := testRepository.BareClone()
+	checkErr(t, err, "cloning test repository")
+
+	t.Cleanup(func() {
+		_ = bareRepository.Remove()
+	})
+
+	outputPath := filepath.Join(t.TempDir(), "output")
+
+	t.Setenv("GITHUB_ACTIONS", "true")
+	t.Setenv("GITHUB_SERVER_URL", filepath.Dir(bareRepository.Path))
+	t.Setenv("GITHUB_REPOSITORY", strings.TrimSuffix(filepath.Base(bareRepository.Path), ".git"))
+	t.Setenv("GITHUB_REF", "refs/heads/master")
+	t.Setenv("GITHUB_TOKEN", "secret")
+	t.Setenv("GITHUB_OUTPUT", outputPath)
+
+	th := NewTestHelper(t)
+
+	out, err := th.ExecuteCommand("release")
+	checkErr(t, err, "executing command")
+
+	var output cmdOutput
+	err = json.Unmarshal(out, &output)
+	checkErr(t, err, "unmarshalling output")
+
+	assert.Equal("master", output.Branch, "branch should have been inferred")
+	assert.Equal("0.1.0", output.Version, "version should be equal")
+	assert.Equal("secret", th.Ctx.AccessTokenFlag, "access token should have been inferred")
+
+	githubOutput, err := os.ReadFile(outputPath)
+	checkErr(t, err, "reading GitHub output")
+
+	assert.Contains(string(githubOutput), "MASTER_SEMVER=v0.1.0", "GitHub output should have been written")
+
+	exists, err := tag.Exists(bareRepository.Repository, "v0.1.0")
+	checkErr(t, err, "checking if tag exists")
+	assert.True(exists, "tag should have been pushed")
+}
+
+func TestReleaseCmd_GitHubActionInputs(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	t.Setenv("INPUT_REPOSITORY", testRepository.Path)
+	t.Setenv("INPUT_BRANCHES", `[{"name": "master"}]`)
+	t.Setenv("INPUT_TAG_PREFIX", "release-")
+	t.Setenv("INPUT_DRY-RUN", "true")
+	t.Setenv("INPUT_GPG-KEY-PATH", "")
+
+	th := NewTestHelper(t)
+
+	out, err := th.ExecuteCommand("release", "--github-action")
+	checkErr(t, err, "executing command")
+
+	var output cmdOutput
+	err = json.Unmarshal(out, &output)
+	checkErr(t, err, "unmarshalling output")
+
+	assert.Equal("0.1.0", output.Version, "version should be equal")
+	assert.Equal("release-", th.Ctx.TagPrefixFlag, "tag prefix should have been read from inputs")
+	assert.True(th.Ctx.DryRunFlag.Enabled(), "dry-run should have been read from inputs")
+
+	t.Setenv("INPUT_TAG-PREFIXX", "v")
+
+	th = NewTestHelper(t)
+
+	_, err = th.ExecuteCommand("release", "--github-action")
+	assert.ErrorIs(err, ErrUnknownActionInput, "unknown inputs should be rejected")
+}
+
+func TestReleaseCmd_NoRepository(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Setenv("GITHUB_ACTIONS", "")
+
+	th := NewTestHelper(t)
+	err := th.SetFlag(BranchesConfiguration, `[{"name": "master"}]`)
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release")
+	assert.ErrorContains(err, "repository path or URL is required")
+}
+
+func TestReleaseCmd_ConfigureRules_DefaultRules(t *testing.T) {
+	assert := assertion.New(t)
+	ctx := NewAppContext()
+
+	rules, err := configureRules(ctx)
+	checkErr(t, err, "configuring rules")
+
+	assert.Equal(rule.Default, rules)
+}
+
+func TestReleaseCmd_ConfigureRules_Order(t *testing.T) {
+	assert := assertion.New(t)
+	ctx := NewAppContext()
+	ctx.RuleOrderFlag = []string{"scope", "breaking", "type"}
+
+	rules, err := configureRules(ctx)
+	checkErr(t, err, "configuring rules")
+
+	assert.Equal([]rule.Kind{rule.KindScope, rule.KindBreaking, rule.KindType}, rules.Order)
+	assert.Equal(rule.Default.Map, rules.Map)
+
+	ctx.RuleOrderFlag = []string{"scope"}
+
+	_, err = configureRules(ctx)
+	assert.ErrorIs(err, rule.ErrInvalidOrder)
+}
+
+func TestReleaseCmd_ConfigureBranches_NoBranches(t *testing.T) {
+	assert := assertion.New(t)
+	ctx := NewAppContext()
+
+	_, err := configureBranches(ctx)
+	assert.ErrorIs(err, branch.ErrNoBranch)
+}
+
+func TestReleaseCmd_ConfigureProjects_NoProjects(t *testing.T) {
+	assert := assertion.New(t)
+	ctx := NewAppContext()
+
+	projects, err := configureProjects(ctx)
+	checkErr(t, err, "configuring projects")
+
+	assert.Nil(projects, "no monorepo configuration, should have gotten nil")
+}
+
+func TestReleaseCmd_ConfigureProjects_RootPath(t *testing.T) {
+	assert := assertion.New(t)
+	ctx := NewAppContext()
+
+	ctx.RootPathFlag = "vendor-products/acme/"
+
+	projects, err := configureProjects(ctx)
+	checkErr(t, err, "configuring projects")
+
+	assert.Equal([]monorepo.Project{{Name: "acme", Path: filepath.FromSlash("vendor-products/acme")}}, projects)
+
+	ctx.MonorepositoryFlag = []map[string]string{{"name": "foo", "path": "foo"}}
+
+	_, err = configureProjects(ctx)
+	assert.ErrorIs(err, ErrRootPathAndMonorepo)
+
+	ctx.MonorepositoryFlag = nil
+	ctx.RootPathFlag = "../acme"
+
+	_, err = configureProjects(ctx)
+	assert.ErrorIs(err, monorepo.ErrRootPath)
+}
+
+func TestReleaseCmd_InvalidCustomRules(t *testing.T) {
+	assert := assertion.New(t)
+	ctx := NewAppContext()
+
+	ctx.RulesFlag = map[string][]string{
+		"minor": {"feat"},
+		"patch": {"feat"},
+	}
+
+	_, err := configureRules(ctx)
+	assert.ErrorIs(err, rule.ErrDuplicateReleaseRule, "should have failed parsing invalid custom rule")
+}
+
+func TestReleaseCmd_InvalidBranch(t *testing.T) {
+	assert := assertion.New(t)
+	ctx := NewAppContext()
+
+	ctx.BranchesFlag = []map[string]any{{"prerelease": true}}
+
+	_, err := configureBranches(ctx)
+	assert.ErrorIs(err, branch.ErrNoName, "should have failed parsing branch with no name")
+}
+
+func TestReleaseCmd_InvalidMonorepoProjects(t *testing.T) {
+	assert := assertion.New(t)
+	ctx := NewAppContext()
+
+	ctx.MonorepositoryFlag = []map[string]string{{"path": "foo"}}
+
+	_, err := configureProjects(ctx)
+	assert.ErrorIs(err, monorepo.ErrNoName, "should have failed parsing project with no name")
+}
+
+func TestReleaseCmd_InvalidArmoredKeyPath(t *testing.T) {
+	assert := assertion.New(t)
+	ctx := NewAppContext()
+
+	ctx.GPGKeyPathFlag = "./does/not/exist"
+
+	_, err := configureGPGKey(ctx)
+
+	assert.ErrorContains(err, "reading armored key", "should have failed trying to open non existing armored GPG key")
+}
+
+func TestReleaseCmd_InvalidArmoredKeyContent(t *testing.T) {
+	assert := assertion.New(t)
+	ctx := NewAppContext()
+
+	gpgKeyDir, err := os.MkdirTemp("./", "gpg-*")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %s", err)
+	}
+
+	defer func() {
+		err = os.RemoveAll(gpgKeyDir)
+		if err != nil {
+			t.Fatalf("failed to remove temporary directory: %s", err)
+		}
+	}()
+
+	keyFilePath := filepath.Join(gpgKeyDir, "key.asc")
+
+	keyFile, err := os.Create(keyFilePath)
+	if err != nil {
+		t.Fatalf("failed to create output file: %s", err)
+	}
+
+	defer func() {
+		err = keyFile.Close()
+		if err != nil {
+			t.Fatalf("failed to create temporary directory: %s", err)
+		}
+	}()
+
+	ctx.GPGKeyPathFlag = keyFilePath
+
+	_, err = configureGPGKey(ctx)
+	assert.ErrorContains(err, "loading armored key", "should have failed trying to read armored key ring from empty file")
+}
+
+func TestReleaseCmd_TaggerIdentity(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	_, err := testRepository.AddCommitWithSpecificFile("feat", "foo/main.go")
+	checkErr(t, err, "adding commit")
+
+	_, err = testRepository.AddCommitWithSpecificFile("fix", "bar/main.go")
+	checkErr(t, err, "adding commit")
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration: `[{"name": "master", "git-email": "release@main.ci"}]`,
+		MonorepoConfiguration: `[{"name": "foo", "path": "foo", "git-name": "Foo Bot", "git-email": "foo@team.ci"}, {"name": "bar", "path": "bar"}]`,
+		GitNameConfiguration:  "Release Bot",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	taggers := map[string][2]string{
+		"foo-v0.1.0": {"Foo Bot", "foo@team.ci"},
+		"bar-v0.0.1": {"Release Bot", "release@main.ci"},
+	}
+
+	for tagName, identity := range taggers {
+		tagObject, err := testRepository.Tag(tagName)
+		checkErr(t, err, "fetching tag")
+
+		annotatedTag, err := testRepository.TagObject(tagObject.Hash())
+		checkErr(t, err, "fetching tag object")
+
+		assert.Equal(identity[0], annotatedTag.Tagger.Name, "tag %s", tagName)
+		assert.Equal(identity[1], annotatedTag.Tagger.Email, "tag %s", tagName)
+	}
+}
+
+func TestReleaseCmd_BranchGPGKey(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	err := testRepository.CheckoutBranch("rc")
+	checkErr(t, err, "checking out rc branch")
+
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit")
+
+	keyDir := t.TempDir()
+	productionKey := writeGPGKey(t, filepath.Join(keyDir, "production.asc"))
+	ciKey := writeGPGKey(t, filepath.Join(keyDir, "ci.asc"))
+
+	branches := fmt.Sprintf(`[{"name": "master"}, {"name": "rc", "prerelease": true, "gpg-key-path": %q}]`, filepath.Join(keyDir, "ci.asc"))
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{BranchesConfiguration: branches, GPGPathConfiguration: filepath.Join(keyDir, "production.asc")})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	signers := map[string]string{"v0.1.0": productionKey, "v0.1.1-rc": ciKey}
+
+	for tagName, armoredKey := range signers {
+		tagObject, err := testRepository.Tag(tagName)
+		checkErr(t, err, "fetching tag")
+
+		annotatedTag, err := testRepository.TagObject(tagObject.Hash())
+		checkErr(t, err, "fetching tag object")
+
+		_, err = annotatedTag.Verify(armoredKey)
+		assert.NoError(err, "tag %s should be signed by its branch key", tagName)
+	}
+}
+
+func TestReleaseCmd_KeychainGPGKey(t *testing.T) {
+	assert := assertion.New(t)
+
+	keyring.MockInit()
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	entity, err := openpgp.NewEntity("Go Semver Release", "", "go-semver@release.ci", nil)
+	checkErr(t, err, "creating GPG entity")
+
+	err = entity.EncryptPrivateKeys([]byte("passphrase"), nil)
+	checkErr(t, err, "encrypting private key")
+
+	private := new(bytes.Buffer)
+
+	w, err := armor.Encode(private, openpgp.PrivateKeyType, nil)
+	checkErr(t, err, "creating armor encoder")
+
+	// Signatures are already made by NewEntity, and cannot be made again with an encrypted key.
+	err = entity.SerializePrivateWithoutSigning(w, nil)
+	checkErr(t, err, "serializing private key")
+
+	err = w.Close()
+	checkErr(t, err, "closing armor encoder")
+
+	err = keyring.Set("go-semver-release", "signing-key", private.String())
+	checkErr(t, err, "storing key in keychain")
+
+	err = keyring.Set("go-semver-release", "passphrase", "passphrase")
+	checkErr(t, err, "storing passphrase in keychain")
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{BranchesConfiguration: "master", GPGPathConfiguration: "keychain:go-semver-release/signing-key"})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, gpg.ErrEncryptedKey)
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration:      "master",
+		GPGPathConfiguration:       "keychain:go-semver-release/signing-key",
+		GPGPassphraseConfiguration: "keychain:go-semver-release/passphrase",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	tagObject, err := testRepository.Tag("v0.1.0")
+	checkErr(t, err, "fetching tag")
+
+	annotatedTag, err := testRepository.TagObject(tagObject.Hash())
+	checkErr(t, err, "fetching tag object")
+
+	assert.NotEmpty(annotatedTag.PGPSignature, "tag should be signed by the keychain key")
+}
+
+func TestReleaseCmd_DescribeTag(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	keyPath := filepath.Join(t.TempDir(), "key.asc")
+	publicKey := writeGPGKey(t, keyPath)
+
+	keyring, err := openpgp.ReadArmoredKeyRing(strings.NewReader(publicKey))
+	checkErr(t, err, "reading public key")
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{BranchesConfiguration: `[{"name": "master"}]`, GPGPathConfiguration: keyPath})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	reference, err := testRepository.Tag("v0.1.0")
+	checkErr(t, err, "fetching tag")
+
+	expected := fmt.Sprintf(`"branch":"master","tag":"v0.1.0","tag-hash":"%s","signed":true,"signer-fingerprint":"%X","message":"new release found"`, reference.Hash(), keyring[0].PrimaryKey.Fingerprint)
+	assert.Contains(string(out), expected, "the release output should describe the created tag")
+
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit")
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{BranchesConfiguration: `[{"name": "master"}]`})
+	checkErr(t, err, "setting flags")
+
+	out, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.Contains(string(out), `"tag":"v0.1.1","tag-hash":"`)
+	assert.Contains(string(out), `"signed":false,"message":"new release found"`, "an unsigned tag should have no signer")
+}
+
+func TestReleaseCmd_TrustedKeys(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	head, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	err = testRepository.AddTag("v0.1.0", head.Hash())
+	checkErr(t, err, "adding tag")
+
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit")
+
+	keyDir := t.TempDir()
+	publicKeyPath := filepath.Join(keyDir, "trusted.pub.asc")
+
+	publicKey := writeGPGKey(t, filepath.Join(keyDir, "trusted.asc"))
+	writeFile(t, publicKeyPath, publicKey)
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{BranchesConfiguration: `[{"name": "master"}]`, TrustedKeysConfiguration: publicKeyPath})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, parser.ErrUntrustedTag, "an unsigned latest tag should abort the run")
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{BranchesConfiguration: `[{"name": "master"}]`, TrustedKeysConfiguration: publicKeyPath, UntrustedTagsConfiguration: "warn"})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, parser.ErrInvalidUntrustedTagsPolicy)
+}
+
+// writeGPGKey writes a new armored private key to the given path and returns the armored public key.
+func writeGPGKey(t *testing.T, path string) string {
+	entity, err := openpgp.NewEntity("Go Semver Release", "", "go-semver@release.ci", nil)
+	checkErr(t, err, "creating GPG entity")
+
+	private := new(bytes.Buffer)
+
+	w, err := armor.Encode(private, openpgp.PrivateKeyType, nil)
+	checkErr(t, err, "creating armor encoder")
+
+	err = entity.SerializePrivate(w, nil)
+	checkErr(t, err, "serializing private key")
+
+	err = w.Close()
+	checkErr(t, err, "closing armor encoder")
+
+	err = os.WriteFile(path, private.Bytes(), 0o600)
+	checkErr(t, err, "writing private key")
+
+	public := new(bytes.Buffer)
+
+	w, err = armor.Encode(public, openpgp.PublicKeyType, nil)
+	checkErr(t, err, "creating armor encoder")
+
+	err = entity.Serialize(w)
+	checkErr(t, err, "serializing public key")
+
+	err = w.Close()
+	checkErr(t, err, "closing armor encoder")
+
+	return public.String()
+}
+
+// Test utilities
+func NewTestRepository(t *testing.T, commits []string) *gittest.TestRepository {
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, err, "creating sample repository")
+
+	for _, commit := range commits {
+		_, err = testRepository.AddCommit(commit)
+		checkErr(t, err, "creating sample commit")
+	}
+
+	t.Cleanup(func() {
+		os.RemoveAll(testRepository.Path)
+	})
+
+	return testRepository
+}
+
+type TestHelper struct {
+	Ctx *appcontext.AppContext
+	Cmd *cobra.Command
+}
+
+// NewTestHelper creates a new TestHelper with a fresh AppContext and Command
+func NewTestHelper(t *testing.T) *TestHelper {
+	ctx := &appcontext.AppContext{
+		Viper: viper.New(),
+	}
+	cmd := NewRootCommand(ctx)
+	return &TestHelper{
+		Ctx: ctx,
+		Cmd: cmd,
+	}
+}
+
+// SetFlag sets a flag value for the test
+func (th *TestHelper) SetFlag(name string, value string) error {
+	return th.Cmd.PersistentFlags().Set(name, value)
+}
+
+// SetFlags sets multiple flag values for the test
+func (th *TestHelper) SetFlags(flags map[string]string) error {
+	for name, value := range flags {
+		if err := th.SetFlag(name, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ExecuteCommand executes the command with given arguments
+func (th *TestHelper) ExecuteCommand(args ...string) ([]byte, error) {
+	th.Cmd.SetArgs(args)
+	return ExecuteCommand(th.Cmd, args...)
+}
+
+// ExecuteCommand is a helper function to execute a command and capture its output
+func ExecuteCommand(cmd *cobra.Command, args ...string) ([]byte, error) {
+	output := new(bytes.Buffer)
+	cmd.SetOut(output)
+	cmd.SetErr(output)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return output.Bytes(), err
+}
+
+func checkErr(t *testing.T, err error, message string) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %s", message, err)
+	}
+}
+
+func TestReleaseCmd_Render(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	_, err := testRepository.AddCommitWithSpecificFile("fix", "./api/api.txt")
+	checkErr(t, err, "adding commit")
+
+	dir := t.TempDir()
+	templatePath := filepath.Join(dir, "version.go.tmpl")
+
+	err = os.WriteFile(templatePath, []byte("package {{ .Project }}\n\nconst Version = \"{{ .Version }}\" // {{ .Tag }}\n"), 0o644)
+	checkErr(t, err, "writing template")
+
+	files := fmt.Sprintf(`[{"template": %q, "output": %q, "project": "api"}]`, templatePath, filepath.Join(dir, "{{ .Project }}", "version.go"))
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration: `[{"name": "master"}]`,
+		MonorepoConfiguration: `[{"name": "api", "path": "api"}, {"name": "web", "path": "web"}]`,
+		RenderConfiguration:   files,
+		DryRunConfiguration:   "true",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	content, err := os.ReadFile(filepath.Join(dir, "api", "version.go"))
+	checkErr(t, err, "reading rendered file")
+
+	assert.Equal("package api\n\nconst Version = \"0.0.1\" // api-v0.0.1\n", string(content), "files should be rendered by a dry-run")
+	assert.NoDirExists(filepath.Join(dir, "web"), "files of another project should not be rendered")
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{BranchesConfiguration: `[{"name": "master"}]`, RenderConfiguration: `[{"output": "version.go"}]`})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, render.ErrNoTemplate, "files without template should be rejected")
+}
+
+func TestReleaseCmd_Deprecations(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"fix"})
+
+	_, err := testRepository.AddCommitWithMessage("feat(api): add v2 endpoints\n\nDEPRECATED: the v1 endpoints")
+	checkErr(t, err, "adding commit")
+
+	changelogDir := t.TempDir()
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{BranchesConfiguration: `[{"name": "master"}]`, ChangelogDirConfiguration: changelogDir, DryRunConfiguration: "true"})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.Contains(string(out), `"branch":"master","deprecations":["the v1 endpoints"]`, "deprecations should be listed in the output")
+
+	content, err := os.ReadFile(filepath.Join(changelogDir, "master.md"))
+	checkErr(t, err, "reading changelog")
+
+	assert.Contains(string(content), "### Deprecations\n\n- **api:** the v1 endpoints")
+}
+
+func TestReleaseCmd_Links(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"fix"})
+
+	_, err := testRepository.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{"git@github.com:acme/app.git"}})
+	checkErr(t, err, "creating remote")
+
+	_, err = testRepository.AddCommitWithMessage("feat: add v2 endpoints (#42)")
+	checkErr(t, err, "adding commit")
+
+	changelogDir := t.TempDir()
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{BranchesConfiguration: `[{"name": "master"}]`, ChangelogDirConfiguration: changelogDir, DryRunConfiguration: "true"})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	content, err := os.ReadFile(filepath.Join(changelogDir, "master.md"))
+	checkErr(t, err, "reading changelog")
+
+	assert.Contains(string(content), "- add v2 endpoints ([#42](https://github.com/acme/app/pull/42)) ([", "links should be derived from the remote URL")
+	assert.Contains(string(content), "](https://github.com/acme/app/commit/")
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration:          `[{"name": "master"}]`,
+		ChangelogDirConfiguration:      changelogDir,
+		CommitURLTemplateConfiguration: "https://git.acme.com/app/commit/{{ .ShortHash }}",
+		PullRequestURLConfiguration:    "https://git.acme.com/app/pull/{{ .Number }}",
+		DryRunConfiguration:            "true",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	content, err = os.ReadFile(filepath.Join(changelogDir, "master.md"))
+	checkErr(t, err, "reading changelog")
+
+	assert.Contains(string(content), "- add v2 endpoints ([#42](https://git.acme.com/app/pull/42)) ([", "configured templates should override the derived links")
+	assert.Contains(string(content), "](https://git.acme.com/app/commit/")
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{BranchesConfiguration: `[{"name": "master"}]`, CommitURLTemplateConfiguration: "{{ .Sha }}"})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorContains(err, "commit URL template", "invalid templates should be rejected")
+}
+
+func TestReleaseCmd_RuleStats(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat", "fix", "fix", "chore"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{BranchesConfiguration: `[{"name": "master"}]`, RuleStatsConfiguration: "true", DryRunConfiguration: "true"})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.Contains(string(out), `"rule-stats":{"feat":1,"fix":2,"ignored":2}`, "rule statistics should be in the output")
+}
+
+func TestReleaseCmd_ChangedPaths(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"chore"})
+
+	_, err := testRepository.AddCommitWithContent("feat", "api/main.go", "package main")
+	checkErr(t, err, "adding commit")
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{BranchesConfiguration: `[{"name": "master"}]`, ChangedPathsConfiguration: "true", DryRunConfiguration: "true"})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.Contains(string(out), `"changed-paths":["api/main.go",`, "changed paths should be in the output")
+}
+
+func TestReleaseCmd_CascadeBumps(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	_, err := testRepository.AddCommitWithSpecificFile("fix", "./lib/lib.txt")
+	checkErr(t, err, "adding commit")
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration:     `[{"name": "master"}]`,
+		MonorepoConfiguration:     `[{"name": "api", "path": "api", "depends-on": "lib"}, {"name": "lib", "path": "lib"}]`,
+		CascadeBumpsConfiguration: "true",
+	})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.Contains(string(out), `"project":"lib","release-order":1,"bump-reason":"commits"`)
+	assert.Contains(string(out), `"project":"api","release-order":2,"bump-reason":"dependency","bumped-by":["lib"]`)
+
+	exists, err := tag.Exists(testRepository.Repository, "api-v0.0.1")
+	checkErr(t, err, "checking if tag exists")
+	assert.True(exists, "cascaded release should be tagged")
+}
+
+func TestReleaseCmd_ArtifactsBucket(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	objects := make(map[string]string)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, _ := io.ReadAll(r.Body)
+		objects[r.URL.Path] = string(content)
+	}))
+	defer server.Close()
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_ENDPOINT_URL_S3", server.URL)
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{BranchesConfiguration: "master", ArtifactsBucketConfiguration: "s3://acme/app", DryRunConfiguration: "artifacts"})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.Empty(objects, "nothing should be uploaded when artifacts are suppressed")
+
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit")
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{BranchesConfiguration: "master", ArtifactsBucketConfiguration: "s3://acme/app"})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.Contains(objects["/acme/app/v0.1.1/release.json"], `"previous-version": "0.1.0"`)
+	assert.Contains(objects["/acme/app/v0.1.1/changelog.md"], "0.1.1")
+	assert.Contains(objects["/acme/app/v0.1.1/manifest.json"], `"key": "app/v0.1.1/changelog.md"`)
+}
+
+func TestReleaseCmd_Events(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	var bodies []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(content))
+
+		_, _ = w.Write([]byte(`{"offsets": [{"partition": 0, "offset": 0}]}`))
+	}))
+	defer server.Close()
+
+	events := fmt.Sprintf(`[{"type": "kafka", "topic": "releases", "url": %q}]`, server.URL)
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{BranchesConfiguration: "master", EventsConfiguration: events, DryRunConfiguration: "events"})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.Empty(bodies, "nothing should be published when events are suppressed")
+
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit")
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{BranchesConfiguration: "master", EventsConfiguration: events})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.Len(bodies, 1)
+	assert.Contains(bodies[0], `"key":"v0.1.1"`)
+	assert.Contains(bodies[0], `"type":"release","tag":"v0.1.1","version":"0.1.1","previous-version":"0.1.0","branch":"master"`)
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{BranchesConfiguration: "master", EventsConfiguration: `[{"type": "rabbitmq", "topic": "releases"}]`})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, event.ErrUnknownType)
+}
+
+func TestReleaseCmd_TerraformModule(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"chore"})
+
+	_, err := testRepository.AddCommitWithSpecificFile("feat", "./vpc/main.tf")
+	checkErr(t, err, "adding commit")
+	_, err = testRepository.AddCommitWithSpecificFile("fix", "./dns/main.tf")
+	checkErr(t, err, "adding commit")
+
+	var published []string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /organizations/acme/registry-modules/private/acme/{name}/aws/versions", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("Bearer token", r.Header.Get("Authorization"))
+
+		published = append(published, r.PathValue("name"))
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	apiURL := terraform.APIURL
+	t.Cleanup(func() {
+		terraform.APIURL = apiURL
+	})
+
+	terraform.APIURL = func(string) string {
+		return server.URL
+	}
+
+	t.Setenv("TF_TOKEN_app_terraform_io", "token")
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration: `[{"name": "master"}]`,
+		MonorepoConfiguration: `[{"name": "vpc", "path": "vpc", "terraform-module": "app.terraform.io/acme/vpc/aws", "terraform-publish": "api"}, {"name": "dns", "path": "dns", "terraform-module": "app.terraform.io/acme/dns/aws"}]`,
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, terraform.ErrInvalidTag, "a module published from tags prefixed by its project name should fail to be released")
+
+	assert.Equal([]string{"vpc"}, published, "only the modules published with the API should be published")
+
+	_, err = testRepository.Tag("dns-v0.0.1")
+	assert.Error(err, "the module published from its tags should not have been tagged")
+}
+
+func TestReleaseCmd_CheckTerraformVersion(t *testing.T) {
+	assert := assertion.New(t)
+
+	module := &terraform.Module{Host: "app.terraform.io", Namespace: "acme", Name: "vpc", Provider: "aws"}
+
+	output := parser.ComputeNewSemverOutput{
+		Semver:  &semver.Version{Major: 1, Minor: 2},
+		Project: monorepo.Project{Name: "modules/vpc", TerraformModule: module, TerraformPublish: terraform.PublishTag},
+	}
+
+	tagger := tag.NewTagger("Go Semver Release", "go-semver@release.ci", tag.WithTagPrefix("v"))
+	tagger.SetProjectName("modules/vpc")
+
+	assert.ErrorIs(checkTerraformVersion(tagger, output), terraform.ErrInvalidTag, "tags prefixed by the project name should be rejected")
+
+	output.Project.TagSource = "https://example.com/vpc.git"
+	assert.NoError(checkTerraformVersion(tagger, output), "the tags of the tag source should be checked without the project name")
+	assert.Equal("modules/vpc", tagger.ProjectName, "the tagger should be left untouched")
+
+	tagger.SetTagPrefix("release-")
+	assert.ErrorIs(checkTerraformVersion(tagger, output), terraform.ErrInvalidTag)
+
+	output.Project.TerraformPublish = terraform.PublishAPI
+	assert.NoError(checkTerraformVersion(tagger, output), "the tag prefix should not matter to modules published with the API")
+
+	output.Semver.Metadata = "build.1"
+	assert.ErrorIs(checkTerraformVersion(tagger, output), terraform.ErrInvalidTag)
+}
+
+func TestReleaseCmd_HelmChart(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"chore"})
+
+	_, err := testRepository.AddCommitWithContent("feat", "charts/api/Chart.yaml", "apiVersion: v2\nname: api\nversion: 0.0.1 # bumped on release\nappVersion: \"0.0.1\"\n")
+	checkErr(t, err, "adding commit")
+	_, err = testRepository.AddCommitWithContent("fix", "charts/web/Chart.yaml", "apiVersion: v2\nname: web\nversion: 0.0.0\n")
+	checkErr(t, err, "adding commit")
+
+	// A branch checked out by the origin cannot be pushed.
+	origin, err := testRepository.BareClone()
+	checkErr(t, err, "cloning bare repository")
+
+	t.Cleanup(func() {
+		_ = origin.Remove()
+	})
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration: `[{"name": "master"}]`,
+		MonorepoConfiguration: `[{"name": "api", "path": "charts/api", "type": "helm-chart"}, {"name": "web", "path": "charts/web", "type": "helm-chart"}]`,
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", origin.Path)
+	checkErr(t, err, "executing command")
+
+	chart := func(revision, path string) string {
+		hash, err := origin.ResolveRevision(plumbing.Revision(revision))
+		checkErr(t, err, "resolving revision")
+
+		commit, err := origin.CommitObject(*hash)
+		checkErr(t, err, "getting commit")
+
+		file, err := commit.File(path)
+		checkErr(t, err, "getting file")
+
+		content, err := file.Contents()
+		checkErr(t, err, "reading file")
+
+		return content
+	}
+
+	assert.Equal("apiVersion: v2\nname: api\nversion: 0.1.0 # bumped on release\nappVersion: \"0.1.0\"\n", chart("api-v0.1.0", "charts/api/Chart.yaml"))
+	assert.Equal("apiVersion: v2\nname: web\nversion: 0.0.0\n", chart("api-v0.1.0", "charts/web/Chart.yaml"), "the other charts should be left untouched")
+	assert.Equal("apiVersion: v2\nname: web\nversion: 0.0.1\n", chart("web-v0.0.1", "charts/web/Chart.yaml"))
+	assert.Equal("apiVersion: v2\nname: api\nversion: 0.1.0 # bumped on release\nappVersion: \"0.1.0\"\n", chart("master", "charts/api/Chart.yaml"), "the bumps should be pushed to the branch")
+	assert.Equal("apiVersion: v2\nname: web\nversion: 0.0.1\n", chart("master", "charts/web/Chart.yaml"), "the bumps should be pushed to the branch")
+}
+
+func TestReleaseCmd_CommitReleaseFiles(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	// The released branch is not the one checked out, so that the commit of the files can be pushed to it.
+	err := testRepository.CheckoutBranch("work")
+	checkErr(t, err, "checking out branch")
+
+	// Files are committed when rendered inside the worktree of the repository, here the working directory.
+	wd, err := os.Getwd()
+	checkErr(t, err, "getting working directory")
+
+	err = os.Chdir(testRepository.Path)
+	checkErr(t, err, "changing working directory")
+
+	t.Cleanup(func() {
+		_ = os.Chdir(wd)
+	})
+
+	err = os.WriteFile("version.txt.tmpl", []byte("{{ .Version }}\n"), 0o644)
+	checkErr(t, err, "writing template")
+
+	file := func(revision, path string) string {
+		hash, err := testRepository.ResolveRevision(plumbing.Revision(revision))
+		checkErr(t, err, "resolving revision")
+
+		commit, err := testRepository.CommitObject(*hash)
+		checkErr(t, err, "getting commit")
+
+		file, err := commit.File(path)
+		checkErr(t, err, "getting file")
+
+		content, err := file.Contents()
+		checkErr(t, err, "reading file")
+
+		return content
+	}
+
+	for range 2 {
+		th := NewTestHelper(t)
+		err = th.SetFlags(map[string]string{
+			BranchesConfiguration:      `[{"name": "master"}]`,
+			RenderConfiguration:        `[{"template": "version.txt.tmpl", "output": "version.txt"}]`,
+			ChangelogFileConfiguration: "CHANGELOG.md",
+		})
+		checkErr(t, err, "setting flags")
+
+		_, err = th.ExecuteCommand("release", testRepository.Path)
+		checkErr(t, err, "executing command")
+	}
+
+	assert.Equal("0.1.0\n", file("v0.1.0", "version.txt"), "the tag should contain the rendered files")
+	assert.True(strings.HasPrefix(file("v0.1.0", "CHANGELOG.md"), "# Changelog\n\n"+changelog.Marker+"\n\n## [0.1.0] - "), "the tag should contain the changelog file")
+	assert.Equal("0.1.0\n", file("master", "version.txt"), "the files should be pushed to the branch")
+
+	head, err := testRepository.ResolveRevision("master")
+	checkErr(t, err, "resolving revision")
+
+	commit, err := testRepository.CommitObject(*head)
+	checkErr(t, err, "getting commit")
+
+	assert.Equal("chore(release): update files of v0.1.0", strings.TrimSpace(commit.Message))
+	assert.Len(commit.ParentHashes, 1)
+
+	tagHash, err := testRepository.ResolveRevision("v0.1.0^{commit}")
+	checkErr(t, err, "resolving tag")
+	assert.Equal(*head, *tagHash, "the tag should be created on the commit of the files")
+
+	parent, err := commit.Parent(0)
+	checkErr(t, err, "getting parent")
+	assert.True(strings.HasPrefix(parent.Message, "feat"), "running the release again should not commit the files again")
+}
+
+func TestReleaseCmd_CommitReleaseFilesOutsideWorktree(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	// Files written relative to a working directory that is not the worktree of the repository are not committed.
+	wd, err := os.Getwd()
+	checkErr(t, err, "getting working directory")
+
+	err = os.Chdir(t.TempDir())
+	checkErr(t, err, "changing working directory")
+
+	t.Cleanup(func() {
+		_ = os.Chdir(wd)
+	})
+
+	err = os.WriteFile("version.txt.tmpl", []byte("{{ .Version }}\n"), 0o644)
+	checkErr(t, err, "writing template")
+
+	head, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration:      `[{"name": "master"}]`,
+		RenderConfiguration:        `[{"template": "version.txt.tmpl", "output": "version.txt"}]`,
+		ChangelogFileConfiguration: "CHANGELOG.md",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.FileExists("version.txt", "the file should still be rendered locally")
+	assert.FileExists("CHANGELOG.md", "the changelog file should still be written locally")
+
+	tagHash, err := testRepository.ResolveRevision("v0.1.0^{commit}")
+	checkErr(t, err, "resolving tag")
+	assert.Equal(head.Hash(), *tagHash, "the tag should be created on the release commit")
+
+	master, err := testRepository.ResolveRevision("master")
+	checkErr(t, err, "resolving revision")
+	assert.Equal(head.Hash(), *master, "no commit should be pushed to the branch")
+}
+
+func TestReleaseCmd_DiscoverProjects(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"chore"})
+
+	_, err := testRepository.AddCommitWithContent("chore", "package.json", `{"private": true, "workspaces": ["packages/*"]}`)
+	checkErr(t, err, "adding commit")
+	_, err = testRepository.AddCommitWithContent("feat", "packages/ui/package.json", `{"name": "@acme/ui"}`)
+	checkErr(t, err, "adding commit")
+	_, err = testRepository.AddCommitWithContent("fix", "packages/web/package.json", `{"name": "@acme/web", "dependencies": {"@acme/ui": "*"}}`)
+	checkErr(t, err, "adding commit")
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration:         `[{"name": "master"}]`,
+		DiscoverProjectsConfiguration: "true",
+		MonorepoConfiguration:         `[{"name": "docs", "path": "docs"}]`,
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	names := make([]string, 0, len(th.Ctx.Projects))
+	for _, project := range th.Ctx.Projects {
+		names = append(names, project.Name)
+	}
+
+	assert.Equal([]string{"ui", "web", "docs"}, names, "discovered projects should be released along with the configured ones")
+
+	_, err = testRepository.Tag("ui-v0.1.0")
+	assert.NoError(err, "the discovered project should have been released")
+
+	_, err = testRepository.Tag("web-v0.0.1")
+	assert.NoError(err, "the discovered project should have been released")
+}
+
+func TestReleaseCmd_FloatingTags(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration:     `[{"name": "master"}]`,
+		FloatingTagsConfiguration: "true",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	resolve := func(revision string) plumbing.Hash {
+		hash, err := testRepository.ResolveRevision(plumbing.Revision(revision))
+		checkErr(t, err, "resolving revision")
+
+		return *hash
+	}
+
+	assert.Equal(resolve("v0.1.0"), resolve("v0"))
+	assert.Equal(resolve("v0.1.0"), resolve("v0.1"))
+
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.Equal(resolve("v0.1.1"), resolve("v0"), "the floating tags should be moved to the new release")
+	assert.Equal(resolve("v0.1.1"), resolve("v0.1"), "the floating tags should be moved to the new release")
+}
+
+func TestReleaseCmd_LatestRef(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration:  `[{"name": "master"}]`,
+		LatestRefConfiguration: "latest-release",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	resolve := func(revision string) plumbing.Hash {
+		hash, err := testRepository.ResolveRevision(plumbing.Revision(revision))
+		checkErr(t, err, "resolving revision")
+
+		return *hash
+	}
+
+	assert.Equal(resolve("v0.1.0"), resolve("refs/heads/latest-release"), "the branch should be created on the first release")
+
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.Equal(resolve("v0.1.1"), resolve("refs/heads/latest-release"), "the branch should be fast-forwarded to the new release")
+
+	err = th.SetFlags(map[string]string{LatestRefConfiguration: "refs/releases/latest"})
+	checkErr(t, err, "setting flags")
+
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.Equal(resolve("v0.1.2"), resolve("refs/releases/latest"))
+}
+
+type recordingWriter struct {
+	releases *[]sink.Release
+}
+
+func (recordingWriter) Name() string {
+	return "recording"
+}
+
+func (w recordingWriter) Write(release sink.Release, _ sink.Options) error {
+	*w.releases = append(*w.releases, release)
+	return nil
+}
+
+func TestReleaseCmd_Outputs(t *testing.T) {
+	assert := assertion.New(t)
+
+	var releases []sink.Release
+
+	sink.Register(recordingWriter{releases: &releases})
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	channelsDir := t.TempDir()
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration:    `[{"name": "master"}]`,
+		ChannelsDirConfiguration: channelsDir,
+		DryRunConfiguration:      "true",
+		OutputsConfiguration:     "recording",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.Equal([]sink.Release{{Version: "0.1.0", Branch: "master", TagPrefix: "v", NewRelease: true}}, releases, "the release should be reported to the custom writer")
+
+	channel, err := os.ReadFile(filepath.Join(channelsDir, "master"))
+	checkErr(t, err, "reading channel file")
+
+	assert.Equal("0.1.0\n", string(channel), "the channels writer should be enabled by the channels directory")
+
+	err = th.SetFlags(map[string]string{OutputsConfiguration: "github,unknown"})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, sink.ErrUnknownWriter)
+}
+
+func TestReleaseCmd_Provenance(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	path := filepath.Join(t.TempDir(), "provenance.intoto.jsonl")
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration:       `[{"name": "master"}]`,
+		ProvenanceFileConfiguration: path,
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	first, err := testRepository.ResolveRevision("v0.1.0")
+	checkErr(t, err, "resolving first release")
+
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	second, err := testRepository.ResolveRevision("v0.1.1")
+	checkErr(t, err, "resolving second release")
+
+	content, err := os.ReadFile(path)
+	checkErr(t, err, "reading provenance file")
+
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	assert.Len(lines, 2, "every release should be attested")
+
+	var envelope provenance.Envelope
+	checkErr(t, json.Unmarshal([]byte(lines[1]), &envelope), "unmarshalling envelope")
+
+	statement, err := provenance.Open(envelope, nil)
+	checkErr(t, err, "opening envelope")
+
+	assert.Equal([]provenance.Descriptor{{Name: "v0.1.1", Digest: map[string]string{"gitCommit": second.String()}}}, statement.Subject)
+	assert.Equal("0.1.1", statement.Predicate.BuildDefinition.ExternalParameters["version"])
+	assert.Equal("refs/heads/master", statement.Predicate.BuildDefinition.ExternalParameters["ref"])
+	assert.Equal([]provenance.Descriptor{{
+		Name:   "v0.1.0",
+		URI:    "git+" + testRepository.Path + "@refs/tags/v0.1.0",
+		Digest: map[string]string{"gitCommit": first.String()},
+	}}, statement.Predicate.BuildDefinition.ResolvedDependencies)
+
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit")
+
+	err = th.SetFlag(DryRunConfiguration, "true")
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	content, err = os.ReadFile(path)
+	checkErr(t, err, "reading provenance file")
+
+	assert.Len(strings.Split(strings.TrimSpace(string(content)), "\n"), 2, "dry-runs should not be attested")
 }
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/s0ders/go-semver-release/v6/internal/appcontext"
+	"github.com/s0ders/go-semver-release/v6/internal/audit"
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
+	"github.com/s0ders/go-semver-release/v6/internal/tag"
+	"github.com/s0ders/go-semver-release/v6/internal/vcs"
+)
+
+var ErrRetagSameCommit = errors.New("tag already references the commit")
+
+func NewRetagCmd(ctx *appcontext.AppContext) *cobra.Command {
+	var to string
+
+	retagCmd := &cobra.Command{
+		Use:   "retag [REPOSITORY_PATH_OR_URL] <TAG_OR_VERSION> --to <COMMIT>",
+		Short: "Move a release tag to another commit",
+		Long:  "Recreate a release tag on another commit, e.g. to recut a release on a fixed commit, and force push it as long as the remote tag was not moved in the meantime",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			repositoryPath, err := repositoryArgument(ctx, args)
+			if err != nil {
+				return err
+			}
+
+			ctx.Projects, err = configureProjects(ctx)
+			if err != nil {
+				return fmt.Errorf("loading projects configuration: %w", err)
+			}
+
+			entity, err := configureGPGKey(ctx)
+			if err != nil {
+				return fmt.Errorf("configuring GPG key: %w", err)
+			}
+
+			backend, err := vcs.Get(ctx.VCSFlag)
+			if err != nil {
+				return fmt.Errorf("configuring VCS backend: %w", err)
+			}
+
+			ctx.Workspace = configureWorkspace(ctx)
+			defer func() {
+				err = errors.Join(err, cleanupWorkspace(ctx))
+			}()
+
+			tagger := tag.NewTagger(ctx.GitNameFlag, ctx.GitEmailFlag, tag.WithSignKey(entity))
+
+			repository, err := backend.Clone(repositoryPath, vcs.Options{
+				RemoteName: ctx.RemoteNameFlag,
+				Token:      ctx.AccessTokenFlag,
+				Tagger:     tagger,
+				Workspace:  ctx.Workspace,
+			})
+			if err != nil {
+				return fmt.Errorf("cloning %s repository: %w", backend.Name(), err)
+			}
+
+			target, err := findTag(ctx, repository, args[len(args)-1])
+			if err != nil {
+				return err
+			}
+
+			resolver, ok := repository.(vcs.RevisionResolver)
+			if !ok {
+				return fmt.Errorf("resolving commit %q: %w", to, vcs.ErrUnsupported)
+			}
+
+			commit, err := resolver.ResolveRevision(to)
+			if err != nil {
+				return fmt.Errorf("resolving commit %q: %w", to, err)
+			}
+
+			var auditLogger *audit.Logger
+			if ctx.AuditLogFlag != "" {
+				auditLogger = audit.New(ctx.AuditLogFlag, audit.WithSignKey(entity))
+			}
+
+			return moveTag(ctx, repository, tagger, auditLogger, repositoryPath, target, commit)
+		},
+	}
+
+	retagCmd.Flags().StringVar(&to, "to", "", "Hash, or any revision, of the commit the tag is moved to")
+	_ = retagCmd.MarkFlagRequired("to")
+
+	return retagCmd
+}
+
+// moveTag recreates a tag on the given commit, signed if the tagger has a sign key, and force pushes it with lease
+// semantics: the remote tag is only replaced if it still references the tag that was cloned. The message of the tag
+// keeps the message of the moved tag followed by a line recording the move, which is also written to the audit log.
+func moveTag(ctx *appcontext.AppContext, repository vcs.Repository, tagger *tag.Tagger, auditLogger *audit.Logger, repositoryPath string, target vcs.Tag, commit string) error {
+	if target.Commit == commit {
+		return fmt.Errorf("%w: %q references %s", ErrRetagSameCommit, target.Name, commit)
+	}
+
+	mover, ok := repository.(vcs.TagMover)
+	if !ok {
+		return fmt.Errorf("moving tag %q: %w", target.Name, vcs.ErrUnsupported)
+	}
+
+	// Lightweight tags have no message of their own, their name being used instead.
+	message := target.Name
+	if describer, ok := repository.(vcs.TagDescriber); ok {
+		if described, err := describer.DescribeTag(target.Name); err == nil {
+			message = strings.TrimSpace(described.Message)
+		}
+	}
+
+	actor := audit.Actor()
+
+	tagger.SetMessage(fmt.Sprintf("%s\n\nRecut from commit %s to commit %s by %s.\n", message, target.Commit, commit, actor))
+
+	previous, err := mover.MoveTag(target.Name, commit)
+	if err != nil {
+		return fmt.Errorf("recreating tag %q: %w", target.Name, err)
+	}
+
+	err = mover.ForcePushTag(target.Name, previous)
+	if err != nil {
+		return err
+	}
+
+	record := audit.Record{
+		Repository:     repositoryPath,
+		Project:        tagProject(ctx.Projects, target.Name, tag.EpochNamespace(ctx.TagNamespaceFlag, ctx.EpochFlag)).Name,
+		Tag:            target.Name,
+		Commit:         commit,
+		PreviousCommit: target.Commit,
+		Actor:          actor,
+	}
+
+	if version, err := semver.NewFromString(target.Name); err == nil {
+		record.Version = version.String()
+	}
+
+	err = appendAuditRecord(auditLogger, audit.ActionRetag, record)
+	if err != nil {
+		return err
+	}
+
+	ctx.Logger.Info().Str("tag", target.Name).Str("previous-commit", target.Commit).Str("commit", commit).Msg("tag moved")
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/vcs"
+)
+
+func TestRetagCmd(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	head, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	err = testRepository.AddTag("v0.1.0", head.Hash())
+	checkErr(t, err, "adding tag")
+
+	fixed, err := testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit")
+
+	logPath := filepath.Join(t.TempDir(), "audit.log")
+
+	th := NewTestHelper(t)
+	err = th.SetFlag(AuditLogConfiguration, logPath)
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("retag", testRepository.Path, "0.1.0", "--to", fixed.String()[:7])
+	checkErr(t, err, "executing command")
+
+	assert.Contains(string(out), `"message":"tag moved"`)
+
+	ref, err := testRepository.Tag("v0.1.0")
+	checkErr(t, err, "fetching tag")
+
+	tagObject, err := testRepository.TagObject(ref.Hash())
+	checkErr(t, err, "fetching tag object")
+
+	assert.Equal(fixed, tagObject.Target, "tag should have been moved to the fixed commit")
+	assert.Contains(tagObject.Message, "Recut from commit "+head.Hash().String()+" to commit "+fixed.String())
+
+	log, err := os.ReadFile(logPath)
+	checkErr(t, err, "reading audit log")
+
+	assert.Contains(string(log), `"action":"retag"`)
+	assert.Contains(string(log), `"previous-commit":"`+head.Hash().String()+`"`)
+
+	th = NewTestHelper(t)
+
+	_, err = th.ExecuteCommand("retag", testRepository.Path, "v0.1.0", "--to", fixed.String())
+	assert.ErrorIs(err, ErrRetagSameCommit)
+
+	th = NewTestHelper(t)
+
+	_, err = th.ExecuteCommand("retag", testRepository.Path, "v0.1.0")
+	assert.ErrorContains(err, `required flag(s) "to" not set`)
+
+	th = NewTestHelper(t)
+	err = th.SetFlag(VCSConfiguration, "hg")
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("retag", testRepository.Path, "v0.1.0", "--to", head.Hash().String())
+	assert.ErrorIs(err, vcs.ErrUnknownBackend, "the configured VCS backend should be used")
+}
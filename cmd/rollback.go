@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/s0ders/go-semver-release/v6/internal/appcontext"
+	"github.com/s0ders/go-semver-release/v6/internal/audit"
+	"github.com/s0ders/go-semver-release/v6/internal/forge"
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
+	"github.com/s0ders/go-semver-release/v6/internal/tag"
+	"github.com/s0ders/go-semver-release/v6/internal/vcs"
+)
+
+// rolledBackMetadata is the build metadata of the tag marking a rolled back version as used.
+const rolledBackMetadata = "rolled-back"
+
+var (
+	ErrNoRepositoryArgument = errors.New("a repository path or URL is required, either as argument or with --repository")
+	ErrRollbackAborted      = errors.New("rollback aborted")
+	ErrTagNotFound          = errors.New("tag not found")
+)
+
+func NewRollbackCmd(ctx *appcontext.AppContext) *cobra.Command {
+	var (
+		yes    bool
+		reopen bool
+	)
+
+	rollbackCmd := &cobra.Command{
+		Use:   "rollback [REPOSITORY_PATH_OR_URL] <TAG_OR_VERSION>",
+		Short: "Delete a mistakenly created release tag",
+		Long:  "Delete a release tag from the repository and its remote, along with its forge release if any, and mark its version as used unless it is reopened for the next run",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			repositoryPath, err := repositoryArgument(ctx, args)
+			if err != nil {
+				return err
+			}
+
+			ctx.Projects, err = configureProjects(ctx)
+			if err != nil {
+				return fmt.Errorf("loading projects configuration: %w", err)
+			}
+
+			entity, err := configureGPGKey(ctx)
+			if err != nil {
+				return fmt.Errorf("configuring GPG key: %w", err)
+			}
+
+			backend, err := vcs.Get(ctx.VCSFlag)
+			if err != nil {
+				return fmt.Errorf("configuring VCS backend: %w", err)
+			}
+
+			ctx.Workspace = configureWorkspace(ctx)
+			defer func() {
+				err = errors.Join(err, cleanupWorkspace(ctx))
+			}()
+
+			tagger := tag.NewTagger(ctx.GitNameFlag, ctx.GitEmailFlag, tag.WithSignKey(entity))
+
+			repository, err := backend.Clone(repositoryPath, vcs.Options{
+				RemoteName: ctx.RemoteNameFlag,
+				Token:      ctx.AccessTokenFlag,
+				Tagger:     tagger,
+				Workspace:  ctx.Workspace,
+			})
+			if err != nil {
+				return fmt.Errorf("cloning %s repository: %w", backend.Name(), err)
+			}
+
+			target, err := findTag(ctx, repository, args[len(args)-1])
+			if err != nil {
+				return err
+			}
+
+			if !yes {
+				err = confirmRollback(cmd.InOrStdin(), cmd.OutOrStdout(), target.Name, repositoryPath)
+				if err != nil {
+					return err
+				}
+			}
+
+			ctx.Forge, err = forge.New(repositoryPath, ctx.AccessTokenFlag, ctx.ForgeFlag, os.Getenv("GITHUB_API_URL"))
+			if err != nil {
+				ctx.Logger.Debug().Err(err).Msg("forge API unavailable, no release to delete")
+				ctx.Forge = nil
+			}
+
+			var auditLogger *audit.Logger
+			if ctx.AuditLogFlag != "" {
+				auditLogger = audit.New(ctx.AuditLogFlag, audit.WithSignKey(entity))
+			}
+
+			return rollbackTag(ctx, repository, auditLogger, repositoryPath, target, reopen)
+		},
+	}
+
+	rollbackCmd.Flags().BoolVarP(&yes, "yes", "y", false, "Roll back without asking for confirmation")
+	rollbackCmd.Flags().BoolVar(&reopen, "reopen", false, "Let the next run release the rolled back version again")
+
+	return rollbackCmd
+}
+
+// repositoryArgument returns the repository given as the first of two arguments, or with the repository flag.
+func repositoryArgument(ctx *appcontext.AppContext, args []string) (string, error) {
+	if len(args) == 2 {
+		return args[0], nil
+	}
+
+	if ctx.RepositoryFlag == "" {
+		return "", ErrNoRepositoryArgument
+	}
+
+	return ctx.RepositoryFlag, nil
+}
+
+// findTag returns the tag given either by its name or by a version formatted with the tag prefix and namespace.
+func findTag(ctx *appcontext.AppContext, repository vcs.Repository, name string) (vcs.Tag, error) {
+	tags, err := repository.Tags()
+	if err != nil {
+		return vcs.Tag{}, err
+	}
+
+	namespace := tag.EpochNamespace(ctx.TagNamespaceFlag, ctx.EpochFlag)
+	candidates := []string{name, tag.Qualify(namespace, name), tag.Qualify(namespace, ctx.TagPrefixFlag+name)}
+
+	for _, candidate := range candidates {
+		for _, t := range tags {
+			if t.Name == candidate {
+				return t, nil
+			}
+		}
+	}
+
+	return vcs.Tag{}, fmt.Errorf("%w: %q", ErrTagNotFound, name)
+}
+
+// confirmRollback asks to confirm the deletion of a tag, only an explicit yes confirming it.
+func confirmRollback(in io.Reader, out io.Writer, tagName, repositoryPath string) error {
+	fmt.Fprintf(out, "Delete tag %q from %s, along with its forge release if any? [y/N]: ", tagName, repositoryPath)
+
+	scanner := bufio.NewScanner(in)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return fmt.Errorf("reading answer: %w", err)
+		}
+
+		return ErrRollbackAborted
+	}
+
+	switch strings.ToLower(strings.TrimSpace(scanner.Text())) {
+	case "y", "yes":
+		return nil
+	default:
+		return ErrRollbackAborted
+	}
+}
+
+// rollbackTag deletes the forge release of a tag, if a forge is available, then the tag itself from the repository
+// and its remote. Unless reopened, the version of the tag is then marked as used by a tag on the same commit whose
+// build metadata tells it was rolled back, so that the next run releases the following version instead of releasing
+// the same version with a different content.
+func rollbackTag(ctx *appcontext.AppContext, repository vcs.Repository, auditLogger *audit.Logger, repositoryPath string, target vcs.Tag, reopen bool) error {
+	if ctx.Forge != nil {
+		err := ctx.Forge.DeleteRelease(context.Background(), target.Name)
+		if err != nil {
+			return fmt.Errorf("deleting forge release: %w", err)
+		}
+	}
+
+	err := repository.DeleteTag(target.Name)
+	if err != nil {
+		return fmt.Errorf("deleting tag: %w", err)
+	}
+
+	record := audit.Record{
+		Repository: repositoryPath,
+		Project:    tagProject(ctx.Projects, target.Name, tag.EpochNamespace(ctx.TagNamespaceFlag, ctx.EpochFlag)).Name,
+		Tag:        target.Name,
+		Commit:     target.Commit,
+		Actor:      audit.Actor(),
+	}
+
+	if version, err := semver.NewFromString(target.Name); err == nil {
+		record.Version = version.String()
+	}
+
+	err = appendAuditRecord(auditLogger, audit.ActionRollback, record)
+	if err != nil {
+		return err
+	}
+
+	logEvent := ctx.Logger.Info().Str("tag", target.Name).Bool("reopened", reopen)
+
+	if !reopen {
+		marker := rolledBackTagName(target.Name)
+
+		err = repository.CreateTag(marker, target.Commit)
+		if err != nil {
+			return fmt.Errorf("tagging rolled back version: %w", err)
+		}
+
+		err = repository.PushTag(marker)
+		if err != nil {
+			return fmt.Errorf("pushing rolled back version tag: %w", err)
+		}
+
+		logEvent.Str("marker", marker)
+	}
+
+	logEvent.Msg("tag rolled back")
+
+	return nil
+}
+
+// rolledBackTagName returns the name of the tag marking the version of the given tag as rolled back, which adds the
+// rolled back build metadata to the version of the tag, e.g. "v1.2.3+rolled-back".
+func rolledBackTagName(name string) string {
+	if strings.Contains(name, "+") {
+		return name + "." + rolledBackMetadata
+	}
+
+	return name + "+" + rolledBackMetadata
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/appcontext"
+	"github.com/s0ders/go-semver-release/v6/internal/audit"
+	"github.com/s0ders/go-semver-release/v6/internal/forge"
+	"github.com/s0ders/go-semver-release/v6/internal/tag"
+	"github.com/s0ders/go-semver-release/v6/internal/vcs"
+)
+
+func TestRollbackCmd(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	head, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	err = testRepository.AddTag("v0.1.0", head.Hash())
+	checkErr(t, err, "adding tag")
+
+	th := NewTestHelper(t)
+	th.Cmd.SetIn(strings.NewReader("n\n"))
+
+	_, err = th.ExecuteCommand("rollback", testRepository.Path, "0.1.0")
+	assert.ErrorIs(err, ErrRollbackAborted)
+
+	_, err = testRepository.Tag("v0.1.0")
+	assert.NoError(err, "tag should not be deleted without confirmation")
+
+	th = NewTestHelper(t)
+	th.Cmd.SetIn(strings.NewReader("yes\n"))
+
+	out, err := th.ExecuteCommand("rollback", testRepository.Path, "v0.1.0")
+	checkErr(t, err, "executing command")
+
+	assert.Contains(string(out), `"message":"tag rolled back"`)
+
+	_, err = testRepository.Tag("v0.1.0")
+	assert.Error(err, "tag should have been deleted from the remote")
+
+	_, err = testRepository.Tag("v0.1.0+rolled-back")
+	assert.NoError(err, "version should have been marked as used")
+
+	th = NewTestHelper(t)
+	err = th.SetFlag(BranchesConfiguration, "master")
+	checkErr(t, err, "setting flags")
+
+	out, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.Contains(string(out), `"message":"no new release"`, "a rolled back version should not be released again")
+}
+
+func TestRollbackCmd_Reopen(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	head, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	err = testRepository.AddTag("v0.1.0", head.Hash())
+	checkErr(t, err, "adding tag")
+
+	th := NewTestHelper(t)
+
+	_, err = th.ExecuteCommand("rollback", testRepository.Path, "0.1.0", "--yes", "--reopen")
+	checkErr(t, err, "executing command")
+
+	_, err = testRepository.Tag("v0.1.0+rolled-back")
+	assert.Error(err, "a reopened version should not be marked as used")
+
+	_, err = th.ExecuteCommand("rollback", testRepository.Path, "0.1.0", "--yes")
+	assert.ErrorIs(err, ErrTagNotFound)
+
+	th = NewTestHelper(t)
+	err = th.SetFlag(BranchesConfiguration, "master")
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	_, err = testRepository.Tag("v0.1.0")
+	assert.NoError(err, "a reopened version should be released again")
+}
+
+func TestRollbackTag_ForgeReleaseAndAuditLog(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	head, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	err = testRepository.AddTag("api-v1.0.0", head.Hash())
+	checkErr(t, err, "adding tag")
+
+	repository, err := vcs.GitBackend{}.Clone(testRepository.Path, vcs.Options{RemoteName: "origin", Tagger: tag.NewTagger("Go Semver Release", "go-semver@release.ci")})
+	checkErr(t, err, "cloning repository")
+
+	releases := []forge.Release{{Tag: "api-v1.0.0"}, {Tag: "web-v1.0.0"}}
+	logPath := filepath.Join(t.TempDir(), "audit.log")
+
+	ctx := &appcontext.AppContext{Logger: zerolog.Nop(), Forge: fakeForge{releases: &releases}}
+
+	target, err := findTag(ctx, repository, "api-v1.0.0")
+	checkErr(t, err, "finding tag")
+
+	err = rollbackTag(ctx, repository, audit.New(logPath), testRepository.Path, target, true)
+	checkErr(t, err, "rolling back tag")
+
+	assert.Equal([]forge.Release{{Tag: "web-v1.0.0"}}, releases, "the release of the tag should have been deleted")
+
+	log, err := os.ReadFile(logPath)
+	checkErr(t, err, "reading audit log")
+
+	assert.Contains(string(log), `"action":"rollback"`)
+	assert.Contains(string(log), `"version":"1.0.0"`)
+	assert.Contains(string(log), `"tag":"api-v1.0.0"`)
+}
+
+func TestRolledBackTagName(t *testing.T) {
+	assert := assertion.New(t)
+
+	assert.Equal("v1.2.3+rolled-back", rolledBackTagName("v1.2.3"))
+	assert.Equal("v1.2.3+build.5.rolled-back", rolledBackTagName("v1.2.3+build.5"))
+}
@@ -5,7 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/spf13/cobra"
@@ -14,27 +16,107 @@ import (
 
 	"github.com/s0ders/go-semver-release/v6/internal/appcontext"
 	"github.com/s0ders/go-semver-release/v6/internal/branch"
+	"github.com/s0ders/go-semver-release/v6/internal/changelog"
+	"github.com/s0ders/go-semver-release/v6/internal/ci"
+	"github.com/s0ders/go-semver-release/v6/internal/dryrun"
+	"github.com/s0ders/go-semver-release/v6/internal/event"
 	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
+	"github.com/s0ders/go-semver-release/v6/internal/policy"
+	"github.com/s0ders/go-semver-release/v6/internal/render"
 	"github.com/s0ders/go-semver-release/v6/internal/rule"
+	"github.com/s0ders/go-semver-release/v6/internal/target"
 )
 
+var ErrUnknownActionInput = errors.New("unknown GitHub Action input")
+
 const (
 	defaultConfigFile = ".semver"
 	configFileFormat  = "yaml"
 )
 
 const (
-	AccessTokenConfiguration   = "access-token"
-	BranchesConfiguration      = "branches"
-	BuildMetadataConfiguration = "build-metadata"
-	DryRunConfiguration        = "dry-run"
-	GitEmailConfiguration      = "git-email"
-	GitNameConfiguration       = "git-name"
-	GPGPathConfiguration       = "gpg-key-path"
-	MonorepoConfiguration      = "monorepo"
-	RemoteNameConfiguration    = "remote-name"
-	RulesConfiguration         = "rules"
-	TagPrefixConfiguration     = "tag-prefix"
+	AccessTokenConfiguration           = "access-token"
+	APIDiffConfiguration               = "api-diff"
+	APIDiffAnalyzerConfiguration       = "api-diff-analyzer"
+	ApproveConfiguration               = "approve"
+	ArtifactsBucketConfiguration       = "artifacts-bucket"
+	AsOfConfiguration                  = "as-of"
+	AtConfiguration                    = "at"
+	AuditLogConfiguration              = "audit-log"
+	AuthorDomainsConfiguration         = "author-domains"
+	BadgesDirConfiguration             = "badges-dir"
+	BranchesConfiguration              = "branches"
+	BuildMetadataConfiguration         = "build-metadata"
+	CacheDirConfiguration              = "cache-dir"
+	CascadeBumpsConfiguration          = "cascade-bumps"
+	ChangedPathsConfiguration          = "changed-paths"
+	ChangelogDirConfiguration          = "changelog-dir"
+	ChangelogFileConfiguration         = "changelog-file"
+	ChangelogFormatConfiguration       = "changelog-format"
+	ChannelsDirConfiguration           = "channels-dir"
+	CheckRunConfiguration              = "check-run"
+	CommitURLTemplateConfiguration     = "commit-url-template"
+	ConfirmMajorConfiguration          = "confirm-major"
+	ContinueOnErrorConfiguration       = "continue-on-error"
+	ContributorHandlesConfiguration    = "contributor-handles"
+	DeduplicateCommitsConfiguration    = "deduplicate-commits"
+	DeploymentsConfiguration           = "deployments"
+	DetectCherryPicksConfiguration     = "detect-cherry-picks"
+	DiscoverProjectsConfiguration      = "discover-projects"
+	DryRunConfiguration                = "dry-run"
+	EpochConfiguration                 = "epoch"
+	EventsConfiguration                = "events"
+	FloatingTagsConfiguration          = "floating-tags"
+	ForceBumpConfiguration             = "force-bump"
+	ForgeConfiguration                 = "forge"
+	GitEmailConfiguration              = "git-email"
+	GitNameConfiguration               = "git-name"
+	GitHubActionConfiguration          = "github-action"
+	GPGPathConfiguration               = "gpg-key-path"
+	GPGPassphraseConfiguration         = "gpg-passphrase"
+	HistoryBoundaryConfiguration       = "history-boundary"
+	KeepWorkspaceConfiguration         = "keep-workspace"
+	LatestRefConfiguration             = "latest-ref"
+	LockConfiguration                  = "lock"
+	LockTTLConfiguration               = "lock-ttl"
+	MaxBumpPerRunConfiguration         = "max-bump-per-run"
+	MaxVersionSkipConfiguration        = "max-version-skip"
+	MergeQueueConfiguration            = "merge-queue"
+	MonorepoConfiguration              = "monorepo"
+	OnlyChangedConfiguration           = "only-changed"
+	OnlyProjectsConfiguration          = "only-projects"
+	OutputsConfiguration               = "outputs"
+	PolicyConfiguration                = "policy"
+	ProgressConfiguration              = "progress"
+	ProvenanceFileConfiguration        = "provenance-file"
+	PullRequestNotesConfiguration      = "pull-request-notes"
+	PullRequestURLConfiguration        = "pull-request-url-template"
+	PushCheckConfiguration             = "push-check"
+	ReleaseSummaryConfiguration        = "release-summary"
+	ReleaseSummaryCommentConfiguration = "release-summary-comment"
+	ReleaseSummaryTagConfiguration     = "release-summary-tag"
+	RemoteNameConfiguration            = "remote-name"
+	RenderConfiguration                = "render"
+	RepositoryConfiguration            = "repository"
+	RequireChecksConfiguration         = "require-checks"
+	RootPathConfiguration              = "root-path"
+	RuleOrderConfiguration             = "rule-order"
+	RuleStatsConfiguration             = "rule-stats"
+	RulesConfiguration                 = "rules"
+	SkipMarkersConfiguration           = "skip-markers"
+	StrictAuthorshipConfiguration      = "strict-authorship"
+	StrictEnvConfiguration             = "strict-env"
+	SubmoduleConfiguration             = "submodule-analysis"
+	SuppressNoReleaseConfiguration     = "suppress-no-release"
+	TagAliasesConfiguration            = "tag-aliases"
+	TagNamespaceConfiguration          = "tag-namespace"
+	TagPrefixConfiguration             = "tag-prefix"
+	TargetsConfiguration               = "targets"
+	TemplatesDirConfiguration          = "templates-dir"
+	TrustedKeysConfiguration           = "trusted-keys"
+	UntrustedTagsConfiguration         = "untrusted-tags"
+	VCSConfiguration                   = "vcs"
+	WorkspaceTTLConfiguration          = "workspace-ttl"
 )
 
 func NewAppContext() *appcontext.AppContext {
@@ -60,48 +142,147 @@ func NewRootCommand(ctx *appcontext.AppContext) *cobra.Command {
 	}
 
 	rootCmd.PersistentFlags().StringVar(&ctx.AccessTokenFlag, AccessTokenConfiguration, "", "Access token used to push tag to Git remote")
-	rootCmd.PersistentFlags().VarP(&ctx.BranchesFlag, BranchesConfiguration, "b", "An array of branches such as [{\"name\": \"main\"}, {\"name\": \"rc\", \"prerelease\": true}]")
+	rootCmd.PersistentFlags().StringVar(&ctx.APIDiffFlag, APIDiffConfiguration, "", "Check the public API for incompatible changes made without a breaking change commit, either \"warn\" or \"fail\"")
+	rootCmd.PersistentFlags().StringVar(&ctx.APIDiffAnalyzerFlag, APIDiffAnalyzerConfiguration, "go", "Language analyzer used to extract the public API")
+	rootCmd.PersistentFlags().StringSliceVar(&ctx.ApproveFlag, ApproveConfiguration, nil, "Names of the policies requiring an approval that are approved for this run")
+	rootCmd.PersistentFlags().StringVar(&ctx.ArtifactsBucketFlag, ArtifactsBucketConfiguration, "", "URL of an S3 or GCS bucket to which the result, changelog and manifest of each release are uploaded, e.g. \"s3://acme-releases/app\" or \"gs://acme-releases/app\"")
+	rootCmd.PersistentFlags().StringVar(&ctx.AsOfFlag, AsOfConfiguration, "", "Date (e.g. \"2024-03-01\" or \"2024-03-01T12:00:00Z\") or commit SHA as of which versions are computed, ignoring later commits and tags, in dry-run mode")
+	rootCmd.PersistentFlags().StringVar(&ctx.AtFlag, AtConfiguration, "", "Commit SHA to analyze instead of the tip of the configured branch, e.g. a detached HEAD checked out by a CI runner")
+	rootCmd.PersistentFlags().StringVar(&ctx.AuditLogFlag, AuditLogConfiguration, "", "Path to an append-only JSON lines file recording every tagging and pushing action")
+	rootCmd.PersistentFlags().StringSliceVar(&ctx.AuthorDomainsFlag, AuthorDomainsConfiguration, []string{}, "Glob patterns of the email domains allowed for the authors of released commits in strict authorship mode, e.g. \"*.acme.com\"")
+	rootCmd.PersistentFlags().StringVar(&ctx.BadgesDirFlag, BadgesDirConfiguration, "", "Directory in which a shields.io endpoint badge of the latest released version is written for every branch and project")
+	rootCmd.PersistentFlags().VarP(&ctx.BranchesFlag, BranchesConfiguration, "b", "An array of branches such as [{\"name\": \"main\"}, {\"name\": \"rc\", \"prerelease\": true}], or its shorthand main,rc:prerelease")
 	rootCmd.PersistentFlags().StringVar(&ctx.BuildMetadataFlag, BuildMetadataConfiguration, "", "Build metadata (e.g. build number) that will be appended to the SemVer")
-	rootCmd.PersistentFlags().StringVar(&ctx.CfgFileFlag, "config", "", "Configuration file path (default \"./"+defaultConfigFile+"."+configFileFormat+"\")")
-	rootCmd.PersistentFlags().BoolVarP(&ctx.DryRunFlag, DryRunConfiguration, "d", false, "Only compute the next SemVer, do not push any tag")
+	rootCmd.PersistentFlags().StringVar(&ctx.CacheDirFlag, CacheDirConfiguration, "", "Directory in which repositories are cloned once and then updated incrementally by later runs")
+	rootCmd.PersistentFlags().BoolVar(&ctx.CascadeBumpsFlag, CascadeBumpsConfiguration, false, "Release a project whose dependencies are released by the run with a patch bump, even if none of its commits triggers a release")
+	rootCmd.PersistentFlags().BoolVar(&ctx.ChangedPathsFlag, ChangedPathsConfiguration, false, "List the files changed since the previous release of every branch and project in its output")
+	rootCmd.PersistentFlags().StringVar(&ctx.ChangelogDirFlag, ChangelogDirConfiguration, "", "Directory in which the changelog of every new release is written")
+	rootCmd.PersistentFlags().StringVar(&ctx.ChangelogFileFlag, ChangelogFileConfiguration, "", "Markdown changelog file, e.g. \"CHANGELOG.md\", in which the changelog of every new release is inserted under a marker comment")
+	rootCmd.PersistentFlags().StringVar(&ctx.ChangelogFormatFlag, ChangelogFormatConfiguration, changelog.FormatKeepAChangelog, "Format of the changelogs, either \"keep-a-changelog\" or \"conventional-json\"")
+	rootCmd.PersistentFlags().StringVar(&ctx.ChannelsDirFlag, ChannelsDirConfiguration, "", "Directory in which a file containing the latest version is written for every branch and project")
+	rootCmd.PersistentFlags().StringVar(&ctx.CheckRunFlag, CheckRunConfiguration, "", "Name of a check run reported on the analyzed commit of every branch, summarizing the releases it leads to")
+	rootCmd.PersistentFlags().StringVar(&ctx.CommitURLTemplateFlag, CommitURLTemplateConfiguration, "", "Template of the URL of the commit pages linked by changelogs, such as \"https://git.acme.com/repo/commit/{{ .Hash }}\", derived from the repository URL on GitHub, GitLab, Bitbucket, Gitea and Forgejo")
+	rootCmd.PersistentFlags().BoolVar(&ctx.ConfirmMajorFlag, ConfirmMajorConfiguration, false, "Confirm a major release that is capped or reported as an anomaly")
+	rootCmd.PersistentFlags().BoolVar(&ctx.ContinueOnErrorFlag, ContinueOnErrorConfiguration, false, "Keep processing the other branches and projects when computing the release of one fails, then exit with an error")
+	rootCmd.PersistentFlags().BoolVar(&ctx.ContributorHandlesFlag, ContributorHandlesConfiguration, false, "Map the emails of the contributors listed in changelogs to their forge handle")
+	rootCmd.PersistentFlags().StringVar(&ctx.CfgFileFlag, "config", "", "Configuration file path, optionally followed by \"#<key>\" to read the configuration from a section of a shared file (default \"./"+defaultConfigFile+"."+configFileFormat+"\")")
+	rootCmd.PersistentFlags().BoolVar(&ctx.DeduplicateCommitsFlag, DeduplicateCommitsConfiguration, false, "Ignore the commits repeating an older commit of the release range, by Change-Id trailer or patch ID")
+	rootCmd.PersistentFlags().BoolVar(&ctx.DeploymentsFlag, DeploymentsConfiguration, false, "Record a forge deployment to the environment of the released branch after pushing a tag")
+	rootCmd.PersistentFlags().BoolVar(&ctx.DetectCherryPicksFlag, DetectCherryPicksConfiguration, false, "Ignore the commits whose change was already released on another branch, e.g. cherry-picked hotfixes")
+	rootCmd.PersistentFlags().BoolVar(&ctx.DiscoverProjectsFlag, DiscoverProjectsConfiguration, false, "Discover the monorepo projects from the Cargo, npm and pnpm workspaces of the repository, along with their dependencies")
+	rootCmd.PersistentFlags().VarP(&ctx.DryRunFlag, DryRunConfiguration, "d", "Only compute the next SemVer, suppressing either all side effects or the given ones among \"lock\", \"checks\", \"tag\", \"push\", \"deployment\", \"release\", \"comment\", \"check-run\", \"artifacts\", \"registry\" and \"events\"")
+	rootCmd.PersistentFlags().Lookup(DryRunConfiguration).NoOptDefVal = dryrun.All
+	rootCmd.PersistentFlags().IntVar(&ctx.EpochFlag, EpochConfiguration, 0, "Epoch of the versions, whose tags are created and looked up under the \"epoch<N>\" namespace so that versions are only compared within the epoch, 0 disabling epochs")
+	rootCmd.PersistentFlags().Var(&ctx.EventsFlag, EventsConfiguration, "An array of message queue topics to which an event is published for every release, such as [{\"type\": \"sns\", \"topic\": \"arn:aws:sns:eu-west-1:123456789012:releases\"}]")
+	rootCmd.PersistentFlags().BoolVar(&ctx.FloatingTagsFlag, FloatingTagsConfiguration, false, "Move the vX and vX.Y floating tags to every new stable release, as expected by the consumers of GitHub Actions")
+	rootCmd.PersistentFlags().StringVar(&ctx.ForceBumpFlag, ForceBumpConfiguration, "", "Force a release of the given type (\"patch\", \"minor\" or \"major\") when no commit triggers one")
+	rootCmd.PersistentFlags().StringVar(&ctx.ForgeFlag, ForgeConfiguration, "", "Forge hosting the repository, either \"github\", \"bitbucket\", \"bitbucket-server\" or \"gitea\", inferred from the repository URL by default")
 	rootCmd.PersistentFlags().StringVar(&ctx.GitEmailFlag, GitEmailConfiguration, "go-semver@release.ci", "Email used in semantic version tags")
 	rootCmd.PersistentFlags().StringVar(&ctx.GitNameFlag, GitNameConfiguration, "Go Semver Release", "Name used in semantic version tags")
-	rootCmd.PersistentFlags().StringVar(&ctx.GPGKeyPathFlag, GPGPathConfiguration, "", "Path to an armored GPG key used to sign produced tags")
+	rootCmd.PersistentFlags().BoolVar(&ctx.GitHubActionFlag, GitHubActionConfiguration, false, "Read the configuration from the GitHub Action inputs passed as INPUT_<NAME> environment variables")
+	rootCmd.PersistentFlags().StringVar(&ctx.GPGKeyPathFlag, GPGPathConfiguration, "", "Path to an armored GPG key used to sign produced tags, or a keychain:<service>/<account> reference to read it from the OS keychain")
+	rootCmd.PersistentFlags().StringVar(&ctx.GPGPassphraseFlag, GPGPassphraseConfiguration, "", "Passphrase protecting the GPG keys, or a keychain:<service>/<account> reference to read it from the OS keychain")
+	rootCmd.PersistentFlags().StringVar(&ctx.HistoryBoundaryFlag, HistoryBoundaryConfiguration, "", "Commit SHA or tag at which the analysis of the history stops, e.g. the graft point of a migrated repository")
+	rootCmd.PersistentFlags().BoolVar(&ctx.KeepWorkspaceFlag, KeepWorkspaceConfiguration, false, "Keep the temporary directories in which repositories are cloned once the run is over, e.g. to debug a failed run")
+	rootCmd.PersistentFlags().StringVar(&ctx.LatestRefFlag, LatestRefConfiguration, "", "Branch fast-forwarded, or full reference such as \"refs/releases/latest\" updated, to the latest stable release")
+	rootCmd.PersistentFlags().BoolVar(&ctx.LockFlag, LockConfiguration, false, "Lock the released branches on the remote so that concurrent releases do not conflict")
+	rootCmd.PersistentFlags().DurationVar(&ctx.LockTTLFlag, LockTTLConfiguration, 10*time.Minute, "Duration after which a lock that was not released is considered abandoned")
+	rootCmd.PersistentFlags().StringVar(&ctx.MaxBumpPerRunFlag, MaxBumpPerRunConfiguration, "", "Highest release type (\"patch\", \"minor\" or \"major\") a single run can produce, higher ones being capped")
+	rootCmd.PersistentFlags().IntVar(&ctx.MaxVersionSkipFlag, MaxVersionSkipConfiguration, 0, "Number of versions a single run can skip before being reported as an anomaly, 0 disabling anomaly detection")
+	rootCmd.PersistentFlags().BoolVar(&ctx.MergeQueueFlag, MergeQueueConfiguration, false, "Analyze the titles of the pull requests bundled by merge queue commits (e.g., \"Merge #123 #124\") instead of their message")
 	rootCmd.PersistentFlags().Var(&ctx.MonorepositoryFlag, MonorepoConfiguration, "An array of branches such as [{\"name\": \"foo\", \"path\": \"./foo/\"}]")
+	rootCmd.PersistentFlags().BoolVar(&ctx.OnlyChangedFlag, OnlyChangedConfiguration, false, "Only analyze the monorepo projects whose files changed since their latest release")
+	rootCmd.PersistentFlags().StringSliceVar(&ctx.OnlyProjectsFlag, OnlyProjectsConfiguration, nil, "Only analyze the given monorepo projects, such as \"api,web\"")
+	rootCmd.PersistentFlags().StringSliceVar(&ctx.OutputsFlag, OutputsConfiguration, []string{"github"}, "Output writers to which the outcome of every release is reported, such as \"github,channels\"")
+	rootCmd.PersistentFlags().Var(&ctx.PolicyFlag, PolicyConfiguration, "An array of policies denying, or holding until approved, the releases matching a CEL expression, such as [{\"name\": \"no-friday-major\", \"expression\": \"release.type == 'major' && now.getDayOfWeek() == 5\"}]")
+	rootCmd.PersistentFlags().BoolVar(&ctx.ProgressFlag, ProgressConfiguration, false, "Log the progress of long operations, such as cloning and scanning the history, at most once per second")
+	rootCmd.PersistentFlags().StringVar(&ctx.ProvenanceFileFlag, ProvenanceFileConfiguration, "", "Path of a file to which the in-toto provenance attestation of every release is appended")
+	rootCmd.PersistentFlags().BoolVar(&ctx.PullRequestNotesFlag, PullRequestNotesConfiguration, false, "Use the title and description of the merged pull requests instead of the commit messages in changelogs")
+	rootCmd.PersistentFlags().StringVar(&ctx.PullRequestURLTemplateFlag, PullRequestURLConfiguration, "", "Template of the URL of the pull request pages linked by changelogs, such as \"https://git.acme.com/repo/pull/{{ .Number }}\", derived from the repository URL on GitHub, GitLab, Bitbucket, Gitea and Forgejo")
+	rootCmd.PersistentFlags().BoolVar(&ctx.PushCheckFlag, PushCheckConfiguration, false, "Check that the remote accepts the release tags with the given credentials before any release action, even in dry-run mode")
+	rootCmd.PersistentFlags().StringVar(&ctx.ReleaseSummaryFlag, ReleaseSummaryConfiguration, "", "Path of a Markdown file summarizing all the releases of a run, with the changelog of every project")
+	rootCmd.PersistentFlags().IntVar(&ctx.ReleaseSummaryCommentFlag, ReleaseSummaryCommentConfiguration, 0, "Number of a pull request on which the release summary is posted as a comment")
+	rootCmd.PersistentFlags().StringVar(&ctx.ReleaseSummaryTagFlag, ReleaseSummaryTagConfiguration, "", "Tag of a forge release publishing the release summary, which can be a template using the summary data such as its .Date")
 	rootCmd.PersistentFlags().StringVar(&ctx.RemoteNameFlag, RemoteNameConfiguration, "origin", "Name of the Git repository remote")
+	rootCmd.PersistentFlags().Var(&ctx.RenderFlag, RenderConfiguration, "An array of template files rendered with the version of every new release, such as [{\"template\": \"version.go.tmpl\", \"output\": \"version.go\"}]")
+	rootCmd.PersistentFlags().StringVar(&ctx.RepositoryFlag, RepositoryConfiguration, "", "Path or URL of the repository to release, if not given as an argument")
+	rootCmd.PersistentFlags().StringSliceVar(&ctx.RequireChecksFlag, RequireChecksConfiguration, nil, "CI checks that must have passed on the release commit before tagging it, such as \"build,test\"")
+	rootCmd.PersistentFlags().StringVar(&ctx.RootPathFlag, RootPathConfiguration, "", "Subdirectory of the repository released on its own, such as \"vendor-products/acme\", its tags being prefixed by its name")
+	rootCmd.PersistentFlags().StringSliceVar(&ctx.RuleOrderFlag, RuleOrderConfiguration, nil, "Order in which the kinds of release rules are evaluated, the first matching one giving the release type, \"breaking,scope,type\" by default")
+	rootCmd.PersistentFlags().BoolVar(&ctx.RuleStatsFlag, RuleStatsConfiguration, false, "Report how many commits matched each release rule, and how many were ignored, in the output of every branch and project")
 	rootCmd.PersistentFlags().Var(&ctx.RulesFlag, RulesConfiguration, "An hashmap of array such as {\"minor\": [\"feat\"], \"patch\": [\"fix\", \"perf\"]} ]")
+	rootCmd.PersistentFlags().StringSliceVar(&ctx.SkipMarkersFlag, SkipMarkersConfiguration, []string{"[skip release]", "[release skip]"}, "Markers excluding a commit from the release, or skipping the release of a branch when found on its head commit")
+	rootCmd.PersistentFlags().BoolVar(&ctx.StrictAuthorshipFlag, StrictAuthorshipConfiguration, false, "Fail the release of commits without an author name and email, or whose email domain is not allowed")
+	rootCmd.PersistentFlags().BoolVar(&ctx.StrictEnvFlag, StrictEnvConfiguration, false, "Fail if the configuration file references an undefined environment variable without default value")
+	rootCmd.PersistentFlags().BoolVar(&ctx.SubmoduleAnalysisFlag, SubmoduleConfiguration, false, "Analyze the commits of submodules whose pointer is updated")
+	rootCmd.PersistentFlags().BoolVar(&ctx.SuppressNoReleaseFlag, SuppressNoReleaseConfiguration, false, "Leave the branches and projects without new release out of the command output, which then ends with the counts of the run")
+	rootCmd.PersistentFlags().StringToStringVar(&ctx.TagAliasesFlag, TagAliasesConfiguration, nil, "Versions of tags whose name is not a semantic version, such as RELEASE_2020_07=3.5.0")
+	rootCmd.PersistentFlags().StringVar(&ctx.TagNamespaceFlag, TagNamespaceConfiguration, "", "Namespace under which tags are created and looked up, e.g. \"releases\" for refs/tags/releases/v1.2.3")
 	rootCmd.PersistentFlags().StringVar(&ctx.TagPrefixFlag, TagPrefixConfiguration, "v", "Prefix added to the version tag name")
+	rootCmd.PersistentFlags().Var(&ctx.TargetsFlag, TargetsConfiguration, "An array of repositories released by a single run, such as [{\"repository\": \"git@github.com:org/foo.git\", \"branches\": \"main,rc:prerelease\"}]")
+	rootCmd.PersistentFlags().StringVar(&ctx.TemplatesDirFlag, TemplatesDirConfiguration, "", "Directory of templates overriding the built-in ones used to render tag messages and changelogs")
+	rootCmd.PersistentFlags().StringSliceVar(&ctx.TrustedKeysFlag, TrustedKeysConfiguration, nil, "Paths to armored GPG public keys, one of which must have signed the latest tag for it to be used as the base version")
+	rootCmd.PersistentFlags().StringVar(&ctx.UntrustedTagsFlag, UntrustedTagsConfiguration, "fail", "What to do with tags not signed by a trusted key, either \"fail\" or \"ignore\"")
+	rootCmd.PersistentFlags().StringVar(&ctx.VCSFlag, VCSConfiguration, "git", "Version control system hosting the repository")
+	rootCmd.PersistentFlags().DurationVar(&ctx.WorkspaceTTLFlag, WorkspaceTTLConfiguration, 24*time.Hour, "Age after which a temporary directory left behind by a previous run is removed, 0 disabling the removal")
 	rootCmd.PersistentFlags().BoolVarP(&ctx.VerboseFlag, "verbose", "v", false, "Verbose output")
 
 	releaseCmd := NewReleaseCmd(ctx)
+	doctorCmd := NewDoctorCmd(ctx)
+	explainCmd := NewExplainCmd(ctx)
+	initCmd := NewInitCmd(ctx)
+	migrateConfigCmd := NewMigrateConfigCmd(ctx)
+	retagCmd := NewRetagCmd(ctx)
+	rollbackCmd := NewRollbackCmd(ctx)
+	rulesCmd := NewRulesCmd(ctx)
 	versionCmd := NewVersionCmd()
 
 	rootCmd.AddCommand(releaseCmd)
+	rootCmd.AddCommand(doctorCmd)
+	rootCmd.AddCommand(explainCmd)
+	rootCmd.AddCommand(initCmd)
+	rootCmd.AddCommand(migrateConfigCmd)
+	rootCmd.AddCommand(retagCmd)
+	rootCmd.AddCommand(rollbackCmd)
+	rootCmd.AddCommand(rulesCmd)
 	rootCmd.AddCommand(versionCmd)
 
 	return rootCmd
 }
 
 func initializeConfig(cmd *cobra.Command, ctx *appcontext.AppContext) error {
-	if ctx.CfgFileFlag != "" {
-		ctx.Viper.SetConfigFile(ctx.CfgFileFlag)
+	// Action inputs are bound first, since they may hold the configuration file path.
+	if ctx.GitHubActionFlag {
+		if err := bindActionInputs(cmd); err != nil {
+			return err
+		}
+	}
+
+	cfgFile, cfgSection := splitConfigPath(ctx.CfgFileFlag)
+
+	if cfgFile != "" {
+		ctx.Viper.SetConfigFile(cfgFile)
 	} else {
 		ctx.Viper.AddConfigPath(".")
 		ctx.Viper.SetConfigType(configFileFormat)
 		ctx.Viper.SetConfigName(defaultConfigFile)
 	}
 
-	absCfgPath, err := filepath.Abs(ctx.CfgFileFlag)
+	absCfgPath, err := filepath.Abs(cfgFile)
 	if err != nil {
 		return fmt.Errorf("getting configuration file absolute path: %w", err)
 	}
-	ctx.Logger.Debug().Str("path", absCfgPath).Msg("using the following configuration file")
+	ctx.Logger.Debug().Str("path", absCfgPath).Str("section", cfgSection).Msg("using the following configuration file")
 
 	ctx.Viper.SetEnvPrefix("GO_SEMVER_RELEASE")
 	ctx.Viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
 	ctx.Viper.AutomaticEnv()
 
-	if err := ctx.Viper.ReadInConfig(); err != nil {
+	if cfgSection != "" {
+		if err := readConfigSection(ctx.Viper, cfgFile, cfgSection); err != nil {
+			return err
+		}
+	} else if err := ctx.Viper.ReadInConfig(); err != nil {
 		var configFileNotFoundError viper.ConfigFileNotFoundError
 
 		if !errors.As(err, &configFileNotFoundError) {
@@ -109,6 +290,18 @@ func initializeConfig(cmd *cobra.Command, ctx *appcontext.AppContext) error {
 		}
 	}
 
+	if err := mergeExtendedConfig(ctx.Viper); err != nil {
+		return err
+	}
+
+	if err := interpolateConfig(ctx.Viper, ctx.StrictEnvFlag || ctx.Viper.GetBool(StrictEnvConfiguration)); err != nil {
+		return err
+	}
+
+	if err := decryptConfig(ctx.Viper); err != nil {
+		return err
+	}
+
 	if err := bindFlags(cmd, ctx.Viper); err != nil {
 		return err
 	}
@@ -132,7 +325,13 @@ func bindFlags(cmd *cobra.Command, v *viper.Viper) error {
 			val := v.Get(configName)
 
 			switch flagType := f.Value.(type) {
-			case *branch.Flag, *rule.Flag, *monorepo.Flag:
+			case *branch.Flag, *rule.Flag, *monorepo.Flag, *policy.Flag, *render.Flag, *target.Flag, *event.Flag:
+				// Values read from the environment are strings, e.g. the shorthand form of the branches.
+				if s, ok := val.(string); ok {
+					err = flagType.Set(s)
+					break
+				}
+
 				jsonStr, jsonErr := json.Marshal(val)
 				if jsonErr != nil {
 					err = fmt.Errorf("marshaling %q value: %w", configName, jsonErr)
@@ -140,6 +339,16 @@ func bindFlags(cmd *cobra.Command, v *viper.Viper) error {
 
 				err = flagType.Set(string(jsonStr))
 			default:
+				if m, ok := val.(map[string]any); ok {
+					err = cmd.Flags().Set(f.Name, formatStringMap(m))
+					break
+				}
+
+				if l, ok := val.([]any); ok {
+					err = cmd.Flags().Set(f.Name, formatList(l))
+					break
+				}
+
 				err = cmd.Flags().Set(f.Name, fmt.Sprintf("%v", val))
 			}
 
@@ -149,3 +358,60 @@ func bindFlags(cmd *cobra.Command, v *viper.Viper) error {
 
 	return err
 }
+
+// formatList formats a configuration list as the comma separated list expected by slice flags.
+func formatList(l []any) string {
+	items := make([]string, len(l))
+
+	for i, item := range l {
+		items[i] = fmt.Sprintf("%v", item)
+	}
+
+	return strings.Join(items, ",")
+}
+
+// formatStringMap formats a configuration map as the comma separated list of key=value pairs expected by map flags.
+func formatStringMap(m map[string]any) string {
+	pairs := make([]string, 0, len(m))
+
+	for key, value := range m {
+		pairs = append(pairs, fmt.Sprintf("%s=%v", key, value))
+	}
+
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, ",")
+}
+
+// bindActionInputs binds the GitHub Action inputs to their corresponding Cobra flag if the flag has not been set. An
+// input is named after its flag, using either hyphens or underscores. Inputs that do not match any flag are rejected
+// so that a typo in a workflow does not go unnoticed.
+func bindActionInputs(cmd *cobra.Command) error {
+	inputs := ci.ActionInputs()
+
+	names := make([]string, 0, len(inputs))
+	for name := range inputs {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		flagName := strings.ReplaceAll(name, "_", "-")
+
+		f := cmd.Flags().Lookup(flagName)
+		if f == nil {
+			return fmt.Errorf("%w: %q", ErrUnknownActionInput, name)
+		}
+
+		if f.Changed {
+			continue
+		}
+
+		if err := cmd.Flags().Set(flagName, inputs[name]); err != nil {
+			return fmt.Errorf("binding %q action input: %w", name, err)
+		}
+	}
+
+	return nil
+}
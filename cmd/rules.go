@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/s0ders/go-semver-release/v6/internal/appcontext"
+	"github.com/s0ders/go-semver-release/v6/internal/parser"
+)
+
+func NewRulesCmd(ctx *appcontext.AppContext) *cobra.Command {
+	rulesCmd := &cobra.Command{
+		Use:   "rules",
+		Short: "Inspect the configured release rules",
+	}
+
+	testCmd := &cobra.Command{
+		Use:   "test <COMMIT_MESSAGE>...",
+		Short: "Print how the configured release rules classify commit messages",
+		Long:  "Print the type, scope and breaking change marker of commit messages, along with the release rule they match and the type of release they trigger under the configured rules, without any repository",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			ctx.Rules, err = configureRules(ctx)
+			if err != nil {
+				return fmt.Errorf("loading rules configuration: %w", err)
+			}
+
+			p := parser.New(ctx)
+			out := cmd.OutOrStdout()
+
+			for i, message := range args {
+				if i > 0 {
+					fmt.Fprintln(out)
+				}
+
+				err = printClassification(out, message, p.Classify(message))
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+
+	rulesCmd.AddCommand(testCmd)
+
+	return rulesCmd
+}
+
+// printClassification prints how a commit message is classified, one field per line.
+func printClassification(out io.Writer, message string, classification parser.Classification) error {
+	subject, _, _ := strings.Cut(message, "\n")
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(w, "Message:\t%s\n", subject)
+
+	if !classification.Conventional {
+		fmt.Fprintf(w, "Release:\tnone, not a conventional commit\n")
+		return flushClassification(w)
+	}
+
+	scope := classification.Scope
+	if scope == "" {
+		scope = "none"
+	}
+
+	fmt.Fprintf(w, "Type:\t%s\n", classification.Type)
+	fmt.Fprintf(w, "Scope:\t%s\n", scope)
+	fmt.Fprintf(w, "Breaking:\t%t\n", classification.Breaking)
+
+	switch {
+	case classification.Skipped:
+		fmt.Fprintf(w, "Release:\tnone, skipped by a skip marker\n")
+	case classification.Rule == "":
+		fmt.Fprintf(w, "Release:\tnone, no rule matches\n")
+	default:
+		fmt.Fprintf(w, "Rule:\t%s\n", classification.Rule)
+		fmt.Fprintf(w, "Release:\t%s\n", classification.Release)
+	}
+
+	return flushClassification(w)
+}
+
+func flushClassification(w *tabwriter.Writer) error {
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("writing classification: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestRulesCmd_Test(t *testing.T) {
+	assert := assertion.New(t)
+
+	th := NewTestHelper(t)
+
+	out, err := th.ExecuteCommand("rules", "test", "feat(api)!: drop v1 endpoints", "docs: fix typo", "Update README")
+	checkErr(t, err, "executing command")
+
+	want := "Message:   feat(api)!: drop v1 endpoints\n" +
+		"Type:      feat\n" +
+		"Scope:     api\n" +
+		"Breaking:  true\n" +
+		"Rule:      breaking\n" +
+		"Release:   major\n" +
+		"\n" +
+		"Message:   docs: fix typo\n" +
+		"Type:      docs\n" +
+		"Scope:     none\n" +
+		"Breaking:  false\n" +
+		"Release:   none, no rule matches\n" +
+		"\n" +
+		"Message:  Update README\n" +
+		"Release:  none, not a conventional commit\n"
+
+	assert.Equal(want, string(out))
+}
+
+func TestRulesCmd_Test_CustomRules(t *testing.T) {
+	assert := assertion.New(t)
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		RulesConfiguration:     `{"minor": ["feat"], "patch": ["fix", "feat(experimental)"]}`,
+		RuleOrderConfiguration: "scope,breaking,type",
+	})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("rules", "test", "feat(experimental)!: drop the preview endpoint")
+	checkErr(t, err, "executing command")
+
+	assert.Contains(string(out), "Rule:      feat(experimental)\nRelease:   patch\n", "the configured rules and order should be used")
+
+	_, err = th.ExecuteCommand("rules", "test")
+	assert.Error(err, "a commit message should be required")
+}
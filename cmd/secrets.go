@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"fmt"
+
+	"filippo.io/age"
+	"github.com/spf13/viper"
+
+	"github.com/s0ders/go-semver-release/v6/internal/secret"
+)
+
+// decryptConfig decrypts the age encrypted values of the configuration file, so that secrets such as the access token
+// can be stored in the repository along with the rest of the configuration. The decryption keys are only read from
+// the environment if the configuration contains an encrypted value.
+func decryptConfig(v *viper.Viper) error {
+	d := &configDecrypter{}
+
+	decrypted := make(map[string]any)
+
+	for key, value := range v.AllSettings() {
+		plain, changed, err := d.decrypt(key, value)
+		if err != nil {
+			return err
+		}
+
+		if changed {
+			decrypted[key] = plain
+		}
+	}
+
+	if len(decrypted) == 0 {
+		return nil
+	}
+
+	return v.MergeConfigMap(decrypted)
+}
+
+type configDecrypter struct {
+	identities []age.Identity
+}
+
+// decrypt returns a copy of a configuration value in which encrypted strings are replaced by their plaintext, along
+// with whether any string was decrypted.
+func (d *configDecrypter) decrypt(key string, value any) (any, bool, error) {
+	switch typed := value.(type) {
+	case string:
+		if !secret.IsEncrypted(typed) {
+			return typed, false, nil
+		}
+
+		if d.identities == nil {
+			identities, err := secret.IdentitiesFromEnv()
+			if err != nil {
+				return nil, false, fmt.Errorf("decrypting %q: %w", key, err)
+			}
+			d.identities = identities
+		}
+
+		plain, err := secret.Decrypt(typed, d.identities...)
+		if err != nil {
+			return nil, false, fmt.Errorf("decrypting %q: %w", key, err)
+		}
+
+		return plain, true, nil
+	case map[string]any:
+		result := make(map[string]any, len(typed))
+		changed := false
+
+		for k, item := range typed {
+			plain, itemChanged, err := d.decrypt(key+"."+k, item)
+			if err != nil {
+				return nil, false, err
+			}
+
+			result[k] = plain
+			changed = changed || itemChanged
+		}
+
+		return result, changed, nil
+	case []any:
+		result := make([]any, len(typed))
+		changed := false
+
+		for i, item := range typed {
+			plain, itemChanged, err := d.decrypt(fmt.Sprintf("%s[%d]", key, i), item)
+			if err != nil {
+				return nil, false, err
+			}
+
+			result[i] = plain
+			changed = changed || itemChanged
+		}
+
+		return result, changed, nil
+	default:
+		return value, false, nil
+	}
+}
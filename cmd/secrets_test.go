@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/secret"
+)
+
+func TestSecrets_DecryptConfig(t *testing.T) {
+	assert := assertion.New(t)
+
+	identity, err := age.GenerateX25519Identity()
+	checkErr(t, err, "generating identity")
+
+	t.Setenv(secret.KeyEnv, identity.String())
+	t.Setenv("GO_SEMVER_RELEASE_ACCESS_TOKEN", "")
+
+	cfgPath := filepath.Join(t.TempDir(), ".semver.yaml")
+	writeFile(t, cfgPath, `
+access-token: |
+`+indent(encryptSecret(t, "s3cr3t", identity.Recipient()), "  ")+`
+git-name: Robot
+branches:
+  - name: master
+`)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{"config": cfgPath, DryRunConfiguration: "true"})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.Equal("s3cr3t", th.Ctx.AccessTokenFlag)
+	assert.Equal("Robot", th.Ctx.GitNameFlag)
+}
+
+func TestSecrets_DecryptConfig_NoIdentity(t *testing.T) {
+	assert := assertion.New(t)
+
+	identity, err := age.GenerateX25519Identity()
+	checkErr(t, err, "generating identity")
+
+	t.Setenv(secret.KeyEnv, "")
+	t.Setenv(secret.KeyFileEnv, "")
+	t.Setenv("SOPS_AGE_KEY", "")
+	t.Setenv("SOPS_AGE_KEY_FILE", "")
+	t.Setenv("GO_SEMVER_RELEASE_ACCESS_TOKEN", "")
+
+	cfgPath := filepath.Join(t.TempDir(), ".semver.yaml")
+	writeFile(t, cfgPath, "access-token: |\n"+indent(encryptSecret(t, "s3cr3t", identity.Recipient()), "  "))
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{"config": cfgPath, DryRunConfiguration: "true"})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, secret.ErrNoIdentity)
+}
+
+func encryptSecret(t *testing.T, plaintext string, recipient age.Recipient) string {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	armored := armor.NewWriter(buf)
+
+	w, err := age.Encrypt(armored, recipient)
+	checkErr(t, err, "creating encrypter")
+
+	_, err = io.WriteString(w, plaintext)
+	checkErr(t, err, "writing plaintext")
+	checkErr(t, w.Close(), "closing encrypter")
+	checkErr(t, armored.Close(), "closing armor")
+
+	return buf.String()
+}
+
+func indent(str, prefix string) string {
+	lines := strings.Split(strings.TrimRight(str, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = prefix + line
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
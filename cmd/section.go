@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// configSectionSeparator separates the path of a shared configuration file from the key of the section holding the
+// configuration, e.g. ".ci/config.yaml#semver-release".
+const configSectionSeparator = "#"
+
+var ErrConfigSectionNotFound = errors.New("configuration section not found")
+
+// splitConfigPath splits a configuration path into the path of the file and the key of the section holding the
+// configuration, which is empty if the whole file is the configuration.
+func splitConfigPath(configPath string) (string, string) {
+	i := strings.LastIndex(configPath, configSectionSeparator)
+	if i < 0 {
+		return configPath, ""
+	}
+
+	return configPath[:i], configPath[i+1:]
+}
+
+// readConfigSection reads the configuration from the section with the given key of a shared configuration file, such
+// as a CI configuration file gathering the settings of several tools. Nested sections are designated by dot-separated
+// keys (e.g., "tools.semver-release").
+func readConfigSection(v *viper.Viper, configPath, key string) error {
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		return fmt.Errorf("reading configuration file: %w", err)
+	}
+
+	configType := strings.TrimPrefix(filepath.Ext(configPath), ".")
+	if !slices.Contains(viper.SupportedExts, configType) {
+		configType = configFileFormat
+	}
+
+	file := viper.New()
+	file.SetConfigType(configType)
+
+	if err = file.ReadConfig(bytes.NewReader(content)); err != nil {
+		return fmt.Errorf("parsing %q: %w", configPath, err)
+	}
+
+	section, ok := file.Get(key).(map[string]any)
+	if !ok {
+		return fmt.Errorf("%w: %q in %q", ErrConfigSectionNotFound, key, configPath)
+	}
+
+	// The file is still set as the configuration file so that relative paths, such as an extended configuration, are
+	// resolved from its directory.
+	v.SetConfigFile(configPath)
+
+	return v.MergeConfigMap(section)
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestSection_SplitConfigPath(t *testing.T) {
+	assert := assertion.New(t)
+
+	path, key := splitConfigPath(".ci/config.yaml#semver-release")
+	assert.Equal(".ci/config.yaml", path)
+	assert.Equal("semver-release", key)
+
+	path, key = splitConfigPath(".semver.yaml")
+	assert.Equal(".semver.yaml", path)
+	assert.Empty(key)
+}
+
+func TestSection_ReadSection(t *testing.T) {
+	assert := assertion.New(t)
+
+	cfgDir := t.TempDir()
+
+	writeFile(t, filepath.Join(cfgDir, "base.yaml"), "git-email: platform@acme.com\n")
+
+	cfgPath := filepath.Join(cfgDir, "config.yaml")
+	writeFile(t, cfgPath, `
+linter:
+  git-name: Linter Robot
+semver-release:
+  extends: ./base.yaml
+  git-name: Release Robot
+  branches:
+    - name: master
+tools:
+  semver-release:
+    git-name: Nested Robot
+    branches:
+      - name: master
+`)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{"config": cfgPath + "#semver-release", DryRunConfiguration: "true"})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.Equal("Release Robot", th.Ctx.GitNameFlag)
+	assert.Equal("platform@acme.com", th.Ctx.GitEmailFlag, "extended configuration should be resolved from the file directory")
+	assert.Equal("master", th.Ctx.Branches[0].Name)
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{"config": cfgPath + "#tools.semver-release", DryRunConfiguration: "true"})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.Equal("Nested Robot", th.Ctx.GitNameFlag, "nested sections should be supported")
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{"config": cfgPath + "#go-semver-release"})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, ErrConfigSectionNotFound)
+}
@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"runtime/debug"
 
 	"github.com/spf13/cobra"
 )
@@ -10,20 +12,100 @@ var (
 	cmdVersion      string
 	buildNumber     string
 	buildCommitHash string
+	buildCommitDate string
+	buildDate       string
 )
 
+// buildInfo describes the build of the CLI. Values set at link time take precedence over the ones recorded by the Go
+// toolchain, which are used as a fallback, e.g. for binaries installed with "go install". The build date is only known
+// when set at link time, since the Go toolchain records none: reproducible builds leave it unset, a build date making
+// two builds of the same commit differ, and rely on the date of the commit built instead.
+type buildInfo struct {
+	Version   string       `json:"version"`
+	Build     string       `json:"build"`
+	Commit    string       `json:"commit"`
+	Date      string       `json:"commit-date"`
+	BuildDate string       `json:"build-date,omitempty"`
+	GoVersion string       `json:"go-version"`
+	Modules   []moduleInfo `json:"modules"`
+}
+
+type moduleInfo struct {
+	Path    string `json:"path"`
+	Version string `json:"version"`
+}
+
 func NewVersionCmd() *cobra.Command {
+	var jsonOutput bool
+
 	versionCmd := &cobra.Command{
 		Use:   "version",
 		Short: "Display CLI current version",
 		Long:  "Display CLI current version, the associated build number and commit hash",
 		Args:  cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
-			fmt.Fprintf(cmd.OutOrStdout(), "Version: %s\nBuild: %s\nCommit: %s\n", cmdVersion, buildNumber, buildCommitHash)
+			info := readBuildInfo()
+
+			if jsonOutput {
+				encoder := json.NewEncoder(cmd.OutOrStdout())
+				encoder.SetIndent("", "  ")
+
+				if err = encoder.Encode(info); err != nil {
+					return fmt.Errorf("encoding build info: %w", err)
+				}
+
+				return nil
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Version: %s\nBuild: %s\nCommit: %s\n", info.Version, info.Build, info.Commit)
 
 			return nil
 		},
 	}
 
+	versionCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the build information, Go version and module versions included, as JSON")
+
 	return versionCmd
 }
+
+func readBuildInfo() buildInfo {
+	info := buildInfo{
+		Version:   cmdVersion,
+		Build:     buildNumber,
+		Commit:    buildCommitHash,
+		Date:      buildCommitDate,
+		BuildDate: buildDate,
+		Modules:   []moduleInfo{},
+	}
+
+	goInfo, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+
+	info.GoVersion = goInfo.GoVersion
+
+	if info.Version == "" && goInfo.Main.Version != "(devel)" {
+		info.Version = goInfo.Main.Version
+	}
+
+	for _, setting := range goInfo.Settings {
+		switch {
+		case setting.Key == "vcs.revision" && info.Commit == "":
+			info.Commit = setting.Value
+		case setting.Key == "vcs.time" && info.Date == "":
+			info.Date = setting.Value
+		}
+	}
+
+	for _, dep := range goInfo.Deps {
+		module := dep
+		if dep.Replace != nil {
+			module = dep.Replace
+		}
+
+		info.Modules = append(info.Modules, moduleInfo{Path: dep.Path, Version: module.Version})
+	}
+
+	return info
+}
@@ -2,6 +2,9 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
+	"runtime"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -20,3 +23,68 @@ func TestCmd_Version(t *testing.T) {
 	err := rootCmd.Execute()
 	assert.NoError(err, "local command executed with error")
 }
+
+func TestCmd_VersionJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	previousVersion, previousDate := cmdVersion, buildCommitDate
+	cmdVersion, buildCommitDate = "v6.1.0", "2024-03-01T00:00:00Z"
+
+	t.Cleanup(func() {
+		cmdVersion, buildCommitDate = previousVersion, previousDate
+	})
+
+	actual := new(bytes.Buffer)
+	ctx := NewAppContext()
+
+	rootCmd := NewRootCommand(ctx)
+	rootCmd.SetOut(actual)
+	rootCmd.SetErr(actual)
+	rootCmd.SetArgs([]string{"version", "--json"})
+
+	err := rootCmd.Execute()
+	checkErr(t, err, "executing command")
+
+	var info buildInfo
+
+	err = json.Unmarshal(actual.Bytes(), &info)
+	checkErr(t, err, "unmarshalling build info")
+
+	assert.Equal("v6.1.0", info.Version, "link time values should take precedence")
+	assert.Equal("2024-03-01T00:00:00Z", info.Date)
+	assert.Empty(info.BuildDate)
+	assert.NotContains(actual.String(), `"build-date"`, "the build date should be omitted when not set at link time")
+	assert.Equal(runtime.Version(), info.GoVersion)
+	assert.True(slices.ContainsFunc(info.Modules, func(module moduleInfo) bool {
+		return module.Path == "github.com/spf13/cobra" && module.Version != ""
+	}), "module versions should be listed")
+}
+
+func TestCmd_VersionJSON_BuildDate(t *testing.T) {
+	assert := assert.New(t)
+
+	previousDate := buildDate
+	buildDate = "2024-03-02T00:00:00Z"
+
+	t.Cleanup(func() {
+		buildDate = previousDate
+	})
+
+	actual := new(bytes.Buffer)
+	ctx := NewAppContext()
+
+	rootCmd := NewRootCommand(ctx)
+	rootCmd.SetOut(actual)
+	rootCmd.SetErr(actual)
+	rootCmd.SetArgs([]string{"version", "--json"})
+
+	err := rootCmd.Execute()
+	checkErr(t, err, "executing command")
+
+	var info buildInfo
+
+	err = json.Unmarshal(actual.Bytes(), &info)
+	checkErr(t, err, "unmarshalling build info")
+
+	assert.Equal("2024-03-02T00:00:00Z", info.BuildDate, "the build date set at link time should be reported")
+}
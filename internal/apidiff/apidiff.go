@@ -0,0 +1,129 @@
+// Package apidiff provides analyzers detecting changes to the public API of a project between two revisions.
+//
+// Analyzers are language specific and registered by name, so that new languages can be supported without changing the
+// code relying on them.
+package apidiff
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+const (
+	ModeWarn = "warn"
+	ModeFail = "fail"
+)
+
+var (
+	ErrUnknownAnalyzer = errors.New("unknown API analyzer")
+	ErrInvalidMode     = errors.New("invalid API diff mode")
+	ErrIncompatibleAPI = errors.New("incompatible API changes without a breaking change commit")
+)
+
+var (
+	registry   = map[string]Analyzer{}
+	registryMu sync.RWMutex
+)
+
+func init() {
+	Register(GoAnalyzer{})
+}
+
+// API represents the public API of a project as a set of symbols associated with a textual signature. Two symbols with
+// the same key but a different signature are considered incompatible.
+type API map[string]string
+
+// Analyzer extracts the public API of the files located under a given path of a Git tree. Symbols are identified
+// relatively to that path.
+type Analyzer interface {
+	Name() string
+	API(tree *object.Tree, path string) (API, error)
+}
+
+// Register makes an analyzer available by its name, replacing any analyzer previously registered under that name.
+func Register(analyzer Analyzer) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	registry[analyzer.Name()] = analyzer
+}
+
+// Get returns the analyzer registered under the given name.
+func Get(name string) (Analyzer, error) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	analyzer, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownAnalyzer, name)
+	}
+
+	return analyzer, nil
+}
+
+// ValidateMode checks that the given API diff mode is supported, an empty mode meaning the API diff is disabled.
+func ValidateMode(mode string) error {
+	switch mode {
+	case "", ModeWarn, ModeFail:
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidMode, mode)
+	}
+}
+
+// Revision is the state of a project at a given point of its history.
+type Revision struct {
+	Tree *object.Tree
+	Path string
+}
+
+type Report struct {
+	Incompatible []string
+	Compatible   []string
+}
+
+// HasIncompatibleChanges returns whether the API changes require a major release.
+func (r Report) HasIncompatibleChanges() bool {
+	return len(r.Incompatible) > 0
+}
+
+// Diff compares the public API of two revisions of a project.
+func Diff(analyzer Analyzer, previous, current Revision) (Report, error) {
+	var report Report
+
+	previousAPI, err := analyzer.API(previous.Tree, previous.Path)
+	if err != nil {
+		return report, fmt.Errorf("extracting previous API: %w", err)
+	}
+
+	currentAPI, err := analyzer.API(current.Tree, current.Path)
+	if err != nil {
+		return report, fmt.Errorf("extracting current API: %w", err)
+	}
+
+	for symbol, signature := range previousAPI {
+		currentSignature, ok := currentAPI[symbol]
+
+		switch {
+		case !ok:
+			report.Incompatible = append(report.Incompatible, fmt.Sprintf("%s: removed", symbol))
+		case currentSignature != signature:
+			report.Incompatible = append(report.Incompatible, fmt.Sprintf("%s: changed from %q to %q", symbol, signature, currentSignature))
+		}
+	}
+
+	for symbol := range currentAPI {
+		if _, ok := previousAPI[symbol]; !ok {
+			report.Compatible = append(report.Compatible, fmt.Sprintf("%s: added", symbol))
+		}
+	}
+
+	sort.Strings(report.Incompatible)
+	sort.Strings(report.Compatible)
+
+	return report, nil
+}
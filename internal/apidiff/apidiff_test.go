@@ -0,0 +1,187 @@
+package apidiff
+
+import (
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/pkg/gittest"
+)
+
+const previousSource = `package foo
+
+type Client struct {
+	Name    string
+	timeout int
+}
+
+func New(name string) *Client { return &Client{Name: name} }
+
+func (c *Client) Do(req string) error { return nil }
+
+func helper() {}
+`
+
+func TestGoAnalyzer_Diff(t *testing.T) {
+	assert := assertion.New(t)
+
+	type test struct {
+		name             string
+		source           string
+		wantIncompatible int
+		wantCompatible   int
+	}
+
+	tests := []test{
+		{name: "unchanged", source: previousSource},
+		{name: "unexported change", source: previousSource + "\nfunc otherHelper() {}\n"},
+		{name: "added function", source: previousSource + "\nfunc Close() error { return nil }\n", wantCompatible: 1},
+		{name: "added field", source: `package foo
+
+type Client struct {
+	Name    string
+	Retries int
+}
+
+func New(name string) *Client { return &Client{Name: name} }
+
+func (c *Client) Do(req string) error { return nil }
+`, wantCompatible: 1},
+		{name: "changed signature", source: `package foo
+
+type Client struct {
+	Name string
+}
+
+func New(name string, retries int) *Client { return &Client{Name: name} }
+
+func (c *Client) Do(req string) error { return nil }
+`, wantIncompatible: 1},
+		{name: "removed method", source: `package foo
+
+type Client struct {
+	Name string
+}
+
+func New(name string) *Client { return &Client{Name: name} }
+`, wantIncompatible: 1},
+	}
+
+	for _, tc := range tests {
+		previous, current := trees(t, previousSource, tc.source)
+
+		report, err := Diff(GoAnalyzer{}, Revision{Tree: previous, Path: "."}, Revision{Tree: current, Path: "."})
+		checkErr(t, "diffing API", err)
+
+		assert.Len(report.Incompatible, tc.wantIncompatible, tc.name)
+		assert.Len(report.Compatible, tc.wantCompatible, tc.name)
+	}
+}
+
+func TestGoAnalyzer_IgnoredPackages(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	for _, file := range []string{"internal/foo/foo.go", "foo/foo_test.go", "vendor/foo/foo.go", "cmd/main.go"} {
+		_, err = testRepository.AddCommitWithContent("feat", file, "package main\n\nfunc Exported() {}\n")
+		checkErr(t, "adding commit", err)
+	}
+
+	tree := headTree(t, testRepository)
+
+	api, err := GoAnalyzer{}.API(tree, ".")
+	checkErr(t, "extracting API", err)
+
+	assert.Empty(api, "no importable package should have been found")
+}
+
+func TestGoAnalyzer_RelativeSymbols(t *testing.T) {
+	assert := assertion.New(t)
+
+	previous, current := trees(t, previousSource, previousSource)
+
+	report, err := Diff(GoAnalyzer{}, Revision{Tree: previous, Path: "foo"}, Revision{Tree: current, Path: "./foo/"})
+	checkErr(t, "diffing API", err)
+
+	assert.False(report.HasIncompatibleChanges(), "symbols should be identified relatively to the project path")
+
+	api, err := GoAnalyzer{}.API(current, "foo")
+	checkErr(t, "extracting API", err)
+
+	assert.Contains(api, ".New")
+}
+
+func TestApidiff_Registry(t *testing.T) {
+	assert := assertion.New(t)
+
+	analyzer, err := Get("go")
+	checkErr(t, "getting analyzer", err)
+
+	assert.Equal("go", analyzer.Name())
+
+	_, err = Get("cobol")
+	assert.ErrorIs(err, ErrUnknownAnalyzer)
+
+	assert.NoError(ValidateMode(""))
+	assert.NoError(ValidateMode(ModeFail))
+	assert.ErrorIs(ValidateMode("maybe"), ErrInvalidMode)
+}
+
+func trees(t *testing.T, previous, current string) (*object.Tree, *object.Tree) {
+	t.Helper()
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	_, err = testRepository.AddCommitWithContent("feat", "foo/foo.go", previous)
+	checkErr(t, "adding commit", err)
+
+	previousTree := headTree(t, testRepository)
+
+	var hash plumbing.Hash
+
+	hash, err = testRepository.AddCommitWithContent("feat", "foo/foo.go", current+"\n")
+	checkErr(t, "adding commit", err)
+
+	commit, err := testRepository.CommitObject(hash)
+	checkErr(t, "fetching commit", err)
+
+	currentTree, err := commit.Tree()
+	checkErr(t, "fetching tree", err)
+
+	return previousTree, currentTree
+}
+
+func headTree(t *testing.T, testRepository *gittest.TestRepository) *object.Tree {
+	t.Helper()
+
+	head, err := testRepository.Head()
+	checkErr(t, "fetching head", err)
+
+	commit, err := testRepository.CommitObject(head.Hash())
+	checkErr(t, "fetching commit", err)
+
+	tree, err := commit.Tree()
+	checkErr(t, "fetching tree", err)
+
+	return tree
+}
+
+func checkErr(t *testing.T, msg string, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %s", msg, err)
+	}
+}
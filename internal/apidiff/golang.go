@@ -0,0 +1,203 @@
+package apidiff
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// GoAnalyzer extracts the exported declarations of the importable Go packages of a tree. Packages that cannot be
+// imported by other modules (i.e., "main", "internal", "testdata" and "vendor" packages) and test files are ignored.
+type GoAnalyzer struct{}
+
+func (GoAnalyzer) Name() string {
+	return "go"
+}
+
+func (GoAnalyzer) API(tree *object.Tree, root string) (API, error) {
+	api := make(API)
+
+	if tree == nil {
+		return api, nil
+	}
+
+	root = path.Clean(strings.TrimPrefix(root, "./"))
+
+	err := tree.Files().ForEach(func(f *object.File) error {
+		if !isImportableGoFile(f.Name, root) {
+			return nil
+		}
+
+		content, err := f.Contents()
+		if err != nil {
+			return err
+		}
+
+		fset := token.NewFileSet()
+
+		// Files that cannot be parsed are not part of a buildable API and are skipped.
+		file, err := parser.ParseFile(fset, f.Name, content, parser.SkipObjectResolution)
+		if err != nil || file.Name.Name == "main" {
+			return nil
+		}
+
+		pkg := path.Dir(f.Name)
+		if root != "." {
+			pkg = strings.TrimPrefix(strings.TrimPrefix(pkg, root), "/")
+		}
+
+		collectGoDeclarations(api, fset, pkg, file)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return api, nil
+}
+
+func isImportableGoFile(name, root string) bool {
+	if !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+		return false
+	}
+
+	if root != "." && name != root && !strings.HasPrefix(name, root+"/") {
+		return false
+	}
+
+	segments := strings.Split(path.Dir(name), "/")
+
+	return !slices.ContainsFunc(segments, func(segment string) bool {
+		return segment == "internal" || segment == "testdata" || segment == "vendor"
+	})
+}
+
+func collectGoDeclarations(api API, fset *token.FileSet, pkg string, file *ast.File) {
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			collectGoFunc(api, fset, pkg, d)
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					collectGoType(api, fset, pkg, s)
+				case *ast.ValueSpec:
+					collectGoValue(api, fset, pkg, d.Tok, s)
+				}
+			}
+		}
+	}
+}
+
+func collectGoFunc(api API, fset *token.FileSet, pkg string, decl *ast.FuncDecl) {
+	if !decl.Name.IsExported() {
+		return
+	}
+
+	if decl.Recv == nil {
+		api[pkg+"."+decl.Name.Name] = "func" + strings.TrimPrefix(format(fset, decl.Type), "func")
+		return
+	}
+
+	receiver := decl.Recv.List[0].Type
+	pointer := ""
+
+	if star, ok := receiver.(*ast.StarExpr); ok {
+		receiver = star.X
+		pointer = "*"
+	}
+
+	switch r := receiver.(type) {
+	case *ast.IndexExpr:
+		receiver = r.X
+	case *ast.IndexListExpr:
+		receiver = r.X
+	}
+
+	ident, ok := receiver.(*ast.Ident)
+	if !ok || !ident.IsExported() {
+		return
+	}
+
+	api[pkg+"."+ident.Name+"."+decl.Name.Name] = "func(" + pointer + ident.Name + ")" + strings.TrimPrefix(format(fset, decl.Type), "func")
+}
+
+func collectGoType(api API, fset *token.FileSet, pkg string, spec *ast.TypeSpec) {
+	if !spec.Name.IsExported() {
+		return
+	}
+
+	key := pkg + "." + spec.Name.Name
+
+	var typeParams string
+	if spec.TypeParams != nil {
+		typeParams = format(fset, spec.TypeParams)
+	}
+
+	if spec.Assign.IsValid() {
+		api[key] = "type" + typeParams + " = " + format(fset, spec.Type)
+		return
+	}
+
+	structType, ok := spec.Type.(*ast.StructType)
+	if !ok {
+		api[key] = "type" + typeParams + " " + format(fset, spec.Type)
+		return
+	}
+
+	// Adding a field to a struct is compatible, each exported field is therefore a symbol on its own.
+	api[key] = "type" + typeParams + " struct"
+
+	for _, field := range structType.Fields.List {
+		fieldType := format(fset, field.Type)
+
+		if len(field.Names) == 0 {
+			name := strings.TrimPrefix(fieldType, "*")
+			name = name[strings.LastIndex(name, ".")+1:]
+
+			if ast.IsExported(name) {
+				api[key+"."+name] = "embedded " + fieldType
+			}
+
+			continue
+		}
+
+		for _, name := range field.Names {
+			if name.IsExported() {
+				api[key+"."+name.Name] = fieldType
+			}
+		}
+	}
+}
+
+func collectGoValue(api API, fset *token.FileSet, pkg string, tok token.Token, spec *ast.ValueSpec) {
+	signature := tok.String()
+	if spec.Type != nil {
+		signature += " " + format(fset, spec.Type)
+	}
+
+	for _, name := range spec.Names {
+		if name.IsExported() {
+			api[pkg+"."+name.Name] = signature
+		}
+	}
+}
+
+func format(fset *token.FileSet, node ast.Node) string {
+	buf := new(bytes.Buffer)
+
+	if err := printer.Fprint(buf, fset, node); err != nil {
+		return ""
+	}
+
+	return buf.String()
+}
@@ -5,31 +5,124 @@
 package appcontext
 
 import (
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
 	"github.com/rs/zerolog"
 	"github.com/spf13/viper"
 
+	"github.com/s0ders/go-semver-release/v6/internal/artifact"
 	"github.com/s0ders/go-semver-release/v6/internal/branch"
+	"github.com/s0ders/go-semver-release/v6/internal/dryrun"
+	"github.com/s0ders/go-semver-release/v6/internal/event"
+	"github.com/s0ders/go-semver-release/v6/internal/forge"
 	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
+	"github.com/s0ders/go-semver-release/v6/internal/policy"
+	"github.com/s0ders/go-semver-release/v6/internal/render"
 	"github.com/s0ders/go-semver-release/v6/internal/rule"
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
+	"github.com/s0ders/go-semver-release/v6/internal/target"
+	"github.com/s0ders/go-semver-release/v6/internal/workspace"
 )
 
 type AppContext struct {
-	Viper              *viper.Viper
-	Branches           []branch.Branch
-	Projects           []monorepo.Project
-	Rules              rule.Rules
-	BranchesFlag       branch.Flag
-	MonorepositoryFlag monorepo.Flag
-	RulesFlag          rule.Flag
-	Logger             zerolog.Logger
-	CfgFileFlag        string
-	GitNameFlag        string
-	GitEmailFlag       string
-	TagPrefixFlag      string
-	AccessTokenFlag    string
-	RemoteNameFlag     string
-	GPGKeyPathFlag     string
-	BuildMetadataFlag  string
-	DryRunFlag         bool
-	VerboseFlag        bool
+	Viper                      *viper.Viper
+	Branches                   []branch.Branch
+	Projects                   []monorepo.Project
+	Rules                      rule.Rules
+	Policies                   []policy.Policy
+	RenderFiles                []render.File
+	TagAliases                 map[string]*semver.Version
+	TrustedKeys                openpgp.EntityList
+	Forge                      forge.Client
+	Links                      forge.Links
+	ArtifactStore              artifact.Store
+	ArtifactsBucket            artifact.Bucket
+	EventPublishers            []event.Publisher
+	Workspace                  *workspace.Manager
+	BranchesFlag               branch.Flag
+	MonorepositoryFlag         monorepo.Flag
+	TargetsFlag                target.Flag
+	PolicyFlag                 policy.Flag
+	RenderFlag                 render.Flag
+	EventsFlag                 event.Flag
+	DryRunFlag                 dryrun.Flag
+	RulesFlag                  rule.Flag
+	TagAliasesFlag             map[string]string
+	RequireChecksFlag          []string
+	ApproveFlag                []string
+	SkipMarkersFlag            []string
+	OnlyChangedFlag            bool
+	OnlyProjectsFlag           []string
+	OutputsFlag                []string
+	RuleOrderFlag              []string
+	StrictAuthorshipFlag       bool
+	StrictEnvFlag              bool
+	TrustedKeysFlag            []string
+	Logger                     zerolog.Logger
+	CfgFileFlag                string
+	GitNameFlag                string
+	HistoryBoundaryFlag        string
+	LatestRefFlag              string
+	GitEmailFlag               string
+	TagPrefixFlag              string
+	TagNamespaceFlag           string
+	EpochFlag                  int
+	AccessTokenFlag            string
+	ArtifactsBucketFlag        string
+	AsOfFlag                   string
+	AtFlag                     string
+	APIDiffFlag                string
+	APIDiffAnalyzerFlag        string
+	AuditLogFlag               string
+	AuthorDomainsFlag          []string
+	BadgesDirFlag              string
+	CacheDirFlag               string
+	ChangelogDirFlag           string
+	ChangelogFileFlag          string
+	ChangelogFormatFlag        string
+	ChannelsDirFlag            string
+	CommitURLTemplateFlag      string
+	TemplatesDirFlag           string
+	PullRequestURLTemplateFlag string
+	ReleaseSummaryFlag         string
+	ReleaseSummaryCommentFlag  int
+	ReleaseSummaryTagFlag      string
+	RemoteNameFlag             string
+	RepositoryFlag             string
+	GPGKeyPathFlag             string
+	GPGPassphraseFlag          string
+	BuildMetadataFlag          string
+	VCSFlag                    string
+	UntrustedTagsFlag          string
+	ForceBumpFlag              string
+	ForgeFlag                  string
+	MaxBumpPerRunFlag          string
+	MaxVersionSkipFlag         int
+	CascadeBumpsFlag           bool
+	ChangedPathsFlag           bool
+	ConfirmMajorFlag           bool
+	ContinueOnErrorFlag        bool
+	ContributorHandlesFlag     bool
+	PullRequestNotesFlag       bool
+	ProvenanceFileFlag         string
+	RootPathFlag               string
+	CheckRunFlag               string
+	DeduplicateCommitsFlag     bool
+	DeploymentsFlag            bool
+	DetectCherryPicksFlag      bool
+	FloatingTagsFlag           bool
+	DiscoverProjectsFlag       bool
+	MergeQueueFlag             bool
+	ProgressFlag               bool
+	PushCheckFlag              bool
+	GitHubActionFlag           bool
+	KeepWorkspaceFlag          bool
+	LockFlag                   bool
+	RuleStatsFlag              bool
+	SubmoduleAnalysisFlag      bool
+	SuppressNoReleaseFlag      bool
+	VerboseFlag                bool
+	LockTTLFlag                time.Duration
+	WorkspaceTTLFlag           time.Duration
 }
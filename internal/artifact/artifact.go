@@ -0,0 +1,188 @@
+// Package artifact uploads the artifacts of releases, such as their changelog, to an object storage bucket giving
+// other teams a single location for release metadata.
+package artifact
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/s0ders/go-semver-release/v6/internal/cloud"
+)
+
+const (
+	SchemeS3  = "s3"
+	SchemeGCS = "gs"
+
+	// ManifestName is the name of the manifest listing the artifacts of a release, uploaded last so that its presence
+	// tells that every artifact was uploaded.
+	ManifestName = "manifest.json"
+
+	// ManifestSchemaVersion is the version of the schema of the manifest, incremented on breaking changes.
+	ManifestSchemaVersion = 1
+)
+
+var (
+	ErrInvalidBucket     = errors.New("invalid bucket URL")
+	ErrUnsupportedScheme = errors.New("unsupported bucket scheme")
+)
+
+// Bucket is an object storage bucket and the prefix of the keys of the artifacts uploaded to it, given by a URL such
+// as "s3://bucket/releases" or "gs://bucket/releases".
+type Bucket struct {
+	Scheme string
+	Name   string
+	Prefix string
+}
+
+// ParseBucket parses the URL of a bucket.
+func ParseBucket(raw string) (Bucket, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return Bucket{}, fmt.Errorf("%w %q: %w", ErrInvalidBucket, raw, err)
+	}
+
+	if u.Scheme != SchemeS3 && u.Scheme != SchemeGCS {
+		return Bucket{}, fmt.Errorf("%w %q, must be %q or %q", ErrUnsupportedScheme, u.Scheme, SchemeS3, SchemeGCS)
+	}
+
+	if u.Host == "" {
+		return Bucket{}, fmt.Errorf("%w %q: no bucket name", ErrInvalidBucket, raw)
+	}
+
+	return Bucket{Scheme: u.Scheme, Name: u.Host, Prefix: strings.Trim(u.Path, "/")}, nil
+}
+
+// Key returns the key of an artifact of the release with the given tag, e.g. "releases/v1.2.0/manifest.json".
+func (b Bucket) Key(tag, name string) string {
+	return path.Join(b.Prefix, tag, name)
+}
+
+func (b Bucket) String() string {
+	return b.Scheme + "://" + path.Join(b.Name, b.Prefix)
+}
+
+// Store puts objects in a bucket.
+type Store interface {
+	Put(ctx context.Context, key string, content []byte, contentType string) error
+}
+
+// New returns a store of the given bucket, authenticated with the credentials of its provider: the AWS credentials
+// given by the environment for S3, or a Google Cloud access token for GCS. The AWS_ENDPOINT_URL_S3 (or
+// AWS_ENDPOINT_URL) and STORAGE_EMULATOR_HOST environment variables override the endpoints of S3 and GCS, e.g. for
+// MinIO or an emulator.
+func New(ctx context.Context, bucket Bucket) (Store, error) {
+	switch bucket.Scheme {
+	case SchemeS3:
+		credentials, ok := cloud.AWSCredentialsFromEnv()
+		if !ok {
+			return nil, fmt.Errorf("%w: AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must be set", cloud.ErrNoCredentials)
+		}
+
+		return NewS3(bucket.Name, cloud.AWSRegion(), cloud.AWSEndpoint("s3"), credentials), nil
+	case SchemeGCS:
+		if host := os.Getenv("STORAGE_EMULATOR_HOST"); host != "" {
+			if !strings.Contains(host, "://") {
+				host = "http://" + host
+			}
+
+			return NewGCS(bucket.Name, host, ""), nil
+		}
+
+		token, err := cloud.GoogleAccessToken(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("fetching Google Cloud access token: %w", err)
+		}
+
+		return NewGCS(bucket.Name, "", token), nil
+	default:
+		return nil, fmt.Errorf("%w %q", ErrUnsupportedScheme, bucket.Scheme)
+	}
+}
+
+// Artifact is a file produced by a release.
+type Artifact struct {
+	Name        string
+	ContentType string
+	Content     []byte
+}
+
+// Manifest describes a release and lists its artifacts.
+type Manifest struct {
+	SchemaVersion int             `json:"schema-version"`
+	Tag           string          `json:"tag"`
+	Version       string          `json:"version"`
+	Branch        string          `json:"branch"`
+	Project       string          `json:"project,omitempty"`
+	Commit        string          `json:"commit"`
+	Date          time.Time       `json:"date"`
+	Artifacts     []ManifestEntry `json:"artifacts"`
+}
+
+// ManifestEntry is an artifact listed by a manifest, along with its digest so that consumers can check it.
+type ManifestEntry struct {
+	Name        string `json:"name"`
+	Key         string `json:"key"`
+	ContentType string `json:"content-type"`
+	Size        int    `json:"size"`
+	SHA256      string `json:"sha256"`
+}
+
+// Upload puts the artifacts of a release in the bucket under a directory named after its tag, then its manifest
+// listing them. It returns the key of the manifest.
+func Upload(ctx context.Context, store Store, bucket Bucket, manifest Manifest, artifacts []Artifact) (string, error) {
+	manifest.SchemaVersion = ManifestSchemaVersion
+	manifest.Artifacts = make([]ManifestEntry, 0, len(artifacts))
+
+	for _, artifact := range artifacts {
+		key := bucket.Key(manifest.Tag, artifact.Name)
+
+		if err := store.Put(ctx, key, artifact.Content, artifact.ContentType); err != nil {
+			return "", fmt.Errorf("uploading %q: %w", artifact.Name, err)
+		}
+
+		digest := sha256.Sum256(artifact.Content)
+
+		manifest.Artifacts = append(manifest.Artifacts, ManifestEntry{
+			Name:        artifact.Name,
+			Key:         key,
+			ContentType: artifact.ContentType,
+			Size:        len(artifact.Content),
+			SHA256:      hex.EncodeToString(digest[:]),
+		})
+	}
+
+	content, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("encoding manifest: %w", err)
+	}
+
+	key := bucket.Key(manifest.Tag, ManifestName)
+
+	if err = store.Put(ctx, key, content, "application/json"); err != nil {
+		return "", fmt.Errorf("uploading manifest: %w", err)
+	}
+
+	return key, nil
+}
+
+// checkResponse returns an error holding the status and the beginning of the body of an unsuccessful response.
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+
+	return fmt.Errorf("unexpected status %q: %s", resp.Status, strings.TrimSpace(string(body)))
+}
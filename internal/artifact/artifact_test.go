@@ -0,0 +1,158 @@
+package artifact
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/cloud"
+)
+
+type memoryStore struct {
+	objects map[string]string
+	keys    []string
+	err     error
+}
+
+func (s *memoryStore) Put(_ context.Context, key string, content []byte, _ string) error {
+	if s.err != nil {
+		return s.err
+	}
+
+	s.objects[key] = string(content)
+	s.keys = append(s.keys, key)
+
+	return nil
+}
+
+func TestArtifact_ParseBucket(t *testing.T) {
+	assert := assertion.New(t)
+
+	type test struct {
+		raw    string
+		bucket Bucket
+		err    error
+	}
+
+	matrix := []test{
+		{raw: "s3://acme-releases/app/", bucket: Bucket{Scheme: SchemeS3, Name: "acme-releases", Prefix: "app"}},
+		{raw: "gs://acme-releases", bucket: Bucket{Scheme: SchemeGCS, Name: "acme-releases"}},
+		{raw: "https://acme-releases", err: ErrUnsupportedScheme},
+		{raw: "acme-releases", err: ErrUnsupportedScheme},
+		{raw: "s3:///app", err: ErrInvalidBucket},
+	}
+
+	for _, tc := range matrix {
+		bucket, err := ParseBucket(tc.raw)
+		if tc.err != nil {
+			assert.ErrorIs(err, tc.err, tc.raw)
+			continue
+		}
+
+		checkErr(t, err, "parsing bucket")
+		assert.Equal(tc.bucket, bucket, tc.raw)
+	}
+}
+
+func TestArtifact_BucketKey(t *testing.T) {
+	assert := assertion.New(t)
+
+	assert.Equal("app/v1.0.0/manifest.json", Bucket{Name: "acme", Prefix: "app"}.Key("v1.0.0", ManifestName))
+	assert.Equal("v1.0.0/manifest.json", Bucket{Name: "acme"}.Key("v1.0.0", ManifestName))
+	assert.Equal("s3://acme/app", Bucket{Scheme: SchemeS3, Name: "acme", Prefix: "app"}.String())
+}
+
+func TestArtifact_Upload(t *testing.T) {
+	assert := assertion.New(t)
+
+	store := &memoryStore{objects: make(map[string]string)}
+	bucket := Bucket{Scheme: SchemeS3, Name: "acme", Prefix: "app"}
+
+	manifest := Manifest{Tag: "v1.0.0", Version: "1.0.0", Branch: "main", Commit: "abc", Date: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)}
+
+	key, err := Upload(context.Background(), store, bucket, manifest, []Artifact{
+		{Name: "release.json", ContentType: "application/json", Content: []byte("{}")},
+		{Name: "changelog.md", ContentType: "text/markdown", Content: []byte("hello")},
+	})
+	checkErr(t, err, "uploading artifacts")
+
+	assert.Equal("app/v1.0.0/manifest.json", key)
+	assert.Equal([]string{"app/v1.0.0/release.json", "app/v1.0.0/changelog.md", key}, store.keys, "the manifest should be uploaded last")
+
+	var uploaded Manifest
+	err = json.Unmarshal([]byte(store.objects[key]), &uploaded)
+	checkErr(t, err, "decoding manifest")
+
+	assert.Equal(ManifestSchemaVersion, uploaded.SchemaVersion)
+	assert.Equal("v1.0.0", uploaded.Tag)
+	assert.Equal(ManifestEntry{
+		Name:        "changelog.md",
+		Key:         "app/v1.0.0/changelog.md",
+		ContentType: "text/markdown",
+		Size:        5,
+		SHA256:      "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+	}, uploaded.Artifacts[1])
+
+	store.err = errors.New("access denied")
+
+	_, err = Upload(context.Background(), store, bucket, manifest, []Artifact{{Name: "release.json"}})
+	assert.ErrorContains(err, `uploading "release.json": access denied`)
+}
+
+func TestArtifact_New(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+
+	_, err := New(context.Background(), Bucket{Scheme: SchemeS3, Name: "acme"})
+	assert.ErrorIs(err, cloud.ErrNoCredentials)
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_ENDPOINT_URL_S3", "")
+	t.Setenv("AWS_ENDPOINT_URL", "http://minio:9000")
+
+	store, err := New(context.Background(), Bucket{Scheme: SchemeS3, Name: "acme"})
+	checkErr(t, err, "creating S3 store")
+
+	assert.Equal("http://minio:9000", store.(*S3).endpoint)
+
+	t.Setenv("STORAGE_EMULATOR_HOST", "localhost:4443")
+
+	store, err = New(context.Background(), Bucket{Scheme: SchemeGCS, Name: "acme"})
+	checkErr(t, err, "creating GCS store")
+
+	assert.Equal("http://localhost:4443", store.(*GCS).endpoint)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	metadataTokenURL := cloud.GoogleMetadataTokenURL
+	t.Cleanup(func() {
+		cloud.GoogleMetadataTokenURL = metadataTokenURL
+	})
+
+	cloud.GoogleMetadataTokenURL = server.URL
+
+	t.Setenv("STORAGE_EMULATOR_HOST", "")
+	t.Setenv(cloud.GoogleAccessTokenEnv, "")
+
+	_, err = New(context.Background(), Bucket{Scheme: SchemeGCS, Name: "acme"})
+	assert.ErrorIs(err, cloud.ErrNoCredentials)
+}
+
+func checkErr(t *testing.T, err error, msg string) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %s", msg, err)
+	}
+}
@@ -0,0 +1,68 @@
+package artifact
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// GCSEndpoint is the endpoint of the Google Cloud Storage JSON API.
+const GCSEndpoint = "https://storage.googleapis.com"
+
+// GCS puts objects in a Google Cloud Storage bucket.
+type GCS struct {
+	httpClient *http.Client
+	bucket     string
+	endpoint   string
+	token      string
+}
+
+// NewGCS returns a store of a Google Cloud Storage bucket, authenticated with the given OAuth access token if any. The
+// endpoint defaults to GCSEndpoint.
+func NewGCS(bucket, endpoint, token string) *GCS {
+	if endpoint == "" {
+		endpoint = GCSEndpoint
+	}
+
+	return &GCS{
+		httpClient: &http.Client{Timeout: time.Minute},
+		bucket:     bucket,
+		endpoint:   strings.TrimSuffix(endpoint, "/"),
+		token:      token,
+	}
+}
+
+// Put uploads an object with a single request.
+func (g *GCS) Put(ctx context.Context, key string, content []byte, contentType string) error {
+	endpoint := fmt.Sprintf("%s/upload/storage/v1/b/%s/o?uploadType=media&name=%s", g.endpoint, url.PathEscape(g.bucket), url.QueryEscape(key))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(content))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", contentType)
+
+	if g.token != "" {
+		req.Header.Set("Authorization", "Bearer "+g.token)
+	}
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("putting object %q: %w", key, err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if err = checkResponse(resp); err != nil {
+		return fmt.Errorf("putting object %q: %w", key, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package artifact
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestGCS_Put(t *testing.T) {
+	assert := assertion.New(t)
+
+	var body string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /upload/storage/v1/b/acme/o", func(w http.ResponseWriter, r *http.Request) {
+		content, _ := io.ReadAll(r.Body)
+		body = string(content)
+
+		assert.Equal("media", r.URL.Query().Get("uploadType"))
+		assert.Equal("releases/v1.0.0/changelog.md", r.URL.Query().Get("name"))
+		assert.Equal("Bearer token", r.Header.Get("Authorization"))
+		assert.Equal("text/markdown", r.Header.Get("Content-Type"))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	err := NewGCS("acme", server.URL, "token").Put(context.Background(), "releases/v1.0.0/changelog.md", []byte("hello"), "text/markdown")
+	checkErr(t, err, "putting object")
+
+	assert.Equal("hello", body)
+
+	err = NewGCS("unknown", server.URL, "token").Put(context.Background(), "key", nil, "application/json")
+	assert.ErrorContains(err, "404")
+}
+
+func TestGCS_DefaultEndpoint(t *testing.T) {
+	assert := assertion.New(t)
+
+	assert.Equal(GCSEndpoint, NewGCS("acme", "", "").endpoint)
+}
@@ -0,0 +1,98 @@
+package artifact
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/s0ders/go-semver-release/v6/internal/cloud"
+)
+
+// S3 puts objects in an Amazon S3 bucket, or a bucket of an S3 compatible storage such as MinIO, authenticating
+// requests with AWS Signature Version 4.
+type S3 struct {
+	httpClient  *http.Client
+	bucket      string
+	region      string
+	endpoint    string
+	credentials cloud.AWSCredentials
+}
+
+// NewS3 returns a store of an S3 bucket of the given region. Objects are addressed in the virtual-hosted style of AWS
+// (e.g., "https://bucket.s3.eu-west-1.amazonaws.com/key") unless an endpoint is given, in which case they are addressed
+// in the path style supported by compatible storages (e.g., "http://minio:9000/bucket/key").
+func NewS3(bucket, region, endpoint string, credentials cloud.AWSCredentials) *S3 {
+	return &S3{
+		httpClient:  &http.Client{Timeout: time.Minute},
+		bucket:      bucket,
+		region:      region,
+		endpoint:    strings.TrimSuffix(endpoint, "/"),
+		credentials: credentials,
+	}
+}
+
+// Put uploads an object.
+func (s *S3) Put(ctx context.Context, key string, content []byte, contentType string) error {
+	endpoint := s.endpoint + "/" + s.bucket
+	if s.endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.s3.%s.amazonaws.com", s.bucket, s.region)
+	}
+
+	u, err := url.Parse(endpoint + "/" + escapePath(key))
+	if err != nil {
+		return fmt.Errorf("parsing object URL: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, u.String(), bytes.NewReader(content))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", contentType)
+
+	digest := sha256.Sum256(content)
+	s.credentials.Sign(req, s.region, "s3", hex.EncodeToString(digest[:]), time.Now())
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("putting object %q: %w", key, err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if err = checkResponse(resp); err != nil {
+		return fmt.Errorf("putting object %q: %w", key, err)
+	}
+
+	return nil
+}
+
+// escapePath escapes each segment of an object key as required by AWS Signature Version 4, i.e. every character but
+// the unreserved ones of RFC 3986.
+func escapePath(key string) string {
+	segments := strings.Split(key, "/")
+
+	for i, segment := range segments {
+		var b strings.Builder
+
+		for _, c := range []byte(segment) {
+			if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-' || c == '_' || c == '.' || c == '~' {
+				b.WriteByte(c)
+			} else {
+				fmt.Fprintf(&b, "%%%02X", c)
+			}
+		}
+
+		segments[i] = b.String()
+	}
+
+	return strings.Join(segments, "/")
+}
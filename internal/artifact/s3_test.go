@@ -0,0 +1,55 @@
+package artifact
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/cloud"
+)
+
+var testCredentials = cloud.AWSCredentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "wJalrXUtnFEMI/K7MDENG/bPxRfiCYEXAMPLEKEY"}
+
+func TestS3_Put(t *testing.T) {
+	assert := assertion.New(t)
+
+	var body string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, _ := io.ReadAll(r.Body)
+		body = string(content)
+
+		assert.Equal(http.MethodPut, r.Method)
+		assert.Equal("/acme/releases/v1.0.0/release%20notes.md", r.URL.EscapedPath(), "objects should be addressed in path style")
+		assert.Equal("text/markdown", r.Header.Get("Content-Type"))
+		assert.Contains(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/")
+	}))
+	defer server.Close()
+
+	err := NewS3("acme", "eu-west-1", server.URL+"/", testCredentials).Put(context.Background(), "releases/v1.0.0/release notes.md", []byte("hello"), "text/markdown")
+	checkErr(t, err, "putting object")
+
+	assert.Equal("hello", body)
+}
+
+func TestS3_PutError(t *testing.T) {
+	assert := assertion.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "<Error><Code>AccessDenied</Code></Error>", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	err := NewS3("acme", "eu-west-1", server.URL, testCredentials).Put(context.Background(), "key", nil, "application/json")
+	assert.ErrorContains(err, "AccessDenied")
+}
+
+func TestS3_EscapePath(t *testing.T) {
+	assert := assertion.New(t)
+
+	assert.Equal("a/b%20c/d~e_f.g-h/%C3%A9%2B", escapePath("a/b c/d~e_f.g-h/é+"))
+}
@@ -0,0 +1,224 @@
+// Package audit provides an append-only and tamper-evident log of the release actions performed by the program.
+//
+// Each record is written as a single JSON line. Records are chained together: every record embeds the hash of the
+// previous one so that removing or altering a line breaks the chain. If a GPG key is available, each record hash is
+// also signed.
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+)
+
+const (
+	ActionTag      = "tag"
+	ActionPush     = "push"
+	ActionRollback = "rollback"
+	ActionRetag    = "retag"
+)
+
+var ErrBrokenChain = errors.New("audit log chain is broken")
+
+type Record struct {
+	Timestamp      time.Time `json:"timestamp"`
+	Action         string    `json:"action"`
+	Repository     string    `json:"repository"`
+	Branch         string    `json:"branch"`
+	Project        string    `json:"project,omitempty"`
+	Version        string    `json:"version"`
+	Tag            string    `json:"tag"`
+	Commit         string    `json:"commit"`
+	PreviousCommit string    `json:"previous-commit,omitempty"`
+	Actor          string    `json:"actor"`
+	PreviousHash   string    `json:"previous-hash"`
+	Hash           string    `json:"hash"`
+	Signature      string    `json:"signature,omitempty"`
+}
+
+type OptionFunc func(l *Logger)
+
+func WithSignKey(key *openpgp.Entity) OptionFunc {
+	return func(l *Logger) {
+		l.signKey = key
+	}
+}
+
+type Logger struct {
+	signKey *openpgp.Entity
+	path    string
+}
+
+func New(path string, options ...OptionFunc) *Logger {
+	logger := &Logger{path: path}
+
+	for _, option := range options {
+		option(logger)
+	}
+
+	return logger
+}
+
+// Append chains the given record to the last record of the log, computes its hash, signs it if a key is configured
+// and appends it to the log file.
+func (l *Logger) Append(record Record) (err error) {
+	previousHash, err := l.lastHash()
+	if err != nil {
+		return fmt.Errorf("reading last audit record: %w", err)
+	}
+
+	if record.Timestamp.IsZero() {
+		record.Timestamp = time.Now().UTC()
+	}
+
+	record.PreviousHash = previousHash
+	record.Signature = ""
+
+	record.Hash, err = hashRecord(record)
+	if err != nil {
+		return fmt.Errorf("hashing audit record: %w", err)
+	}
+
+	if l.signKey != nil {
+		record.Signature, err = sign(l.signKey, record.Hash)
+		if err != nil {
+			return fmt.Errorf("signing audit record: %w", err)
+		}
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("marshalling audit record: %w", err)
+	}
+
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("opening audit log: %w", err)
+	}
+
+	defer func() {
+		err = errors.Join(err, f.Close())
+	}()
+
+	_, err = f.Write(append(line, '\n'))
+	if err != nil {
+		return fmt.Errorf("writing audit record: %w", err)
+	}
+
+	return nil
+}
+
+// lastHash returns the hash of the last record written to the log, or an empty string if the log does not exist yet.
+func (l *Logger) lastHash() (string, error) {
+	content, err := os.ReadFile(l.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	lines := bytes.Split(bytes.TrimSpace(content), []byte("\n"))
+	last := lines[len(lines)-1]
+
+	if len(last) == 0 {
+		return "", nil
+	}
+
+	var record Record
+	if err = json.Unmarshal(last, &record); err != nil {
+		return "", fmt.Errorf("unmarshalling record: %w", err)
+	}
+
+	return record.Hash, nil
+}
+
+// Verify reads an audit log and checks that every record hash is valid and correctly chained to the previous one. If
+// a keyring is given, record signatures are checked against it.
+func Verify(reader io.Reader, keyring openpgp.KeyRing) error {
+	var previousHash string
+
+	scanner := bufio.NewScanner(reader)
+	line := 0
+
+	for scanner.Scan() {
+		line++
+
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return fmt.Errorf("unmarshalling record on line %d: %w", line, err)
+		}
+
+		if record.PreviousHash != previousHash {
+			return fmt.Errorf("record on line %d: %w", line, ErrBrokenChain)
+		}
+
+		signature := record.Signature
+		record.Signature = ""
+
+		hash, err := hashRecord(record)
+		if err != nil {
+			return fmt.Errorf("hashing record on line %d: %w", line, err)
+		}
+
+		if hash != record.Hash {
+			return fmt.Errorf("record on line %d has been altered: %w", line, ErrBrokenChain)
+		}
+
+		if keyring != nil {
+			_, err = openpgp.CheckArmoredDetachedSignature(keyring, strings.NewReader(hash), strings.NewReader(signature), nil)
+			if err != nil {
+				return fmt.Errorf("checking signature of record on line %d: %w", line, err)
+			}
+		}
+
+		previousHash = record.Hash
+	}
+
+	return scanner.Err()
+}
+
+// Actor returns the identity of whoever triggered the current execution, based on well known CI environment
+// variables.
+func Actor() string {
+	for _, key := range []string{"GITHUB_ACTOR", "GITLAB_USER_LOGIN", "USER", "USERNAME"} {
+		if value, ok := os.LookupEnv(key); ok && value != "" {
+			return value
+		}
+	}
+
+	return "unknown"
+}
+
+func hashRecord(record Record) (string, error) {
+	record.Hash = ""
+
+	b, err := json.Marshal(record)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(b)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func sign(key *openpgp.Entity, hash string) (string, error) {
+	buf := new(bytes.Buffer)
+
+	if err := openpgp.ArmoredDetachSignText(buf, key, strings.NewReader(hash), nil); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
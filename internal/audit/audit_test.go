@@ -0,0 +1,92 @@
+package audit
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestAudit_AppendAndVerify(t *testing.T) {
+	assert := assertion.New(t)
+
+	logPath := filepath.Join(t.TempDir(), "audit.log")
+
+	logger := New(logPath)
+
+	err := logger.Append(Record{Action: ActionTag, Branch: "main", Version: "1.0.0", Tag: "v1.0.0"})
+	checkErr(t, "appending first record", err)
+
+	err = logger.Append(Record{Action: ActionPush, Branch: "main", Version: "1.0.0", Tag: "v1.0.0"})
+	checkErr(t, "appending second record", err)
+
+	content, err := os.ReadFile(logPath)
+	checkErr(t, "reading audit log", err)
+
+	assert.Equal(2, bytes.Count(content, []byte("\n")), "log should contain two lines")
+	assert.NoError(Verify(bytes.NewReader(content), nil), "audit log should be valid")
+}
+
+func TestAudit_VerifyAlteredRecord(t *testing.T) {
+	assert := assertion.New(t)
+
+	logPath := filepath.Join(t.TempDir(), "audit.log")
+
+	logger := New(logPath)
+
+	err := logger.Append(Record{Action: ActionTag, Branch: "main", Version: "1.0.0"})
+	checkErr(t, "appending record", err)
+
+	err = logger.Append(Record{Action: ActionPush, Branch: "main", Version: "1.0.0"})
+	checkErr(t, "appending record", err)
+
+	content, err := os.ReadFile(logPath)
+	checkErr(t, "reading audit log", err)
+
+	altered := bytes.Replace(content, []byte(`"version":"1.0.0"`), []byte(`"version":"2.0.0"`), 1)
+	assert.ErrorIs(Verify(bytes.NewReader(altered), nil), ErrBrokenChain, "altered record should be detected")
+
+	lines := bytes.SplitAfter(content, []byte("\n"))
+	assert.ErrorIs(Verify(bytes.NewReader(lines[1]), nil), ErrBrokenChain, "removed record should be detected")
+}
+
+func TestAudit_SignedRecords(t *testing.T) {
+	assert := assertion.New(t)
+
+	entity, err := openpgp.NewEntity("John Doe", "", "john.doe@example.com", &packet.Config{Algorithm: packet.PubKeyAlgoEdDSA})
+	checkErr(t, "creating entity", err)
+
+	logPath := filepath.Join(t.TempDir(), "audit.log")
+
+	err = New(logPath, WithSignKey(entity)).Append(Record{Action: ActionTag, Version: "1.0.0"})
+	checkErr(t, "appending record", err)
+
+	content, err := os.ReadFile(logPath)
+	checkErr(t, "reading audit log", err)
+
+	assert.NoError(Verify(bytes.NewReader(content), openpgp.EntityList{entity}), "signature should be valid")
+
+	otherEntity, err := openpgp.NewEntity("Jane Doe", "", "jane.doe@example.com", &packet.Config{Algorithm: packet.PubKeyAlgoEdDSA})
+	checkErr(t, "creating entity", err)
+
+	assert.Error(Verify(bytes.NewReader(content), openpgp.EntityList{otherEntity}), "signature should not match another key")
+}
+
+func TestAudit_Actor(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Setenv("GITHUB_ACTOR", "octocat")
+
+	assert.Equal("octocat", Actor())
+}
+
+func checkErr(t *testing.T, msg string, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %s", msg, err)
+	}
+}
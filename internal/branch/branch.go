@@ -4,16 +4,33 @@ package branch
 import (
 	"errors"
 	"fmt"
+
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
 )
 
 var (
-	ErrNoBranch = errors.New("no branch configuration")
-	ErrNoName   = errors.New("no name in branch configuration")
+	ErrNoBranch           = errors.New("no branch configuration")
+	ErrNoName             = errors.New("no name in branch configuration")
+	ErrFrozenStableBranch = errors.New("base version can only be frozen on prerelease branches")
 )
 
 type Branch struct {
 	Name       string
 	Prerelease bool
+	// VersionRange, if set, is the constraint every version released from the branch must satisfy.
+	VersionRange *semver.Constraint
+	// TagPrefix, if set, overrides the prefix of the tags of the branch, an empty prefix included.
+	TagPrefix *string
+	// GPGKeyPath, if set, is the path of the armored GPG key signing the tags of the branch instead of the default one.
+	GPGKeyPath string
+	// Environment, if set, is the environment the releases of the branch are deployed to (e.g., "production").
+	Environment string
+	// FreezeBaseVersion, if set, keeps the base version of the prereleases of the branch once one has been tagged, only
+	// their counter being incremented (e.g., "1.2.0-rc.1" then "1.2.0-rc.2").
+	FreezeBaseVersion bool
+	// GitName and GitEmail, if set, override the identity of the tagger of the tags of the branch.
+	GitName  string
+	GitEmail string
 }
 
 // Unmarshall takes a raw Viper configuration and returns a slice of Branch representing a branch configuration.
@@ -48,6 +65,85 @@ func Unmarshall(input []map[string]any) ([]Branch, error) {
 			branch.Prerelease = boolPrerelease
 		}
 
+		versionRange, ok := b["version-range"]
+		if ok {
+			stringVersionRange, ok := versionRange.(string)
+			if !ok {
+				return nil, fmt.Errorf("could not assert that the \"version-range\" property of the branch configuration is a string")
+			}
+
+			constraint, err := semver.NewConstraint(stringVersionRange)
+			if err != nil {
+				return nil, fmt.Errorf("parsing branch %q version range: %w", stringName, err)
+			}
+
+			branch.VersionRange = constraint
+		}
+
+		tagPrefix, ok := b["tag-prefix"]
+		if ok {
+			stringTagPrefix, ok := tagPrefix.(string)
+			if !ok {
+				return nil, fmt.Errorf("could not assert that the \"tag-prefix\" property of the branch configuration is a string")
+			}
+
+			branch.TagPrefix = &stringTagPrefix
+		}
+
+		gpgKeyPath, ok := b["gpg-key-path"]
+		if ok {
+			stringGPGKeyPath, ok := gpgKeyPath.(string)
+			if !ok {
+				return nil, fmt.Errorf("could not assert that the \"gpg-key-path\" property of the branch configuration is a string")
+			}
+
+			branch.GPGKeyPath = stringGPGKeyPath
+		}
+
+		environment, ok := b["environment"]
+		if ok {
+			stringEnvironment, ok := environment.(string)
+			if !ok {
+				return nil, fmt.Errorf("could not assert that the \"environment\" property of the branch configuration is a string")
+			}
+
+			branch.Environment = stringEnvironment
+		}
+
+		gitName, ok := b["git-name"]
+		if ok {
+			stringGitName, ok := gitName.(string)
+			if !ok {
+				return nil, fmt.Errorf("could not assert that the \"git-name\" property of the branch configuration is a string")
+			}
+
+			branch.GitName = stringGitName
+		}
+
+		gitEmail, ok := b["git-email"]
+		if ok {
+			stringGitEmail, ok := gitEmail.(string)
+			if !ok {
+				return nil, fmt.Errorf("could not assert that the \"git-email\" property of the branch configuration is a string")
+			}
+
+			branch.GitEmail = stringGitEmail
+		}
+
+		freezeBaseVersion, ok := b["freeze-base-version"]
+		if ok {
+			boolFreezeBaseVersion, ok := freezeBaseVersion.(bool)
+			if !ok {
+				return nil, fmt.Errorf("could not assert that the \"freeze-base-version\" property of the branch configuration is a bool")
+			}
+
+			if boolFreezeBaseVersion && !branch.Prerelease {
+				return nil, fmt.Errorf("configuring branch %q: %w", stringName, ErrFrozenStableBranch)
+			}
+
+			branch.FreezeBaseVersion = boolFreezeBaseVersion
+		}
+
 		branches[i] = branch
 	}
 
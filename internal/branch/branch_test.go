@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
 )
 
 func TestBranch_Unmarshall(t *testing.T) {
@@ -42,3 +44,106 @@ func TestBranch_UnmarshallErrors(t *testing.T) {
 		assert.Equal(tc.want, err)
 	}
 }
+
+func TestBranch_UnmarshallVersionRange(t *testing.T) {
+	assert := assertion.New(t)
+
+	branches, err := Unmarshall([]map[string]any{{"name": "v1", "version-range": "^1"}})
+	if err != nil {
+		t.Fatalf("unmarshalling branches: %s", err)
+	}
+
+	assert.Equal("^1", branches[0].VersionRange.String())
+	assert.True(branches[0].VersionRange.Check(&semver.Version{Major: 1, Minor: 4}))
+	assert.False(branches[0].VersionRange.Check(&semver.Version{Major: 2}))
+
+	_, err = Unmarshall([]map[string]any{{"name": "v1", "version-range": "^foo"}})
+	assert.ErrorIs(err, semver.ErrInvalidConstraint)
+
+	_, err = Unmarshall([]map[string]any{{"name": "v1", "version-range": 1}})
+	assert.Error(err)
+}
+
+func TestBranch_UnmarshallTagPrefix(t *testing.T) {
+	assert := assertion.New(t)
+
+	branches, err := Unmarshall([]map[string]any{{"name": "nightly", "tag-prefix": "nightly-"}, {"name": "raw", "tag-prefix": ""}, {"name": "main"}})
+	if err != nil {
+		t.Fatalf("unmarshalling branches: %s", err)
+	}
+
+	assert.Equal("nightly-", *branches[0].TagPrefix)
+	assert.Equal("", *branches[1].TagPrefix, "an empty prefix should override the global one")
+	assert.Nil(branches[2].TagPrefix)
+
+	_, err = Unmarshall([]map[string]any{{"name": "nightly", "tag-prefix": 1}})
+	assert.Error(err)
+}
+
+func TestBranch_UnmarshallGPGKeyPath(t *testing.T) {
+	assert := assertion.New(t)
+
+	branches, err := Unmarshall([]map[string]any{{"name": "rc", "prerelease": true, "gpg-key-path": "ci.asc"}, {"name": "main"}})
+	if err != nil {
+		t.Fatalf("unmarshalling branches: %s", err)
+	}
+
+	assert.Equal("ci.asc", branches[0].GPGKeyPath)
+	assert.Empty(branches[1].GPGKeyPath)
+
+	_, err = Unmarshall([]map[string]any{{"name": "rc", "gpg-key-path": true}})
+	assert.Error(err)
+}
+
+func TestBranch_UnmarshallEnvironment(t *testing.T) {
+	assert := assertion.New(t)
+
+	branches, err := Unmarshall([]map[string]any{{"name": "main", "environment": "production"}, {"name": "feature"}})
+	if err != nil {
+		t.Fatalf("unmarshalling branches: %s", err)
+	}
+
+	assert.Equal("production", branches[0].Environment)
+	assert.Empty(branches[1].Environment)
+
+	_, err = Unmarshall([]map[string]any{{"name": "main", "environment": 1}})
+	assert.Error(err)
+}
+
+func TestBranch_UnmarshallFreezeBaseVersion(t *testing.T) {
+	assert := assertion.New(t)
+
+	branches, err := Unmarshall([]map[string]any{{"name": "rc", "prerelease": true, "freeze-base-version": true}, {"name": "main"}})
+	if err != nil {
+		t.Fatalf("unmarshalling branches: %s", err)
+	}
+
+	assert.True(branches[0].FreezeBaseVersion)
+	assert.False(branches[1].FreezeBaseVersion)
+
+	_, err = Unmarshall([]map[string]any{{"name": "main", "freeze-base-version": true}})
+	assert.ErrorIs(err, ErrFrozenStableBranch)
+
+	_, err = Unmarshall([]map[string]any{{"name": "rc", "prerelease": true, "freeze-base-version": "yes"}})
+	assert.Error(err)
+}
+
+func TestBranch_UnmarshallGitIdentity(t *testing.T) {
+	assert := assertion.New(t)
+
+	branches, err := Unmarshall([]map[string]any{{"name": "rc", "git-name": "RC Bot", "git-email": "rc@bot.ci"}, {"name": "main"}})
+	if err != nil {
+		t.Fatalf("unmarshalling branches: %s", err)
+	}
+
+	assert.Equal("RC Bot", branches[0].GitName)
+	assert.Equal("rc@bot.ci", branches[0].GitEmail)
+	assert.Empty(branches[1].GitName)
+	assert.Empty(branches[1].GitEmail)
+
+	_, err = Unmarshall([]map[string]any{{"name": "rc", "git-name": 1}})
+	assert.Error(err)
+
+	_, err = Unmarshall([]map[string]any{{"name": "rc", "git-email": true}})
+	assert.Error(err)
+}
@@ -3,6 +3,7 @@ package branch
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/pflag"
 )
@@ -24,9 +25,24 @@ func (f *Flag) String() string {
 	return string(b)
 }
 
+// Set parses either a JSON array of branches, such as [{"name": "main"}, {"name": "rc", "prerelease": true}], or its
+// shorthand form, a comma separated list of branch names each followed by colon separated options, such as
+// "main,rc:prerelease". An option is either a boolean property set to true (e.g., "prerelease") or a property and its
+// value (e.g., "environment=staging").
 func (f *Flag) Set(value string) error {
 	var temp []map[string]any
 
+	// Anything looking like JSON is parsed as JSON, so that malformed JSON is reported as such.
+	if trimmed := strings.TrimSpace(value); !strings.HasPrefix(trimmed, "[") && !strings.HasPrefix(trimmed, "{") {
+		branches, err := parseShorthand(value)
+		if err != nil {
+			return fmt.Errorf("parsing branch flag shorthand: %w", err)
+		}
+
+		*f = branches
+		return nil
+	}
+
 	if err := json.Unmarshal([]byte(value), &temp); err != nil {
 		return fmt.Errorf("unmarshalling branch flag value: %w", err)
 	}
@@ -35,6 +51,44 @@ func (f *Flag) Set(value string) error {
 	return nil
 }
 
+// parseShorthand parses the shorthand form of the branch flag into the structured form of its JSON counterpart.
+func parseShorthand(value string) ([]map[string]any, error) {
+	var branches []map[string]any
+
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		name, options, _ := strings.Cut(item, ":")
+		if name == "" {
+			return nil, fmt.Errorf("missing branch name in %q", item)
+		}
+
+		b := map[string]any{"name": name}
+
+		if options != "" {
+			for _, option := range strings.Split(options, ":") {
+				key, val, hasValue := strings.Cut(option, "=")
+				if key == "" {
+					return nil, fmt.Errorf("empty option in %q", item)
+				}
+
+				if hasValue {
+					b[key] = val
+				} else {
+					b[key] = true
+				}
+			}
+		}
+
+		branches = append(branches, b)
+	}
+
+	return branches, nil
+}
+
 func (f *Flag) Type() string {
 	return FlagType
 }
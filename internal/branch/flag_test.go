@@ -40,6 +40,26 @@ func TestBranchFlag_Set(t *testing.T) {
 	assert.Error(t, err, "should have errored, invalid JSON string")
 }
 
+func TestBranchFlag_SetShorthand(t *testing.T) {
+	assert := assert.New(t)
+
+	var flag Flag
+
+	err := flag.Set("main, rc:prerelease:environment=staging,")
+	assert.NoError(err, "should not have errored")
+	assert.Equal(Flag{{"name": "main"}, {"name": "rc", "prerelease": true, "environment": "staging"}}, flag)
+
+	branches, err := Unmarshall(flag)
+	assert.NoError(err, "shorthand should be unmarshalled as its JSON form")
+	assert.True(branches[1].Prerelease)
+
+	err = flag.Set(":prerelease")
+	assert.Error(err, "should have errored, missing branch name")
+
+	err = flag.Set("rc::prerelease")
+	assert.Error(err, "should have errored, empty option")
+}
+
 func TestBranchFlag_Type(t *testing.T) {
 	var f Flag
 
@@ -0,0 +1,445 @@
+// Package changelog renders the release notes of a new version from the commits that triggered it.
+package changelog
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/s0ders/go-semver-release/v6/internal/forge"
+	"github.com/s0ders/go-semver-release/v6/internal/render"
+)
+
+const (
+	// FormatKeepAChangelog renders Markdown sections following https://keepachangelog.com.
+	FormatKeepAChangelog = "keep-a-changelog"
+	// FormatConventionalJSON renders the commits as the JSON AST produced by conventional-changelog parsers.
+	FormatConventionalJSON = "conventional-json"
+
+	// Marker is the comment under which the sections of new releases are inserted in a changelog file.
+	Marker = "<!-- go-semver-release: new releases -->"
+
+	breakingChangeNote = "BREAKING CHANGE"
+	deprecatedNote     = "DEPRECATED"
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown changelog format")
+	ErrNoMarker      = errors.New("no insertion marker in changelog file")
+)
+
+var (
+	headerRegex = regexp.MustCompile(`^(\w+)(?:\(([^)]*)\))?(!)?: (.+)$`)
+	noteRegex   = regexp.MustCompile(`^(BREAKING[ -]CHANGE|DEPRECATED): ?(.*)`)
+	footerRegex = regexp.MustCompile(`^[\w-]+(?:: | #)`)
+	// coAuthorRegex matches the trailers crediting the co-authors of a commit, e.g. "Co-authored-by: Jane <jane@example.com>".
+	coAuthorRegex = regexp.MustCompile(`(?i)^co-authored-by:\s*(.*?)\s*<([^>]*)>\s*$`)
+	// pullRequestRegex matches the number of the pull request suffixing the subject of squash merged commits, e.g.
+	// "add v2 endpoints (#123)".
+	pullRequestRegex = regexp.MustCompile(`^(.*?)\s*\(#(\d+)\)$`)
+)
+
+// Keep a Changelog sections, in the order they are rendered. Conventional commits do not tell removals and security
+// fixes apart, the corresponding sections are therefore never rendered. Deprecations are announced by commit footers
+// rather than by commit types, and are listed apart from these sections.
+var sections = []string{"Added", "Changed", "Fixed"}
+
+// Note is a note of a commit footer, such as a breaking change or a deprecation description.
+type Note struct {
+	Title string `json:"title"`
+	Text  string `json:"text"`
+}
+
+// Author is an author or a co-author of a commit. Its handle is the name of its account on the forge, if known.
+type Author struct {
+	Name   string `json:"name"`
+	Email  string `json:"email"`
+	Handle string `json:"handle,omitempty"`
+}
+
+// Commit is a conventional commit, with the fields of the conventional-changelog AST and its authors.
+type Commit struct {
+	Type    string   `json:"type"`
+	Scope   string   `json:"scope"`
+	Subject string   `json:"subject"`
+	Header  string   `json:"header"`
+	Body    string   `json:"body"`
+	Footer  string   `json:"footer"`
+	Notes   []Note   `json:"notes"`
+	Hash    string   `json:"hash"`
+	Authors []Author `json:"authors"`
+}
+
+// Breaking returns whether the commit introduces a breaking change.
+func (c Commit) Breaking() bool {
+	for _, note := range c.Notes {
+		if note.Title == breakingChangeNote {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Deprecations returns the descriptions of the deprecations announced by the "DEPRECATED:" footers of the commit.
+func (c Commit) Deprecations() []string {
+	var deprecations []string
+
+	for _, note := range c.Notes {
+		if note.Title == deprecatedNote {
+			deprecations = append(deprecations, note.Text)
+		}
+	}
+
+	return deprecations
+}
+
+// Release is a new version and the commits it contains. Its Markdown changelog links to the commit and pull request
+// pages of the given links. Authors are the authors and co-authors of every commit since the previous version,
+// including those that are not part of the changelog.
+type Release struct {
+	Version string      `json:"version"`
+	Date    time.Time   `json:"date"`
+	Commits []Commit    `json:"commits"`
+	Authors []Author    `json:"-"`
+	Links   forge.Links `json:"-"`
+}
+
+// ValidateFormat checks that the given changelog format is supported.
+func ValidateFormat(format string) error {
+	switch format {
+	case FormatKeepAChangelog, FormatConventionalJSON:
+		return nil
+	default:
+		return fmt.Errorf("%w: %q, must be %q or %q", ErrUnknownFormat, format, FormatKeepAChangelog, FormatConventionalJSON)
+	}
+}
+
+// Extension returns the file extension matching a changelog format.
+func Extension(format string) string {
+	if format == FormatConventionalJSON {
+		return ".json"
+	}
+
+	return ".md"
+}
+
+// ParseCommit parses a conventional commit message. It returns false if the message header is not conventional.
+func ParseCommit(hash, message string) (Commit, bool) {
+	header, rest, _ := strings.Cut(strings.TrimSpace(message), "\n")
+
+	match := headerRegex.FindStringSubmatch(header)
+	if match == nil {
+		return Commit{}, false
+	}
+
+	commit := Commit{
+		Type:    match[1],
+		Scope:   match[2],
+		Subject: match[4],
+		Header:  header,
+		Notes:   []Note{},
+		Hash:    hash,
+		Authors: []Author{},
+	}
+
+	var body, footer []string
+
+	// The footer starts at the first paragraph made of trailers (e.g., "Refs: #123") or of a breaking change note.
+	for _, paragraph := range strings.Split(strings.TrimSpace(rest), "\n\n") {
+		if paragraph == "" {
+			continue
+		}
+
+		if len(footer) > 0 || noteRegex.MatchString(paragraph) || footerRegex.MatchString(paragraph) {
+			footer = append(footer, paragraph)
+			continue
+		}
+
+		body = append(body, paragraph)
+	}
+
+	commit.Body = strings.Join(body, "\n\n")
+	commit.Footer = strings.Join(footer, "\n\n")
+
+	// A note spans the lines following it until the next trailer.
+	var note *Note
+
+	for _, line := range strings.Split(commit.Footer, "\n") {
+		if match := coAuthorRegex.FindStringSubmatch(line); match != nil {
+			commit.Authors = append(commit.Authors, Author{Name: match[1], Email: match[2]})
+		}
+
+		switch match := noteRegex.FindStringSubmatch(line); {
+		case match != nil:
+			title := breakingChangeNote
+			if match[1] == deprecatedNote {
+				title = deprecatedNote
+			}
+
+			commit.Notes = append(commit.Notes, Note{Title: title, Text: match[2]})
+			note = &commit.Notes[len(commit.Notes)-1]
+		case footerRegex.MatchString(line):
+			note = nil
+		case note != nil:
+			note.Text += "\n" + line
+		}
+	}
+
+	for i := range commit.Notes {
+		commit.Notes[i].Text = strings.TrimSpace(commit.Notes[i].Text)
+	}
+
+	if match[3] == "!" && !commit.Breaking() {
+		commit.Notes = append(commit.Notes, Note{Title: breakingChangeNote, Text: commit.Subject})
+	}
+
+	return commit, true
+}
+
+// Render writes the changelog of a release in the given format. Markdown changelogs are rendered with the changelog
+// template of the given renderer.
+func Render(w io.Writer, renderer *render.Renderer, format string, release Release) error {
+	switch format {
+	case FormatKeepAChangelog:
+		return renderer.Render(w, render.Changelog, release.Data())
+	case FormatConventionalJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+
+		if release.Commits == nil {
+			release.Commits = []Commit{}
+		}
+
+		if err := encoder.Encode(release); err != nil {
+			return fmt.Errorf("encoding changelog: %w", err)
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+}
+
+// Data returns the data of the changelog template, commits being grouped by Keep a Changelog section.
+func (r Release) Data() render.ChangelogData {
+	entries := make(map[string][]render.ChangelogEntry)
+	data := render.ChangelogData{Version: r.Version, Date: r.Date}
+
+	for _, commit := range r.Commits {
+		entry := render.ChangelogEntry{
+			Type:     commit.Type,
+			Scope:    commit.Scope,
+			Subject:  commit.Subject,
+			Breaking: commit.Breaking(),
+			Hash:     commit.Hash,
+		}
+
+		if len(commit.Hash) >= 7 {
+			entry.ShortHash = commit.Hash[:7]
+		}
+
+		if commit.Hash != "" {
+			entry.CommitURL = r.Links.CommitURL(commit.Hash)
+		}
+
+		if match := pullRequestRegex.FindStringSubmatch(commit.Subject); match != nil {
+			entry.Subject = match[1]
+			entry.PullRequest, _ = strconv.Atoi(match[2])
+			entry.PullRequestURL = r.Links.PullRequestURL(entry.PullRequest)
+		}
+
+		section := section(commit)
+		entries[section] = append(entries[section], entry)
+
+		for _, text := range commit.Deprecations() {
+			data.Deprecations = append(data.Deprecations, render.ChangelogDeprecation{
+				Scope:     entry.Scope,
+				Text:      text,
+				Hash:      entry.Hash,
+				ShortHash: entry.ShortHash,
+				CommitURL: entry.CommitURL,
+			})
+		}
+	}
+
+	for _, author := range r.Contributors() {
+		data.Contributors = append(data.Contributors, render.ChangelogContributor(author))
+	}
+
+	for _, section := range sections {
+		if len(entries[section]) > 0 {
+			data.Sections = append(data.Sections, render.ChangelogSection{Title: section, Entries: entries[section]})
+		}
+	}
+
+	return data
+}
+
+// Contributors returns the authors of the release, followed by the authors and co-authors of its commits, in order of
+// first appearance. Authors are deduplicated by email, case-insensitively, or by name when they have no email.
+func (r Release) Contributors() []Author {
+	var contributors []Author
+
+	seen := make(map[string]bool)
+
+	add := func(authors []Author) {
+		for _, author := range authors {
+			key := strings.ToLower(author.Email)
+			if key == "" {
+				key = author.Name
+			}
+
+			if key == "" || seen[key] {
+				continue
+			}
+
+			seen[key] = true
+			contributors = append(contributors, author)
+		}
+	}
+
+	add(r.Authors)
+
+	for _, commit := range r.Commits {
+		add(commit.Authors)
+	}
+
+	return contributors
+}
+
+// CoAuthors returns the co-authors credited by the "Co-authored-by:" trailers of a commit message.
+func CoAuthors(message string) []Author {
+	var authors []Author
+
+	for _, line := range strings.Split(message, "\n") {
+		if match := coAuthorRegex.FindStringSubmatch(strings.TrimSpace(line)); match != nil {
+			authors = append(authors, Author{Name: match[1], Email: match[2]})
+		}
+	}
+
+	return authors
+}
+
+// Deprecations returns the descriptions of the deprecations announced by the commits of the release, in order.
+func (r Release) Deprecations() []string {
+	var deprecations []string
+
+	for _, commit := range r.Commits {
+		deprecations = append(deprecations, commit.Deprecations()...)
+	}
+
+	return deprecations
+}
+
+// section returns the Keep a Changelog section of a commit. Breaking changes are listed as changes whatever their type.
+func section(commit Commit) string {
+	switch {
+	case commit.Breaking():
+		return "Changed"
+	case commit.Type == "feat":
+		return "Added"
+	case commit.Type == "fix":
+		return "Fixed"
+	default:
+		return "Changed"
+	}
+}
+
+// WriteFile writes the changelog of a release in the given format to a file, creating its directory if needed.
+func WriteFile(path string, renderer *render.Renderer, format string, release Release) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating changelog directory: %w", err)
+	}
+
+	var b bytes.Buffer
+
+	if err := Render(&b, renderer, format, release); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, b.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("writing changelog file: %w", err)
+	}
+
+	return nil
+}
+
+// Insert inserts the Markdown changelog of a release under the marker of a changelog file, keeping its content. A
+// missing file is created with a title and the marker. Insertion is idempotent: nothing is written if the file already
+// has a heading naming the version, e.g. when a release is run again. It returns whether the file was written.
+func Insert(path string, renderer *render.Renderer, release Release) (bool, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		content = nil
+	} else if err != nil {
+		return false, fmt.Errorf("reading changelog file: %w", err)
+	}
+
+	updated, inserted, err := InsertContent(content, renderer, release)
+	if err != nil {
+		return false, fmt.Errorf("updating %q: %w", path, err)
+	}
+
+	if !inserted {
+		return false, nil
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return false, fmt.Errorf("creating changelog directory: %w", err)
+	}
+
+	if err = os.WriteFile(path, updated, 0o644); err != nil {
+		return false, fmt.Errorf("writing changelog file: %w", err)
+	}
+
+	return true, nil
+}
+
+// InsertContent returns the content of a changelog file in which the Markdown changelog of a release is inserted, as
+// done by Insert, and whether it was inserted. A nil content is the one of a missing file.
+func InsertContent(content []byte, renderer *render.Renderer, release Release) ([]byte, bool, error) {
+	if content == nil {
+		content = []byte("# Changelog\n\n" + Marker + "\n")
+	}
+
+	if hasVersionHeading(string(content), release.Version) {
+		return content, false, nil
+	}
+
+	before, after, ok := strings.Cut(string(content), Marker)
+	if !ok {
+		return nil, false, fmt.Errorf("%w, expected %q", ErrNoMarker, Marker)
+	}
+
+	var b bytes.Buffer
+
+	if err := Render(&b, renderer, FormatKeepAChangelog, release); err != nil {
+		return nil, false, err
+	}
+
+	section := strings.TrimSpace(b.String())
+
+	updated := before + Marker + "\n\n" + section + "\n"
+	if rest := strings.TrimLeft(after, "\n"); rest != "" {
+		updated += "\n" + rest
+	}
+
+	return []byte(updated), true, nil
+}
+
+// hasVersionHeading reports whether a Markdown document has a heading naming the given version, such as "## [1.2.0]"
+// or "## v1.2.0 - 2024-03-01".
+func hasVersionHeading(content, version string) bool {
+	headingRegex := regexp.MustCompile(`(?m)^#+ +\[?v?` + regexp.QuoteMeta(version) + `\]?(?:\s|$)`)
+
+	return headingRegex.MatchString(content)
+}
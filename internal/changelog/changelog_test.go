@@ -0,0 +1,315 @@
+package changelog
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/forge"
+	"github.com/s0ders/go-semver-release/v6/internal/render"
+)
+
+const hash = "3f1c2a9d0be4e6a2c1b7f5e8d9a0b1c2d3e4f5a6"
+
+func TestChangelog_ParseCommit(t *testing.T) {
+	assert := assertion.New(t)
+
+	commit, ok := ParseCommit(hash, "feat(api)!: remove v1 endpoints\n\nThe v1 endpoints were deprecated.\n\nBREAKING CHANGE: use v2 endpoints\nRefs: #12")
+	assert.True(ok)
+	assert.Equal("feat", commit.Type)
+	assert.Equal("api", commit.Scope)
+	assert.Equal("remove v1 endpoints", commit.Subject)
+	assert.Equal("feat(api)!: remove v1 endpoints", commit.Header)
+	assert.Equal("The v1 endpoints were deprecated.", commit.Body)
+	assert.Equal("BREAKING CHANGE: use v2 endpoints\nRefs: #12", commit.Footer)
+	assert.Equal([]Note{{Title: "BREAKING CHANGE", Text: "use v2 endpoints"}}, commit.Notes)
+	assert.True(commit.Breaking())
+
+	commit, ok = ParseCommit(hash, "fix!: handle empty payloads")
+	assert.True(ok)
+	assert.Equal([]Note{{Title: "BREAKING CHANGE", Text: "handle empty payloads"}}, commit.Notes, "breaking change marker should add a note")
+
+	commit, ok = ParseCommit(hash, "fix: handle empty payloads")
+	assert.True(ok)
+	assert.False(commit.Breaking())
+
+	_, ok = ParseCommit(hash, "Merge branch 'main'")
+	assert.False(ok)
+}
+
+func TestChangelog_Deprecations(t *testing.T) {
+	assert := assertion.New(t)
+
+	commit, ok := ParseCommit(hash, "feat(api): add v2 endpoints\n\nDEPRECATED: the v1 endpoints, use v2\ninstead\nRefs: #12\nBREAKING CHANGE: drop the legacy header")
+	assert.True(ok)
+	assert.Equal([]Note{{Title: "DEPRECATED", Text: "the v1 endpoints, use v2\ninstead"}, {Title: "BREAKING CHANGE", Text: "drop the legacy header"}}, commit.Notes)
+	assert.Equal([]string{"the v1 endpoints, use v2\ninstead"}, commit.Deprecations())
+	assert.True(commit.Breaking(), "deprecations should not hide breaking changes")
+
+	fix := mustParse(t, "fix: handle empty payloads\n\nDEPRECATED: the payload size option")
+	assert.False(fix.Breaking(), "deprecations should not be breaking changes")
+
+	release := Release{
+		Version: "1.1.0",
+		Date:    time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		Commits: []Commit{mustParse(t, "feat: add v2 endpoints\n\nDEPRECATED: the v1 endpoints"), fix, mustParse(t, "fix: handle errors")},
+	}
+
+	assert.Equal([]string{"the v1 endpoints", "the payload size option"}, release.Deprecations())
+
+	var b strings.Builder
+
+	err := Render(&b, newRenderer(t), FormatKeepAChangelog, release)
+	checkErr(t, "rendering changelog", err)
+
+	assert.Contains(b.String(), "### Deprecations\n\n- the v1 endpoints (3f1c2a9)\n- the payload size option (3f1c2a9)\n")
+
+	b.Reset()
+
+	err = Render(&b, newRenderer(t), FormatKeepAChangelog, Release{Version: "1.0.0", Commits: []Commit{mustParse(t, "fix: handle errors")}})
+	checkErr(t, "rendering changelog", err)
+
+	assert.NotContains(b.String(), "### Deprecations", "releases without deprecations should not have a deprecations section")
+}
+
+func TestChangelog_ValidateFormat(t *testing.T) {
+	assert := assertion.New(t)
+
+	assert.NoError(ValidateFormat(FormatKeepAChangelog))
+	assert.NoError(ValidateFormat(FormatConventionalJSON))
+	assert.ErrorIs(ValidateFormat("html"), ErrUnknownFormat)
+}
+
+func TestChangelog_Render_KeepAChangelog(t *testing.T) {
+	assert := assertion.New(t)
+
+	release := Release{
+		Version: "2.0.0",
+		Date:    time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		Commits: []Commit{
+			mustParse(t, "fix: handle empty payloads"),
+			mustParse(t, "feat(api): add v2 endpoints"),
+			mustParse(t, "feat!: remove v1 endpoints"),
+			mustParse(t, "perf: cache responses"),
+		},
+	}
+
+	var b strings.Builder
+
+	err := Render(&b, newRenderer(t), FormatKeepAChangelog, release)
+	checkErr(t, "rendering changelog", err)
+
+	expected := `## [2.0.0] - 2024-03-01
+
+### Added
+
+- **api:** add v2 endpoints (3f1c2a9)
+
+### Changed
+
+- **BREAKING:** remove v1 endpoints (3f1c2a9)
+- cache responses (3f1c2a9)
+
+### Fixed
+
+- handle empty payloads (3f1c2a9)
+`
+
+	assert.Equal(expected, b.String())
+}
+
+func TestChangelog_Render_ConventionalJSON(t *testing.T) {
+	assert := assertion.New(t)
+
+	release := Release{
+		Version: "1.1.0",
+		Date:    time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		Commits: []Commit{mustParse(t, "feat(api): add v2 endpoints")},
+	}
+
+	var b strings.Builder
+
+	err := Render(&b, newRenderer(t), FormatConventionalJSON, release)
+	checkErr(t, "rendering changelog", err)
+
+	var decoded map[string]any
+
+	err = json.Unmarshal([]byte(b.String()), &decoded)
+	checkErr(t, "decoding changelog", err)
+
+	assert.Equal("1.1.0", decoded["version"])
+
+	commits := decoded["commits"].([]any)
+	assert.Len(commits, 1)
+
+	commit := commits[0].(map[string]any)
+	assert.Equal("feat", commit["type"])
+	assert.Equal("api", commit["scope"])
+	assert.Equal("add v2 endpoints", commit["subject"])
+	assert.Equal(hash, commit["hash"])
+	assert.Equal([]any{}, commit["notes"])
+
+	err = Render(&b, newRenderer(t), "html", release)
+	assert.ErrorIs(err, ErrUnknownFormat)
+}
+
+func TestChangelog_Contributors(t *testing.T) {
+	assert := assertion.New(t)
+
+	commit, ok := ParseCommit(hash, "feat: add v2 endpoints\n\nCo-authored-by: Jane Doe <jane@example.com>\nco-authored-by: John <john@example.com>")
+	assert.True(ok)
+	assert.Equal([]Author{{Name: "Jane Doe", Email: "jane@example.com"}, {Name: "John", Email: "john@example.com"}}, commit.Authors)
+
+	fix := mustParse(t, "fix: handle empty payloads")
+	fix.Authors = []Author{{Name: "Jane", Email: "JANE@example.com"}, {Name: "Bot", Handle: "bot"}}
+
+	release := Release{
+		Version: "1.1.0",
+		Date:    time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		Commits: []Commit{commit, fix},
+	}
+
+	assert.Equal([]Author{{Name: "Jane Doe", Email: "jane@example.com"}, {Name: "John", Email: "john@example.com"}, {Name: "Bot", Handle: "bot"}}, release.Contributors(), "authors should be deduplicated by email")
+
+	var b strings.Builder
+
+	err := Render(&b, newRenderer(t), FormatKeepAChangelog, release)
+	checkErr(t, "rendering changelog", err)
+
+	assert.Contains(b.String(), "### Contributors\n\n- Jane Doe\n- John\n- @bot\n")
+
+	release.Authors = append([]Author{{Name: "Alice", Email: "alice@example.com"}}, CoAuthors("docs typo\n\nCo-authored-by: Jane <jane@example.com>")...)
+
+	assert.Equal([]Author{{Name: "Alice", Email: "alice@example.com"}, {Name: "Jane", Email: "jane@example.com"}, {Name: "John", Email: "john@example.com"}, {Name: "Bot", Handle: "bot"}}, release.Contributors(), "the authors of every commit of the release should be credited")
+}
+
+func TestChangelog_Links(t *testing.T) {
+	assert := assertion.New(t)
+
+	release := Release{
+		Version: "1.1.0",
+		Date:    time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		Commits: []Commit{
+			mustParse(t, "feat: add v2 endpoints (#42)\n\nDEPRECATED: the v1 endpoints"),
+			mustParse(t, "fix: handle empty payloads"),
+		},
+		Links: forge.Links{Commit: "https://git.acme.com/app/commit/{{ .Hash }}", PullRequest: "https://git.acme.com/app/pull/{{ .Number }}"},
+	}
+
+	var b strings.Builder
+
+	err := Render(&b, newRenderer(t), FormatKeepAChangelog, release)
+	checkErr(t, "rendering changelog", err)
+
+	commitLink := "([3f1c2a9](https://git.acme.com/app/commit/" + hash + "))"
+
+	assert.Contains(b.String(), "- add v2 endpoints ([#42](https://git.acme.com/app/pull/42)) "+commitLink+"\n")
+	assert.Contains(b.String(), "- handle empty payloads "+commitLink+"\n")
+	assert.Contains(b.String(), "- the v1 endpoints "+commitLink+"\n", "deprecations should link their commit")
+
+	release.Links = forge.Links{}
+	b.Reset()
+
+	err = Render(&b, newRenderer(t), FormatKeepAChangelog, release)
+	checkErr(t, "rendering changelog", err)
+
+	assert.Contains(b.String(), "- add v2 endpoints (#42) (3f1c2a9)\n", "pull requests should be referenced without links")
+}
+
+func TestChangelog_WriteFile(t *testing.T) {
+	assert := assertion.New(t)
+
+	path := filepath.Join(t.TempDir(), "out", "main.md")
+
+	err := WriteFile(path, newRenderer(t), FormatKeepAChangelog, Release{Version: "0.1.0", Commits: []Commit{mustParse(t, "feat: add foo")}})
+	checkErr(t, "writing changelog", err)
+
+	content, err := os.ReadFile(path)
+	checkErr(t, "reading changelog", err)
+
+	assert.Contains(string(content), "- add foo (3f1c2a9)")
+	assert.Equal(".json", Extension(FormatConventionalJSON))
+	assert.Equal(".md", Extension(FormatKeepAChangelog))
+}
+
+func TestChangelog_Insert(t *testing.T) {
+	assert := assertion.New(t)
+
+	path := filepath.Join(t.TempDir(), "CHANGELOG.md")
+	renderer := newRenderer(t)
+
+	written, err := Insert(path, renderer, Release{Version: "0.1.0", Commits: []Commit{mustParse(t, "feat: add foo")}})
+	checkErr(t, "inserting changelog", err)
+
+	assert.True(written)
+
+	written, err = Insert(path, renderer, Release{Version: "0.2.0", Commits: []Commit{mustParse(t, "feat: add bar")}})
+	checkErr(t, "inserting changelog", err)
+
+	assert.True(written)
+
+	written, err = Insert(path, renderer, Release{Version: "0.2.0", Commits: []Commit{mustParse(t, "feat: add bar")}})
+	checkErr(t, "inserting changelog again", err)
+
+	assert.False(written, "a release already in the changelog should not be inserted again")
+
+	content, err := os.ReadFile(path)
+	checkErr(t, "reading changelog", err)
+
+	expected := "# Changelog\n\n" + Marker + "\n\n" +
+		"## [0.2.0] - 0001-01-01\n\n### Added\n\n- add bar (3f1c2a9)\n\n" +
+		"## [0.1.0] - 0001-01-01\n\n### Added\n\n- add foo (3f1c2a9)\n"
+
+	assert.Equal(expected, string(content))
+
+	unmarked := filepath.Join(t.TempDir(), "CHANGELOG.md")
+	checkErr(t, "writing changelog", os.WriteFile(unmarked, []byte("# Changelog\n"), 0o644))
+
+	_, err = Insert(unmarked, renderer, Release{Version: "0.1.0"})
+	assert.ErrorIs(err, ErrNoMarker)
+}
+
+func TestChangelog_HasVersionHeading(t *testing.T) {
+	assert := assertion.New(t)
+
+	content := "# Changelog\n\n## [1.2.0] - 2024-03-01\n\n## v1.1.0\n\n## [1.0.0-rc.1]\n"
+
+	assert.True(hasVersionHeading(content, "1.2.0"))
+	assert.True(hasVersionHeading(content, "1.1.0"))
+	assert.True(hasVersionHeading(content, "1.0.0-rc.1"))
+	assert.False(hasVersionHeading(content, "1.0.0"), "prerelease heading should not match its release")
+	assert.False(hasVersionHeading("Released 1.3.0 today", "1.3.0"), "only headings should match")
+}
+
+func newRenderer(t *testing.T) *render.Renderer {
+	t.Helper()
+
+	renderer, err := render.New("")
+	checkErr(t, "creating renderer", err)
+
+	return renderer
+}
+
+func mustParse(t *testing.T, message string) Commit {
+	t.Helper()
+
+	commit, ok := ParseCommit(hash, message)
+	if !ok {
+		t.Fatalf("parsing commit %q", message)
+	}
+
+	return commit
+}
+
+func checkErr(t *testing.T, msg string, err error) {
+	t.Helper()
+
+	if err != nil {
+		t.Fatalf("%s: %s", msg, err)
+	}
+}
@@ -0,0 +1,27 @@
+package ci
+
+import (
+	"os"
+	"strings"
+)
+
+const actionInputPrefix = "INPUT_"
+
+// ActionInputs returns the inputs of a GitHub Action, which the runner passes as "INPUT_<NAME>" environment variables,
+// <NAME> being the uppercased input name. Returned names are lowercased as in the action metadata file. Inputs with an
+// empty value, i.e. not set by the workflow and without a default value, are omitted.
+func ActionInputs() map[string]string {
+	inputs := make(map[string]string)
+
+	for _, env := range os.Environ() {
+		key, value, _ := strings.Cut(env, "=")
+
+		if !strings.HasPrefix(key, actionInputPrefix) || value == "" {
+			continue
+		}
+
+		inputs[strings.ToLower(strings.TrimPrefix(key, actionInputPrefix))] = value
+	}
+
+	return inputs
+}
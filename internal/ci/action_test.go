@@ -0,0 +1,21 @@
+package ci
+
+import (
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestCI_ActionInputs(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Setenv("INPUT_TAG-PREFIX", "v")
+	t.Setenv("INPUT_DRY_RUN", "true")
+	t.Setenv("INPUT_GPG-KEY-PATH", "")
+
+	inputs := ActionInputs()
+
+	assert.Equal("v", inputs["tag-prefix"])
+	assert.Equal("true", inputs["dry_run"])
+	assert.NotContains(inputs, "gpg-key-path", "empty inputs should be omitted")
+}
@@ -0,0 +1,63 @@
+package ci
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
+)
+
+// BadgeFileName is the name of the badge file written for every release channel.
+const BadgeFileName = "badge.json"
+
+// Badge is the content of a shields.io endpoint badge, see https://shields.io/badges/endpoint-badge.
+type Badge struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Label         string `json:"label"`
+	Message       string `json:"message"`
+	Color         string `json:"color"`
+}
+
+// NewBadge returns the badge of the latest version of a release channel, labelled after the project if any, and
+// colored differently for prereleases.
+func NewBadge(semver *semver.Version, project string) Badge {
+	badge := Badge{
+		SchemaVersion: 1,
+		Label:         "version",
+		Message:       semver.String(),
+		Color:         "blue",
+	}
+
+	if project != "" {
+		badge.Label = project
+	}
+
+	if semver.Prerelease != "" {
+		badge.Color = "orange"
+	}
+
+	return badge
+}
+
+// WriteBadgeFile writes the badge of the latest version of a release channel to a badge file inside a directory named
+// after the channel in the given directory, which are created if needed.
+func WriteBadgeFile(dir string, semver *semver.Version, branch, project string) error {
+	channelDir := filepath.Join(dir, ChannelName(branch, project))
+
+	if err := os.MkdirAll(channelDir, 0o755); err != nil {
+		return fmt.Errorf("creating badges directory: %w", err)
+	}
+
+	content, err := json.Marshal(NewBadge(semver, project))
+	if err != nil {
+		return fmt.Errorf("marshalling badge: %w", err)
+	}
+
+	if err = os.WriteFile(filepath.Join(channelDir, BadgeFileName), append(content, '\n'), 0o644); err != nil {
+		return fmt.Errorf("writing badge file: %w", err)
+	}
+
+	return nil
+}
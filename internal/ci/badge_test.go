@@ -0,0 +1,32 @@
+package ci
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
+)
+
+func TestCI_NewBadge(t *testing.T) {
+	assert := assertion.New(t)
+
+	assert.Equal(Badge{SchemaVersion: 1, Label: "version", Message: "1.2.3", Color: "blue"}, NewBadge(&semver.Version{Major: 1, Minor: 2, Patch: 3}, ""))
+	assert.Equal(Badge{SchemaVersion: 1, Label: "api", Message: "1.3.0-rc", Color: "orange"}, NewBadge(&semver.Version{Major: 1, Minor: 3, Prerelease: "rc"}, "api"))
+}
+
+func TestCI_WriteBadgeFile(t *testing.T) {
+	assert := assertion.New(t)
+
+	dir := filepath.Join(t.TempDir(), "badges")
+
+	err := WriteBadgeFile(dir, &semver.Version{Major: 1, Minor: 2, Patch: 3}, "release/1.x", "api")
+	checkErr(t, "writing badge file", err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "api-release-1.x", BadgeFileName))
+	checkErr(t, "reading badge file", err)
+
+	assert.Equal("{\"schemaVersion\":1,\"label\":\"api\",\"message\":\"1.2.3\",\"color\":\"blue\"}\n", string(content))
+}
@@ -0,0 +1,37 @@
+package ci
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
+)
+
+// ChannelName returns the name of the release channel of a branch, prefixed by the project name if any. Slashes are
+// replaced by hyphens so that the name can be used as a file name (e.g., "release/1.x" becomes "release-1.x").
+func ChannelName(branch, project string) string {
+	name := branch
+	if project != "" {
+		name = project + "-" + branch
+	}
+
+	return strings.ReplaceAll(name, "/", "-")
+}
+
+// WriteChannelFile writes the latest version of a release channel to a file named after the channel inside the given
+// directory, which is created if needed.
+func WriteChannelFile(dir string, semver *semver.Version, branch, project string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("creating channels directory: %w", err)
+	}
+
+	path := filepath.Join(dir, ChannelName(branch, project))
+
+	if err := os.WriteFile(path, []byte(semver.String()+"\n"), 0o644); err != nil {
+		return fmt.Errorf("writing channel file: %w", err)
+	}
+
+	return nil
+}
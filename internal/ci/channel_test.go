@@ -0,0 +1,36 @@
+package ci
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
+)
+
+func TestCI_ChannelName(t *testing.T) {
+	assert := assertion.New(t)
+
+	assert.Equal("main", ChannelName("main", ""))
+	assert.Equal("api-main", ChannelName("main", "api"))
+	assert.Equal("api-release-1.x", ChannelName("release/1.x", "api"))
+}
+
+func TestCI_WriteChannelFile(t *testing.T) {
+	assert := assertion.New(t)
+
+	dir := filepath.Join(t.TempDir(), "out")
+
+	err := WriteChannelFile(dir, &semver.Version{Major: 1, Minor: 2, Patch: 3}, "main", "api")
+	checkErr(t, "writing channel file", err)
+
+	err = WriteChannelFile(dir, &semver.Version{Major: 1, Minor: 3, Patch: 0}, "main", "api")
+	checkErr(t, "overwriting channel file", err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "api-main"))
+	checkErr(t, "reading channel file", err)
+
+	assert.Equal("1.3.0\n", string(content))
+}
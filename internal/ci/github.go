@@ -15,6 +15,7 @@ type GitHubOutput struct {
 	Branch      string
 	TagPrefix   string
 	ProjectName string
+	Environment string
 	NewRelease  bool
 }
 
@@ -24,6 +25,7 @@ func (g GitHubOutput) String() string {
 	versionKey := branch + "_SEMVER"
 	releaseKey := branch + "_NEW_RELEASE"
 	projectKey := branch + "_PROJECT"
+	environmentKey := branch + "_ENVIRONMENT"
 
 	str := "\n"
 
@@ -34,6 +36,10 @@ func (g GitHubOutput) String() string {
 		str += fmt.Sprintf("%s=%s\n", projectKey, g.ProjectName)
 	}
 
+	if g.Environment != "" {
+		str += fmt.Sprintf("%s=%s\n", environmentKey, g.Environment)
+	}
+
 	return str
 }
 
@@ -57,6 +63,12 @@ func WithProject(project string) OptionFunc {
 	}
 }
 
+func WithEnvironment(environment string) OptionFunc {
+	return func(o *GitHubOutput) {
+		o.Environment = environment
+	}
+}
+
 func GenerateGitHubOutput(semver *semver.Version, branch string, options ...OptionFunc) (err error) {
 	path, exists := os.LookupEnv("GITHUB_OUTPUT")
 
@@ -86,3 +98,36 @@ func GenerateGitHubOutput(semver *semver.Version, branch string, options ...Opti
 
 	return
 }
+
+// GitHubEnvironment is the configuration that can be inferred from the environment of a GitHub Actions runner.
+type GitHubEnvironment struct {
+	RepositoryURL string
+	Branch        string
+	Token         string
+}
+
+// DetectGitHubEnvironment returns the configuration inferred from the default environment variables of a GitHub
+// Actions runner, and whether the program is running on such a runner. The branch is only inferred if the workflow
+// was triggered by a branch, not by a tag or a pull request.
+func DetectGitHubEnvironment() (GitHubEnvironment, bool) {
+	var environment GitHubEnvironment
+
+	if os.Getenv("GITHUB_ACTIONS") != "true" {
+		return environment, false
+	}
+
+	serverURL := os.Getenv("GITHUB_SERVER_URL")
+	repository := os.Getenv("GITHUB_REPOSITORY")
+
+	if serverURL != "" && repository != "" {
+		environment.RepositoryURL = strings.TrimSuffix(serverURL, "/") + "/" + repository + ".git"
+	}
+
+	if ref := os.Getenv("GITHUB_REF"); strings.HasPrefix(ref, "refs/heads/") {
+		environment.Branch = strings.TrimPrefix(ref, "refs/heads/")
+	}
+
+	environment.Token = os.Getenv("GITHUB_TOKEN")
+
+	return environment, true
+}
@@ -69,6 +69,35 @@ func TestCI_GenerateGitHub_HappyScenarioWithProject(t *testing.T) {
 	assert.Equal(want, got, "output should match")
 }
 
+func TestCI_GenerateGitHub_HappyScenarioWithEnvironment(t *testing.T) {
+	assert := assertion.New(t)
+
+	err := setup()
+	checkErr(t, "setting up test", err)
+
+	defer func() {
+		err = teardown()
+		checkErr(t, "tearing down test", err)
+	}()
+
+	version := &semver.Version{Major: 1, Minor: 2, Patch: 3}
+
+	err = GenerateGitHubOutput(version, "main", WithNewRelease(true), WithTagPrefix("v"), WithProject("foo"), WithEnvironment("production"))
+	if err != nil {
+		t.Fatalf("creating github output: %s", err)
+	}
+
+	outputPath := os.Getenv("GITHUB_OUTPUT")
+
+	writtenOutput, err := os.ReadFile(outputPath)
+	checkErr(t, "reading output file", err)
+
+	want := "\nMAIN_SEMVER=v1.2.3\nMAIN_NEW_RELEASE=true\nMAIN_PROJECT=foo\nMAIN_ENVIRONMENT=production\n"
+	got := string(writtenOutput)
+
+	assert.Equal(want, got, "output should match")
+}
+
 func TestCI_GenerateGitHub_NoEnvVar(t *testing.T) {
 	assert := assertion.New(t)
 
@@ -98,6 +127,35 @@ func TestCI_GenerateGitHub_ReadOnlyOutput(t *testing.T) {
 	assert.Error(err, "should have failed since output file is readonly")
 }
 
+func TestCI_DetectGitHubEnvironment(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Setenv("GITHUB_ACTIONS", "true")
+	t.Setenv("GITHUB_SERVER_URL", "https://github.com")
+	t.Setenv("GITHUB_REPOSITORY", "octocat/hello-world")
+	t.Setenv("GITHUB_REF", "refs/heads/release/v1")
+	t.Setenv("GITHUB_TOKEN", "secret")
+
+	environment, ok := DetectGitHubEnvironment()
+
+	assert.True(ok, "GitHub Actions runner should have been detected")
+	assert.Equal(GitHubEnvironment{
+		RepositoryURL: "https://github.com/octocat/hello-world.git",
+		Branch:        "release/v1",
+		Token:         "secret",
+	}, environment)
+
+	t.Setenv("GITHUB_REF", "refs/pull/1/merge")
+
+	environment, _ = DetectGitHubEnvironment()
+	assert.Empty(environment.Branch, "branch should not be inferred from a pull request")
+
+	t.Setenv("GITHUB_ACTIONS", "")
+
+	_, ok = DetectGitHubEnvironment()
+	assert.False(ok, "GitHub Actions runner should not have been detected")
+}
+
 func setup() error {
 	dirPath, err := os.MkdirTemp("", "output-*")
 	if err != nil {
@@ -0,0 +1,125 @@
+// Package cloud provides the credentials of the cloud providers whose services are integrated without their SDK, such
+// as AWS and Google Cloud.
+package cloud
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// AWSDefaultRegion is the region of AWS requests when none is configured.
+const AWSDefaultRegion = "us-east-1"
+
+const amzDateFormat = "20060102T150405Z"
+
+// AWSCredentials are the credentials signing the requests made to AWS.
+type AWSCredentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	// SessionToken is the token of temporary credentials, e.g. of an assumed role, if any.
+	SessionToken string
+}
+
+// AWSCredentialsFromEnv returns the credentials given by the AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and
+// AWS_SESSION_TOKEN environment variables, as set by most CI systems, and whether they are set.
+func AWSCredentialsFromEnv() (AWSCredentials, bool) {
+	credentials := AWSCredentials{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+
+	return credentials, credentials.AccessKeyID != "" && credentials.SecretAccessKey != ""
+}
+
+// AWSRegion returns the region given by the AWS_REGION or AWS_DEFAULT_REGION environment variables, or
+// AWSDefaultRegion.
+func AWSRegion() string {
+	for _, key := range []string{"AWS_REGION", "AWS_DEFAULT_REGION"} {
+		if region := os.Getenv(key); region != "" {
+			return region
+		}
+	}
+
+	return AWSDefaultRegion
+}
+
+// AWSEndpoint returns the endpoint of an AWS service given by the AWS_ENDPOINT_URL_<SERVICE> or AWS_ENDPOINT_URL
+// environment variables, e.g. of an S3 compatible storage or a local emulator, or an empty string if none is set.
+func AWSEndpoint(service string) string {
+	for _, key := range []string{"AWS_ENDPOINT_URL_" + strings.ToUpper(service), "AWS_ENDPOINT_URL"} {
+		if endpoint := os.Getenv(key); endpoint != "" {
+			return strings.TrimSuffix(endpoint, "/")
+		}
+	}
+
+	return ""
+}
+
+// Sign adds the AWS Signature Version 4 of a request made at the given time to a service of a region, with a payload
+// of the given SHA-256 hash, to its headers. Every header of the request is signed.
+func (c AWSCredentials) Sign(req *http.Request, region, service, payloadHash string, t time.Time) {
+	amzDate := t.UTC().Format(amzDateFormat)
+	date := amzDate[:8]
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	if c.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", c.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{req.Method, req.URL.EscapedPath(), req.URL.RawQuery, canonicalHeaders.String(), signedHeaders, payloadHash}, "\n")
+	canonicalHash := sha256.Sum256([]byte(canonicalRequest))
+
+	scope := fmt.Sprintf("%s/%s/%s/aws4_request", date, region, service)
+	stringToSign := fmt.Sprintf("AWS4-HMAC-SHA256\n%s\n%s\n%s", amzDate, scope, hex.EncodeToString(canonicalHash[:]))
+
+	signature := HMACSHA256(c.SigningKey(date, region, service), stringToSign)
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s", c.AccessKeyID, scope, signedHeaders, hex.EncodeToString(signature)))
+}
+
+// SigningKey derives the AWS Signature Version 4 key signing the requests made on the given date (e.g., "20240301")
+// to a service of a region.
+func (c AWSCredentials) SigningKey(date, region, service string) []byte {
+	key := HMACSHA256([]byte("AWS4"+c.SecretAccessKey), date)
+	key = HMACSHA256(key, region)
+	key = HMACSHA256(key, service)
+
+	return HMACSHA256(key, "aws4_request")
+}
+
+// HMACSHA256 returns the HMAC-SHA256 of data with the given key.
+func HMACSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+
+	return mac.Sum(nil)
+}
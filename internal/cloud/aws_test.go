@@ -0,0 +1,97 @@
+package cloud
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestAWS_CredentialsFromEnv(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_SESSION_TOKEN", "session")
+
+	credentials, ok := AWSCredentialsFromEnv()
+	assert.True(ok)
+	assert.Equal(AWSCredentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret", SessionToken: "session"}, credentials)
+
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+
+	_, ok = AWSCredentialsFromEnv()
+	assert.False(ok, "credentials without secret should not be usable")
+}
+
+func TestAWS_Region(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_DEFAULT_REGION", "")
+
+	assert.Equal(AWSDefaultRegion, AWSRegion())
+
+	t.Setenv("AWS_DEFAULT_REGION", "eu-west-3")
+	assert.Equal("eu-west-3", AWSRegion())
+
+	t.Setenv("AWS_REGION", "eu-west-1")
+	assert.Equal("eu-west-1", AWSRegion(), "AWS_REGION should take precedence")
+}
+
+func TestAWS_SigningKey(t *testing.T) {
+	assert := assertion.New(t)
+
+	// Example of the AWS Signature Version 4 documentation.
+	credentials := AWSCredentials{SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"}
+
+	key := credentials.SigningKey("20120215", "us-east-1", "iam")
+
+	assert.Equal("f4780e2d9f65fa895f9c67b32ce1baf0b0d8a43505a000a1a9e090d414db404d", hex.EncodeToString(key))
+}
+
+func TestAWS_Endpoint(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Setenv("AWS_ENDPOINT_URL_S3", "")
+	t.Setenv("AWS_ENDPOINT_URL", "")
+
+	assert.Empty(AWSEndpoint("s3"))
+
+	t.Setenv("AWS_ENDPOINT_URL", "http://localstack:4566/")
+	assert.Equal("http://localstack:4566", AWSEndpoint("s3"))
+
+	t.Setenv("AWS_ENDPOINT_URL_S3", "http://minio:9000")
+	assert.Equal("http://minio:9000", AWSEndpoint("s3"), "the endpoint of the service should take precedence")
+}
+
+func TestAWS_Sign(t *testing.T) {
+	assert := assertion.New(t)
+
+	credentials := AWSCredentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "wJalrXUtnFEMI/K7MDENG/bPxRfiCYEXAMPLEKEY"}
+
+	req, err := http.NewRequest(http.MethodPut, "https://acme.s3.eu-west-1.amazonaws.com/releases/v1.0.0/release%20notes.md", strings.NewReader("hello"))
+	checkErr(t, err, "creating request")
+
+	req.Header.Set("Content-Type", "text/markdown")
+
+	digest := sha256.Sum256([]byte("hello"))
+	credentials.Sign(req, "eu-west-1", "s3", hex.EncodeToString(digest[:]), time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC))
+
+	assert.Equal("20240301T120000Z", req.Header.Get("X-Amz-Date"))
+	assert.Equal("AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20240301/eu-west-1/s3/aws4_request, SignedHeaders=content-type;host;x-amz-content-sha256;x-amz-date, Signature=ade4d964ed70540d5769d96270122ec388d41a3276e1b139a7156a0d5de84d2d", req.Header.Get("Authorization"))
+
+	credentials.SessionToken = "session"
+
+	req, err = http.NewRequest(http.MethodPut, "https://acme.s3.eu-west-1.amazonaws.com/key", nil)
+	checkErr(t, err, "creating request")
+
+	credentials.Sign(req, "eu-west-1", "s3", "hash", time.Now())
+
+	assert.Equal("session", req.Header.Get("X-Amz-Security-Token"))
+	assert.Contains(req.Header.Get("Authorization"), "SignedHeaders=host;x-amz-content-sha256;x-amz-date;x-amz-security-token,")
+}
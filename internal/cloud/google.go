@@ -0,0 +1,68 @@
+package cloud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// GoogleAccessTokenEnv is the environment variable holding an OAuth access token for Google Cloud, as printed by
+// "gcloud auth print-access-token".
+const GoogleAccessTokenEnv = "GOOGLE_OAUTH_ACCESS_TOKEN"
+
+var ErrNoCredentials = errors.New("no credentials found")
+
+// GoogleMetadataTokenURL is the endpoint of the metadata server of Google Cloud serving access tokens of the service
+// account attached to the instance, replaced in tests.
+var GoogleMetadataTokenURL = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+
+// GoogleAccessToken returns an OAuth access token for Google Cloud: the one given by the GOOGLE_OAUTH_ACCESS_TOKEN
+// environment variable, else the one of the service account attached to the Google Cloud instance the program runs
+// on, e.g. a Cloud Build worker, fetched from the metadata server.
+func GoogleAccessToken(ctx context.Context) (string, error) {
+	if token := os.Getenv(GoogleAccessTokenEnv); token != "" {
+		return token, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, GoogleMetadataTokenURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Metadata-Flavor", "Google")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%w: set %s when not running on Google Cloud: %w", ErrNoCredentials, GoogleAccessTokenEnv, err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: metadata server responded %q", ErrNoCredentials, resp.Status)
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("decoding metadata server response: %w", err)
+	}
+
+	if token.AccessToken == "" {
+		return "", fmt.Errorf("%w: metadata server returned no access token", ErrNoCredentials)
+	}
+
+	return strings.TrimSpace(token.AccessToken), nil
+}
@@ -0,0 +1,54 @@
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestGoogle_AccessToken(t *testing.T) {
+	assert := assertion.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("Google", r.Header.Get("Metadata-Flavor"))
+		_, _ = fmt.Fprint(w, `{"access_token": "metadata-token", "expires_in": 3599, "token_type": "Bearer"}`)
+	}))
+	defer server.Close()
+
+	metadataTokenURL := GoogleMetadataTokenURL
+	t.Cleanup(func() {
+		GoogleMetadataTokenURL = metadataTokenURL
+	})
+
+	GoogleMetadataTokenURL = server.URL
+
+	t.Setenv(GoogleAccessTokenEnv, "env-token")
+
+	token, err := GoogleAccessToken(context.Background())
+	checkErr(t, err, "fetching access token")
+
+	assert.Equal("env-token", token)
+
+	t.Setenv(GoogleAccessTokenEnv, "")
+
+	token, err = GoogleAccessToken(context.Background())
+	checkErr(t, err, "fetching access token")
+
+	assert.Equal("metadata-token", token, "the token of the instance service account should be used last")
+
+	GoogleMetadataTokenURL = "http://127.0.0.1:1/token"
+
+	_, err = GoogleAccessToken(context.Background())
+	assert.ErrorIs(err, ErrNoCredentials)
+}
+
+func checkErr(t *testing.T, err error, msg string) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %s", msg, err)
+	}
+}
@@ -0,0 +1,125 @@
+// Package dryrun provides functions to handle the side effects suppressed by a dry-run.
+package dryrun
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Side effects of a release that can be suppressed by a dry-run.
+const (
+	// Lock is the acquisition of the locks of the released branches.
+	Lock = "lock"
+	// Checks is the verification of the required checks of the release commits.
+	Checks = "checks"
+	// Tag is the creation of the release tags in the cloned repository.
+	Tag = "tag"
+	// Push is the push of the release tags to the remote.
+	Push = "push"
+	// Deployment is the recording of the releases as deployments on the forge.
+	Deployment = "deployment"
+	// Release is the publication of the release summary as a forge release.
+	Release = "release"
+	// Comment is the publication of the release summary as a pull request comment.
+	Comment = "comment"
+	// CheckRun is the report of the releases as a check run on the analyzed commits.
+	CheckRun = "check-run"
+	// Artifacts is the upload of the artifacts of the releases to the configured bucket.
+	Artifacts = "artifacts"
+	// Registry is the publication of the versions of Terraform modules to their registry.
+	Registry = "registry"
+	// Events is the publication of the release events to the configured message queues.
+	Events = "events"
+)
+
+// All suppresses every side effect.
+const All = "all"
+
+const FlagType = "side effects"
+
+var ErrUnknownEffect = errors.New("unknown dry-run side effect")
+
+// Effects are the side effects that can be suppressed, in the order they happen.
+var Effects = []string{Lock, Checks, Tag, Push, Deployment, Release, Comment, CheckRun, Artifacts, Registry, Events}
+
+// implied are the side effects that cannot happen without another one: a tag that is not created cannot be pushed,
+// and a tag that is not pushed cannot be deployed.
+var implied = map[string][]string{
+	Tag:  {Push, Deployment},
+	Push: {Deployment},
+}
+
+// Flag is the set of side effects suppressed by a dry-run, empty if not in dry-run mode. Artifacts without side
+// effects, such as GitHub outputs, channel files, changelogs and the release summary file, are produced regardless.
+type Flag []string
+
+func (f *Flag) String() string {
+	if f == nil || len(*f) == 0 {
+		return ""
+	}
+
+	if len(*f) == len(Effects) {
+		return All
+	}
+
+	return strings.Join(*f, ",")
+}
+
+// Set parses either a boolean, "true" suppressing every side effect, "all", or a comma separated list of side
+// effects such as "tag,push". Side effects depending on a suppressed one are suppressed too.
+func (f *Flag) Set(value string) error {
+	switch strings.TrimSpace(strings.ToLower(value)) {
+	case "", "false":
+		*f = nil
+		return nil
+	case "true", All:
+		*f = slices.Clone(Effects)
+		return nil
+	}
+
+	suppressed := make(map[string]bool)
+
+	for _, effect := range strings.Split(value, ",") {
+		effect = strings.TrimSpace(strings.ToLower(effect))
+		if effect == "" {
+			continue
+		}
+
+		if !slices.Contains(Effects, effect) {
+			return fmt.Errorf("%w: %q", ErrUnknownEffect, effect)
+		}
+
+		suppressed[effect] = true
+
+		for _, dependent := range implied[effect] {
+			suppressed[dependent] = true
+		}
+	}
+
+	var effects Flag
+
+	for _, effect := range Effects {
+		if suppressed[effect] {
+			effects = append(effects, effect)
+		}
+	}
+
+	*f = effects
+	return nil
+}
+
+func (f *Flag) Type() string {
+	return FlagType
+}
+
+// Enabled reports whether any side effect is suppressed.
+func (f Flag) Enabled() bool {
+	return len(f) > 0
+}
+
+// Suppresses reports whether the given side effect is suppressed.
+func (f Flag) Suppresses(effect string) bool {
+	return slices.Contains(f, effect)
+}
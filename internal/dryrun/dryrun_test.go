@@ -0,0 +1,56 @@
+package dryrun
+
+import (
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestFlag_Set(t *testing.T) {
+	assert := assertion.New(t)
+
+	type test struct {
+		have string
+		want Flag
+	}
+
+	tests := []test{
+		{have: "", want: nil},
+		{have: "false", want: nil},
+		{have: "true", want: Flag(Effects)},
+		{have: "all", want: Flag(Effects)},
+		{have: "push", want: Flag{Push, Deployment}},
+		{have: "release, Tag", want: Flag{Tag, Push, Deployment, Release}},
+		{have: "lock,checks", want: Flag{Lock, Checks}},
+	}
+
+	for _, tc := range tests {
+		var f Flag
+
+		err := f.Set(tc.have)
+		assert.NoError(err, "setting %q", tc.have)
+		assert.Equal(tc.want, f, "setting %q", tc.have)
+	}
+
+	var f Flag
+
+	err := f.Set("tag,commit")
+	assert.ErrorIs(err, ErrUnknownEffect)
+}
+
+func TestFlag_String(t *testing.T) {
+	assert := assertion.New(t)
+
+	var f Flag
+	assert.Equal("", f.String())
+	assert.False(f.Enabled())
+
+	_ = f.Set("true")
+	assert.Equal("all", f.String())
+	assert.True(f.Enabled())
+
+	_ = f.Set("push")
+	assert.Equal("push,deployment", f.String())
+	assert.True(f.Suppresses(Deployment))
+	assert.False(f.Suppresses(Tag))
+}
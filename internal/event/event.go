@@ -0,0 +1,140 @@
+// Package event publishes an event for every release to message queues, such as AWS SNS, Google Cloud Pub/Sub or
+// Kafka, so that queue-driven systems are notified of new releases instead of polling tags.
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	TypeSNS    = "sns"
+	TypePubSub = "pubsub"
+	TypeKafka  = "kafka"
+
+	// ReleaseType is the type of the events published for new releases.
+	ReleaseType = "release"
+
+	// SchemaVersion is the version of the schema of the events, incremented on breaking changes.
+	SchemaVersion = 1
+)
+
+var (
+	ErrNoType      = errors.New("event sink has no type")
+	ErrUnknownType = errors.New("unknown event sink type")
+	ErrNoTopic     = errors.New("event sink has no topic")
+	ErrNoURL       = errors.New("event sink has no URL")
+)
+
+// Event is the JSON message published for a release.
+type Event struct {
+	SchemaVersion   int       `json:"schema-version"`
+	Type            string    `json:"type"`
+	Tag             string    `json:"tag"`
+	Version         string    `json:"version"`
+	PreviousVersion string    `json:"previous-version,omitempty"`
+	Branch          string    `json:"branch"`
+	Environment     string    `json:"environment,omitempty"`
+	Project         string    `json:"project,omitempty"`
+	Commit          string    `json:"commit"`
+	Date            time.Time `json:"date"`
+}
+
+// Sink is a message queue topic to which release events are published.
+type Sink struct {
+	// Type is the kind of message queue: TypeSNS, TypePubSub or TypeKafka.
+	Type string
+	// Topic is the ARN of an SNS topic, the full name of a Pub/Sub topic (e.g., "projects/acme/topics/releases") or
+	// the name of a Kafka topic.
+	Topic string
+	// URL is the URL of the Kafka REST Proxy through which Kafka events are produced.
+	URL string
+}
+
+// Publisher publishes events to a sink.
+type Publisher interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+// Unmarshall takes a raw Viper configuration and returns a slice of Sink representing an events configuration.
+func Unmarshall(input []map[string]string) ([]Sink, error) {
+	sinks := make([]Sink, len(input))
+
+	for i, s := range input {
+		sink := Sink{Type: s["type"], Topic: s["topic"], URL: s["url"]}
+
+		switch sink.Type {
+		case "":
+			return nil, ErrNoType
+		case TypeSNS, TypePubSub, TypeKafka:
+		default:
+			return nil, fmt.Errorf("%w %q, must be %q, %q or %q", ErrUnknownType, sink.Type, TypeSNS, TypePubSub, TypeKafka)
+		}
+
+		if sink.Topic == "" {
+			return nil, fmt.Errorf("%s sink: %w", sink.Type, ErrNoTopic)
+		}
+
+		if sink.Type == TypeKafka && sink.URL == "" {
+			return nil, fmt.Errorf("kafka sink %q: %w", sink.Topic, ErrNoURL)
+		}
+
+		sinks[i] = sink
+	}
+
+	return sinks, nil
+}
+
+func (s Sink) String() string {
+	return s.Type + ":" + s.Topic
+}
+
+// Publisher returns a publisher of the sink, authenticated with the credentials of its provider: the AWS credentials
+// given by the environment for SNS, or a Google Cloud access token for Pub/Sub.
+func (s Sink) Publisher(ctx context.Context) (Publisher, error) {
+	switch s.Type {
+	case TypeSNS:
+		return newSNSFromEnv(s.Topic)
+	case TypePubSub:
+		return newPubSubFromEnv(ctx, s.Topic)
+	case TypeKafka:
+		return NewKafka(s.URL, s.Topic), nil
+	default:
+		return nil, fmt.Errorf("%w %q", ErrUnknownType, s.Type)
+	}
+}
+
+// send sends a request and decodes the JSON body of its response into out, if not nil. It returns an error holding
+// the status and the beginning of the body of an unsuccessful response.
+func send(client *http.Client, req *http.Request, out any) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		if out == nil {
+			return nil
+		}
+
+		if err = json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("decoding response: %w", err)
+		}
+
+		return nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+
+	return fmt.Errorf("unexpected status %q: %s", resp.Status, strings.TrimSpace(string(body)))
+}
@@ -0,0 +1,98 @@
+package event
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/cloud"
+)
+
+func TestEvent_Unmarshall(t *testing.T) {
+	assert := assertion.New(t)
+
+	sinks, err := Unmarshall([]map[string]string{
+		{"type": "sns", "topic": "arn:aws:sns:eu-west-1:123456789012:releases"},
+		{"type": "kafka", "topic": "releases", "url": "https://kafka-rest.acme.com"},
+	})
+	checkErr(t, err, "unmarshalling sinks")
+
+	assert.Equal([]Sink{
+		{Type: TypeSNS, Topic: "arn:aws:sns:eu-west-1:123456789012:releases"},
+		{Type: TypeKafka, Topic: "releases", URL: "https://kafka-rest.acme.com"},
+	}, sinks)
+
+	type test struct {
+		input map[string]string
+		err   error
+	}
+
+	matrix := []test{
+		{input: map[string]string{"topic": "releases"}, err: ErrNoType},
+		{input: map[string]string{"type": "rabbitmq", "topic": "releases"}, err: ErrUnknownType},
+		{input: map[string]string{"type": "pubsub"}, err: ErrNoTopic},
+		{input: map[string]string{"type": "kafka", "topic": "releases"}, err: ErrNoURL},
+	}
+
+	for _, tc := range matrix {
+		_, err = Unmarshall([]map[string]string{tc.input})
+		assert.ErrorIs(err, tc.err, tc.input)
+	}
+}
+
+func TestEvent_SinkPublisher(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+
+	sns := Sink{Type: TypeSNS, Topic: "arn:aws:sns:eu-west-1:123456789012:releases"}
+
+	_, err := sns.Publisher(context.Background())
+	assert.ErrorIs(err, cloud.ErrNoCredentials)
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_ENDPOINT_URL_SNS", "")
+	t.Setenv("AWS_ENDPOINT_URL", "")
+
+	publisher, err := sns.Publisher(context.Background())
+	checkErr(t, err, "creating SNS publisher")
+
+	assert.Equal("https://sns.eu-west-1.amazonaws.com", publisher.(*SNS).endpoint)
+
+	t.Setenv("PUBSUB_EMULATOR_HOST", "localhost:8085")
+
+	publisher, err = Sink{Type: TypePubSub, Topic: "projects/acme/topics/releases"}.Publisher(context.Background())
+	checkErr(t, err, "creating Pub/Sub publisher")
+
+	assert.Equal("http://localhost:8085", publisher.(*PubSub).endpoint)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	metadataTokenURL := cloud.GoogleMetadataTokenURL
+	t.Cleanup(func() {
+		cloud.GoogleMetadataTokenURL = metadataTokenURL
+	})
+
+	cloud.GoogleMetadataTokenURL = server.URL
+
+	t.Setenv("PUBSUB_EMULATOR_HOST", "")
+	t.Setenv(cloud.GoogleAccessTokenEnv, "")
+
+	_, err = Sink{Type: TypePubSub, Topic: "projects/acme/topics/releases"}.Publisher(context.Background())
+	assert.ErrorIs(err, cloud.ErrNoCredentials)
+}
+
+func checkErr(t *testing.T, err error, msg string) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %s", msg, err)
+	}
+}
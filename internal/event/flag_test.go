@@ -0,0 +1,32 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventFlag_String(t *testing.T) {
+	flag := Flag{{"type": "kafka", "topic": "releases"}}
+
+	var emptyFlag Flag
+
+	assert.Equal(t, "[{\"topic\":\"releases\",\"type\":\"kafka\"}]", flag.String())
+	assert.Equal(t, "[]", emptyFlag.String())
+}
+
+func TestEventFlag_Set(t *testing.T) {
+	var flag Flag
+
+	err := flag.Set("[{\"type\": \"kafka\", \"topic\": \"releases\"}]")
+	assert.NoError(t, err, "should not have errored")
+
+	err = flag.Set("{\"type\": \"kafka\"}")
+	assert.Error(t, err, "should have errored, invalid JSON string")
+}
+
+func TestEventFlag_Type(t *testing.T) {
+	var f Flag
+
+	assert.Equal(t, FlagType, f.Type())
+}
@@ -0,0 +1,56 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestKafka_Publish(t *testing.T) {
+	assert := assertion.New(t)
+
+	var records []struct {
+		Key   string `json:"key"`
+		Value Event  `json:"value"`
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /topics/releases", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Records json.RawMessage `json:"records"`
+		}
+
+		checkErr(t, json.NewDecoder(r.Body).Decode(&body), "decoding body")
+		checkErr(t, json.Unmarshal(body.Records, &records), "decoding records")
+
+		user, password, _ := r.BasicAuth()
+		assert.Equal("user:secret", user+":"+password, "credentials of the URL should be sent")
+		assert.Equal("application/vnd.kafka.json.v2+json", r.Header.Get("Content-Type"))
+
+		_, _ = w.Write([]byte(`{"offsets": [{"partition": 0, "offset": 42}]}`))
+	})
+	mux.HandleFunc("POST /topics/failing", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"offsets": [{"error_code": 50002, "error": "Kafka error: leader not available"}]}`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	proxyURL := "http://user:secret@" + server.Listener.Addr().String()
+
+	event := Event{SchemaVersion: SchemaVersion, Type: ReleaseType, Tag: "v1.0.0", Version: "1.0.0", Branch: "main"}
+
+	err := NewKafka(proxyURL, "releases").Publish(context.Background(), event)
+	checkErr(t, err, "publishing event")
+
+	assert.Len(records, 1)
+	assert.Equal("v1.0.0", records[0].Key, "records should be keyed by tag")
+	assert.Equal(event, records[0].Value)
+
+	err = NewKafka(proxyURL, "failing").Publish(context.Background(), event)
+	assert.ErrorContains(err, "leader not available")
+}
@@ -0,0 +1,100 @@
+package event
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/s0ders/go-semver-release/v6/internal/cloud"
+)
+
+// PubSubEndpoint is the endpoint of the Google Cloud Pub/Sub REST API.
+const PubSubEndpoint = "https://pubsub.googleapis.com"
+
+// PubSub publishes events to a Google Cloud Pub/Sub topic.
+type PubSub struct {
+	httpClient *http.Client
+	topic      string
+	endpoint   string
+	token      string
+}
+
+// NewPubSub returns a publisher to the Pub/Sub topic with the given full name, e.g. "projects/acme/topics/releases",
+// authenticated with the given OAuth access token if any. The endpoint defaults to PubSubEndpoint.
+func NewPubSub(topic, endpoint, token string) *PubSub {
+	if endpoint == "" {
+		endpoint = PubSubEndpoint
+	}
+
+	return &PubSub{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		topic:      topic,
+		endpoint:   strings.TrimSuffix(endpoint, "/"),
+		token:      token,
+	}
+}
+
+// newPubSubFromEnv returns a publisher to a Pub/Sub topic authenticated with a Google Cloud access token, or to the
+// emulator given by the PUBSUB_EMULATOR_HOST environment variable.
+func newPubSubFromEnv(ctx context.Context, topic string) (*PubSub, error) {
+	if host := os.Getenv("PUBSUB_EMULATOR_HOST"); host != "" {
+		if !strings.Contains(host, "://") {
+			host = "http://" + host
+		}
+
+		return NewPubSub(topic, host, ""), nil
+	}
+
+	token, err := cloud.GoogleAccessToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetching Google Cloud access token: %w", err)
+	}
+
+	return NewPubSub(topic, "", token), nil
+}
+
+// Publish publishes an event, its type and schema version being given as attributes so that subscriptions can filter
+// events.
+func (p *PubSub) Publish(ctx context.Context, event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("encoding event: %w", err)
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"messages": []map[string]any{{
+			"data": base64.StdEncoding.EncodeToString(data),
+			"attributes": map[string]string{
+				"type":           event.Type,
+				"schema-version": strconv.Itoa(event.SchemaVersion),
+			},
+		}},
+	})
+	if err != nil {
+		return fmt.Errorf("encoding message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/v1/%s:publish", p.endpoint, p.topic), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if p.token != "" {
+		req.Header.Set("Authorization", "Bearer "+p.token)
+	}
+
+	if err = send(p.httpClient, req, nil); err != nil {
+		return fmt.Errorf("publishing to Pub/Sub topic %q: %w", p.topic, err)
+	}
+
+	return nil
+}
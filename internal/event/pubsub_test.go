@@ -0,0 +1,53 @@
+package event
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestPubSub_Publish(t *testing.T) {
+	assert := assertion.New(t)
+
+	var published Event
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/projects/acme/topics/releases:publish", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Messages []struct {
+				Data       string            `json:"data"`
+				Attributes map[string]string `json:"attributes"`
+			} `json:"messages"`
+		}
+
+		checkErr(t, json.NewDecoder(r.Body).Decode(&body), "decoding body")
+
+		assert.Equal("Bearer token", r.Header.Get("Authorization"))
+		assert.Equal(map[string]string{"type": ReleaseType, "schema-version": "1"}, body.Messages[0].Attributes)
+
+		data, err := base64.StdEncoding.DecodeString(body.Messages[0].Data)
+		checkErr(t, err, "decoding data")
+
+		checkErr(t, json.Unmarshal(data, &published), "decoding event")
+
+		_, _ = w.Write([]byte(`{"messageIds": ["1"]}`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	event := Event{SchemaVersion: SchemaVersion, Type: ReleaseType, Tag: "api-v1.0.0", Version: "1.0.0", Branch: "main", Project: "api"}
+
+	err := NewPubSub("projects/acme/topics/releases", server.URL, "token").Publish(context.Background(), event)
+	checkErr(t, err, "publishing event")
+
+	assert.Equal(event, published)
+
+	err = NewPubSub("projects/acme/topics/unknown", server.URL, "token").Publish(context.Background(), event)
+	assert.ErrorContains(err, "404")
+}
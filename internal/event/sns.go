@@ -0,0 +1,94 @@
+package event
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/s0ders/go-semver-release/v6/internal/cloud"
+)
+
+// SNS publishes events to an AWS SNS topic, authenticating requests with AWS Signature Version 4.
+type SNS struct {
+	httpClient  *http.Client
+	topicARN    string
+	region      string
+	endpoint    string
+	credentials cloud.AWSCredentials
+}
+
+// NewSNS returns a publisher to the SNS topic with the given ARN, e.g. "arn:aws:sns:eu-west-1:123456789012:releases",
+// whose region is the one of the topic. The endpoint defaults to the one of SNS in that region.
+func NewSNS(topicARN, endpoint string, credentials cloud.AWSCredentials) (*SNS, error) {
+	parts := strings.Split(topicARN, ":")
+	if len(parts) != 6 || parts[0] != "arn" || parts[2] != "sns" || parts[3] == "" {
+		return nil, fmt.Errorf("%q is not the ARN of an SNS topic", topicARN)
+	}
+
+	region := parts[3]
+
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://sns.%s.amazonaws.com", region)
+	}
+
+	return &SNS{
+		httpClient:  &http.Client{Timeout: 30 * time.Second},
+		topicARN:    topicARN,
+		region:      region,
+		endpoint:    strings.TrimSuffix(endpoint, "/"),
+		credentials: credentials,
+	}, nil
+}
+
+// newSNSFromEnv returns a publisher to an SNS topic authenticated with the AWS credentials given by the environment.
+// The AWS_ENDPOINT_URL_SNS or AWS_ENDPOINT_URL environment variables override the endpoint, e.g. for LocalStack.
+func newSNSFromEnv(topicARN string) (*SNS, error) {
+	credentials, ok := cloud.AWSCredentialsFromEnv()
+	if !ok {
+		return nil, fmt.Errorf("%w: AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must be set", cloud.ErrNoCredentials)
+	}
+
+	return NewSNS(topicARN, cloud.AWSEndpoint("sns"), credentials)
+}
+
+// Publish publishes an event with the Publish action of the SNS query API. Its type is given as a message attribute
+// so that subscriptions can filter events.
+func (s *SNS) Publish(ctx context.Context, event Event) error {
+	message, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("encoding event: %w", err)
+	}
+
+	form := url.Values{}
+	form.Set("Action", "Publish")
+	form.Set("Version", "2010-03-31")
+	form.Set("TopicArn", s.topicARN)
+	form.Set("Message", string(message))
+	form.Set("MessageAttributes.entry.1.Name", "type")
+	form.Set("MessageAttributes.entry.1.Value.DataType", "String")
+	form.Set("MessageAttributes.entry.1.Value.StringValue", event.Type)
+
+	body := form.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint+"/", strings.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+
+	digest := sha256.Sum256([]byte(body))
+	s.credentials.Sign(req, s.region, "sns", hex.EncodeToString(digest[:]), time.Now())
+
+	if err = send(s.httpClient, req, nil); err != nil {
+		return fmt.Errorf("publishing to SNS topic %q: %w", s.topicARN, err)
+	}
+
+	return nil
+}
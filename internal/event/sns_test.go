@@ -0,0 +1,51 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/cloud"
+)
+
+func TestSNS_Publish(t *testing.T) {
+	assert := assertion.New(t)
+
+	var published Event
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		checkErr(t, r.ParseForm(), "parsing form")
+
+		assert.Equal("Publish", r.PostForm.Get("Action"))
+		assert.Equal("arn:aws:sns:eu-west-1:123456789012:releases", r.PostForm.Get("TopicArn"))
+		assert.Equal(ReleaseType, r.PostForm.Get("MessageAttributes.entry.1.Value.StringValue"))
+		assert.Contains(r.Header.Get("Authorization"), "/eu-west-1/sns/aws4_request")
+
+		checkErr(t, json.Unmarshal([]byte(r.PostForm.Get("Message")), &published), "decoding message")
+	}))
+	defer server.Close()
+
+	sns, err := NewSNS("arn:aws:sns:eu-west-1:123456789012:releases", server.URL, cloud.AWSCredentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret"})
+	checkErr(t, err, "creating publisher")
+
+	event := Event{SchemaVersion: SchemaVersion, Type: ReleaseType, Tag: "v1.0.0", Version: "1.0.0", Branch: "main"}
+
+	err = sns.Publish(context.Background(), event)
+	checkErr(t, err, "publishing event")
+
+	assert.Equal(event, published)
+}
+
+func TestSNS_InvalidARN(t *testing.T) {
+	assert := assertion.New(t)
+
+	_, err := NewSNS("releases", "", cloud.AWSCredentials{})
+	assert.ErrorContains(err, "is not the ARN of an SNS topic")
+
+	_, err = NewSNS("arn:aws:sqs:eu-west-1:123456789012:releases", "", cloud.AWSCredentials{})
+	assert.Error(err, "the ARN of a queue should be rejected")
+}
@@ -0,0 +1,386 @@
+package forge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMaxRetries = 3
+	defaultMaxWait    = time.Minute
+	defaultBackoff    = time.Second
+)
+
+var (
+	ErrRateLimited      = errors.New("API rate limit exceeded")
+	ErrUnexpectedStatus = errors.New("unexpected status")
+	ErrNotFound         = errors.New("resource not found")
+	ErrForeignLink      = errors.New("link outside of the API")
+)
+
+var nextLinkRegex = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// APIOptionFunc configures an API client.
+type APIOptionFunc func(c *APIClient)
+
+// WithMaxRetries sets how many times a request failing with a transient error (e.g., a 429 or 5xx status) is retried.
+// Only rate limited requests are retried for methods that are not idempotent, see Do.
+func WithMaxRetries(retries int) APIOptionFunc {
+	return func(c *APIClient) {
+		c.maxRetries = retries
+	}
+}
+
+// WithMaxWait sets the longest time the client waits for before retrying a request or for a rate limit to reset. A
+// request requiring a longer wait fails with ErrRateLimited.
+func WithMaxWait(wait time.Duration) APIOptionFunc {
+	return func(c *APIClient) {
+		c.maxWait = wait
+	}
+}
+
+// WithBaseURL sets the base URL of the API, e.g. the one of a test server.
+func WithBaseURL(baseURL string) APIOptionFunc {
+	return func(c *APIClient) {
+		c.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// APIClient is a client of a forge REST API handling what every integration needs: authentication headers, retries of
+// transient errors with exponential backoff, rate limits and pagination. Forge specific clients are built on top of it.
+type APIClient struct {
+	httpClient *http.Client
+	baseURL    string
+	header     http.Header
+	maxRetries int
+	maxWait    time.Duration
+
+	// sleep waits for the given duration unless the context is done, it is replaced in tests.
+	sleep func(ctx context.Context, d time.Duration) error
+
+	mu             sync.Mutex
+	rateLimitReset time.Time
+}
+
+// NewAPIClient returns a client of the API at the given base URL, sending the given headers (e.g., an authorization
+// header) with every request.
+func NewAPIClient(httpClient *http.Client, baseURL string, header http.Header, options ...APIOptionFunc) *APIClient {
+	c := &APIClient{
+		httpClient: httpClient,
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		header:     header,
+		maxRetries: defaultMaxRetries,
+		maxWait:    defaultMaxWait,
+		sleep:      sleep,
+	}
+
+	for _, option := range options {
+		option(c)
+	}
+
+	return c
+}
+
+// Get fetches the given path, relative to the base URL of the API, or absolute URL and decodes the JSON response into
+// v. It returns the headers of the response, e.g. to read pagination links.
+func (c *APIClient) Get(ctx context.Context, path string, v any) (http.Header, error) {
+	return c.Do(ctx, http.MethodGet, path, nil, v)
+}
+
+// Do sends a request with the given JSON body, if any, and decodes the JSON response into v, if not nil. Requests
+// failing with a transient error are retried, waiting for the delay given by the API if any. A request with a method
+// that is not idempotent (e.g., POST or PATCH) is only retried when rejected by the rate limit: a server error may
+// happen once it was processed, and retrying it could, for instance, create a release twice.
+func (c *APIClient) Do(ctx context.Context, method, path string, body, v any) (http.Header, error) {
+	if body == nil {
+		return c.do(ctx, method, path, nil, "", v)
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("encoding request body: %w", err)
+	}
+
+	return c.do(ctx, method, path, payload, "application/json", v)
+}
+
+// Upload posts the given content as is, with the given content type, e.g. a file attached to a release, and decodes the
+// JSON response into v, if not nil.
+func (c *APIClient) Upload(ctx context.Context, path, contentType string, content []byte, v any) (http.Header, error) {
+	return c.do(ctx, http.MethodPost, path, content, contentType, v)
+}
+
+func (c *APIClient) do(ctx context.Context, method, path string, payload []byte, contentType string, v any) (http.Header, error) {
+	endpoint := path
+	if !strings.HasPrefix(path, "http://") && !strings.HasPrefix(path, "https://") {
+		endpoint = c.baseURL + "/" + strings.TrimPrefix(path, "/")
+	}
+
+	for attempt := 0; ; attempt++ {
+		if err := c.waitRateLimit(ctx); err != nil {
+			return nil, err
+		}
+
+		resp, err := c.send(ctx, method, endpoint, payload, contentType)
+		if err != nil {
+			return nil, err
+		}
+
+		c.trackRateLimit(resp.Header)
+
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return resp.Header, decode(resp, endpoint, v)
+		}
+
+		_ = resp.Body.Close()
+
+		statusErr := fmt.Errorf("requesting %q: %w %q", endpoint, ErrUnexpectedStatus, resp.Status)
+		if resp.StatusCode == http.StatusNotFound {
+			statusErr = fmt.Errorf("%w: %w", ErrNotFound, statusErr)
+		}
+
+		wait, retryable := c.retryDelay(method, resp, attempt)
+		if !retryable || attempt >= c.maxRetries {
+			return nil, statusErr
+		}
+
+		if wait > c.maxWait {
+			return nil, fmt.Errorf("requesting %q: %w, retry possible in %s", endpoint, ErrRateLimited, wait.Round(time.Second))
+		}
+
+		if err = c.sleep(ctx, wait); err != nil {
+			return nil, fmt.Errorf("waiting before retrying %q: %w", endpoint, err)
+		}
+	}
+}
+
+// Paginate fetches every page of a list endpoint, following the "next" links of the Link response header used by both
+// GitHub and GitLab, and calls fn with each decoded page. A link to another scheme or host than the one of the API is
+// refused with ErrForeignLink, since following it would send the authentication headers there.
+func Paginate[T any](ctx context.Context, c *APIClient, path string, fn func(page T) error) error {
+	for path != "" {
+		var page T
+
+		header, err := c.Get(ctx, path, &page)
+		if err != nil {
+			return err
+		}
+
+		if err = fn(page); err != nil {
+			return err
+		}
+
+		path = nextLink(header)
+
+		if err = c.checkLink(path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkLink returns ErrForeignLink if the given link is an absolute URL whose scheme or host differs from the ones of
+// the base URL of the API.
+func (c *APIClient) checkLink(link string) error {
+	if !strings.HasPrefix(link, "http://") && !strings.HasPrefix(link, "https://") {
+		return nil
+	}
+
+	target, err := url.Parse(link)
+	if err != nil {
+		return fmt.Errorf("parsing link %q: %w", link, err)
+	}
+
+	base, err := url.Parse(c.baseURL)
+	if err != nil {
+		return fmt.Errorf("parsing base URL %q: %w", c.baseURL, err)
+	}
+
+	if !strings.EqualFold(target.Scheme, base.Scheme) || !strings.EqualFold(target.Host, base.Host) {
+		return fmt.Errorf("following %q: %w %q", link, ErrForeignLink, base.Scheme+"://"+base.Host)
+	}
+
+	return nil
+}
+
+func (c *APIClient) send(ctx context.Context, method, endpoint string, payload []byte, contentType string) (*http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	for key, values := range c.header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("requesting %q: %w", endpoint, err)
+	}
+
+	return resp, nil
+}
+
+// retryDelay returns how long to wait before retrying a failed request, and whether it can be retried at all. Rate
+// limited requests are retried once the delay given by the API has elapsed, server errors after an exponential backoff
+// and only for idempotent methods.
+func (c *APIClient) retryDelay(method string, resp *http.Response, attempt int) (time.Duration, bool) {
+	backoff := defaultBackoff << attempt
+
+	rateLimited := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusForbidden && rateLimitExhausted(resp.Header)
+
+	// A rate limited request was not processed, unlike a request failing otherwise which may have had side effects.
+	if !rateLimited && !idempotent(method) {
+		return 0, false
+	}
+
+	if retryAfter, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		return time.Duration(retryAfter) * time.Second, true
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		if reset, ok := rateLimitReset(resp.Header); ok {
+			return time.Until(reset), true
+		}
+
+		return backoff, true
+	case resp.StatusCode == http.StatusForbidden && rateLimitExhausted(resp.Header):
+		// GitHub reports an exhausted primary rate limit with a 403 status.
+		if reset, ok := rateLimitReset(resp.Header); ok {
+			return time.Until(reset), true
+		}
+
+		return backoff, true
+	case resp.StatusCode >= 500:
+		return backoff, true
+	default:
+		return 0, false
+	}
+}
+
+// idempotent returns whether sending a request with the given method several times has the same effect as sending it
+// once.
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// trackRateLimit records when the rate limit resets once it is exhausted, so that the next request waits for it
+// instead of failing.
+func (c *APIClient) trackRateLimit(header http.Header) {
+	if !rateLimitExhausted(header) {
+		return
+	}
+
+	reset, ok := rateLimitReset(header)
+	if !ok {
+		return
+	}
+
+	c.mu.Lock()
+	c.rateLimitReset = reset
+	c.mu.Unlock()
+}
+
+func (c *APIClient) waitRateLimit(ctx context.Context) error {
+	c.mu.Lock()
+	wait := time.Until(c.rateLimitReset)
+	c.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	if wait > c.maxWait {
+		return fmt.Errorf("%w, reset in %s", ErrRateLimited, wait.Round(time.Second))
+	}
+
+	if err := c.sleep(ctx, wait); err != nil {
+		return fmt.Errorf("waiting for rate limit reset: %w", err)
+	}
+
+	return nil
+}
+
+// rateLimitExhausted returns whether no request remains before the rate limit resets, as reported by GitHub
+// (X-RateLimit-Remaining) and GitLab (RateLimit-Remaining).
+func rateLimitExhausted(header http.Header) bool {
+	return header.Get("X-RateLimit-Remaining") == "0" || header.Get("RateLimit-Remaining") == "0"
+}
+
+// rateLimitReset returns the time at which the rate limit resets, given as a Unix timestamp by GitHub
+// (X-RateLimit-Reset) and GitLab (RateLimit-Reset).
+func rateLimitReset(header http.Header) (time.Time, bool) {
+	for _, key := range []string{"X-RateLimit-Reset", "RateLimit-Reset"} {
+		if timestamp, err := strconv.ParseInt(header.Get(key), 10, 64); err == nil {
+			return time.Unix(timestamp, 0), true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func nextLink(header http.Header) string {
+	match := nextLinkRegex.FindStringSubmatch(header.Get("Link"))
+	if match == nil {
+		return ""
+	}
+
+	return match[1]
+}
+
+func decode(resp *http.Response, endpoint string, v any) error {
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if v == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("decoding %q response: %w", endpoint, err)
+	}
+
+	return nil
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,291 @@
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+// newTestAPIClient returns a client of the given server recording the delays it waits for instead of sleeping.
+func newTestAPIClient(server *httptest.Server, waits *[]time.Duration, options ...APIOptionFunc) *APIClient {
+	client := NewAPIClient(server.Client(), server.URL, http.Header{"Authorization": []string{"Bearer token"}}, options...)
+
+	client.sleep = func(_ context.Context, d time.Duration) error {
+		*waits = append(*waits, d)
+		return nil
+	}
+
+	return client
+}
+
+func TestAPIClient_RetryServerErrors(t *testing.T) {
+	assert := assertion.New(t)
+
+	var requests int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("Bearer token", r.Header.Get("Authorization"))
+
+		requests++
+		if requests < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, `{"title": "feat: add foo"}`)
+	}))
+	defer server.Close()
+
+	var waits []time.Duration
+
+	client := newTestAPIClient(server, &waits)
+
+	var body struct {
+		Title string `json:"title"`
+	}
+
+	_, err := client.Get(context.Background(), "/pulls/1", &body)
+	checkErr(t, err, "fetching pull request")
+
+	assert.Equal("feat: add foo", body.Title)
+	assert.Equal([]time.Duration{time.Second, 2 * time.Second}, waits, "retries should back off exponentially")
+}
+
+func TestAPIClient_RetryExhausted(t *testing.T) {
+	assert := assertion.New(t)
+
+	var requests int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	var waits []time.Duration
+
+	_, err := newTestAPIClient(server, &waits, WithMaxRetries(2)).Get(context.Background(), "pulls/1", nil)
+	assert.ErrorIs(err, ErrUnexpectedStatus)
+	assert.Equal(3, requests, "a request should be retried the configured number of times")
+}
+
+func TestAPIClient_NoRetryOnClientErrors(t *testing.T) {
+	assert := assertion.New(t)
+
+	var requests int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	var waits []time.Duration
+
+	_, err := newTestAPIClient(server, &waits).Get(context.Background(), "pulls/1", nil)
+	assert.ErrorIs(err, ErrUnexpectedStatus)
+	assert.ErrorIs(err, ErrNotFound)
+	assert.Equal(1, requests)
+	assert.Empty(waits)
+}
+
+func TestAPIClient_NoRetryOfNonIdempotentRequests(t *testing.T) {
+	assert := assertion.New(t)
+
+	var requests int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		if requests == 2 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	var waits []time.Duration
+
+	client := newTestAPIClient(server, &waits)
+
+	_, err := client.Do(context.Background(), http.MethodPost, "releases", map[string]string{"tag_name": "v1.0.0"}, nil)
+	assert.ErrorIs(err, ErrUnexpectedStatus)
+	assert.Equal(1, requests, "a request that is not idempotent should not be retried on server errors")
+
+	_, err = client.Do(context.Background(), http.MethodPost, "releases", map[string]string{"tag_name": "v1.0.0"}, nil)
+	assert.ErrorIs(err, ErrUnexpectedStatus)
+	assert.Equal(3, requests, "a request that is not idempotent should be retried once rate limited")
+	assert.Equal([]time.Duration{time.Second}, waits)
+
+	_, err = client.Do(context.Background(), http.MethodPut, "releases/1", map[string]string{"tag_name": "v1.0.0"}, nil)
+	assert.ErrorIs(err, ErrUnexpectedStatus)
+	assert.Equal(7, requests, "an idempotent request should be retried on server errors")
+}
+
+func TestAPIClient_RateLimit(t *testing.T) {
+	assert := assertion.New(t)
+
+	var requests int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+
+		switch requests {
+		case 1:
+			w.Header().Set("Retry-After", "5")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			// The last request allowed before the rate limit resets.
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(30*time.Second).Unix(), 10))
+			_, _ = fmt.Fprint(w, `{}`)
+		default:
+			_, _ = fmt.Fprint(w, `{}`)
+		}
+	}))
+	defer server.Close()
+
+	var waits []time.Duration
+
+	client := newTestAPIClient(server, &waits)
+
+	_, err := client.Get(context.Background(), "pulls/1", nil)
+	checkErr(t, err, "fetching pull request")
+
+	assert.Equal([]time.Duration{5 * time.Second}, waits, "the Retry-After delay should be waited for")
+
+	_, err = client.Get(context.Background(), "pulls/2", nil)
+	checkErr(t, err, "fetching pull request")
+
+	assert.Len(waits, 2, "an exhausted rate limit should be waited for before the next request")
+	assert.InDelta(30*time.Second, waits[1], float64(2*time.Second))
+}
+
+func TestAPIClient_RateLimitTooLong(t *testing.T) {
+	assert := assertion.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	var waits []time.Duration
+
+	_, err := newTestAPIClient(server, &waits).Get(context.Background(), "pulls/1", nil)
+	assert.ErrorIs(err, ErrRateLimited)
+	assert.Empty(waits, "a rate limit resetting after the maximum wait should not be waited for")
+}
+
+func TestAPIClient_ContextCanceled(t *testing.T) {
+	assert := assertion.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	client := NewAPIClient(server.Client(), server.URL, nil)
+
+	_, err := client.Get(ctx, "pulls/1", nil)
+	assert.ErrorIs(err, context.Canceled)
+}
+
+func TestAPIClient_Do(t *testing.T) {
+	assert := assertion.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(http.MethodPost, r.Method)
+		assert.Equal("application/json", r.Header.Get("Content-Type"))
+
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		assert.Equal("v1.0.0", body["tag_name"])
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprint(w, `{"id": 1}`)
+	}))
+	defer server.Close()
+
+	var release struct {
+		ID int `json:"id"`
+	}
+
+	_, err := NewAPIClient(server.Client(), server.URL, nil).Do(context.Background(), http.MethodPost, "releases", map[string]string{"tag_name": "v1.0.0"}, &release)
+	checkErr(t, err, "creating release")
+
+	assert.Equal(1, release.ID)
+}
+
+func TestAPIClient_Paginate(t *testing.T) {
+	assert := assertion.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+
+		if page < 3 {
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s/items?page=%d>; rel="next"`, r.Host, page+1))
+		}
+
+		_, _ = fmt.Fprintf(w, `[%d, %d]`, 2*page-1, 2*page)
+	}))
+	defer server.Close()
+
+	var items []int
+
+	err := Paginate(context.Background(), NewAPIClient(server.Client(), server.URL, nil), "items", func(page []int) error {
+		items = append(items, page...)
+		return nil
+	})
+	checkErr(t, err, "paginating items")
+
+	assert.Equal([]int{1, 2, 3, 4, 5, 6}, items)
+}
+
+func TestAPIClient_Paginate_ForeignLink(t *testing.T) {
+	assert := assertion.New(t)
+
+	var leaked []string
+
+	foreign := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		leaked = append(leaked, r.Header.Get("Authorization"))
+		_, _ = fmt.Fprint(w, `[3, 4]`)
+	}))
+	defer foreign.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Link", fmt.Sprintf(`<%s/items?page=2>; rel="next"`, foreign.URL))
+		_, _ = fmt.Fprint(w, `[1, 2]`)
+	}))
+	defer server.Close()
+
+	var waits []time.Duration
+
+	var items []int
+
+	err := Paginate(context.Background(), newTestAPIClient(server, &waits), "items", func(page []int) error {
+		items = append(items, page...)
+		return nil
+	})
+	assert.ErrorIs(err, ErrForeignLink, "a next link to another host should be refused")
+
+	assert.Equal([]int{1, 2}, items)
+	assert.Empty(leaked, "the other host should not be requested")
+}
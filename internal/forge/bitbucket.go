@@ -0,0 +1,498 @@
+package forge
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	BitbucketHost   = "bitbucket.org"
+	BitbucketAPIURL = "https://api.bitbucket.org/2.0"
+
+	bitbucketPageSize = 100
+)
+
+var commitHashRegex = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// Bitbucket is a client of the Bitbucket Cloud REST API.
+type Bitbucket struct {
+	repository Repository
+	client     *APIClient
+}
+
+// NewBitbucket returns a client of the Bitbucket Cloud API for the given repository, whose owner is its workspace. The
+// token is either an access token, or a username and an app password separated by a colon.
+func NewBitbucket(repository Repository, token string, options ...APIOptionFunc) *Bitbucket {
+	header := http.Header{}
+	header.Set("Accept", "application/json")
+
+	if username, password, ok := strings.Cut(token, ":"); ok {
+		header.Set("Authorization", "Basic "+basicAuth(username, password))
+	} else if token != "" {
+		header.Set("Authorization", "Bearer "+token)
+	}
+
+	return &Bitbucket{
+		repository: repository,
+		client:     NewAPIClient(&http.Client{Timeout: 30 * time.Second}, BitbucketAPIURL, header, options...),
+	}
+}
+
+type bitbucketStatuses struct {
+	Values []struct {
+		Key   string `json:"key"`
+		Name  string `json:"name"`
+		State string `json:"state"`
+	} `json:"values"`
+	Next string `json:"next"`
+}
+
+// Checks returns the build statuses reported on a commit, named after their name or, if they have none, their key.
+func (b *Bitbucket) Checks(ctx context.Context, commit string) ([]Check, error) {
+	var checks []Check
+
+	path := b.path("commit/%s/statuses?pagelen=%d", commit, bitbucketPageSize)
+
+	for path != "" {
+		var page bitbucketStatuses
+
+		if _, err := b.client.Get(ctx, path, &page); err != nil {
+			return nil, fmt.Errorf("fetching build statuses: %w", err)
+		}
+
+		for _, status := range page.Values {
+			checks = append(checks, Check{Name: buildStatusName(status.Name, status.Key), State: buildStatusState(status.State)})
+		}
+
+		path = page.Next
+	}
+
+	return checks, nil
+}
+
+// PullRequestTitle returns the title of a pull request.
+func (b *Bitbucket) PullRequestTitle(ctx context.Context, number int) (string, error) {
+	var pullRequest struct {
+		Title string `json:"title"`
+	}
+
+	_, err := b.client.Get(ctx, b.path("pullrequests/%d", number), &pullRequest)
+	if err != nil {
+		return "", fmt.Errorf("fetching pull request #%d: %w", number, err)
+	}
+
+	return pullRequest.Title, nil
+}
+
+type bitbucketPullRequests struct {
+	Values []struct {
+		ID          int    `json:"id"`
+		Title       string `json:"title"`
+		Description string `json:"description"`
+		State       string `json:"state"`
+		MergeCommit *struct {
+			Hash string `json:"hash"`
+		} `json:"merge_commit"`
+	} `json:"values"`
+	Next string `json:"next"`
+}
+
+// CommitPullRequests returns the pull requests containing a commit or merged by it. Bitbucket Cloud abbreviates the
+// hash of merge commits, which is expanded when it prefixes the given commit.
+func (b *Bitbucket) CommitPullRequests(ctx context.Context, commit string) ([]PullRequest, error) {
+	var pullRequests []PullRequest
+
+	path := b.path("commit/%s/pullrequests?pagelen=%d", commit, bitbucketPageSize)
+
+	for path != "" {
+		var page bitbucketPullRequests
+
+		if _, err := b.client.Get(ctx, path, &page); err != nil {
+			return nil, fmt.Errorf("fetching commit %s pull requests: %w", commit, err)
+		}
+
+		for _, pr := range page.Values {
+			pullRequest := PullRequest{Number: pr.ID, Title: pr.Title, Body: pr.Description, Merged: pr.State == "MERGED"}
+
+			if pr.MergeCommit != nil {
+				pullRequest.MergeCommit = pr.MergeCommit.Hash
+				if pullRequest.MergeCommit != "" && strings.HasPrefix(commit, pullRequest.MergeCommit) {
+					pullRequest.MergeCommit = commit
+				}
+			}
+
+			pullRequests = append(pullRequests, pullRequest)
+		}
+
+		path = page.Next
+	}
+
+	return pullRequests, nil
+}
+
+// CommentPullRequest posts a comment on a pull request.
+func (b *Bitbucket) CommentPullRequest(ctx context.Context, number int, body string) error {
+	comment := map[string]any{"content": map[string]string{"raw": body}}
+
+	_, err := b.client.Do(ctx, http.MethodPost, b.path("pullrequests/%d/comments", number), comment, nil)
+	if err != nil {
+		return fmt.Errorf("commenting pull request #%d: %w", number, err)
+	}
+
+	return nil
+}
+
+// CreateCheckRun is not supported, Bitbucket having no check runs.
+func (b *Bitbucket) CreateCheckRun(_ context.Context, _ CheckRun) error {
+	return fmt.Errorf("creating check runs: %w", ErrUnsupported)
+}
+
+// CreateRelease creates the tag of a release, since Bitbucket has no releases: the tag is annotated with the name and
+// body of the release. A tag that already exists is left untouched.
+func (b *Bitbucket) CreateRelease(ctx context.Context, release Release) error {
+	if len(release.Assets) > 0 {
+		return fmt.Errorf("attaching release assets: %w", ErrUnsupported)
+	}
+
+	_, err := b.client.Get(ctx, b.path("refs/tags/%s", url.PathEscape(release.Tag)), nil)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("fetching tag %q: %w", release.Tag, err)
+	}
+
+	hash := release.Target
+
+	if !commitHashRegex.MatchString(hash) {
+		var branch struct {
+			Target struct {
+				Hash string `json:"hash"`
+			} `json:"target"`
+		}
+
+		if _, err = b.client.Get(ctx, b.path("refs/branches/%s", url.PathEscape(release.Target)), &branch); err != nil {
+			return fmt.Errorf("fetching branch %q: %w", release.Target, err)
+		}
+
+		hash = branch.Target.Hash
+	}
+
+	tag := map[string]any{
+		"name":    release.Tag,
+		"target":  map[string]string{"hash": hash},
+		"message": releaseMessage(release),
+	}
+
+	if _, err = b.client.Do(ctx, http.MethodPost, b.path("refs/tags"), tag, nil); err != nil {
+		return fmt.Errorf("creating tag %q: %w", release.Tag, err)
+	}
+
+	return nil
+}
+
+// DeleteRelease does nothing since Bitbucket has no releases, only tags.
+func (b *Bitbucket) DeleteRelease(_ context.Context, _ string) error {
+	return nil
+}
+
+// CreateDeployment is not supported, Bitbucket deployments being created by Bitbucket Pipelines only.
+func (b *Bitbucket) CreateDeployment(_ context.Context, _ Deployment) error {
+	return fmt.Errorf("creating deployments: %w", ErrUnsupported)
+}
+
+// UserHandle returns an empty string since Bitbucket Cloud does not expose the emails of its users.
+func (b *Bitbucket) UserHandle(_ context.Context, _ string) (string, error) {
+	return "", nil
+}
+
+// path returns the path of an endpoint of the repository.
+func (b *Bitbucket) path(format string, a ...any) string {
+	return fmt.Sprintf("repositories/%s/%s/", url.PathEscape(b.repository.Owner), url.PathEscape(b.repository.Name)) + fmt.Sprintf(format, a...)
+}
+
+// BitbucketServer is a client of the REST API of a Bitbucket Server or Data Center instance.
+type BitbucketServer struct {
+	repository Repository
+	client     *APIClient
+}
+
+// NewBitbucketServer returns a client of the API of the Bitbucket Server instance at the given URL for the given
+// repository, whose owner is its project key, authenticated with the given HTTP access token if any.
+func NewBitbucketServer(repository Repository, serverURL, token string, options ...APIOptionFunc) *BitbucketServer {
+	header := http.Header{}
+	header.Set("Accept", "application/json")
+
+	if token != "" {
+		header.Set("Authorization", "Bearer "+token)
+	}
+
+	return &BitbucketServer{
+		repository: repository,
+		client:     NewAPIClient(&http.Client{Timeout: 30 * time.Second}, strings.TrimSuffix(serverURL, "/")+"/rest", header, options...),
+	}
+}
+
+type bitbucketServerPage[T any] struct {
+	Values        []T  `json:"values"`
+	IsLastPage    bool `json:"isLastPage"`
+	NextPageStart int  `json:"nextPageStart"`
+}
+
+type bitbucketServerStatus struct {
+	Key   string `json:"key"`
+	Name  string `json:"name"`
+	State string `json:"state"`
+}
+
+// Checks returns the build statuses reported on a commit, named after their name or, if they have none, their key.
+func (b *BitbucketServer) Checks(ctx context.Context, commit string) ([]Check, error) {
+	var checks []Check
+
+	err := paginateBitbucketServer(ctx, b.client, fmt.Sprintf("build-status/1.0/commits/%s", commit), func(status bitbucketServerStatus) {
+		checks = append(checks, Check{Name: buildStatusName(status.Name, status.Key), State: buildStatusState(status.State)})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetching build statuses: %w", err)
+	}
+
+	return checks, nil
+}
+
+// PullRequestTitle returns the title of a pull request.
+func (b *BitbucketServer) PullRequestTitle(ctx context.Context, number int) (string, error) {
+	var pullRequest struct {
+		Title string `json:"title"`
+	}
+
+	_, err := b.client.Get(ctx, b.path("pull-requests/%d", number), &pullRequest)
+	if err != nil {
+		return "", fmt.Errorf("fetching pull request #%d: %w", number, err)
+	}
+
+	return pullRequest.Title, nil
+}
+
+type bitbucketServerPullRequest struct {
+	ID          int    `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	State       string `json:"state"`
+	Properties  struct {
+		MergeCommit struct {
+			ID string `json:"id"`
+		} `json:"mergeCommit"`
+	} `json:"properties"`
+}
+
+// CommitPullRequests returns the pull requests containing a commit or merged by it.
+func (b *BitbucketServer) CommitPullRequests(ctx context.Context, commit string) ([]PullRequest, error) {
+	var pullRequests []PullRequest
+
+	err := paginateBitbucketServer(ctx, b.client, b.path("commits/%s/pull-requests", commit), func(pr bitbucketServerPullRequest) {
+		pullRequests = append(pullRequests, PullRequest{
+			Number:      pr.ID,
+			Title:       pr.Title,
+			Body:        pr.Description,
+			Merged:      pr.State == "MERGED",
+			MergeCommit: pr.Properties.MergeCommit.ID,
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetching commit %s pull requests: %w", commit, err)
+	}
+
+	return pullRequests, nil
+}
+
+// CommentPullRequest posts a comment on a pull request.
+func (b *BitbucketServer) CommentPullRequest(ctx context.Context, number int, body string) error {
+	_, err := b.client.Do(ctx, http.MethodPost, b.path("pull-requests/%d/comments", number), map[string]string{"text": body}, nil)
+	if err != nil {
+		return fmt.Errorf("commenting pull request #%d: %w", number, err)
+	}
+
+	return nil
+}
+
+// CreateCheckRun is not supported, Bitbucket Server having no check runs.
+func (b *BitbucketServer) CreateCheckRun(_ context.Context, _ CheckRun) error {
+	return fmt.Errorf("creating check runs: %w", ErrUnsupported)
+}
+
+// CreateRelease creates the tag of a release, since Bitbucket has no releases: the tag is annotated with the name and
+// body of the release. A tag that already exists is left untouched.
+func (b *BitbucketServer) CreateRelease(ctx context.Context, release Release) error {
+	if len(release.Assets) > 0 {
+		return fmt.Errorf("attaching release assets: %w", ErrUnsupported)
+	}
+
+	_, err := b.client.Get(ctx, b.path("tags/%s", url.PathEscape(release.Tag)), nil)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("fetching tag %q: %w", release.Tag, err)
+	}
+
+	tag := map[string]string{
+		"name":       release.Tag,
+		"startPoint": release.Target,
+		"message":    releaseMessage(release),
+	}
+
+	if _, err = b.client.Do(ctx, http.MethodPost, b.path("tags"), tag, nil); err != nil {
+		return fmt.Errorf("creating tag %q: %w", release.Tag, err)
+	}
+
+	return nil
+}
+
+// DeleteRelease does nothing since Bitbucket has no releases, only tags.
+func (b *BitbucketServer) DeleteRelease(_ context.Context, _ string) error {
+	return nil
+}
+
+// CreateDeployment is not supported, Bitbucket Server having no deployments API.
+func (b *BitbucketServer) CreateDeployment(_ context.Context, _ Deployment) error {
+	return fmt.Errorf("creating deployments: %w", ErrUnsupported)
+}
+
+type bitbucketServerUser struct {
+	Name         string `json:"name"`
+	EmailAddress string `json:"emailAddress"`
+}
+
+// UserHandle returns the username of the Bitbucket Server user with the given email.
+func (b *BitbucketServer) UserHandle(ctx context.Context, email string) (string, error) {
+	var handle string
+
+	err := paginateBitbucketServer(ctx, b.client, "api/1.0/users?filter="+url.QueryEscape(email), func(user bitbucketServerUser) {
+		if handle == "" && strings.EqualFold(user.EmailAddress, email) {
+			handle = user.Name
+		}
+	})
+	if err != nil {
+		return "", fmt.Errorf("searching user with email %q: %w", email, err)
+	}
+
+	return handle, nil
+}
+
+// path returns the path of an endpoint of the repository.
+func (b *BitbucketServer) path(format string, a ...any) string {
+	return fmt.Sprintf("api/1.0/projects/%s/repos/%s/", url.PathEscape(b.repository.Owner), url.PathEscape(b.repository.Name)) + fmt.Sprintf(format, a...)
+}
+
+// paginateBitbucketServer fetches every page of a list endpoint of the Bitbucket Server API, which are paginated by a
+// "start" query parameter instead of links, and calls fn with each value.
+func paginateBitbucketServer[T any](ctx context.Context, c *APIClient, path string, fn func(value T)) error {
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+
+	for start := 0; ; {
+		var page bitbucketServerPage[T]
+
+		if _, err := c.Get(ctx, fmt.Sprintf("%s%slimit=%d&start=%d", path, separator, bitbucketPageSize, start), &page); err != nil {
+			return err
+		}
+
+		for _, value := range page.Values {
+			fn(value)
+		}
+
+		if page.IsLastPage || len(page.Values) == 0 {
+			return nil
+		}
+
+		start = page.NextPageStart
+	}
+}
+
+// parseBitbucketServerURL extracts the project key and slug of a repository hosted by a Bitbucket Server instance from
+// its HTTP(S) clone URL (e.g., "https://git.acme.com/scm/proj/app.git") or SSH URL (e.g.,
+// "ssh://git@git.acme.com:7999/proj/app.git"), along with the URL of the instance. The URL of an instance cloned over
+// SSH is assumed to be served over HTTPS on the same host.
+func parseBitbucketServerURL(repositoryURL string) (Repository, string, error) {
+	var repository Repository
+
+	host := repositoryHost(repositoryURL)
+	if host == "" {
+		return repository, "", fmt.Errorf("%q is not a repository URL", repositoryURL)
+	}
+
+	serverURL := "https://" + host
+	path := repositoryURL
+
+	if u, err := url.Parse(repositoryURL); err == nil && u.Host != "" {
+		path = u.Path
+
+		if u.Scheme == "http" || u.Scheme == "https" {
+			contextPath, repositoryPath, ok := strings.Cut(u.Path, "/scm/")
+			if !ok {
+				return repository, "", fmt.Errorf("%q is not a Bitbucket Server clone URL", repositoryURL)
+			}
+
+			serverURL = u.Scheme + "://" + u.Host + contextPath
+			path = repositoryPath
+		}
+	} else {
+		path = path[strings.Index(path, ":")+1:]
+	}
+
+	parts := strings.Split(strings.Trim(strings.TrimSuffix(path, ".git"), "/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return repository, "", fmt.Errorf("%q does not designate a project and a repository", repositoryURL)
+	}
+
+	repository.Host = host
+	repository.Owner = parts[0]
+	repository.Name = parts[1]
+
+	return repository, serverURL, nil
+}
+
+// buildStatusName returns the name of a Bitbucket build status, or its key if it has no name.
+func buildStatusName(name, key string) string {
+	if name != "" {
+		return name
+	}
+
+	return key
+}
+
+// buildStatusState maps the state of a Bitbucket build status to a check state. Stopped and cancelled builds are
+// failures.
+func buildStatusState(state string) CheckState {
+	switch state {
+	case "SUCCESSFUL":
+		return CheckSuccess
+	case "INPROGRESS":
+		return CheckPending
+	default:
+		return CheckFailure
+	}
+}
+
+// basicAuth returns the credentials of the HTTP basic authentication scheme.
+func basicAuth(username, password string) string {
+	return base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+}
+
+// releaseMessage returns the message of the tag standing for a release on forges without releases.
+func releaseMessage(release Release) string {
+	if release.Body == "" {
+		return release.Name
+	}
+
+	return release.Name + "\n\n" + release.Body
+}
@@ -0,0 +1,329 @@
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+const bitbucketHash = "3f1c2a9d0be4e6a2c1b7f5e8d9a0b1c2d3e4f5a6"
+
+func TestBitbucket_Checks(t *testing.T) {
+	assert := assertion.New(t)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/repositories/team/app/commit/abc/statuses", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("Bearer token", r.Header.Get("Authorization"))
+
+		if r.URL.Query().Get("page") == "2" {
+			_, _ = fmt.Fprint(w, `{"values": [{"key": "e2e", "state": "INPROGRESS"}]}`)
+			return
+		}
+
+		_, _ = fmt.Fprintf(w, `{"values": [
+			{"key": "build-1", "name": "build", "state": "SUCCESSFUL"},
+			{"key": "test-1", "name": "test", "state": "FAILED"},
+			{"key": "lint-1", "name": "lint", "state": "STOPPED"}
+		], "next": "http://%s%s?page=2"}`, r.Host, r.URL.Path)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewBitbucket(Repository{Owner: "team", Name: "app"}, "token", WithBaseURL(server.URL))
+
+	checks, err := client.Checks(context.Background(), "abc")
+	checkErr(t, err, "fetching checks")
+
+	expected := []Check{
+		{Name: "build", State: CheckSuccess},
+		{Name: "test", State: CheckFailure},
+		{Name: "lint", State: CheckFailure},
+		{Name: "e2e", State: CheckPending},
+	}
+
+	assert.Equal(expected, checks)
+}
+
+func TestBitbucket_PullRequests(t *testing.T) {
+	assert := assertion.New(t)
+
+	var comment map[string]map[string]string
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /repositories/team/app/pullrequests/42", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("Basic dXNlcjphcHAtcGFzc3dvcmQ=", r.Header.Get("Authorization"), "app passwords should use basic authentication")
+
+		_, _ = fmt.Fprint(w, `{"id": 42, "title": "feat: add foo"}`)
+	})
+
+	mux.HandleFunc("POST /repositories/team/app/pullrequests/42/comments", func(w http.ResponseWriter, r *http.Request) {
+		checkErr(t, json.NewDecoder(r.Body).Decode(&comment), "decoding comment")
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewBitbucket(Repository{Owner: "team", Name: "app"}, "user:app-password", WithBaseURL(server.URL))
+
+	title, err := client.PullRequestTitle(context.Background(), 42)
+	checkErr(t, err, "fetching pull request title")
+
+	assert.Equal("feat: add foo", title)
+
+	err = client.CommentPullRequest(context.Background(), 42, "## Release summary")
+	checkErr(t, err, "commenting pull request")
+
+	assert.Equal("## Release summary", comment["content"]["raw"])
+}
+
+func TestBitbucket_CreateRelease(t *testing.T) {
+	assert := assertion.New(t)
+
+	var tags []map[string]any
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /repositories/team/app/refs/tags/existing", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprint(w, `{"name": "existing"}`)
+	})
+
+	mux.HandleFunc("GET /repositories/team/app/refs/tags/release-1", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	mux.HandleFunc("GET /repositories/team/app/refs/branches/main", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"name": "main", "target": {"hash": "%s"}}`, bitbucketHash)
+	})
+
+	mux.HandleFunc("POST /repositories/team/app/refs/tags", func(w http.ResponseWriter, r *http.Request) {
+		var tag map[string]any
+		checkErr(t, json.NewDecoder(r.Body).Decode(&tag), "decoding tag")
+
+		tags = append(tags, tag)
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewBitbucket(Repository{Owner: "team", Name: "app"}, "token", WithBaseURL(server.URL))
+
+	err := client.CreateRelease(context.Background(), Release{Tag: "release-1", Target: "main", Name: "Release 1", Body: "Notes"})
+	checkErr(t, err, "creating release")
+
+	err = client.CreateRelease(context.Background(), Release{Tag: "existing", Target: "main", Name: "Existing"})
+	checkErr(t, err, "creating release")
+
+	assert.Equal([]map[string]any{{
+		"name":    "release-1",
+		"target":  map[string]any{"hash": bitbucketHash},
+		"message": "Release 1\n\nNotes",
+	}}, tags, "only missing tags should be created, on the head of their branch")
+
+	assert.NoError(client.DeleteRelease(context.Background(), "release-1"))
+	assert.ErrorIs(client.CreateDeployment(context.Background(), Deployment{}), ErrUnsupported)
+	assert.ErrorIs(client.CreateCheckRun(context.Background(), CheckRun{}), ErrUnsupported)
+}
+
+func TestBitbucketServer_Checks(t *testing.T) {
+	assert := assertion.New(t)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/bitbucket/rest/build-status/1.0/commits/abc", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("Bearer token", r.Header.Get("Authorization"))
+
+		if r.URL.Query().Get("start") == "2" {
+			_, _ = fmt.Fprint(w, `{"values": [{"key": "e2e", "state": "INPROGRESS"}], "isLastPage": true}`)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, `{"values": [
+			{"key": "build-1", "name": "build", "state": "SUCCESSFUL"},
+			{"key": "test-1", "name": "test", "state": "FAILED"}
+		], "isLastPage": false, "nextPageStart": 2}`)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewBitbucketServer(Repository{Owner: "PROJ", Name: "app"}, server.URL+"/bitbucket/", "token")
+
+	checks, err := client.Checks(context.Background(), "abc")
+	checkErr(t, err, "fetching checks")
+
+	expected := []Check{
+		{Name: "build", State: CheckSuccess},
+		{Name: "test", State: CheckFailure},
+		{Name: "e2e", State: CheckPending},
+	}
+
+	assert.Equal(expected, checks)
+}
+
+func TestBitbucketServer_API(t *testing.T) {
+	assert := assertion.New(t)
+
+	var (
+		comment map[string]string
+		tag     map[string]string
+	)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /rest/api/1.0/projects/PROJ/repos/app/pull-requests/42", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprint(w, `{"id": 42, "title": "feat: add foo"}`)
+	})
+
+	mux.HandleFunc("POST /rest/api/1.0/projects/PROJ/repos/app/pull-requests/42/comments", func(w http.ResponseWriter, r *http.Request) {
+		checkErr(t, json.NewDecoder(r.Body).Decode(&comment), "decoding comment")
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	mux.HandleFunc("GET /rest/api/1.0/projects/PROJ/repos/app/tags/release-1", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	mux.HandleFunc("POST /rest/api/1.0/projects/PROJ/repos/app/tags", func(w http.ResponseWriter, r *http.Request) {
+		checkErr(t, json.NewDecoder(r.Body).Decode(&tag), "decoding tag")
+		w.WriteHeader(http.StatusOK)
+	})
+
+	mux.HandleFunc("GET /rest/api/1.0/users", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("jane@acme.com", r.URL.Query().Get("filter"))
+
+		_, _ = fmt.Fprint(w, `{"values": [
+			{"name": "janet", "emailAddress": "janet@acme.com"},
+			{"name": "jane", "emailAddress": "Jane@acme.com"}
+		], "isLastPage": true}`)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewBitbucketServer(Repository{Owner: "PROJ", Name: "app"}, server.URL, "token")
+
+	title, err := client.PullRequestTitle(context.Background(), 42)
+	checkErr(t, err, "fetching pull request title")
+
+	assert.Equal("feat: add foo", title)
+
+	err = client.CommentPullRequest(context.Background(), 42, "## Release summary")
+	checkErr(t, err, "commenting pull request")
+
+	assert.Equal(map[string]string{"text": "## Release summary"}, comment)
+
+	err = client.CreateRelease(context.Background(), Release{Tag: "release-1", Target: "main", Name: "Release 1"})
+	checkErr(t, err, "creating release")
+
+	assert.Equal(map[string]string{"name": "release-1", "startPoint": "main", "message": "Release 1"}, tag)
+
+	handle, err := client.UserHandle(context.Background(), "jane@acme.com")
+	checkErr(t, err, "fetching user handle")
+
+	assert.Equal("jane", handle, "users should be matched by their exact email")
+}
+
+func TestBitbucketServer_ParseURL(t *testing.T) {
+	assert := assertion.New(t)
+
+	type test struct {
+		url        string
+		repository Repository
+		serverURL  string
+	}
+
+	matrix := []test{
+		{url: "https://git.acme.com/scm/proj/app.git", repository: Repository{Host: "git.acme.com", Owner: "proj", Name: "app"}, serverURL: "https://git.acme.com"},
+		{url: "http://git.acme.com:7990/bitbucket/scm/proj/app.git", repository: Repository{Host: "git.acme.com", Owner: "proj", Name: "app"}, serverURL: "http://git.acme.com:7990/bitbucket"},
+		{url: "ssh://git@git.acme.com:7999/proj/app.git", repository: Repository{Host: "git.acme.com", Owner: "proj", Name: "app"}, serverURL: "https://git.acme.com"},
+		{url: "git@git.acme.com:proj/app.git", repository: Repository{Host: "git.acme.com", Owner: "proj", Name: "app"}, serverURL: "https://git.acme.com"},
+	}
+
+	for _, tc := range matrix {
+		repository, serverURL, err := parseBitbucketServerURL(tc.url)
+		checkErr(t, err, "parsing repository URL")
+
+		assert.Equal(tc.repository, repository, tc.url)
+		assert.Equal(tc.serverURL, serverURL, tc.url)
+	}
+
+	for _, url := range []string{"/tmp/repository", "https://git.acme.com/proj/app.git", "ssh://git@git.acme.com/app.git"} {
+		_, _, err := parseBitbucketServerURL(url)
+		assert.Error(err, url)
+	}
+}
+
+func TestBitbucket_CommitPullRequests(t *testing.T) {
+	assert := assertion.New(t)
+
+	commit := "0123456789abcdef0123456789abcdef01234567"
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /repositories/team/app/commit/"+commit+"/pullrequests", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprint(w, `{"values": [
+			{"id": 42, "title": "feat: add foo", "description": "Adds foo.", "state": "MERGED", "merge_commit": {"hash": "0123456789ab"}},
+			{"id": 43, "title": "wip", "description": "", "state": "OPEN", "merge_commit": null}
+		]}`)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewBitbucket(Repository{Owner: "team", Name: "app"}, "token", WithBaseURL(server.URL))
+
+	pullRequests, err := client.CommitPullRequests(context.Background(), commit)
+	checkErr(t, err, "fetching commit pull requests")
+
+	assert.Equal([]PullRequest{
+		{Number: 42, Title: "feat: add foo", Body: "Adds foo.", Merged: true, MergeCommit: commit},
+		{Number: 43, Title: "wip"},
+	}, pullRequests, "abbreviated merge commit hash should be expanded")
+}
+
+func TestBitbucketServer_CommitPullRequests(t *testing.T) {
+	assert := assertion.New(t)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /rest/api/1.0/projects/PROJ/repos/app/commits/abc/pull-requests", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprint(w, `{"values": [
+			{"id": 42, "title": "feat: add foo", "description": "Adds foo.", "state": "MERGED", "properties": {"mergeCommit": {"id": "abc"}}}
+		], "isLastPage": true}`)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewBitbucketServer(Repository{Owner: "PROJ", Name: "app"}, server.URL, "token")
+
+	pullRequests, err := client.CommitPullRequests(context.Background(), "abc")
+	checkErr(t, err, "fetching commit pull requests")
+
+	assert.Equal([]PullRequest{{Number: 42, Title: "feat: add foo", Body: "Adds foo.", Merged: true, MergeCommit: "abc"}}, pullRequests)
+}
+
+func TestBitbucket_CreateReleaseAssets(t *testing.T) {
+	assets := []Asset{{Name: "provenance.intoto.jsonl", Content: []byte("{}\n")}}
+
+	err := NewBitbucket(Repository{Owner: "team", Name: "app"}, "token").CreateRelease(context.Background(), Release{Tag: "v1.0.0", Assets: assets})
+	assertion.ErrorIs(t, err, ErrUnsupported)
+
+	err = NewBitbucketServer(Repository{Owner: "PROJ", Name: "app"}, "https://git.acme.com", "token").CreateRelease(context.Background(), Release{Tag: "v1.0.0", Assets: assets})
+	assertion.ErrorIs(t, err, ErrUnsupported)
+}
+
+func TestBitbucketServer_CreateCheckRun(t *testing.T) {
+	err := NewBitbucketServer(Repository{Owner: "PROJ", Name: "app"}, "https://git.acme.com", "token").CreateCheckRun(context.Background(), CheckRun{Name: "release"})
+	assertion.ErrorIs(t, err, ErrUnsupported)
+}
@@ -0,0 +1,294 @@
+// Package forge provides clients for the APIs of the forges hosting the repositories to release (e.g., GitHub).
+package forge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrUnknownForge    = errors.New("unknown forge")
+	ErrUnsupported     = errors.New("not supported by the forge")
+	ErrChecksNotPassed = errors.New("required checks did not pass")
+)
+
+// Kinds of forges, as given by the --forge flag.
+const (
+	KindGitHub          = "github"
+	KindBitbucket       = "bitbucket"
+	KindBitbucketServer = "bitbucket-server"
+	KindGitea           = "gitea"
+)
+
+// Kinds are the supported kinds of forges.
+var Kinds = []string{KindGitHub, KindBitbucket, KindBitbucketServer, KindGitea}
+
+// CheckState is the state of a CI check reported on a commit.
+type CheckState string
+
+const (
+	CheckSuccess CheckState = "success"
+	CheckPending CheckState = "pending"
+	CheckFailure CheckState = "failure"
+)
+
+// Check is the result of a CI check (e.g., a check run or a commit status) reported on a commit.
+type Check struct {
+	Name  string
+	State CheckState
+}
+
+// Client queries the API of the forge hosting a repository.
+type Client interface {
+	// Checks returns the CI checks reported on the commit with the given hash.
+	Checks(ctx context.Context, commit string) ([]Check, error)
+	// PullRequestTitle returns the title of the pull request with the given number.
+	PullRequestTitle(ctx context.Context, number int) (string, error)
+	// CommitPullRequests returns the pull requests associated with the commit with the given hash, either containing it
+	// or merged by it.
+	CommitPullRequests(ctx context.Context, commit string) ([]PullRequest, error)
+	// CommentPullRequest posts a comment with the given Markdown body on the pull request with the given number.
+	CommentPullRequest(ctx context.Context, number int, body string) error
+	// CreateCheckRun reports a completed check run on a commit.
+	CreateCheckRun(ctx context.Context, run CheckRun) error
+	// CreateRelease publishes a release, creating its tag on the target if it does not exist.
+	CreateRelease(ctx context.Context, release Release) error
+	// DeleteRelease deletes the release of the tag with the given name, if any, but not the tag itself.
+	DeleteRelease(ctx context.Context, tag string) error
+	// CreateDeployment records the deployment of a ref to an environment.
+	CreateDeployment(ctx context.Context, deployment Deployment) error
+	// UserHandle returns the name of the account of the user with the given email, or an empty string if no account
+	// matches it.
+	UserHandle(ctx context.Context, email string) (string, error)
+}
+
+// CheckRun is an informational check run reported on a commit, such as the release decision of a pull request shown
+// among its checks. It never fails, so that it does not block merging.
+type CheckRun struct {
+	Name string
+	// Commit is the hash of the commit the check run is reported on.
+	Commit string
+	Title  string
+	// Summary is the Markdown body of the check run.
+	Summary string
+}
+
+// PullRequest is a pull request associated with a commit.
+type PullRequest struct {
+	Number int
+	Title  string
+	Body   string
+	// Merged reports whether the pull request is merged, MergeCommit being the hash of the commit that merged it, such
+	// as its merge or squash commit.
+	Merged      bool
+	MergeCommit string
+}
+
+// Deployment is a deployment of a ref to an environment recorded on a forge, such as a GitHub deployment.
+type Deployment struct {
+	// Ref is the tag, branch or commit hash deployed.
+	Ref         string
+	Environment string
+	Description string
+}
+
+// Release is a release published on a forge, such as a GitHub release.
+type Release struct {
+	// Tag is the name of the tag of the release.
+	Tag string
+	// Target is the branch or commit hash the tag is created on if it does not exist.
+	Target string
+	Name   string
+	Body   string
+	// Assets are the files attached to the release, which Bitbucket does not support.
+	Assets []Asset
+}
+
+// Asset is a file attached to a release.
+type Asset struct {
+	Name        string
+	ContentType string
+	Content     []byte
+}
+
+// assetContentType returns the content type of an asset, arbitrary binary data unless given.
+func assetContentType(asset Asset) string {
+	if asset.ContentType == "" {
+		return "application/octet-stream"
+	}
+
+	return asset.ContentType
+}
+
+// Repository identifies a repository hosted by a forge.
+type Repository struct {
+	Host  string
+	Owner string
+	Name  string
+}
+
+// New returns a client for the forge of the given kind hosting the repository with the given URL. If no kind is given,
+// it is inferred from the repository URL by Detect. The API URL is the one of a GitHub Enterprise Server instance, it
+// is ignored by the other forges.
+func New(repositoryURL, token, kind, apiURL string) (Client, error) {
+	if kind == "" {
+		kind = Detect(repositoryURL, apiURL)
+	}
+
+	switch kind {
+	case KindBitbucketServer:
+		repository, serverURL, err := parseBitbucketServerURL(repositoryURL)
+		if err != nil {
+			return nil, fmt.Errorf("parsing repository URL: %w", err)
+		}
+
+		return NewBitbucketServer(repository, serverURL, token), nil
+	case KindGitea:
+		repository, serverURL, err := parseGiteaURL(repositoryURL)
+		if err != nil {
+			return nil, fmt.Errorf("parsing repository URL: %w", err)
+		}
+
+		return NewGitea(repository, serverURL, token), nil
+	}
+
+	repository, err := ParseRepositoryURL(repositoryURL)
+	if err != nil {
+		return nil, fmt.Errorf("parsing repository URL: %w", err)
+	}
+
+	switch kind {
+	case KindGitHub:
+		if apiURL != "" {
+			return NewGitHub(repository, token, WithAPIURL(apiURL)), nil
+		}
+
+		return NewGitHub(repository, token), nil
+	case KindBitbucket:
+		return NewBitbucket(repository, token), nil
+	case "":
+		return nil, fmt.Errorf("%w: %q", ErrUnknownForge, repository.Host)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownForge, kind)
+	}
+}
+
+// Detect returns the kind of the forge hosting the repository with the given URL, or an empty string if it cannot be
+// inferred. GitHub, Bitbucket Cloud and Codeberg are recognized by their host, Bitbucket Server and Data Center
+// instances by the "/scm/" path of their HTTP(S) clone URLs or a host containing "bitbucket", and Gitea and Forgejo
+// instances by a host containing "gitea" or "forgejo". Other repositories are assumed to be hosted
+// by a GitHub Enterprise Server instance if its API URL is given.
+func Detect(repositoryURL, apiURL string) string {
+	host := strings.ToLower(repositoryHost(repositoryURL))
+
+	switch {
+	case host == GitHubHost:
+		return KindGitHub
+	case host == BitbucketHost:
+		return KindBitbucket
+	case host != "" && (strings.Contains(repositoryURL, "/scm/") || strings.Contains(host, "bitbucket")):
+		return KindBitbucketServer
+	case host == CodebergHost || strings.Contains(host, "gitea") || strings.Contains(host, "forgejo"):
+		return KindGitea
+	case host != "" && apiURL != "":
+		return KindGitHub
+	default:
+		return ""
+	}
+}
+
+// ValidateKind returns an error wrapping ErrUnknownForge if the given kind of forge is not supported. An empty kind,
+// inferring the forge from the repository URL, is valid.
+func ValidateKind(kind string) error {
+	if kind != "" && !slices.Contains(Kinds, kind) {
+		return fmt.Errorf("%w: %q, must be one of %s", ErrUnknownForge, kind, strings.Join(Kinds, ", "))
+	}
+
+	return nil
+}
+
+// repositoryHost returns the host of a repository given by its HTTP(S) or SSH URL, or an empty string if it is not
+// a URL, e.g. a local path.
+func repositoryHost(repositoryURL string) string {
+	if u, err := url.Parse(repositoryURL); err == nil && u.Host != "" {
+		return u.Hostname()
+	}
+
+	if at, colon := strings.Index(repositoryURL, "@"), strings.Index(repositoryURL, ":"); at >= 0 && colon > at {
+		return repositoryURL[at+1 : colon]
+	}
+
+	return ""
+}
+
+// ParseRepositoryURL extracts the host, owner and name of a repository from its HTTP(S) or SSH URL, such as
+// "https://github.com/owner/name.git" or "git@github.com:owner/name.git".
+func ParseRepositoryURL(repositoryURL string) (Repository, error) {
+	var repository Repository
+
+	var host, path string
+
+	if u, err := url.Parse(repositoryURL); err == nil && u.Host != "" {
+		host, path = u.Hostname(), u.Path
+	} else if at, colon := strings.Index(repositoryURL, "@"), strings.Index(repositoryURL, ":"); at >= 0 && colon > at {
+		host, path = repositoryURL[at+1:colon], repositoryURL[colon+1:]
+	} else {
+		return repository, fmt.Errorf("%q is not a repository URL", repositoryURL)
+	}
+
+	parts := strings.Split(strings.Trim(strings.TrimSuffix(path, ".git"), "/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return repository, fmt.Errorf("%q does not designate an owner and a repository", repositoryURL)
+	}
+
+	repository.Host = host
+	repository.Owner = parts[0]
+	repository.Name = parts[1]
+
+	return repository, nil
+}
+
+// RequireChecks returns an error wrapping ErrChecksNotPassed if any of the required checks is not successful. A
+// required check that was not reported yet is considered pending. When a check is reported several times (e.g., a
+// re-run), it is successful if any of its reports is.
+func RequireChecks(checks []Check, required []string) error {
+	states := make(map[string][]CheckState)
+
+	for _, check := range checks {
+		states[check.Name] = append(states[check.Name], check.State)
+	}
+
+	var failing, pending []string
+
+	for _, name := range required {
+		reported, ok := states[name]
+
+		switch {
+		case slices.Contains(reported, CheckSuccess):
+		case !ok || slices.Contains(reported, CheckPending):
+			pending = append(pending, name)
+		default:
+			failing = append(failing, name)
+		}
+	}
+
+	if len(failing) == 0 && len(pending) == 0 {
+		return nil
+	}
+
+	var details []string
+
+	if len(failing) > 0 {
+		details = append(details, "failing: "+strings.Join(failing, ", "))
+	}
+
+	if len(pending) > 0 {
+		details = append(details, "pending: "+strings.Join(pending, ", "))
+	}
+
+	return fmt.Errorf("%w (%s)", ErrChecksNotPassed, strings.Join(details, "; "))
+}
@@ -0,0 +1,260 @@
+package forge
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	CodebergHost = "codeberg.org"
+
+	giteaPageSize = 50
+)
+
+// Gitea is a client of the REST API of a Gitea or Forgejo instance.
+type Gitea struct {
+	repository Repository
+	client     *APIClient
+}
+
+// NewGitea returns a client of the API of the Gitea instance at the given URL for the given repository, authenticated
+// with the given access token if any.
+func NewGitea(repository Repository, serverURL, token string, options ...APIOptionFunc) *Gitea {
+	header := http.Header{}
+	header.Set("Accept", "application/json")
+
+	if token != "" {
+		header.Set("Authorization", "token "+token)
+	}
+
+	return &Gitea{
+		repository: repository,
+		client:     NewAPIClient(&http.Client{Timeout: 30 * time.Second}, strings.TrimSuffix(serverURL, "/")+"/api/v1", header, options...),
+	}
+}
+
+type giteaStatus struct {
+	Context string `json:"context"`
+	Status  string `json:"status"`
+}
+
+// Checks returns the commit statuses reported on a commit, including the ones of Gitea and Forgejo Actions, named after
+// their context.
+func (g *Gitea) Checks(ctx context.Context, commit string) ([]Check, error) {
+	var checks []Check
+
+	err := Paginate(ctx, g.client, g.path("commits/%s/statuses?limit=%d", commit, giteaPageSize), func(statuses []giteaStatus) error {
+		for _, status := range statuses {
+			checks = append(checks, Check{Name: status.Context, State: giteaStatusState(status.Status)})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetching commit statuses: %w", err)
+	}
+
+	return checks, nil
+}
+
+// PullRequestTitle returns the title of a pull request.
+func (g *Gitea) PullRequestTitle(ctx context.Context, number int) (string, error) {
+	var pullRequest struct {
+		Title string `json:"title"`
+	}
+
+	_, err := g.client.Get(ctx, g.path("pulls/%d", number), &pullRequest)
+	if err != nil {
+		return "", fmt.Errorf("fetching pull request #%d: %w", number, err)
+	}
+
+	return pullRequest.Title, nil
+}
+
+// CommitPullRequests returns the pull request that introduced a commit in its base branch, if any, as Gitea only
+// reports one pull request per commit.
+func (g *Gitea) CommitPullRequests(ctx context.Context, commit string) ([]PullRequest, error) {
+	var pullRequest struct {
+		Number         int    `json:"number"`
+		Title          string `json:"title"`
+		Body           string `json:"body"`
+		Merged         bool   `json:"merged"`
+		MergeCommitSHA string `json:"merge_commit_sha"`
+	}
+
+	_, err := g.client.Get(ctx, g.path("commits/%s/pull", commit), &pullRequest)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fetching commit %s pull request: %w", commit, err)
+	}
+
+	return []PullRequest{{
+		Number:      pullRequest.Number,
+		Title:       pullRequest.Title,
+		Body:        pullRequest.Body,
+		Merged:      pullRequest.Merged,
+		MergeCommit: pullRequest.MergeCommitSHA,
+	}}, nil
+}
+
+// CommentPullRequest posts a comment on a pull request, which Gitea handles as an issue.
+func (g *Gitea) CommentPullRequest(ctx context.Context, number int, body string) error {
+	_, err := g.client.Do(ctx, http.MethodPost, g.path("issues/%d/comments", number), map[string]string{"body": body}, nil)
+	if err != nil {
+		return fmt.Errorf("commenting pull request #%d: %w", number, err)
+	}
+
+	return nil
+}
+
+// CreateCheckRun is not supported, Gitea only reporting commit statuses.
+func (g *Gitea) CreateCheckRun(_ context.Context, _ CheckRun) error {
+	return fmt.Errorf("creating check runs: %w", ErrUnsupported)
+}
+
+// CreateRelease publishes a Gitea release.
+func (g *Gitea) CreateRelease(ctx context.Context, release Release) error {
+	body := map[string]string{
+		"tag_name":         release.Tag,
+		"target_commitish": release.Target,
+		"name":             release.Name,
+		"body":             release.Body,
+	}
+
+	var created struct {
+		ID int64 `json:"id"`
+	}
+
+	// The created release is only read to attach assets to it.
+	var response any
+	if len(release.Assets) > 0 {
+		response = &created
+	}
+
+	_, err := g.client.Do(ctx, http.MethodPost, g.path("releases"), body, response)
+	if err != nil {
+		return fmt.Errorf("creating release %q: %w", release.Tag, err)
+	}
+
+	for _, asset := range release.Assets {
+		var form bytes.Buffer
+
+		writer := multipart.NewWriter(&form)
+
+		part, err := writer.CreateFormFile("attachment", asset.Name)
+		if err == nil {
+			_, err = part.Write(asset.Content)
+		}
+		if err == nil {
+			err = writer.Close()
+		}
+		if err != nil {
+			return fmt.Errorf("encoding %q: %w", asset.Name, err)
+		}
+
+		_, err = g.client.Upload(ctx, g.path("releases/%d/assets?name=%s", created.ID, url.QueryEscape(asset.Name)), writer.FormDataContentType(), form.Bytes(), nil)
+		if err != nil {
+			return fmt.Errorf("attaching %q to release %q: %w", asset.Name, release.Tag, err)
+		}
+	}
+
+	return nil
+}
+
+// DeleteRelease deletes the Gitea release of a tag. A tag without release is not an error.
+func (g *Gitea) DeleteRelease(ctx context.Context, tag string) error {
+	_, err := g.client.Do(ctx, http.MethodDelete, g.path("releases/tags/%s", url.PathEscape(tag)), nil, nil)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("deleting release %q: %w", tag, err)
+	}
+
+	return nil
+}
+
+// CreateDeployment is not supported, Gitea having no deployments.
+func (g *Gitea) CreateDeployment(_ context.Context, _ Deployment) error {
+	return fmt.Errorf("creating deployments: %w", ErrUnsupported)
+}
+
+// UserHandle returns the login of the Gitea user with the given email. Instances only search the emails of the users
+// who made them public, unless the token belongs to an administrator.
+func (g *Gitea) UserHandle(ctx context.Context, email string) (string, error) {
+	var result struct {
+		Data []struct {
+			Login string `json:"login"`
+			Email string `json:"email"`
+		} `json:"data"`
+	}
+
+	_, err := g.client.Get(ctx, "users/search?q="+url.QueryEscape(email), &result)
+	if err != nil {
+		return "", fmt.Errorf("searching user with email %q: %w", email, err)
+	}
+
+	for _, user := range result.Data {
+		if strings.EqualFold(user.Email, email) {
+			return user.Login, nil
+		}
+	}
+
+	return "", nil
+}
+
+// path returns the path of an endpoint of the repository.
+func (g *Gitea) path(format string, a ...any) string {
+	return fmt.Sprintf("repos/%s/%s/", url.PathEscape(g.repository.Owner), url.PathEscape(g.repository.Name)) + fmt.Sprintf(format, a...)
+}
+
+// parseGiteaURL extracts the owner and name of a repository hosted by a Gitea instance from its HTTP(S) or SSH URL,
+// along with the URL of the instance, which may be served under a subpath (e.g., "https://acme.com/git"). The URL of
+// an instance cloned over SSH is assumed to be served over HTTPS on the same host.
+func parseGiteaURL(repositoryURL string) (Repository, string, error) {
+	var repository Repository
+
+	base, host, ok := webURL(repositoryURL)
+	if !ok {
+		return repository, "", fmt.Errorf("%q is not a repository URL", repositoryURL)
+	}
+
+	u, err := url.Parse(base)
+	if err != nil {
+		return repository, "", fmt.Errorf("parsing %q: %w", base, err)
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 2 {
+		return repository, "", fmt.Errorf("%q does not designate an owner and a repository", repositoryURL)
+	}
+
+	repository.Host = host
+	repository.Owner = parts[len(parts)-2]
+	repository.Name = parts[len(parts)-1]
+
+	u.Path = strings.Join(parts[:len(parts)-2], "/")
+	if u.Path != "" {
+		u.Path = "/" + u.Path
+	}
+
+	return repository, u.String(), nil
+}
+
+// giteaStatusState maps the state of a Gitea commit status to a check state. Warnings do not block a release.
+func giteaStatusState(state string) CheckState {
+	switch state {
+	case "success", "warning":
+		return CheckSuccess
+	case "pending":
+		return CheckPending
+	default:
+		return CheckFailure
+	}
+}
@@ -0,0 +1,221 @@
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestGitea_Checks(t *testing.T) {
+	assert := assertion.New(t)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/git/api/v1/repos/owner/name/commits/abc/statuses", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("token token", r.Header.Get("Authorization"))
+
+		if r.URL.Query().Get("page") == "2" {
+			_, _ = fmt.Fprint(w, `[{"context": "e2e", "status": "pending"}]`)
+			return
+		}
+
+		w.Header().Set("Link", fmt.Sprintf(`<http://%s%s?limit=%d&page=2>; rel="next"`, r.Host, r.URL.Path, giteaPageSize))
+
+		_, _ = fmt.Fprint(w, `[
+			{"context": "ci/build", "status": "success"},
+			{"context": "ci/test", "status": "failure"},
+			{"context": "ci/lint", "status": "warning"}
+		]`)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewGitea(Repository{Owner: "owner", Name: "name"}, server.URL+"/git/", "token")
+
+	checks, err := client.Checks(context.Background(), "abc")
+	checkErr(t, err, "fetching checks")
+
+	expected := []Check{
+		{Name: "ci/build", State: CheckSuccess},
+		{Name: "ci/test", State: CheckFailure},
+		{Name: "ci/lint", State: CheckSuccess},
+		{Name: "e2e", State: CheckPending},
+	}
+
+	assert.Equal(expected, checks)
+}
+
+func TestGitea_API(t *testing.T) {
+	assert := assertion.New(t)
+
+	var (
+		comment map[string]string
+		release map[string]string
+		deleted []string
+	)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /api/v1/repos/owner/name/pulls/42", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprint(w, `{"number": 42, "title": "feat: add foo"}`)
+	})
+
+	mux.HandleFunc("POST /api/v1/repos/owner/name/issues/42/comments", func(w http.ResponseWriter, r *http.Request) {
+		checkErr(t, json.NewDecoder(r.Body).Decode(&comment), "decoding comment")
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	mux.HandleFunc("POST /api/v1/repos/owner/name/releases", func(w http.ResponseWriter, r *http.Request) {
+		checkErr(t, json.NewDecoder(r.Body).Decode(&release), "decoding release")
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	mux.HandleFunc("DELETE /api/v1/repos/owner/name/releases/tags/{tag}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("tag") != "v1.0.0" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		deleted = append(deleted, r.PathValue("tag"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("GET /api/v1/users/search", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("jane@acme.com", r.URL.Query().Get("q"))
+
+		_, _ = fmt.Fprint(w, `{"ok": true, "data": [{"login": "janet", "email": "janet@acme.com"}, {"login": "jane", "email": "Jane@acme.com"}]}`)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewGitea(Repository{Owner: "owner", Name: "name"}, server.URL, "token")
+
+	title, err := client.PullRequestTitle(context.Background(), 42)
+	checkErr(t, err, "fetching pull request title")
+
+	assert.Equal("feat: add foo", title)
+
+	err = client.CommentPullRequest(context.Background(), 42, "## Release summary")
+	checkErr(t, err, "commenting pull request")
+
+	assert.Equal(map[string]string{"body": "## Release summary"}, comment)
+
+	err = client.CreateRelease(context.Background(), Release{Tag: "v1.0.0", Target: "main", Name: "v1.0.0", Body: "Notes"})
+	checkErr(t, err, "creating release")
+
+	assert.Equal(map[string]string{"tag_name": "v1.0.0", "target_commitish": "main", "name": "v1.0.0", "body": "Notes"}, release)
+
+	checkErr(t, client.DeleteRelease(context.Background(), "v1.0.0"), "deleting release")
+	checkErr(t, client.DeleteRelease(context.Background(), "v2.0.0"), "deleting missing release")
+
+	assert.Equal([]string{"v1.0.0"}, deleted)
+
+	handle, err := client.UserHandle(context.Background(), "jane@acme.com")
+	checkErr(t, err, "fetching user handle")
+
+	assert.Equal("jane", handle, "users should be matched by their exact email")
+	assert.ErrorIs(client.CreateDeployment(context.Background(), Deployment{}), ErrUnsupported)
+	assert.ErrorIs(client.CreateCheckRun(context.Background(), CheckRun{}), ErrUnsupported)
+}
+
+func TestGitea_ParseURL(t *testing.T) {
+	assert := assertion.New(t)
+
+	type test struct {
+		url        string
+		repository Repository
+		serverURL  string
+	}
+
+	matrix := []test{
+		{url: "https://codeberg.org/owner/name.git", repository: Repository{Host: "codeberg.org", Owner: "owner", Name: "name"}, serverURL: "https://codeberg.org"},
+		{url: "http://git.acme.com:3000/forgejo/owner/name.git", repository: Repository{Host: "git.acme.com", Owner: "owner", Name: "name"}, serverURL: "http://git.acme.com:3000/forgejo"},
+		{url: "git@gitea.acme.com:owner/name.git", repository: Repository{Host: "gitea.acme.com", Owner: "owner", Name: "name"}, serverURL: "https://gitea.acme.com"},
+	}
+
+	for _, tc := range matrix {
+		repository, serverURL, err := parseGiteaURL(tc.url)
+		checkErr(t, err, "parsing repository URL")
+
+		assert.Equal(tc.repository, repository, tc.url)
+		assert.Equal(tc.serverURL, serverURL, tc.url)
+	}
+
+	for _, url := range []string{"/tmp/repository", "https://gitea.acme.com/name.git"} {
+		_, _, err := parseGiteaURL(url)
+		assert.Error(err, url)
+	}
+}
+
+func TestGitea_CommitPullRequests(t *testing.T) {
+	assert := assertion.New(t)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /api/v1/repos/owner/name/commits/abc/pull", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprint(w, `{"number": 42, "title": "feat: add foo", "body": "Adds foo.", "merged": true, "merge_commit_sha": "abc"}`)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewGitea(Repository{Owner: "owner", Name: "name"}, server.URL, "token")
+
+	pullRequests, err := client.CommitPullRequests(context.Background(), "abc")
+	checkErr(t, err, "fetching commit pull requests")
+
+	assert.Equal([]PullRequest{{Number: 42, Title: "feat: add foo", Body: "Adds foo.", Merged: true, MergeCommit: "abc"}}, pullRequests)
+
+	pullRequests, err = client.CommitPullRequests(context.Background(), "def")
+	checkErr(t, err, "fetching commit pull requests")
+
+	assert.Empty(pullRequests, "commit pushed without pull request should have none")
+}
+
+func TestGitea_CreateReleaseAssets(t *testing.T) {
+	assert := assertion.New(t)
+
+	uploaded := make(map[string]string)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /api/v1/repos/owner/name/releases", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprint(w, `{"id": 7}`)
+	})
+
+	mux.HandleFunc("POST /api/v1/repos/owner/name/releases/7/assets", func(w http.ResponseWriter, r *http.Request) {
+		file, header, err := r.FormFile("attachment")
+		checkErr(t, err, "reading attachment")
+
+		content, _ := io.ReadAll(file)
+		uploaded[r.URL.Query().Get("name")] = string(content)
+		assert.Equal("provenance.intoto.jsonl", header.Filename)
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprint(w, `{"id": 1}`)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewGitea(Repository{Owner: "owner", Name: "name"}, server.URL, "token")
+
+	err := client.CreateRelease(context.Background(), Release{
+		Tag:    "v1.0.0",
+		Target: "main",
+		Name:   "v1.0.0",
+		Assets: []Asset{{Name: "provenance.intoto.jsonl", Content: []byte("{}\n")}},
+	})
+	checkErr(t, err, "creating release")
+
+	assert.Equal(map[string]string{"provenance.intoto.jsonl": "{}\n"}, uploaded)
+}
@@ -0,0 +1,346 @@
+package forge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	GitHubHost   = "github.com"
+	GitHubAPIURL = "https://api.github.com"
+
+	githubPageSize = 100
+
+	githubNoReplyDomain = "@users.noreply.github.com"
+
+	// githubCheckRunSummaryLimit is the maximum length of the summary of a check run.
+	githubCheckRunSummaryLimit = 65535
+)
+
+// GitHubOptionFunc configures a GitHub client.
+type GitHubOptionFunc func(g *GitHub)
+
+// WithAPIURL sets the URL of the GitHub API, e.g. the one of a GitHub Enterprise Server instance.
+func WithAPIURL(apiURL string) GitHubOptionFunc {
+	return func(g *GitHub) {
+		g.apiURL = strings.TrimSuffix(apiURL, "/")
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to query the GitHub API.
+func WithHTTPClient(client *http.Client) GitHubOptionFunc {
+	return func(g *GitHub) {
+		g.httpClient = client
+	}
+}
+
+// GitHub is a client of the GitHub REST API.
+type GitHub struct {
+	httpClient *http.Client
+	apiURL     string
+	token      string
+	repository Repository
+	client     *APIClient
+}
+
+// NewGitHub returns a client of the GitHub API for the given repository, authenticated with the given token if any.
+func NewGitHub(repository Repository, token string, options ...GitHubOptionFunc) *GitHub {
+	g := &GitHub{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		apiURL:     GitHubAPIURL,
+		token:      token,
+		repository: repository,
+	}
+
+	for _, option := range options {
+		option(g)
+	}
+
+	header := http.Header{}
+	header.Set("Accept", "application/vnd.github+json")
+	header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	if g.token != "" {
+		header.Set("Authorization", "Bearer "+g.token)
+	}
+
+	g.client = NewAPIClient(g.httpClient, g.apiURL, header)
+
+	return g
+}
+
+type githubCheckRuns struct {
+	CheckRuns []struct {
+		Name       string `json:"name"`
+		Status     string `json:"status"`
+		Conclusion string `json:"conclusion"`
+	} `json:"check_runs"`
+}
+
+type githubCombinedStatus struct {
+	Statuses []struct {
+		Context string `json:"context"`
+		State   string `json:"state"`
+	} `json:"statuses"`
+}
+
+// Checks returns both the check runs (e.g., GitHub Actions jobs) and the commit statuses (e.g., external CI systems)
+// reported on a commit.
+func (g *GitHub) Checks(ctx context.Context, commit string) ([]Check, error) {
+	var checks []Check
+
+	err := Paginate(ctx, g.client, g.path("commits/%s/check-runs?per_page=%d", commit, githubPageSize), func(runs githubCheckRuns) error {
+		for _, run := range runs.CheckRuns {
+			checks = append(checks, Check{Name: run.Name, State: checkRunState(run.Status, run.Conclusion)})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetching check runs: %w", err)
+	}
+
+	err = Paginate(ctx, g.client, g.path("commits/%s/status?per_page=%d", commit, githubPageSize), func(status githubCombinedStatus) error {
+		for _, s := range status.Statuses {
+			checks = append(checks, Check{Name: s.Context, State: statusState(s.State)})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetching commit statuses: %w", err)
+	}
+
+	return checks, nil
+}
+
+// PullRequestTitle returns the title of a pull request, e.g. to analyze the pull requests bundled by a merge queue.
+func (g *GitHub) PullRequestTitle(ctx context.Context, number int) (string, error) {
+	var pullRequest struct {
+		Title string `json:"title"`
+	}
+
+	_, err := g.client.Get(ctx, g.path("pulls/%d", number), &pullRequest)
+	if err != nil {
+		return "", fmt.Errorf("fetching pull request #%d: %w", number, err)
+	}
+
+	return pullRequest.Title, nil
+}
+
+type githubPullRequest struct {
+	Number         int     `json:"number"`
+	Title          string  `json:"title"`
+	Body           string  `json:"body"`
+	MergedAt       *string `json:"merged_at"`
+	MergeCommitSHA string  `json:"merge_commit_sha"`
+}
+
+// CommitPullRequests returns the pull requests associated with a commit. GitHub reports the merged pull request of a
+// merge or squash commit, and the pull requests containing any other commit.
+func (g *GitHub) CommitPullRequests(ctx context.Context, commit string) ([]PullRequest, error) {
+	var pullRequests []PullRequest
+
+	err := Paginate(ctx, g.client, g.path("commits/%s/pulls?per_page=%d", commit, githubPageSize), func(page []githubPullRequest) error {
+		for _, pr := range page {
+			pullRequests = append(pullRequests, PullRequest{
+				Number:      pr.Number,
+				Title:       pr.Title,
+				Body:        pr.Body,
+				Merged:      pr.MergedAt != nil,
+				MergeCommit: pr.MergeCommitSHA,
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetching commit %s pull requests: %w", commit, err)
+	}
+
+	return pullRequests, nil
+}
+
+// CommentPullRequest posts a comment on a pull request, which GitHub handles as an issue.
+func (g *GitHub) CommentPullRequest(ctx context.Context, number int, body string) error {
+	_, err := g.client.Do(ctx, http.MethodPost, g.path("issues/%d/comments", number), map[string]string{"body": body}, nil)
+	if err != nil {
+		return fmt.Errorf("commenting pull request #%d: %w", number, err)
+	}
+
+	return nil
+}
+
+// CreateCheckRun reports a check run with a neutral conclusion, which does not count as a passing or failing check. A
+// summary longer than GitHub allows is truncated. Check runs can only be created with a GitHub App token, such as the
+// GITHUB_TOKEN of GitHub Actions.
+func (g *GitHub) CreateCheckRun(ctx context.Context, run CheckRun) error {
+	summary := run.Summary
+	if len(summary) > githubCheckRunSummaryLimit {
+		const ellipsis = "\n\n…"
+		summary = strings.ToValidUTF8(summary[:githubCheckRunSummaryLimit-len(ellipsis)], "") + ellipsis
+	}
+
+	body := map[string]any{
+		"name":       run.Name,
+		"head_sha":   run.Commit,
+		"status":     "completed",
+		"conclusion": "neutral",
+		"output": map[string]string{
+			"title":   run.Title,
+			"summary": summary,
+		},
+	}
+
+	_, err := g.client.Do(ctx, http.MethodPost, g.path("check-runs"), body, nil)
+	if err != nil {
+		return fmt.Errorf("creating check run %q on commit %s: %w", run.Name, run.Commit, err)
+	}
+
+	return nil
+}
+
+// CreateRelease publishes a GitHub release.
+func (g *GitHub) CreateRelease(ctx context.Context, release Release) error {
+	body := map[string]string{
+		"tag_name":         release.Tag,
+		"target_commitish": release.Target,
+		"name":             release.Name,
+		"body":             release.Body,
+	}
+
+	var created struct {
+		UploadURL string `json:"upload_url"`
+	}
+
+	// The created release is only read to attach assets to it.
+	var response any
+	if len(release.Assets) > 0 {
+		response = &created
+	}
+
+	_, err := g.client.Do(ctx, http.MethodPost, g.path("releases"), body, response)
+	if err != nil {
+		return fmt.Errorf("creating release %q: %w", release.Tag, err)
+	}
+
+	// The upload URL is a URI template, e.g. "https://uploads.github.com/repos/o/r/releases/1/assets{?name,label}".
+	uploadURL, _, _ := strings.Cut(created.UploadURL, "{")
+
+	for _, asset := range release.Assets {
+		_, err = g.client.Upload(ctx, uploadURL+"?name="+url.QueryEscape(asset.Name), assetContentType(asset), asset.Content, nil)
+		if err != nil {
+			return fmt.Errorf("attaching %q to release %q: %w", asset.Name, release.Tag, err)
+		}
+	}
+
+	return nil
+}
+
+// DeleteRelease deletes the GitHub release of a tag. A tag without release is not an error.
+func (g *GitHub) DeleteRelease(ctx context.Context, tag string) error {
+	var release struct {
+		ID int64 `json:"id"`
+	}
+
+	_, err := g.client.Get(ctx, g.path("releases/tags/%s", url.PathEscape(tag)), &release)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("fetching release %q: %w", tag, err)
+	}
+
+	_, err = g.client.Do(ctx, http.MethodDelete, g.path("releases/%d", release.ID), nil, nil)
+	if err != nil {
+		return fmt.Errorf("deleting release %q: %w", tag, err)
+	}
+
+	return nil
+}
+
+// CreateDeployment records a GitHub deployment. The deployment is created whatever the state of the checks of the ref,
+// which are checked beforehand if required.
+func (g *GitHub) CreateDeployment(ctx context.Context, deployment Deployment) error {
+	body := map[string]any{
+		"ref":               deployment.Ref,
+		"environment":       deployment.Environment,
+		"description":       deployment.Description,
+		"auto_merge":        false,
+		"required_contexts": []string{},
+	}
+
+	_, err := g.client.Do(ctx, http.MethodPost, g.path("deployments"), body, nil)
+	if err != nil {
+		return fmt.Errorf("creating deployment of %q to %q: %w", deployment.Ref, deployment.Environment, err)
+	}
+
+	return nil
+}
+
+// UserHandle returns the login of the GitHub user with the given email. The no-reply emails of GitHub (e.g.,
+// "123+octocat@users.noreply.github.com") give the login away, other emails are searched among the public emails of the
+// users.
+func (g *GitHub) UserHandle(ctx context.Context, email string) (string, error) {
+	if local, ok := strings.CutSuffix(strings.ToLower(email), githubNoReplyDomain); ok {
+		_, login, found := strings.Cut(local, "+")
+		if !found {
+			login = local
+		}
+
+		return login, nil
+	}
+
+	var result struct {
+		Items []struct {
+			Login string `json:"login"`
+		} `json:"items"`
+	}
+
+	_, err := g.client.Get(ctx, "search/users?q="+url.QueryEscape(email+" in:email"), &result)
+	if err != nil {
+		return "", fmt.Errorf("searching user with email %q: %w", email, err)
+	}
+
+	if len(result.Items) == 0 {
+		return "", nil
+	}
+
+	return result.Items[0].Login, nil
+}
+
+// path returns the path of an endpoint of the repository.
+func (g *GitHub) path(format string, a ...any) string {
+	return fmt.Sprintf("repos/%s/%s/", url.PathEscape(g.repository.Owner), url.PathEscape(g.repository.Name)) + fmt.Sprintf(format, a...)
+}
+
+// checkRunState maps the status and conclusion of a check run to a check state. Neutral and skipped check runs do not
+// block a release.
+func checkRunState(status, conclusion string) CheckState {
+	if status != "completed" {
+		return CheckPending
+	}
+
+	switch conclusion {
+	case "success", "neutral", "skipped":
+		return CheckSuccess
+	default:
+		return CheckFailure
+	}
+}
+
+func statusState(state string) CheckState {
+	switch state {
+	case "success":
+		return CheckSuccess
+	case "pending":
+		return CheckPending
+	default:
+		return CheckFailure
+	}
+}
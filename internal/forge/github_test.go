@@ -0,0 +1,369 @@
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestGitHub_Checks(t *testing.T) {
+	assert := assertion.New(t)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/repos/owner/name/commits/abc/check-runs", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("Bearer token", r.Header.Get("Authorization"))
+
+		_, _ = fmt.Fprint(w, `{"total_count": 4, "check_runs": [
+			{"name": "build", "status": "completed", "conclusion": "success"},
+			{"name": "test", "status": "completed", "conclusion": "failure"},
+			{"name": "lint", "status": "completed", "conclusion": "skipped"},
+			{"name": "e2e", "status": "in_progress", "conclusion": null}
+		]}`)
+	})
+
+	mux.HandleFunc("/repos/owner/name/commits/abc/status", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprint(w, `{"state": "pending", "total_count": 2, "statuses": [
+			{"context": "ci/jenkins", "state": "error"},
+			{"context": "ci/sonar", "state": "pending"}
+		]}`)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewGitHub(Repository{Owner: "owner", Name: "name"}, "token", WithAPIURL(server.URL+"/"))
+
+	checks, err := client.Checks(context.Background(), "abc")
+	checkErr(t, err, "fetching checks")
+
+	expected := []Check{
+		{Name: "build", State: CheckSuccess},
+		{Name: "test", State: CheckFailure},
+		{Name: "lint", State: CheckSuccess},
+		{Name: "e2e", State: CheckPending},
+		{Name: "ci/jenkins", State: CheckFailure},
+		{Name: "ci/sonar", State: CheckPending},
+	}
+
+	assert.Equal(expected, checks)
+}
+
+func TestGitHub_Checks_Paginated(t *testing.T) {
+	assert := assertion.New(t)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/repos/owner/name/commits/abc/check-runs", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(strconv.Itoa(githubPageSize), r.URL.Query().Get("per_page"))
+
+		count := githubPageSize
+		if r.URL.Query().Get("page") == "2" {
+			count = 1
+		} else {
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s%s?per_page=%d&page=2>; rel="next", <http://%s%s?per_page=%d&page=2>; rel="last"`, r.Host, r.URL.Path, githubPageSize, r.Host, r.URL.Path, githubPageSize))
+		}
+
+		_, _ = fmt.Fprintf(w, `{"total_count": %d, "check_runs": [`, githubPageSize+1)
+		for i := 0; i < count; i++ {
+			if i > 0 {
+				_, _ = fmt.Fprint(w, ",")
+			}
+			_, _ = fmt.Fprint(w, `{"name": "job", "status": "completed", "conclusion": "success"}`)
+		}
+		_, _ = fmt.Fprint(w, `]}`)
+	})
+
+	mux.HandleFunc("/repos/owner/name/commits/abc/status", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprint(w, `{"total_count": 0, "statuses": []}`)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewGitHub(Repository{Owner: "owner", Name: "name"}, "", WithAPIURL(server.URL))
+
+	checks, err := client.Checks(context.Background(), "abc")
+	checkErr(t, err, "fetching checks")
+
+	assert.Len(checks, githubPageSize+1)
+}
+
+func TestGitHub_Checks_UnexpectedStatus(t *testing.T) {
+	assert := assertion.New(t)
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	client := NewGitHub(Repository{Owner: "owner", Name: "name"}, "", WithAPIURL(server.URL))
+
+	_, err := client.Checks(context.Background(), "abc")
+	assert.ErrorIs(err, ErrUnexpectedStatus)
+}
+
+func TestGitHub_PullRequestTitle(t *testing.T) {
+	assert := assertion.New(t)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/repos/owner/name/pulls/123", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprint(w, `{"number": 123, "title": "feat: add foo"}`)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewGitHub(Repository{Owner: "owner", Name: "name"}, "", WithAPIURL(server.URL))
+
+	title, err := client.PullRequestTitle(context.Background(), 123)
+	checkErr(t, err, "fetching pull request title")
+
+	assert.Equal("feat: add foo", title)
+
+	_, err = client.PullRequestTitle(context.Background(), 124)
+	assert.ErrorContains(err, "unexpected status")
+}
+
+func TestGitHub_CreateRelease(t *testing.T) {
+	assert := assertion.New(t)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/repos/owner/name/releases", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(http.MethodPost, r.Method)
+		assert.Equal("Bearer token", r.Header.Get("Authorization"))
+
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		assert.Equal(map[string]string{"tag_name": "release-1", "target_commitish": "main", "name": "Release 1", "body": "# Summary"}, body)
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprint(w, `{"id": 1}`)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewGitHub(Repository{Owner: "owner", Name: "name"}, "token", WithAPIURL(server.URL))
+
+	err := client.CreateRelease(context.Background(), Release{Tag: "release-1", Target: "main", Name: "Release 1", Body: "# Summary"})
+	checkErr(t, err, "creating release")
+}
+
+func TestGitHub_CreateReleaseAssets(t *testing.T) {
+	assert := assertion.New(t)
+
+	var (
+		server   *httptest.Server
+		uploaded = make(map[string]string)
+	)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /repos/owner/name/releases", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintf(w, `{"id": 1, "upload_url": "%s/uploads/releases/1/assets{?name,label}"}`, server.URL)
+	})
+
+	mux.HandleFunc("POST /uploads/releases/1/assets", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("application/vnd.in-toto+jsonl", r.Header.Get("Content-Type"))
+
+		content, _ := io.ReadAll(r.Body)
+		uploaded[r.URL.Query().Get("name")] = string(content)
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprint(w, `{"id": 2}`)
+	})
+
+	server = httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewGitHub(Repository{Owner: "owner", Name: "name"}, "token", WithAPIURL(server.URL))
+
+	err := client.CreateRelease(context.Background(), Release{
+		Tag:    "release-1",
+		Target: "main",
+		Name:   "Release 1",
+		Assets: []Asset{{Name: "provenance.intoto.jsonl", ContentType: "application/vnd.in-toto+jsonl", Content: []byte("{}\n")}},
+	})
+	checkErr(t, err, "creating release")
+
+	assert.Equal(map[string]string{"provenance.intoto.jsonl": "{}\n"}, uploaded)
+}
+
+func TestGitHub_DeleteRelease(t *testing.T) {
+	assert := assertion.New(t)
+
+	var deleted bool
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/repos/owner/name/releases/tags/v1.0.0", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(http.MethodGet, r.Method)
+		_, _ = fmt.Fprint(w, `{"id": 42}`)
+	})
+
+	mux.HandleFunc("/repos/owner/name/releases/tags/v2.0.0", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	mux.HandleFunc("/repos/owner/name/releases/42", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(http.MethodDelete, r.Method)
+		deleted = true
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewGitHub(Repository{Owner: "owner", Name: "name"}, "token", WithAPIURL(server.URL))
+
+	err := client.DeleteRelease(context.Background(), "v1.0.0")
+	checkErr(t, err, "deleting release")
+	assert.True(deleted, "release should have been deleted")
+
+	err = client.DeleteRelease(context.Background(), "v2.0.0")
+	assert.NoError(err, "a tag without release should not be an error")
+}
+
+func TestGitHub_CreateDeployment(t *testing.T) {
+	assert := assertion.New(t)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/repos/owner/name/deployments", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(http.MethodPost, r.Method)
+
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		assert.Equal("v1.0.0", body["ref"])
+		assert.Equal("production", body["environment"])
+		assert.Equal([]any{}, body["required_contexts"], "checks should not be required again")
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprint(w, `{"id": 1}`)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewGitHub(Repository{Owner: "owner", Name: "name"}, "token", WithAPIURL(server.URL))
+
+	err := client.CreateDeployment(context.Background(), Deployment{Ref: "v1.0.0", Environment: "production", Description: "Release v1.0.0"})
+	checkErr(t, err, "creating deployment")
+}
+
+func TestGitHub_CreateCheckRun(t *testing.T) {
+	assert := assertion.New(t)
+
+	var bodies []map[string]any
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/repos/owner/name/check-runs", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(http.MethodPost, r.Method)
+
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		bodies = append(bodies, body)
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprint(w, `{"id": 1}`)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewGitHub(Repository{Owner: "owner", Name: "name"}, "token", WithAPIURL(server.URL))
+
+	err := client.CreateCheckRun(context.Background(), CheckRun{Name: "release", Commit: "abc", Title: "v1.1.0", Summary: "## v1.1.0"})
+	checkErr(t, err, "creating check run")
+
+	err = client.CreateCheckRun(context.Background(), CheckRun{Name: "release", Commit: "abc", Summary: strings.Repeat("é", githubCheckRunSummaryLimit)})
+	checkErr(t, err, "creating check run with long summary")
+
+	if assert.Len(bodies, 2) {
+		assert.Equal("abc", bodies[0]["head_sha"])
+		assert.Equal("neutral", bodies[0]["conclusion"], "check run should neither pass nor fail")
+		assert.Equal(map[string]any{"title": "v1.1.0", "summary": "## v1.1.0"}, bodies[0]["output"])
+
+		summary := bodies[1]["output"].(map[string]any)["summary"].(string)
+		assert.LessOrEqual(len(summary), githubCheckRunSummaryLimit, "long summary should be truncated")
+		assert.True(utf8.ValidString(summary), "truncated summary should be valid UTF-8")
+	}
+}
+
+func TestGitHub_UserHandle(t *testing.T) {
+	assert := assertion.New(t)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/search/users", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("q") {
+		case "jane@example.com in:email":
+			_, _ = fmt.Fprint(w, `{"total_count": 1, "items": [{"login": "jane"}]}`)
+		default:
+			_, _ = fmt.Fprint(w, `{"total_count": 0, "items": []}`)
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewGitHub(Repository{Owner: "owner", Name: "name"}, "token", WithAPIURL(server.URL))
+
+	tests := map[string]string{
+		"jane@example.com":                     "jane",
+		"unknown@example.com":                  "",
+		"123+octocat@users.noreply.github.com": "octocat",
+		"Hubot@users.noreply.github.com":       "hubot",
+	}
+
+	for email, want := range tests {
+		handle, err := client.UserHandle(context.Background(), email)
+		checkErr(t, err, "fetching user handle")
+
+		assert.Equal(want, handle, "email %q", email)
+	}
+}
+
+func TestGitHub_CommitPullRequests(t *testing.T) {
+	assert := assertion.New(t)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /repos/owner/name/commits/abc/pulls", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprint(w, `[
+			{"number": 12, "title": "feat: add foo", "body": "Adds foo.", "merged_at": "2024-05-01T10:00:00Z", "merge_commit_sha": "abc"},
+			{"number": 13, "title": "wip", "body": null, "merged_at": null, "merge_commit_sha": "def"}
+		]`)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewGitHub(Repository{Owner: "owner", Name: "name"}, "", WithAPIURL(server.URL))
+
+	pullRequests, err := client.CommitPullRequests(context.Background(), "abc")
+	checkErr(t, err, "fetching commit pull requests")
+
+	assert.Equal([]PullRequest{
+		{Number: 12, Title: "feat: add foo", Body: "Adds foo.", Merged: true, MergeCommit: "abc"},
+		{Number: 13, Title: "wip", MergeCommit: "def"},
+	}, pullRequests)
+
+	_, err = client.CommitPullRequests(context.Background(), "xyz")
+	assert.ErrorIs(err, ErrNotFound)
+}
@@ -0,0 +1,127 @@
+package forge
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/s0ders/go-semver-release/v6/internal/remote"
+	"github.com/s0ders/go-semver-release/v6/internal/render"
+)
+
+// Links are the templates of the URLs of the commit and pull request pages of a repository, linked by release notes.
+// The commit template is executed with a CommitLinkData and the pull request one with a PullRequestLinkData. An empty
+// template links nothing.
+type Links struct {
+	Commit      string
+	PullRequest string
+}
+
+// CommitLinkData is the data of the commit URL template.
+type CommitLinkData struct {
+	// Hash is the hash of the commit.
+	Hash string
+	// ShortHash is the abbreviated hash of the commit.
+	ShortHash string
+}
+
+// PullRequestLinkData is the data of the pull request URL template.
+type PullRequestLinkData struct {
+	// Number is the number of the pull request, or merge request on GitLab.
+	Number int
+}
+
+// DefaultLinks returns the links of a repository hosted on GitHub, GitLab, Bitbucket, Gitea or Forgejo, derived from its HTTP(S) or SSH
+// URL, e.g. "https://github.com/owner/name/commit/{{ .Hash }}" for "git@github.com:owner/name.git". The forge is inferred
+// from the host of the repository, no links being returned for other hosts.
+func DefaultLinks(repositoryURL string) Links {
+	base, host, ok := webURL(repositoryURL)
+	if !ok {
+		return Links{}
+	}
+
+	switch {
+	case strings.Contains(host, "github"):
+		return Links{Commit: base + "/commit/{{ .Hash }}", PullRequest: base + "/pull/{{ .Number }}"}
+	case strings.Contains(host, "gitlab"):
+		return Links{Commit: base + "/-/commit/{{ .Hash }}", PullRequest: base + "/-/merge_requests/{{ .Number }}"}
+	case strings.Contains(host, "bitbucket"):
+		return Links{Commit: base + "/commits/{{ .Hash }}", PullRequest: base + "/pull-requests/{{ .Number }}"}
+	case host == CodebergHost || strings.Contains(host, "gitea") || strings.Contains(host, "forgejo"):
+		return Links{Commit: base + "/commit/{{ .Hash }}", PullRequest: base + "/pulls/{{ .Number }}"}
+	default:
+		return Links{}
+	}
+}
+
+// Validate checks that the templates of the links can be executed, so that rendering release notes cannot fail.
+func (l Links) Validate() error {
+	if _, err := render.Inline(l.Commit, CommitLinkData{Hash: "0", ShortHash: "0"}); err != nil {
+		return fmt.Errorf("commit URL template: %w", err)
+	}
+
+	if _, err := render.Inline(l.PullRequest, PullRequestLinkData{Number: 1}); err != nil {
+		return fmt.Errorf("pull request URL template: %w", err)
+	}
+
+	return nil
+}
+
+// CommitURL returns the URL of the page of the commit with the given hash, or an empty string if there is none.
+func (l Links) CommitURL(hash string) string {
+	data := CommitLinkData{Hash: hash, ShortHash: hash}
+	if len(hash) >= 7 {
+		data.ShortHash = hash[:7]
+	}
+
+	u, _ := render.Inline(l.Commit, data)
+
+	return u
+}
+
+// PullRequestURL returns the URL of the page of the pull request with the given number, or an empty string if there is
+// none.
+func (l Links) PullRequestURL(number int) string {
+	u, _ := render.Inline(l.PullRequest, PullRequestLinkData{Number: number})
+
+	return u
+}
+
+// webURL returns the URL of the web page of a repository, e.g. "https://github.com/owner/name", along with its host.
+// Repositories cloned over HTTP keep their scheme and port, the others being served over HTTPS.
+func webURL(repositoryURL string) (string, string, bool) {
+	normalized, err := remote.NormalizeURL(repositoryURL)
+	if err != nil {
+		return "", "", false
+	}
+
+	if !strings.Contains(normalized, "://") {
+		// The scp-like syntax, e.g. "git@github.com:owner/name.git".
+		at, colon := strings.Index(normalized, "@"), strings.Index(normalized, ":")
+		if colon < 0 {
+			return "", "", false
+		}
+
+		normalized = "ssh://" + normalized[at+1:colon] + "/" + strings.TrimPrefix(normalized[colon+1:], "/")
+	}
+
+	u, err := url.Parse(normalized)
+	if err != nil || u.Hostname() == "" {
+		return "", "", false
+	}
+
+	path := strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git")
+	if path == "" {
+		return "", "", false
+	}
+
+	host := u.Host
+	scheme := u.Scheme
+
+	if !remote.IsHTTP(normalized) {
+		host = u.Hostname()
+		scheme = "https"
+	}
+
+	return scheme + "://" + host + "/" + path, u.Hostname(), true
+}
@@ -0,0 +1,51 @@
+package forge
+
+import (
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestLinks_DefaultLinks(t *testing.T) {
+	assert := assertion.New(t)
+
+	type test struct {
+		url      string
+		expected Links
+	}
+
+	matrix := []test{
+		{url: "https://github.com/s0ders/go-semver-release.git", expected: Links{Commit: "https://github.com/s0ders/go-semver-release/commit/{{ .Hash }}", PullRequest: "https://github.com/s0ders/go-semver-release/pull/{{ .Number }}"}},
+		{url: "git@github.com:s0ders/go-semver-release.git", expected: Links{Commit: "https://github.com/s0ders/go-semver-release/commit/{{ .Hash }}", PullRequest: "https://github.com/s0ders/go-semver-release/pull/{{ .Number }}"}},
+		{url: "ssh://git@gitlab.com:2222/group/sub/app.git", expected: Links{Commit: "https://gitlab.com/group/sub/app/-/commit/{{ .Hash }}", PullRequest: "https://gitlab.com/group/sub/app/-/merge_requests/{{ .Number }}"}},
+		{url: "http://bitbucket.acme.com:7990/team/app", expected: Links{Commit: "http://bitbucket.acme.com:7990/team/app/commits/{{ .Hash }}", PullRequest: "http://bitbucket.acme.com:7990/team/app/pull-requests/{{ .Number }}"}},
+		{url: "git@codeberg.org:owner/name.git", expected: Links{Commit: "https://codeberg.org/owner/name/commit/{{ .Hash }}", PullRequest: "https://codeberg.org/owner/name/pulls/{{ .Number }}"}},
+		{url: "https://git.acme.com/team/app.git", expected: Links{}},
+		{url: "/tmp/repository", expected: Links{}},
+	}
+
+	for _, tc := range matrix {
+		assert.Equal(tc.expected, DefaultLinks(tc.url), tc.url)
+	}
+}
+
+func TestLinks_URLs(t *testing.T) {
+	assert := assertion.New(t)
+
+	links := Links{Commit: "https://git.acme.com/app/commit/{{ .ShortHash }}?full={{ .Hash }}", PullRequest: "https://git.acme.com/app/pull/{{ .Number }}"}
+	checkErr(t, links.Validate(), "validating links")
+
+	assert.Equal("https://git.acme.com/app/commit/3f1c2a9?full=3f1c2a9d0be4", links.CommitURL("3f1c2a9d0be4"))
+	assert.Equal("https://git.acme.com/app/pull/42", links.PullRequestURL(42))
+
+	assert.Empty(Links{}.CommitURL("3f1c2a9d0be4"), "links without commit template should link nothing")
+	assert.Empty(Links{}.PullRequestURL(42), "links without pull request template should link nothing")
+}
+
+func TestLinks_Validate(t *testing.T) {
+	assert := assertion.New(t)
+
+	assert.NoError(Links{}.Validate())
+	assert.ErrorContains(Links{Commit: "https://git.acme.com/{{ .Sha }}"}.Validate(), "commit URL template")
+	assert.ErrorContains(Links{PullRequest: "https://git.acme.com/{{ .Number"}.Validate(), "pull request URL template")
+}
@@ -2,11 +2,14 @@
 package gpg
 
 import (
+	"errors"
 	"io"
 
 	"github.com/ProtonMail/go-crypto/openpgp"
 )
 
+var ErrEncryptedKey = errors.New("key is protected by a passphrase, none was given")
+
 // FromArmored reads an armored keyring buffer and returns the first key pair.
 func FromArmored(reader io.Reader) (*openpgp.Entity, error) {
 	entities, err := openpgp.ReadArmoredKeyRing(reader)
@@ -16,3 +19,19 @@ func FromArmored(reader io.Reader) (*openpgp.Entity, error) {
 
 	return entities[0], nil
 }
+
+// KeyRingFromArmored reads an armored keyring buffer and returns all of its keys, e.g. the public keys trusted to sign
+// tags.
+func KeyRingFromArmored(reader io.Reader) (openpgp.EntityList, error) {
+	return openpgp.ReadArmoredKeyRing(reader)
+}
+
+// Unlock decrypts the private keys of an entity protected by a passphrase, keys that are not protected being left as
+// they are.
+func Unlock(entity *openpgp.Entity, passphrase []byte) error {
+	if entity.PrivateKey != nil && entity.PrivateKey.Encrypted && len(passphrase) == 0 {
+		return ErrEncryptedKey
+	}
+
+	return entity.DecryptPrivateKeys(passphrase)
+}
@@ -85,3 +85,31 @@ func TestGPG_FromArmored_EmptyReader(t *testing.T) {
 
 	assert.Error(err, "should have failed trying to read empty reader")
 }
+
+func TestGPG_Unlock(t *testing.T) {
+	assert := assertion.New(t)
+
+	opts := &packet.Config{Algorithm: packet.PubKeyAlgoEdDSA}
+	entity, err := openpgp.NewEntity("John Doe", "", "john.doe@example.com", opts)
+	if err != nil {
+		t.Fatalf("entity creation failed: %s", err)
+	}
+
+	err = Unlock(entity, nil)
+	assert.NoError(err, "an unprotected key should not require a passphrase")
+
+	err = entity.EncryptPrivateKeys([]byte("passphrase"), nil)
+	if err != nil {
+		t.Fatalf("encrypting private keys failed: %s", err)
+	}
+
+	err = Unlock(entity, nil)
+	assert.ErrorIs(err, ErrEncryptedKey)
+
+	err = Unlock(entity, []byte("wrong"))
+	assert.Error(err, "should have failed with a wrong passphrase")
+
+	err = Unlock(entity, []byte("passphrase"))
+	assert.NoError(err, "should have decrypted the private keys")
+	assert.False(entity.PrivateKey.Encrypted, "private key should be decrypted")
+}
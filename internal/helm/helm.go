@@ -0,0 +1,33 @@
+// Package helm provides functions to bump the version of Helm charts released from a monorepo.
+package helm
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// ChartFile is the name of the file describing a chart, at the root of its directory.
+const ChartFile = "Chart.yaml"
+
+var ErrNoVersion = errors.New("chart has no version")
+
+var (
+	versionRegex    = regexp.MustCompile(`(?m)^(version:[ \t]*)(["']?)[^"'\s#]*(["']?)`)
+	appVersionRegex = regexp.MustCompile(`(?m)^(appVersion:[ \t]*)(["']?)[^"'\s#]*(["']?)`)
+)
+
+// Bump returns the content of a Chart.yaml file whose version, and appVersion if any, are set to the given version.
+// The rest of the file, including its comments and the quoting of the values, is kept as is.
+func Bump(content []byte, version string) ([]byte, error) {
+	if !versionRegex.Match(content) {
+		return nil, ErrNoVersion
+	}
+
+	replacement := []byte(fmt.Sprintf("${1}${2}%s${3}", version))
+
+	content = versionRegex.ReplaceAll(content, replacement)
+	content = appVersionRegex.ReplaceAll(content, replacement)
+
+	return content, nil
+}
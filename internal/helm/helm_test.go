@@ -0,0 +1,50 @@
+package helm
+
+import (
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestHelm_Bump(t *testing.T) {
+	assert := assertion.New(t)
+
+	chart := `apiVersion: v2
+name: api
+# The version of the chart, bumped on release.
+version: 0.1.0 # managed by go-semver-release
+appVersion: "0.1.0"
+dependencies:
+  - name: redis
+    version: 17.0.0
+`
+
+	bumped, err := Bump([]byte(chart), "1.2.0-rc.1")
+	if err != nil {
+		t.Fatalf("bumping chart: %s", err)
+	}
+
+	assert.Equal(`apiVersion: v2
+name: api
+# The version of the chart, bumped on release.
+version: 1.2.0-rc.1 # managed by go-semver-release
+appVersion: "1.2.0-rc.1"
+dependencies:
+  - name: redis
+    version: 17.0.0
+`, string(bumped), "only the top-level versions should be bumped, keeping comments and quotes")
+}
+
+func TestHelm_BumpWithoutAppVersion(t *testing.T) {
+	assert := assertion.New(t)
+
+	bumped, err := Bump([]byte("apiVersion: v2\nname: lib\ntype: library\nversion: '0.1.0'\n"), "0.2.0")
+	if err != nil {
+		t.Fatalf("bumping chart: %s", err)
+	}
+
+	assert.Equal("apiVersion: v2\nname: lib\ntype: library\nversion: '0.2.0'\n", string(bumped))
+
+	_, err = Bump([]byte("apiVersion: v2\nname: lib\n"), "0.2.0")
+	assert.ErrorIs(err, ErrNoVersion)
+}
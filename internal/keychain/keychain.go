@@ -0,0 +1,61 @@
+// Package keychain provides functions to read secrets from the keychain of the operating system, i.e. the macOS
+// Keychain, the Windows Credential Manager or a Secret Service implementation such as GNOME Keyring on Linux, so that
+// secrets such as signing keys do not have to be written to plaintext files.
+package keychain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/zalando/go-keyring"
+)
+
+// Scheme prefixes the values referencing a keychain secret, such as "keychain:go-semver-release/signing-key".
+const Scheme = "keychain:"
+
+var ErrInvalidReference = errors.New("invalid keychain reference, must be keychain:<service>/<account>")
+
+// IsReference returns whether a value references a keychain secret.
+func IsReference(value string) bool {
+	return strings.HasPrefix(value, Scheme)
+}
+
+// Get returns the secret referenced by a value of the form "keychain:<service>/<account>". The service may contain
+// slashes, the account being what follows the last one.
+func Get(reference string) (string, error) {
+	service, account, err := parse(reference)
+	if err != nil {
+		return "", err
+	}
+
+	secret, err := keyring.Get(service, account)
+	if err != nil {
+		return "", fmt.Errorf("reading keychain secret of account %q of service %q: %w", account, service, err)
+	}
+
+	return secret, nil
+}
+
+// Resolve returns the secret referenced by a value if it is a keychain reference, and the value itself otherwise.
+func Resolve(value string) (string, error) {
+	if !IsReference(value) {
+		return value, nil
+	}
+
+	return Get(value)
+}
+
+func parse(reference string) (service string, account string, err error) {
+	path, ok := strings.CutPrefix(reference, Scheme)
+	if !ok {
+		return "", "", fmt.Errorf("%w: %q", ErrInvalidReference, reference)
+	}
+
+	i := strings.LastIndex(path, "/")
+	if i <= 0 || i == len(path)-1 {
+		return "", "", fmt.Errorf("%w: %q", ErrInvalidReference, reference)
+	}
+
+	return path[:i], path[i+1:], nil
+}
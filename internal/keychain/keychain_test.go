@@ -0,0 +1,53 @@
+package keychain
+
+import (
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+	"github.com/zalando/go-keyring"
+)
+
+func TestIsReference(t *testing.T) {
+	assert := assertion.New(t)
+
+	assert.True(IsReference("keychain:go-semver-release/signing-key"))
+	assert.False(IsReference("./path/to/key.asc"))
+}
+
+func TestGet(t *testing.T) {
+	assert := assertion.New(t)
+
+	keyring.MockInit()
+
+	err := keyring.Set("acme/go-semver-release", "signing-key", "secret")
+	assert.NoError(err, "should not have errored")
+
+	secret, err := Get("keychain:acme/go-semver-release/signing-key")
+	assert.NoError(err, "should not have errored")
+	assert.Equal("secret", secret)
+
+	_, err = Get("keychain:acme/go-semver-release/missing")
+	assert.ErrorIs(err, keyring.ErrNotFound)
+
+	for _, reference := range []string{"keychain:signing-key", "keychain:/signing-key", "keychain:acme/", "signing-key"} {
+		_, err = Get(reference)
+		assert.ErrorIs(err, ErrInvalidReference, reference)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	assert := assertion.New(t)
+
+	keyring.MockInit()
+
+	err := keyring.Set("go-semver-release", "passphrase", "secret")
+	assert.NoError(err, "should not have errored")
+
+	value, err := Resolve("keychain:go-semver-release/passphrase")
+	assert.NoError(err, "should not have errored")
+	assert.Equal("secret", value)
+
+	value, err = Resolve("plaintext")
+	assert.NoError(err, "should not have errored")
+	assert.Equal("plaintext", value)
+}
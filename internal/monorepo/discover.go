@@ -0,0 +1,331 @@
+package monorepo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	CargoManifest = "Cargo.toml"
+	NPMManifest   = "package.json"
+	PNPMWorkspace = "pnpm-workspace.yaml"
+)
+
+var ErrDuplicateProject = errors.New("several projects have the same name")
+
+// IsWorkspaceManifest reports whether the file at the given path is one of the manifests read to discover projects.
+func IsWorkspaceManifest(name string) bool {
+	switch path.Base(name) {
+	case CargoManifest, NPMManifest, PNPMWorkspace:
+		return true
+	default:
+		return false
+	}
+}
+
+// member is a package of a workspace, named after its package without the scope of npm packages, dependencies being
+// the names of the packages it depends on.
+type member struct {
+	dir          string
+	name         string
+	pkg          string
+	dependencies []string
+}
+
+// Discover returns the projects defined by the Cargo, npm and pnpm workspaces of a repository, given the content of
+// its manifests indexed by their slash-separated path relative to the root of the repository. Every member package of
+// a workspace is a project named after its package, without the scope of npm packages, and depending on the other
+// members it depends on.
+func Discover(manifests map[string][]byte) ([]Project, error) {
+	cargo, err := discoverCargo(manifests)
+	if err != nil {
+		return nil, err
+	}
+
+	npm, err := discoverNPM(manifests)
+	if err != nil {
+		return nil, err
+	}
+
+	var members []member
+
+	dirs := make(map[string]string)
+	packages := make(map[string]string)
+
+	for _, m := range append(cargo, npm...) {
+		if dir, ok := dirs[m.name]; ok {
+			// A package member of both a Cargo and an npm workspace, e.g. a crate compiled to WebAssembly, is a
+			// single project.
+			if dir != m.dir {
+				return nil, fmt.Errorf("%w %q: %q and %q", ErrDuplicateProject, m.name, dir, m.dir)
+			}
+		} else {
+			members = append(members, m)
+		}
+
+		dirs[m.name] = m.dir
+		packages[m.pkg] = m.name
+	}
+
+	projects := make([]Project, 0, len(members))
+
+	for _, m := range members {
+		project := Project{Name: m.name, Path: filepath.FromSlash(m.dir)}
+
+		for _, dependency := range m.dependencies {
+			name, ok := packages[dependency]
+			if ok && name != m.name && !slices.Contains(project.DependsOn, name) {
+				project.DependsOn = append(project.DependsOn, name)
+			}
+		}
+
+		slices.Sort(project.DependsOn)
+		projects = append(projects, project)
+	}
+
+	slices.SortFunc(projects, func(a, b Project) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	if _, err = Order(projects); err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
+
+// Merge returns the discovered projects along with the configured ones, a configured project replacing the discovered
+// project of the same name so that its settings, e.g. its tag source, can be given.
+func Merge(discovered, configured []Project) []Project {
+	projects := make([]Project, 0, len(discovered)+len(configured))
+
+	for _, project := range discovered {
+		if !slices.ContainsFunc(configured, func(p Project) bool { return p.Name == project.Name }) {
+			projects = append(projects, project)
+		}
+	}
+
+	return append(projects, configured...)
+}
+
+type cargoManifest struct {
+	Workspace struct {
+		Members []string `toml:"members"`
+		Exclude []string `toml:"exclude"`
+	} `toml:"workspace"`
+	Package struct {
+		Name string `toml:"name"`
+	} `toml:"package"`
+	Dependencies      map[string]any `toml:"dependencies"`
+	DevDependencies   map[string]any `toml:"dev-dependencies"`
+	BuildDependencies map[string]any `toml:"build-dependencies"`
+}
+
+// discoverCargo returns the members of the Cargo workspace defined at the root of the repository, if any.
+func discoverCargo(manifests map[string][]byte) ([]member, error) {
+	content, ok := manifests[CargoManifest]
+	if !ok {
+		return nil, nil
+	}
+
+	var root cargoManifest
+	if err := toml.Unmarshal(content, &root); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", CargoManifest, err)
+	}
+
+	var members []member
+
+	for _, dir := range matchDirs(manifests, CargoManifest, root.Workspace.Members, root.Workspace.Exclude) {
+		manifestPath := path.Join(dir, CargoManifest)
+
+		var manifest cargoManifest
+		if err := toml.Unmarshal(manifests[manifestPath], &manifest); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", manifestPath, err)
+		}
+
+		if manifest.Package.Name == "" {
+			return nil, fmt.Errorf("parsing %s: %w", manifestPath, ErrNoName)
+		}
+
+		m := member{dir: dir, name: manifest.Package.Name, pkg: manifest.Package.Name}
+
+		for _, table := range []map[string]any{manifest.Dependencies, manifest.DevDependencies, manifest.BuildDependencies} {
+			for name, spec := range table {
+				// A dependency renamed in the manifest gives the name of its package in its "package" key.
+				if detailed, ok := spec.(map[string]any); ok {
+					if pkg, ok := detailed["package"].(string); ok {
+						name = pkg
+					}
+				}
+
+				m.dependencies = append(m.dependencies, name)
+			}
+		}
+
+		members = append(members, m)
+	}
+
+	return members, nil
+}
+
+type npmManifest struct {
+	Name                 string            `json:"name"`
+	Workspaces           json.RawMessage   `json:"workspaces"`
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	PeerDependencies     map[string]string `json:"peerDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+}
+
+// discoverNPM returns the members of the pnpm workspace, or else of the npm or Yarn workspaces, defined at the root of
+// the repository, if any.
+func discoverNPM(manifests map[string][]byte) ([]member, error) {
+	var patterns []string
+
+	if content, ok := manifests[PNPMWorkspace]; ok {
+		var workspace struct {
+			Packages []string `yaml:"packages"`
+		}
+
+		if err := yaml.Unmarshal(content, &workspace); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", PNPMWorkspace, err)
+		}
+
+		patterns = workspace.Packages
+	} else if content, ok := manifests[NPMManifest]; ok {
+		var root npmManifest
+		if err := json.Unmarshal(content, &root); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", NPMManifest, err)
+		}
+
+		// Workspaces are either an array of patterns, or an object listing them under "packages" as done by Yarn.
+		if len(root.Workspaces) > 0 && json.Unmarshal(root.Workspaces, &patterns) != nil {
+			var workspaces struct {
+				Packages []string `json:"packages"`
+			}
+
+			if err := json.Unmarshal(root.Workspaces, &workspaces); err != nil {
+				return nil, fmt.Errorf("parsing %s workspaces: %w", NPMManifest, err)
+			}
+
+			patterns = workspaces.Packages
+		}
+	}
+
+	var include, exclude []string
+
+	for _, pattern := range patterns {
+		if excluded, ok := strings.CutPrefix(pattern, "!"); ok {
+			exclude = append(exclude, excluded)
+		} else {
+			include = append(include, pattern)
+		}
+	}
+
+	var members []member
+
+	for _, dir := range matchDirs(manifests, NPMManifest, include, exclude) {
+		manifestPath := path.Join(dir, NPMManifest)
+
+		var manifest npmManifest
+		if err := json.Unmarshal(manifests[manifestPath], &manifest); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", manifestPath, err)
+		}
+
+		name := manifest.Name
+		if name == "" {
+			name = path.Base(dir)
+		}
+
+		m := member{dir: dir, name: unscoped(name), pkg: name}
+
+		for _, table := range []map[string]string{manifest.Dependencies, manifest.DevDependencies, manifest.PeerDependencies, manifest.OptionalDependencies} {
+			for dependency := range table {
+				m.dependencies = append(m.dependencies, dependency)
+			}
+		}
+
+		members = append(members, m)
+	}
+
+	return members, nil
+}
+
+// matchDirs returns the sorted directories, other than the root, holding a manifest of the given name and matching one
+// of the included patterns but none of the excluded ones. Installed npm packages are never matched.
+func matchDirs(manifests map[string][]byte, manifest string, include, exclude []string) []string {
+	var dirs []string
+
+	for name := range manifests {
+		if path.Base(name) != manifest || name == manifest || slices.Contains(strings.Split(name, "/"), "node_modules") {
+			continue
+		}
+
+		dir := path.Dir(name)
+
+		matches := func(pattern string) bool {
+			return matchPattern(pattern, dir)
+		}
+
+		if slices.ContainsFunc(include, matches) && !slices.ContainsFunc(exclude, matches) {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	slices.Sort(dirs)
+
+	return dirs
+}
+
+// matchPattern reports whether a directory matches a workspace pattern, such as "crates/*" or "packages/**", in which
+// "**" matches any number of directories.
+func matchPattern(pattern, dir string) bool {
+	pattern = strings.Trim(path.Clean(strings.TrimPrefix(pattern, "./")), "/")
+
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(dir, "/"))
+}
+
+func matchSegments(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if len(segments) == 0 {
+		return false
+	}
+
+	if ok, err := path.Match(pattern[0], segments[0]); err != nil || !ok {
+		return false
+	}
+
+	return matchSegments(pattern[1:], segments[1:])
+}
+
+// unscoped returns the name of an npm package without its scope, e.g. "ui" for "@acme/ui".
+func unscoped(name string) string {
+	if strings.HasPrefix(name, "@") {
+		if _, after, ok := strings.Cut(name, "/"); ok {
+			return after
+		}
+	}
+
+	return name
+}
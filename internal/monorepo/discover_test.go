@@ -0,0 +1,140 @@
+package monorepo
+
+import (
+	"path/filepath"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestMonorepo_DiscoverCargo(t *testing.T) {
+	assert := assertion.New(t)
+
+	manifests := map[string][]byte{
+		"Cargo.toml":             []byte("[workspace]\nmembers = [\"crates/*\"]\nexclude = [\"crates/scratch\"]\n"),
+		"crates/core/Cargo.toml": []byte("[package]\nname = \"core\"\n\n[dependencies]\nserde = \"1\"\n"),
+		"crates/cli/Cargo.toml": []byte("[package]\nname = \"cli\"\n\n[dependencies]\nengine = { path = \"../core\", package = \"core\" }\n\n" +
+			"[dev-dependencies]\ncore = { workspace = true }\n"),
+		"crates/scratch/Cargo.toml": []byte("[package]\nname = \"scratch\"\n"),
+		"tools/Cargo.toml":          []byte("[package]\nname = \"tools\"\n"),
+	}
+
+	projects, err := Discover(manifests)
+	if err != nil {
+		t.Fatalf("discovering projects: %s", err)
+	}
+
+	want := []Project{
+		{Name: "cli", Path: filepath.FromSlash("crates/cli"), DependsOn: []string{"core"}},
+		{Name: "core", Path: filepath.FromSlash("crates/core")},
+	}
+
+	assert.Equal(want, projects)
+}
+
+func TestMonorepo_DiscoverNPM(t *testing.T) {
+	assert := assertion.New(t)
+
+	manifests := map[string][]byte{
+		"package.json":                            []byte(`{"name": "root", "private": true, "workspaces": ["packages/**", "!packages/legacy"]}`),
+		"packages/ui/package.json":                []byte(`{"name": "@acme/ui", "dependencies": {"react": "^18.0.0"}}`),
+		"packages/web/package.json":               []byte(`{"name": "@acme/web", "dependencies": {"@acme/ui": "*"}, "devDependencies": {"@other/ui": "^1.0.0"}}`),
+		"packages/apps/a/package.json":            []byte(`{"name": "app", "peerDependencies": {"@acme/web": "*"}}`),
+		"packages/legacy/package.json":            []byte(`{"name": "legacy"}`),
+		"packages/ui/node_modules/x/package.json": []byte(`{"name": "x"}`),
+	}
+
+	projects, err := Discover(manifests)
+	if err != nil {
+		t.Fatalf("discovering projects: %s", err)
+	}
+
+	want := []Project{
+		{Name: "app", Path: filepath.FromSlash("packages/apps/a"), DependsOn: []string{"web"}},
+		{Name: "ui", Path: filepath.FromSlash("packages/ui")},
+		{Name: "web", Path: filepath.FromSlash("packages/web"), DependsOn: []string{"ui"}},
+	}
+
+	assert.Equal(want, projects, "scoped packages should be named without their scope, and installed packages ignored")
+
+	manifests["package.json"] = []byte(`{"workspaces": {"packages": ["packages/ui", "packages/web"]}}`)
+
+	projects, err = Discover(manifests)
+	if err != nil {
+		t.Fatalf("discovering projects: %s", err)
+	}
+
+	assert.Len(projects, 2, "Yarn workspaces should be discovered")
+}
+
+func TestMonorepo_DiscoverPNPM(t *testing.T) {
+	assert := assertion.New(t)
+
+	manifests := map[string][]byte{
+		"package.json":             []byte(`{"name": "root", "workspaces": ["ignored/*"]}`),
+		"pnpm-workspace.yaml":      []byte("packages:\n  - 'apps/*'\n  - 'libs/*'\n"),
+		"apps/web/package.json":    []byte(`{"name": "web", "dependencies": {"utils": "workspace:*"}}`),
+		"libs/utils/package.json":  []byte(`{"name": "utils"}`),
+		"ignored/foo/package.json": []byte(`{"name": "foo"}`),
+	}
+
+	projects, err := Discover(manifests)
+	if err != nil {
+		t.Fatalf("discovering projects: %s", err)
+	}
+
+	want := []Project{
+		{Name: "web", Path: filepath.FromSlash("apps/web"), DependsOn: []string{"utils"}},
+		{Name: "utils", Path: filepath.FromSlash("libs/utils")},
+	}
+
+	assert.Equal(want, projects, "the pnpm workspace should take precedence over the npm one")
+}
+
+func TestMonorepo_DiscoverErrors(t *testing.T) {
+	assert := assertion.New(t)
+
+	_, err := Discover(map[string][]byte{
+		"package.json":         []byte(`{"workspaces": ["a", "b"]}`),
+		"a/package.json":       []byte(`{"name": "@acme/lib"}`),
+		"b/package.json":       []byte(`{"name": "@other/lib"}`),
+		"unrelated/Cargo.toml": []byte("not toml"),
+	})
+	assert.ErrorIs(err, ErrDuplicateProject)
+
+	_, err = Discover(map[string][]byte{
+		"package.json":   []byte(`{"workspaces": ["a", "b"]}`),
+		"a/package.json": []byte(`{"name": "a", "dependencies": {"b": "*"}}`),
+		"b/package.json": []byte(`{"name": "b", "devDependencies": {"a": "*"}}`),
+	})
+	assert.ErrorIs(err, ErrDependencyCycle)
+
+	_, err = Discover(map[string][]byte{
+		"Cargo.toml":      []byte("[workspace]\nmembers = [\"core\"]\n"),
+		"core/Cargo.toml": []byte("[package\n"),
+	})
+	assert.Error(err, "invalid manifests should not be ignored")
+
+	projects, err := Discover(map[string][]byte{"package.json": []byte(`{"name": "app"}`)})
+	assert.NoError(err)
+	assert.Empty(projects, "a repository without workspaces should have no projects")
+}
+
+func TestMonorepo_Merge(t *testing.T) {
+	assert := assertion.New(t)
+
+	discovered := []Project{{Name: "ui", Path: "ui"}, {Name: "web", Path: "web", DependsOn: []string{"ui"}}}
+	configured := []Project{{Name: "web", Path: "web", Type: TypeHelmChart}, {Name: "docs", Path: "docs"}}
+
+	want := []Project{{Name: "ui", Path: "ui"}, {Name: "web", Path: "web", Type: TypeHelmChart}, {Name: "docs", Path: "docs"}}
+
+	assert.Equal(want, Merge(discovered, configured))
+}
+
+func TestMonorepo_IsWorkspaceManifest(t *testing.T) {
+	assert := assertion.New(t)
+
+	assert.True(IsWorkspaceManifest("crates/core/Cargo.toml"))
+	assert.True(IsWorkspaceManifest("pnpm-workspace.yaml"))
+	assert.False(IsWorkspaceManifest("crates/core/Cargo.lock"))
+}
@@ -2,11 +2,15 @@ package monorepo
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/pflag"
 )
 
+var ErrInvalidValue = errors.New("invalid project value")
+
 type Flag []map[string]string
 
 const FlagType = "JSON string"
@@ -24,16 +28,59 @@ func (f *Flag) String() string {
 	return string(b)
 }
 
+// Set parses a JSON array of projects. A project key given as a list, such as "branches": ["main"], is turned into a
+// comma-separated string.
 func (f *Flag) Set(value string) error {
-	var temp []map[string]string
-	if err := json.Unmarshal([]byte(value), &temp); err != nil {
+	var raw []map[string]any
+	if err := json.Unmarshal([]byte(value), &raw); err != nil {
 		return fmt.Errorf("unmarshalling monorepo flag value: %w", err)
 	}
 
+	temp := make([]map[string]string, len(raw))
+
+	for i, project := range raw {
+		temp[i] = make(map[string]string, len(project))
+
+		for key, v := range project {
+			s, err := flagString(v)
+			if err != nil {
+				return fmt.Errorf("unmarshalling monorepo flag value: key %q: %w", key, err)
+			}
+
+			temp[i][key] = s
+		}
+	}
+
 	*f = temp
 	return nil
 }
 
+// flagString returns the string form of a project value, a list being joined by commas.
+func flagString(v any) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case []any:
+		items := make([]string, len(v))
+		for i, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return "", fmt.Errorf("%w: list items must be strings", ErrInvalidValue)
+			}
+
+			items[i] = s
+		}
+
+		return strings.Join(items, ","), nil
+	case map[string]any:
+		return "", fmt.Errorf("%w: objects are not supported", ErrInvalidValue)
+	case nil:
+		return "", nil
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
+
 func (f *Flag) Type() string {
 	return FlagType
 }
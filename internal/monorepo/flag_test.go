@@ -40,6 +40,22 @@ func TestBranchFlag_Set(t *testing.T) {
 	assert.Error(t, err, "should have errored, invalid JSON string")
 }
 
+func TestBranchFlag_SetList(t *testing.T) {
+	assert := assert.New(t)
+
+	var flag Flag
+
+	err := flag.Set(`[{"name": "api", "path": "api", "branches": ["main", "rc"], "depends-on": []}]`)
+	assert.NoError(err, "should not have errored")
+	assert.Equal(Flag{{"name": "api", "path": "api", "branches": "main,rc", "depends-on": ""}}, flag)
+
+	err = flag.Set(`[{"name": "api", "branches": [1]}]`)
+	assert.ErrorIs(err, ErrInvalidValue)
+
+	err = flag.Set(`[{"name": "api", "branches": {"main": true}}]`)
+	assert.ErrorIs(err, ErrInvalidValue)
+}
+
 func TestBranchFlag_Type(t *testing.T) {
 	var f Flag
 
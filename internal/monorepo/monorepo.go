@@ -3,18 +3,51 @@ package monorepo
 
 import (
 	"errors"
+	"fmt"
 	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/s0ders/go-semver-release/v6/internal/terraform"
 )
 
+// TypeHelmChart is the type of the projects that are Helm charts, whose Chart.yaml file is bumped on release.
+const TypeHelmChart = "helm-chart"
+
 var (
-	ErrNoProjects = errors.New("no projects found in configuration file despite operating in monorepo mode")
-	ErrNoName     = errors.New("project has no name")
-	ErrNoPath     = errors.New("project has no path")
+	ErrNoProjects  = errors.New("no projects found in configuration file despite operating in monorepo mode")
+	ErrNoName      = errors.New("project has no name")
+	ErrNoPath      = errors.New("project has no path")
+	ErrUnknownType = errors.New("unknown project type")
+	ErrRootPath    = errors.New("root path must be a subdirectory of the repository")
+
+	ErrUnknownDependency = errors.New("project depends on an unknown project")
+	ErrDependencyCycle   = errors.New("projects depend on each other")
 )
 
 type Project struct {
 	Path string
 	Name string
+	// Type, if set, is the kind of the project, whose manifest is bumped on release: TypeHelmChart.
+	Type string
+	// TagSource is the path or URL of an external repository, typically produced by "git subtree split", from which
+	// the project's latest version is read instead of the monorepo tags.
+	TagSource string
+	// GPGKeyPath, if set, is the path of the armored GPG key signing the tags of the project, which takes precedence
+	// over the key of the branch.
+	GPGKeyPath string
+	// GitName and GitEmail, if set, override the identity of the tagger of the tags of the project, taking precedence
+	// over the identity of the branch.
+	GitName  string
+	GitEmail string
+	// DependsOn are the names of the projects that must be released before this one.
+	DependsOn []string
+	// Branches, if set, are the names of the only branches from which the project is released.
+	Branches []string
+	// TerraformModule, if set, is the module of a private Terraform registry published from the project, along with
+	// TerraformPublish, its publishing mode.
+	TerraformModule  *terraform.Module
+	TerraformPublish string
 }
 
 // Unmarshall takes a raw Viper configuration and returns a slice of Project representing various projects in a
@@ -39,12 +72,133 @@ func Unmarshall(input []map[string]string) ([]Project, error) {
 		}
 
 		project := Project{
-			Name: name,
-			Path: filepath.Clean(path),
+			Name:       name,
+			Path:       filepath.Clean(path),
+			Type:       p["type"],
+			TagSource:  p["tag-source"],
+			GPGKeyPath: p["gpg-key-path"],
+			GitName:    p["git-name"],
+			GitEmail:   p["git-email"],
+		}
+
+		if project.Type != "" && project.Type != TypeHelmChart {
+			return nil, fmt.Errorf("project %q: %w %q, must be %q", name, ErrUnknownType, project.Type, TypeHelmChart)
+		}
+
+		if address := p["terraform-module"]; address != "" {
+			module, err := terraform.ParseModule(address)
+			if err != nil {
+				return nil, fmt.Errorf("project %q: %w", name, err)
+			}
+
+			project.TerraformModule = &module
+			project.TerraformPublish = p["terraform-publish"]
+
+			if project.TerraformPublish == "" {
+				project.TerraformPublish = terraform.PublishTag
+			}
+
+			if err = terraform.ValidatePublish(project.TerraformPublish); err != nil {
+				return nil, fmt.Errorf("project %q: %w", name, err)
+			}
+		}
+
+		for _, dependency := range strings.Split(p["depends-on"], ",") {
+			if dependency = strings.TrimSpace(dependency); dependency != "" {
+				project.DependsOn = append(project.DependsOn, dependency)
+			}
+		}
+
+		for _, b := range strings.Split(p["branches"], ",") {
+			if b = strings.TrimSpace(b); b != "" {
+				project.Branches = append(project.Branches, b)
+			}
 		}
 
 		projects[i] = project
 	}
 
+	if _, err := Order(projects); err != nil {
+		return nil, err
+	}
+
 	return projects, nil
 }
+
+// RootProject returns the single project of a repository released from one of its subdirectories, e.g.
+// "vendor-products/acme", only the commits changing that subdirectory being analyzed. The project is named after the
+// last element of its path, which therefore prefixes its tags, e.g. "acme-v1.2.3".
+func RootProject(path string) (Project, error) {
+	path = filepath.Clean(strings.TrimSpace(path))
+
+	if path == "." || filepath.IsAbs(path) || path == ".." || strings.HasPrefix(path, ".."+string(filepath.Separator)) {
+		return Project{}, fmt.Errorf("%w: %q", ErrRootPath, path)
+	}
+
+	return Project{Name: filepath.Base(path), Path: path}, nil
+}
+
+// OnBranch reports whether the project is released from the given branch, a project without branches being released
+// from every branch.
+func (p Project) OnBranch(name string) bool {
+	return len(p.Branches) == 0 || slices.Contains(p.Branches, name)
+}
+
+// Order returns the projects in release order: a project comes after the projects it depends on, and projects
+// otherwise keep their configured order.
+func Order(projects []Project) ([]Project, error) {
+	index := make(map[string]int, len(projects))
+	for i, project := range projects {
+		index[project.Name] = i
+	}
+
+	for _, project := range projects {
+		for _, dependency := range project.DependsOn {
+			if _, ok := index[dependency]; !ok {
+				return nil, fmt.Errorf("project %q depends on %q: %w", project.Name, dependency, ErrUnknownDependency)
+			}
+		}
+	}
+
+	ordered := make([]Project, 0, len(projects))
+	placed := make([]bool, len(projects))
+
+	for len(ordered) < len(projects) {
+		next := -1
+
+		for i, project := range projects {
+			if placed[i] {
+				continue
+			}
+
+			ready := true
+			for _, dependency := range project.DependsOn {
+				if !placed[index[dependency]] {
+					ready = false
+					break
+				}
+			}
+
+			if ready {
+				next = i
+				break
+			}
+		}
+
+		if next == -1 {
+			var names []string
+			for i, project := range projects {
+				if !placed[i] {
+					names = append(names, project.Name)
+				}
+			}
+
+			return nil, fmt.Errorf("ordering projects %s: %w", strings.Join(names, ", "), ErrDependencyCycle)
+		}
+
+		placed[next] = true
+		ordered = append(ordered, projects[next])
+	}
+
+	return ordered, nil
+}
@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/terraform"
 )
 
 func TestMonorepo_Unmarshall(t *testing.T) {
@@ -27,6 +29,49 @@ func TestMonorepo_Unmarshall(t *testing.T) {
 	assert.Equal(want, branches)
 }
 
+func TestMonorepo_UnmarshallTagSource(t *testing.T) {
+	assert := assertion.New(t)
+
+	have := []map[string]string{{"name": "foo", "path": "foo", "tag-source": "https://example.com/foo.git"}}
+
+	projects, err := Unmarshall(have)
+	if err != nil {
+		t.Fatalf("unmarshalling projects: %s", err)
+	}
+
+	assert.Equal("https://example.com/foo.git", projects[0].TagSource)
+}
+
+func TestMonorepo_UnmarshallGPGKeyPath(t *testing.T) {
+	assert := assertion.New(t)
+
+	have := []map[string]string{{"name": "foo", "path": "foo", "gpg-key-path": "foo.asc"}, {"name": "bar", "path": "bar"}}
+
+	projects, err := Unmarshall(have)
+	if err != nil {
+		t.Fatalf("unmarshalling projects: %s", err)
+	}
+
+	assert.Equal("foo.asc", projects[0].GPGKeyPath)
+	assert.Empty(projects[1].GPGKeyPath)
+}
+
+func TestMonorepo_UnmarshallGitIdentity(t *testing.T) {
+	assert := assertion.New(t)
+
+	have := []map[string]string{{"name": "foo", "path": "foo", "git-name": "Foo Bot", "git-email": "foo@bot.ci"}, {"name": "bar", "path": "bar"}}
+
+	projects, err := Unmarshall(have)
+	if err != nil {
+		t.Fatalf("unmarshalling projects: %s", err)
+	}
+
+	assert.Equal("Foo Bot", projects[0].GitName)
+	assert.Equal("foo@bot.ci", projects[0].GitEmail)
+	assert.Empty(projects[1].GitName)
+	assert.Empty(projects[1].GitEmail)
+}
+
 func TestMonorepo_UnmarshallErrors(t *testing.T) {
 	assert := assertion.New(t)
 
@@ -47,3 +92,123 @@ func TestMonorepo_UnmarshallErrors(t *testing.T) {
 		assert.Equal(tc.want, err)
 	}
 }
+
+func TestMonorepo_UnmarshallDependsOn(t *testing.T) {
+	assert := assertion.New(t)
+
+	have := []map[string]string{{"name": "api", "path": "api", "depends-on": "lib, proto"}, {"name": "lib", "path": "lib"}, {"name": "proto", "path": "proto"}}
+
+	projects, err := Unmarshall(have)
+	if err != nil {
+		t.Fatalf("unmarshalling projects: %s", err)
+	}
+
+	assert.Equal([]string{"lib", "proto"}, projects[0].DependsOn)
+	assert.Empty(projects[1].DependsOn)
+
+	_, err = Unmarshall([]map[string]string{{"name": "api", "path": "api", "depends-on": "lib"}})
+	assert.ErrorIs(err, ErrUnknownDependency)
+
+	_, err = Unmarshall([]map[string]string{{"name": "api", "path": "api", "depends-on": "lib"}, {"name": "lib", "path": "lib", "depends-on": "api"}})
+	assert.ErrorIs(err, ErrDependencyCycle)
+}
+
+func TestMonorepo_UnmarshallBranches(t *testing.T) {
+	assert := assertion.New(t)
+
+	have := []map[string]string{{"name": "api", "path": "api", "branches": "main, rc"}, {"name": "web", "path": "web"}}
+
+	projects, err := Unmarshall(have)
+	if err != nil {
+		t.Fatalf("unmarshalling projects: %s", err)
+	}
+
+	assert.Equal([]string{"main", "rc"}, projects[0].Branches)
+	assert.True(projects[0].OnBranch("rc"))
+	assert.False(projects[0].OnBranch("beta"))
+
+	assert.Empty(projects[1].Branches)
+	assert.True(projects[1].OnBranch("beta"), "project without branches should be released from every branch")
+}
+
+func TestMonorepo_RootProject(t *testing.T) {
+	assert := assertion.New(t)
+
+	project, err := RootProject("./vendor-products/acme/")
+	if err != nil {
+		t.Fatalf("creating root project: %s", err)
+	}
+
+	localizedPath, _ := filepath.Localize("vendor-products/acme")
+
+	assert.Equal(Project{Name: "acme", Path: localizedPath}, project)
+
+	for _, path := range []string{"", ".", "/", "/srv/acme", "..", "../acme"} {
+		_, err = RootProject(path)
+		assert.ErrorIs(err, ErrRootPath, "path %q should be rejected", path)
+	}
+}
+
+func TestMonorepo_Order(t *testing.T) {
+	assert := assertion.New(t)
+
+	projects := []Project{
+		{Name: "web", DependsOn: []string{"api"}},
+		{Name: "docs"},
+		{Name: "api", DependsOn: []string{"lib"}},
+		{Name: "lib"},
+	}
+
+	ordered, err := Order(projects)
+	if err != nil {
+		t.Fatalf("ordering projects: %s", err)
+	}
+
+	var names []string
+	for _, project := range ordered {
+		names = append(names, project.Name)
+	}
+
+	assert.Equal([]string{"docs", "lib", "api", "web"}, names)
+}
+
+func TestMonorepo_UnmarshallTerraformModule(t *testing.T) {
+	assert := assertion.New(t)
+
+	have := []map[string]string{
+		{"name": "vpc", "path": "vpc", "terraform-module": "app.terraform.io/acme/vpc/aws"},
+		{"name": "dns", "path": "dns", "terraform-module": "tfe.acme.com/acme/dns/aws", "terraform-publish": "api"},
+		{"name": "bar", "path": "bar"},
+	}
+
+	projects, err := Unmarshall(have)
+	if err != nil {
+		t.Fatalf("unmarshalling projects: %s", err)
+	}
+
+	assert.Equal(&terraform.Module{Host: "app.terraform.io", Namespace: "acme", Name: "vpc", Provider: "aws"}, projects[0].TerraformModule)
+	assert.Equal(terraform.PublishTag, projects[0].TerraformPublish, "modules should be published from their tags by default")
+	assert.Equal(terraform.PublishAPI, projects[1].TerraformPublish)
+	assert.Nil(projects[2].TerraformModule)
+
+	_, err = Unmarshall([]map[string]string{{"name": "vpc", "path": "vpc", "terraform-module": "acme/vpc/aws"}})
+	assert.ErrorIs(err, terraform.ErrInvalidModule)
+
+	_, err = Unmarshall([]map[string]string{{"name": "vpc", "path": "vpc", "terraform-module": "app.terraform.io/acme/vpc/aws", "terraform-publish": "webhook"}})
+	assert.ErrorIs(err, terraform.ErrInvalidPublish)
+}
+
+func TestMonorepo_UnmarshallType(t *testing.T) {
+	assert := assertion.New(t)
+
+	projects, err := Unmarshall([]map[string]string{{"name": "api", "path": "charts/api", "type": "helm-chart"}, {"name": "bar", "path": "bar"}})
+	if err != nil {
+		t.Fatalf("unmarshalling projects: %s", err)
+	}
+
+	assert.Equal(TypeHelmChart, projects[0].Type)
+	assert.Empty(projects[1].Type)
+
+	_, err = Unmarshall([]map[string]string{{"name": "api", "path": "api", "type": "kustomization"}})
+	assert.ErrorIs(err, ErrUnknownType)
+}
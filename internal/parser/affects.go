@@ -0,0 +1,55 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
+)
+
+// affectsTrailerRegex matches an "Affects:" trailer listing the monorepo projects a commit belongs to, e.g.
+// "Affects: api, web".
+var affectsTrailerRegex = regexp.MustCompile(`(?im)^affects:[ \t]*(.*)$`)
+
+// commitAffectsProject returns whether a commit belongs to a monorepo project. A commit declaring the projects it
+// affects with "Affects:" trailers belongs to those projects only, whatever files it changes, so that a refactor
+// touching every project can be released as a change of the one it is meant for. Other commits belong to the projects
+// whose files they change.
+func commitAffectsProject(commit *object.Commit, project monorepo.Project) (bool, error) {
+	if projects, ok := affectedProjects(commit.Message); ok {
+		for _, name := range projects {
+			if strings.EqualFold(name, project.Name) {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	}
+
+	return commitContainsProjectFiles(commit, project.Path)
+}
+
+// affectedProjects returns the names of the projects listed by the "Affects:" trailers of a commit message and whether
+// there are any. The header of the message is not a trailer and is therefore ignored.
+func affectedProjects(message string) ([]string, bool) {
+	_, body, _ := strings.Cut(message, "\n")
+
+	matches := affectsTrailerRegex.FindAllStringSubmatch(body, -1)
+	if matches == nil {
+		return nil, false
+	}
+
+	var projects []string
+
+	for _, match := range matches {
+		for _, name := range strings.Split(match[1], ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				projects = append(projects, name)
+			}
+		}
+	}
+
+	return projects, true
+}
@@ -0,0 +1,72 @@
+package parser
+
+import (
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
+	"github.com/s0ders/go-semver-release/v6/pkg/gittest"
+)
+
+func TestParser_AffectedProjects(t *testing.T) {
+	assert := assertion.New(t)
+
+	type test struct {
+		message  string
+		expected []string
+		ok       bool
+	}
+
+	matrix := []test{
+		{message: "feat: add foo", ok: false},
+		{message: "refactor: rename\n\nAffects: api", expected: []string{"api"}, ok: true},
+		{message: "refactor: rename\n\nSome details.\n\nAffects: api, web\nSigned-off-by: bob", expected: []string{"api", "web"}, ok: true},
+		{message: "refactor: rename\n\naffects: api\nAffects: web", expected: []string{"api", "web"}, ok: true},
+		{message: "Affects: api", ok: false},
+		{message: "refactor: rename\n\nAffects:", ok: true},
+	}
+
+	for _, tc := range matrix {
+		projects, ok := affectedProjects(tc.message)
+
+		assert.Equal(tc.ok, ok, "message: %q", tc.message)
+		assert.Equal(tc.expected, projects, "message: %q", tc.message)
+	}
+}
+
+func TestParser_ComputeNewSemver_AffectsTrailer(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(
+		gittest.CommitFile("feat", "api/main.go", "package main"),
+		gittest.CommitFile("feat", "web/index.html", "<html></html>"),
+		// Changes a file at the root of the repository, i.e. outside of every project, but is attributed to the
+		// web project.
+		gittest.CommitMessage("feat!: rewrite the frontend\n\nAffects: web"),
+	)
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	th := NewTestHelper(t)
+	parser := New(th.Ctx)
+
+	output, err := parser.ComputeNewSemver(testRepository.Repository, monorepo.Project{Name: "api", Path: "api"}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("0.1.0", output.Semver.String(), "commits attributed to another project should be ignored")
+
+	output, err = parser.ComputeNewSemver(testRepository.Repository, monorepo.Project{Name: "web", Path: "web"}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("1.0.0", output.Semver.String(), "commits attributed to the project should be considered")
+
+	// A project without path contains every file, but the trailer takes precedence over the changed paths.
+	output, err = parser.ComputeNewSemver(testRepository.Repository, monorepo.Project{Name: "all"}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("0.2.0", output.Semver.String(), "the trailer should take precedence over the changed paths")
+}
@@ -0,0 +1,86 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+var (
+	ErrNoCommitAsOf  = errors.New("branch has no commit as of the given date")
+	ErrAmbiguousAsOf = errors.New("ambiguous as-of commit")
+)
+
+// asOf is the point of the history as of which versions are computed, ignoring the commits and tags made afterward.
+type asOf struct {
+	date time.Time
+	// commit, if not zero, is the commit given instead of a date, whose date is the date of its committer.
+	commit plumbing.Hash
+}
+
+// resolveAsOf parses the point of the history given either by a date, in RFC 3339 format or as a day such as
+// "2024-03-01" covering the whole day in UTC, or by a revision such as a commit SHA or a tag.
+func resolveAsOf(repository *git.Repository, value string) (*asOf, error) {
+	if date, err := time.Parse(time.RFC3339, value); err == nil {
+		return &asOf{date: date}, nil
+	}
+
+	if day, err := time.Parse(time.DateOnly, value); err == nil {
+		return &asOf{date: day.Add(24*time.Hour - time.Second)}, nil
+	}
+
+	hash, err := resolveRevision(repository, value)
+	if err != nil {
+		return nil, fmt.Errorf("resolving as-of point: %w", err)
+	}
+
+	commit, err := repository.CommitObject(hash)
+	if err != nil {
+		return nil, fmt.Errorf("fetching as-of commit: %w", err)
+	}
+
+	return &asOf{date: commit.Committer.When, commit: hash}, nil
+}
+
+// excludesTag returns whether a tag was created after the as-of point, and therefore did not exist yet. A tag cannot
+// predate the commit it points to, the date of the commit is therefore used instead of a tagger date that precedes
+// it, which can only come from a skewed clock.
+func (a *asOf) excludesTag(tag *object.Tag) bool {
+	if a == nil {
+		return false
+	}
+
+	date := tag.Tagger.When
+
+	if commit, err := tag.Commit(); err == nil && commit.Committer.When.After(date) {
+		date = commit.Committer.When
+	}
+
+	return date.After(a.date)
+}
+
+// head returns the commit from which the history of the branch with the given tip is read: the as-of commit if one is
+// given, or else the most recent commit of the branch made at or before the as-of date.
+func (a *asOf) head(repository *git.Repository, tip plumbing.Hash) (plumbing.Hash, error) {
+	if !a.commit.IsZero() {
+		return a.commit, nil
+	}
+
+	commits, err := repository.Log(&git.LogOptions{From: tip, Until: &a.date, Order: git.LogOrderCommitterTime})
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("fetching commit history: %w", err)
+	}
+
+	defer commits.Close()
+
+	commit, err := commits.Next()
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("%w %s", ErrNoCommitAsOf, a.date.Format(time.RFC3339))
+	}
+
+	return commit.Hash, nil
+}
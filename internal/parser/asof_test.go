@@ -0,0 +1,116 @@
+package parser
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/branch"
+	"github.com/s0ders/go-semver-release/v6/pkg/gittest"
+)
+
+func TestAsOf_Run(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	first, err := testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+
+	err = testRepository.AddTag("v0.1.0", first)
+	checkErr(t, "adding tag", err)
+
+	fix, err := testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+
+	feat, err := testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+
+	err = testRepository.AddTag("v0.2.0", feat)
+	checkErr(t, "adding tag", err)
+
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+
+	fixCommit, err := testRepository.CommitObject(fix)
+	checkErr(t, "fetching commit", err)
+
+	th := NewTestHelper(t)
+
+	for _, asOf := range []string{fix.String(), fixCommit.Committer.When.Format(time.RFC3339)} {
+		th.Ctx.AsOfFlag = asOf
+
+		output, err := New(th.Ctx).Run(context.Background(), testRepository.Repository)
+		checkErr(t, "computing new semver", err)
+
+		assert.Equal("0.1.1", output[0].Semver.String(), "later commits and tags should be ignored as of %q", asOf)
+		assert.True(output[0].NewRelease)
+		assert.Equal(fix, output[0].CommitHash)
+	}
+
+	th.Ctx.AsOfFlag = fixCommit.Committer.When.Format(time.DateOnly)
+
+	output, err := New(th.Ctx).Run(context.Background(), testRepository.Repository)
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("0.2.1", output[0].Semver.String(), "a day should cover the commits of the whole day")
+
+	th.Ctx.AsOfFlag = "1999-12-31"
+
+	_, err = New(th.Ctx).Run(context.Background(), testRepository.Repository)
+	assert.ErrorIs(err, ErrNoCommitAsOf)
+
+	th.Ctx.AsOfFlag = fix.String()
+	th.Ctx.Branches = []branch.Branch{{Name: "master"}, {Name: "rc", Prerelease: true}}
+
+	_, err = New(th.Ctx).Run(context.Background(), testRepository.Repository)
+	assert.ErrorIs(err, ErrAmbiguousAsOf)
+}
+
+func TestAsOf_Run_SkewedTagger(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	first, err := testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+
+	err = testRepository.AddTag("v0.1.0", first)
+	checkErr(t, "adding tag", err)
+
+	feat, err := testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+
+	firstCommit, err := testRepository.CommitObject(first)
+	checkErr(t, "fetching commit", err)
+
+	// The tagger clock is a year behind, dating the tag before the as-of point while its commit was made after it.
+	_, err = testRepository.CreateTag("v0.2.0", feat, &git.CreateTagOptions{
+		Message: "v0.2.0",
+		Tagger:  &object.Signature{Name: "Go Semver Release", Email: "go-semver@release.ci", When: firstCommit.Committer.When.AddDate(-1, 0, 0)},
+	})
+	checkErr(t, "adding tag", err)
+
+	th := NewTestHelper(t)
+	th.Ctx.AsOfFlag = first.String()
+
+	output, err := New(th.Ctx).Run(context.Background(), testRepository.Repository)
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("0.1.0", output[0].Semver.String(), "a tag should not be dated before its commit")
+	assert.False(output[0].NewRelease)
+}
@@ -0,0 +1,97 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/s0ders/go-semver-release/v6/internal/branch"
+	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
+)
+
+var (
+	ErrUntraceableAuthorship = errors.New("released commits without traceable authorship")
+	ErrInvalidAuthorDomain   = errors.New("invalid author domain pattern")
+)
+
+// ValidateAuthorDomains checks that the author domain patterns are valid glob patterns.
+func ValidateAuthorDomains(patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("%w: %q", ErrInvalidAuthorDomain, pattern)
+		}
+	}
+
+	return nil
+}
+
+// checkAuthorship validates the authors of the commits released for a branch or project: every commit must have an
+// author name and email and, if author domains are configured, the domain of the email must match one of them. Every
+// violation is logged before failing, so that they can all be fixed at once. The caller must hold the parser lock.
+func (p *Parser) checkAuthorship(history []*object.Commit, project monorepo.Project, branch branch.Branch) error {
+	var violations int
+
+	for _, commit := range history {
+		if project.Name != "" {
+			affectsProject, err := commitAffectsProject(commit, project)
+			if err != nil {
+				return fmt.Errorf("checking if commit affects project: %w", err)
+			}
+			if !affectsProject {
+				continue
+			}
+		}
+
+		reason := authorshipViolation(commit.Author, p.ctx.AuthorDomainsFlag)
+		if reason == "" {
+			continue
+		}
+
+		violations++
+
+		logEvent := p.ctx.Logger.Warn().Str("branch", branch.Name).Str("commit", commit.Hash.String()).Str("author", commit.Author.String()).Str("reason", reason)
+		if project.Name != "" {
+			logEvent.Str("project", project.Name)
+		}
+		logEvent.Msg("commit authorship violation")
+	}
+
+	if violations > 0 {
+		return fmt.Errorf("%w: %d violation(s)", ErrUntraceableAuthorship, violations)
+	}
+
+	return nil
+}
+
+// authorshipViolation returns why the given author is not traceable, or an empty string if it is.
+func authorshipViolation(author object.Signature, domains []string) string {
+	if strings.TrimSpace(author.Name) == "" {
+		return "empty author name"
+	}
+
+	if strings.TrimSpace(author.Email) == "" {
+		return "empty author email"
+	}
+
+	at := strings.LastIndex(author.Email, "@")
+	if at <= 0 || at == len(author.Email)-1 {
+		return "invalid author email"
+	}
+
+	if len(domains) == 0 {
+		return ""
+	}
+
+	domain := strings.ToLower(author.Email[at+1:])
+
+	for _, pattern := range domains {
+		if ok, _ := path.Match(strings.ToLower(pattern), domain); ok {
+			return ""
+		}
+	}
+
+	return "author email domain not allowed"
+}
@@ -0,0 +1,89 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/branch"
+	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
+	"github.com/s0ders/go-semver-release/v6/pkg/gittest"
+)
+
+func TestParser_AuthorshipViolation(t *testing.T) {
+	assert := assertion.New(t)
+
+	type test struct {
+		author  object.Signature
+		domains []string
+		reason  string
+	}
+
+	matrix := []test{
+		{author: object.Signature{Name: "John Doe", Email: "john.doe@acme.com"}, reason: ""},
+		{author: object.Signature{Name: " ", Email: "john.doe@acme.com"}, reason: "empty author name"},
+		{author: object.Signature{Name: "John Doe"}, reason: "empty author email"},
+		{author: object.Signature{Name: "John Doe", Email: "john.doe"}, reason: "invalid author email"},
+		{author: object.Signature{Name: "John Doe", Email: "john.doe@"}, reason: "invalid author email"},
+		{author: object.Signature{Name: "John Doe", Email: "john.doe@acme.com"}, domains: []string{"acme.com"}, reason: ""},
+		{author: object.Signature{Name: "John Doe", Email: "john.doe@EU.Acme.com"}, domains: []string{"*.acme.com"}, reason: ""},
+		{author: object.Signature{Name: "John Doe", Email: "john.doe@gmail.com"}, domains: []string{"acme.com", "*.acme.com"}, reason: "author email domain not allowed"},
+	}
+
+	for _, tc := range matrix {
+		assert.Equal(tc.reason, authorshipViolation(tc.author, tc.domains), "author: %q, domains: %v", tc.author.String(), tc.domains)
+	}
+
+	assert.NoError(ValidateAuthorDomains([]string{"acme.com", "*.acme.com"}))
+	assert.ErrorIs(ValidateAuthorDomains([]string{"[acme.com"}), ErrInvalidAuthorDomain)
+}
+
+func TestParser_ComputeNewSemver_StrictAuthorship(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(
+		gittest.CommitFile("fix", "api/main.go", "api"),
+	)
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	worktree, err := testRepository.Worktree()
+	checkErr(t, "fetching worktree", err)
+
+	when := testRepository.When()
+
+	_, err = worktree.Commit("feat: anonymous commit", &git.CommitOptions{
+		AllowEmptyCommits: true,
+		Author:            &object.Signature{Email: "ghost@example.com", When: when},
+		Committer:         &object.Signature{Name: "Go Semver Release", Email: "go-semver@release.ci", When: when},
+	})
+	checkErr(t, "creating anonymous commit", err)
+
+	th := NewTestHelper(t)
+	th.Ctx.StrictAuthorshipFlag = true
+
+	_, err = New(th.Ctx).ComputeNewSemver(testRepository.Repository, monorepo.Project{}, branch.Branch{Name: "master"})
+	assert.ErrorIs(err, ErrUntraceableAuthorship)
+
+	output, err := New(th.Ctx).ComputeNewSemver(testRepository.Repository, monorepo.Project{Name: "api", Path: "api"}, branch.Branch{Name: "master"})
+	checkErr(t, "computing project new semver", err)
+
+	assert.Equal("0.0.1", output.Semver.String(), "commits of other projects should not be checked")
+
+	th.Ctx.AuthorDomainsFlag = []string{"acme.com"}
+
+	_, err = New(th.Ctx).ComputeNewSemver(testRepository.Repository, monorepo.Project{Name: "api", Path: "api"}, branch.Branch{Name: "master"})
+	assert.ErrorIs(err, ErrUntraceableAuthorship, "author email domain should be checked")
+
+	th.Ctx.StrictAuthorshipFlag = false
+
+	output, err = New(th.Ctx).ComputeNewSemver(testRepository.Repository, monorepo.Project{}, branch.Branch{Name: "master"})
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("0.1.0", output.Semver.String())
+}
@@ -0,0 +1,99 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// historyBoundary is the commit where the analysis of the history stops, such as the graft point of a migrated
+// repository whose older commits have meaningless dates and types.
+type historyBoundary struct {
+	hash plumbing.Hash
+	// before contains the boundary commit and all its ancestors.
+	before map[plumbing.Hash]bool
+}
+
+// excludesTag returns whether a tag points to a commit made before the boundary. A tag pointing to the boundary itself
+// is kept, so that a migrated repository can be released from the version it was tagged with.
+func (b *historyBoundary) excludesTag(tag *object.Tag) bool {
+	return b != nil && tag.TargetType == plumbing.CommitObject && tag.Target != b.hash && b.before[tag.Target]
+}
+
+// historyBoundary returns the configured history boundary of a repository, or nil if none is configured. Boundaries are
+// computed once per repository.
+func (p *Parser) historyBoundary(repository *git.Repository) (*historyBoundary, error) {
+	if p.ctx.HistoryBoundaryFlag == "" {
+		return nil, nil
+	}
+
+	if boundary, ok := p.boundaries[repository]; ok {
+		return boundary, nil
+	}
+
+	hash, err := resolveRevision(repository, p.ctx.HistoryBoundaryFlag)
+	if err != nil {
+		return nil, fmt.Errorf("resolving history boundary: %w", err)
+	}
+
+	commit, err := repository.CommitObject(hash)
+	if err != nil {
+		return nil, fmt.Errorf("fetching history boundary commit: %w", err)
+	}
+
+	boundary := &historyBoundary{hash: hash, before: make(map[plumbing.Hash]bool)}
+
+	err = object.NewCommitPreorderIter(commit, nil, nil).ForEach(func(c *object.Commit) error {
+		boundary.before[c.Hash] = true
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walking history before boundary: %w", err)
+	}
+
+	p.boundaries[repository] = boundary
+
+	return boundary, nil
+}
+
+// commitHistory returns the commits matching the given log options. The history is not traversed past the history
+// boundary, if any, so that the commits at or before it are never analyzed.
+func (p *Parser) commitHistory(repository *git.Repository, logOptions git.LogOptions) ([]*object.Commit, error) {
+	boundary, err := p.historyBoundary(repository)
+	if err != nil {
+		return nil, err
+	}
+
+	var commits object.CommitIter
+
+	if boundary == nil {
+		commits, err = repository.Log(&logOptions)
+		if err != nil {
+			return nil, fmt.Errorf("fetching commit history: %w", err)
+		}
+	} else {
+		head, err := repository.CommitObject(logOptions.From)
+		if err != nil {
+			return nil, fmt.Errorf("fetching commit history: %w", err)
+		}
+
+		commits = object.NewCommitPreorderIter(head, boundary.before, nil)
+	}
+
+	var history []*object.Commit
+
+	_ = commits.ForEach(func(c *object.Commit) error {
+		p.commits.Add(1)
+
+		if logOptions.Since != nil && c.Committer.When.Before(*logOptions.Since) {
+			return nil
+		}
+
+		history = append(history, c)
+		return nil
+	})
+
+	return history, nil
+}
@@ -0,0 +1,66 @@
+package parser
+
+import (
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
+	"github.com/s0ders/go-semver-release/v6/pkg/gittest"
+)
+
+func TestParser_ComputeNewSemver_HistoryBoundary(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(
+		gittest.Commit("feat!"),
+		gittest.Tag("v3.0.0"),
+		gittest.Commit("feat!"),
+	)
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	boundary, err := testRepository.AddCommit("fix")
+	checkErr(t, "adding boundary commit", err)
+
+	_, err = testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+
+	th := NewTestHelper(t)
+
+	output, err := New(th.Ctx).ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("4.1.0", output.Semver.String(), "the whole history should be analyzed without boundary")
+
+	th.Ctx.HistoryBoundaryFlag = boundary.String()
+
+	output, err = New(th.Ctx).ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("0.1.0", output.Semver.String(), "tags and commits before the boundary should be ignored")
+	assert.Len(output.Changes, 1, "the boundary commit should not be analyzed")
+
+	err = testRepository.AddTag("v1.0.0", boundary)
+	checkErr(t, "tagging boundary", err)
+
+	output, err = New(th.Ctx).ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("1.1.0", output.Semver.String(), "a tag on the boundary should be the base version")
+
+	th.Ctx.HistoryBoundaryFlag = "v1.0.0"
+
+	output, err = New(th.Ctx).ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("1.1.0", output.Semver.String(), "the boundary should be designated by a tag")
+
+	th.Ctx.HistoryBoundaryFlag = "missing"
+
+	_, err = New(th.Ctx).ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	assert.ErrorContains(err, "resolving history boundary")
+}
@@ -0,0 +1,73 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/s0ders/go-semver-release/v6/internal/branch"
+	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
+)
+
+// releasedElsewhere returns the changes already released on other branches than the one whose head is given, if
+// cherry-pick detection is enabled and the branch is a maintenance branch, i.e. one with a version range, or a
+// prerelease branch, nil otherwise. Changes are identified as for commit deduplication, so that a hotfix cherry-picked
+// from the main branch to a maintenance branch does not bump both, while a fix made on a maintenance branch and
+// ported to the main branch is still released there. A change is released elsewhere if its commit is reachable from a
+// release tag, of the given project if any, but not from the head.
+func (p *Parser) releasedElsewhere(repository *git.Repository, project monorepo.Project, branch branch.Branch, head *object.Commit) (map[string]bool, error) {
+	if !p.ctx.DetectCherryPicksFlag || project.TagSource != "" {
+		return nil, nil
+	}
+
+	if branch.VersionRange == nil && !branch.Prerelease {
+		return nil, nil
+	}
+
+	tags, err := p.semverTags(repository, project, "", false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Commits reachable from the head are on the branch itself, hence not released elsewhere.
+	seen := make(map[plumbing.Hash]bool)
+
+	err = object.NewCommitPreorderIter(head, nil, nil).ForEach(func(c *object.Commit) error {
+		seen[c.Hash] = true
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walking branch history: %w", err)
+	}
+
+	released := make(map[string]bool)
+
+	for _, t := range tags {
+		tagCommit, err := t.Commit()
+		if err != nil {
+			return nil, fmt.Errorf("fetching tag %q commit: %w", t.Name, err)
+		}
+
+		err = object.NewCommitPreorderIter(tagCommit, seen, nil).ForEach(func(c *object.Commit) error {
+			seen[c.Hash] = true
+
+			key, err := changeKey(c)
+			if err != nil {
+				return err
+			}
+
+			if key != "" {
+				released[key] = true
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("walking tag %q history: %w", t.Name, err)
+		}
+	}
+
+	return released, nil
+}
@@ -0,0 +1,85 @@
+package parser
+
+import (
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/branch"
+	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
+	"github.com/s0ders/go-semver-release/v6/pkg/gittest"
+)
+
+func TestParser_ComputeNewSemver_DetectCherryPicks(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(
+		gittest.Commit("fix"),
+		gittest.Tag("v1.0.0"),
+		gittest.Branch("release/1.x"),
+		gittest.Checkout("master"),
+		gittest.CommitFile("fix", "hotfix.txt", "hotfix"),
+		gittest.Tag("v1.0.1"),
+		gittest.Checkout("release/1.x"),
+		gittest.CommitFile("fix", "hotfix.txt", "hotfix"),
+		gittest.CommitFile("fix", "backport.txt", "backport"),
+	)
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	versionRange, err := semver.NewConstraint("1.x")
+	checkErr(t, "parsing version range", err)
+
+	th := NewTestHelper(t)
+	maintenance := branch.Branch{Name: "release/1.x", VersionRange: versionRange}
+
+	output, err := New(th.Ctx).ComputeNewSemver(testRepository.Repository, monorepo.Project{}, maintenance)
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("1.0.3", output.Semver.String(), "cherry-picks should be analyzed unless detection is enabled")
+
+	th.Ctx.DetectCherryPicksFlag = true
+
+	output, err = New(th.Ctx).ComputeNewSemver(testRepository.Repository, monorepo.Project{}, maintenance)
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("1.0.2", output.Semver.String(), "the cherry-picked hotfix was already released on master")
+	assert.Len(output.Changes, 1)
+}
+
+func TestParser_ComputeNewSemver_DetectCherryPicks_MainBranch(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(
+		gittest.Commit("fix"),
+		gittest.Tag("v1.0.0"),
+		gittest.Commit("feat"),
+		gittest.Tag("v1.1.0"),
+		gittest.Branch("release/1.x"),
+		gittest.Checkout("release/1.x"),
+		gittest.CommitFile("fix", "hotfix.txt", "hotfix"),
+		gittest.Tag("v1.1.1"),
+		gittest.Checkout("master"),
+		gittest.Commit("feat!"),
+		gittest.Tag("v2.0.0"),
+		gittest.CommitFile("fix", "hotfix.txt", "hotfix"),
+	)
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	th := NewTestHelper(t)
+	th.Ctx.DetectCherryPicksFlag = true
+
+	output, err := New(th.Ctx).ComputeNewSemver(testRepository.Repository, monorepo.Project{}, branch.Branch{Name: "master"})
+	checkErr(t, "computing new semver", err)
+
+	assert.True(output.NewRelease, "a fix ported from a maintenance branch should be released on the main branch")
+	assert.Equal("2.0.1", output.Semver.String())
+}
@@ -0,0 +1,65 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/s0ders/go-semver-release/v6/internal/rule"
+)
+
+// Classification describes how the configured rules classify a commit message. Rule and Release are empty when the
+// message triggers no release, because it is not a conventional commit, holds a skip marker or matches no rule.
+type Classification struct {
+	Conventional bool
+	Type         string
+	Scope        string
+	Breaking     bool
+	Skipped      bool
+	Rule         string
+	Release      string
+}
+
+// Classify returns how the configured rules classify a commit message, as done for every commit of the analyzed
+// history, caps on the release type per run aside.
+func (p *Parser) Classify(message string) Classification {
+	commit, ok := conventionalCommit(message)
+	if !ok {
+		return Classification{}
+	}
+
+	classification := Classification{
+		Conventional: true,
+		Type:         commit.Type,
+		Scope:        commit.Scope,
+		Breaking:     commit.Breaking,
+		Skipped:      p.hasSkipMarker(message),
+	}
+
+	if classification.Skipped {
+		return classification
+	}
+
+	if match, ok := p.ctx.Rules.Evaluate(commit); ok {
+		classification.Rule = match.Rule
+		classification.Release = match.Release
+	}
+
+	return classification
+}
+
+// conventionalCommit returns the type, scope and breaking change marker of a conventional commit message, and whether
+// the message is a conventional commit. A breaking change is marked by a "!" in the header or a "BREAKING CHANGE:"
+// footer in the body.
+func conventionalCommit(message string) (rule.Commit, bool) {
+	match := conventionalCommitRegex.FindStringSubmatch(message)
+	if match == nil {
+		return rule.Commit{}, false
+	}
+
+	_, body, _ := strings.Cut(message, "\n")
+
+	return rule.Commit{
+		Type:     match[1],
+		Scope:    strings.Trim(match[2], "()"),
+		Breaking: match[3] == "!" || breakingChangeRegex.MatchString(body),
+	}, true
+}
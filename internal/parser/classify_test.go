@@ -0,0 +1,38 @@
+package parser
+
+import (
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/rule"
+)
+
+func TestParser_Classify(t *testing.T) {
+	assert := assertion.New(t)
+
+	th := NewTestHelper(t)
+	th.Ctx.SkipMarkersFlag = []string{"[skip release]"}
+	th.Ctx.Rules = rule.Rules{Map: map[string]string{"feat": "minor", "fix": "patch", "fix(deps)": "minor"}}
+
+	p := New(th.Ctx)
+
+	type test struct {
+		message string
+		want    Classification
+	}
+
+	tests := []test{
+		{message: "feat(api)!: drop v1 endpoints", want: Classification{Conventional: true, Type: "feat", Scope: "api", Breaking: true, Rule: "breaking", Release: "major"}},
+		{message: "fix(deps): bump go-git", want: Classification{Conventional: true, Type: "fix", Scope: "deps", Rule: "fix(deps)", Release: "minor"}},
+		{message: "fix: handle empty payloads", want: Classification{Conventional: true, Type: "fix", Rule: "fix", Release: "patch"}},
+		{message: "refactor: drop v1 endpoints\n\nBREAKING CHANGE: v1 is removed", want: Classification{Conventional: true, Type: "refactor", Breaking: true, Rule: "breaking", Release: "major"}},
+		{message: "chore: tidy", want: Classification{Conventional: true, Type: "chore"}},
+		{message: "fix: flaky test [skip release]", want: Classification{Conventional: true, Type: "fix", Skipped: true}},
+		{message: "Update README", want: Classification{}},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(tc.want, p.Classify(tc.message), tc.message)
+	}
+}
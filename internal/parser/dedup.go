@@ -0,0 +1,117 @@
+package parser
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+var (
+	// changeIDRegex matches the "Change-Id" trailer identifying a logical change across its rewrites, as added by Gerrit.
+	changeIDRegex = regexp.MustCompile(`(?im)^change-id:[ \t]*(\S+)[ \t]*$`)
+	// hunkHeaderRegex matches the line numbers of a hunk header, which change when a patch is applied elsewhere.
+	hunkHeaderRegex = regexp.MustCompile(`(?m)^@@ [^@]* @@`)
+)
+
+// duplicateCommits returns the duplicate commits of the history if commit deduplication is enabled, nil otherwise.
+func (p *Parser) duplicateCommits(history []*object.Commit) (map[plumbing.Hash]bool, error) {
+	if !p.ctx.DeduplicateCommitsFlag {
+		return nil, nil
+	}
+
+	return findDuplicates(history)
+}
+
+// findDuplicates returns the commits of the history, sorted from the oldest to the most recent, that repeat an older
+// commit of the history, such as the original commits of a branch and the squash commit made from them. Commits are
+// the same change if they have the same "Change-Id" trailer or, failing that, the same patch ID, i.e. the same diff
+// regardless of line numbers and whitespaces, as computed by "git patch-id". Merge commits have no patch ID.
+func findDuplicates(history []*object.Commit) (map[plumbing.Hash]bool, error) {
+	duplicates := make(map[plumbing.Hash]bool)
+	seen := make(map[string]bool)
+
+	for _, commit := range history {
+		key, err := changeKey(commit)
+		if err != nil {
+			return nil, err
+		}
+
+		if key == "" {
+			continue
+		}
+
+		if seen[key] {
+			duplicates[commit.Hash] = true
+			continue
+		}
+
+		seen[key] = true
+	}
+
+	return duplicates, nil
+}
+
+// changeKey identifies the change made by a commit by its "Change-Id" trailer or, failing that, by its patch ID. It
+// returns an empty string for commits that cannot be identified, such as merge commits.
+func changeKey(commit *object.Commit) (string, error) {
+	if match := changeIDRegex.FindStringSubmatch(commit.Message); match != nil {
+		return "change-id:" + match[1], nil
+	}
+
+	id, err := patchID(commit)
+	if err != nil {
+		return "", fmt.Errorf("computing patch ID of commit %s: %w", commit.Hash, err)
+	}
+
+	if id == "" {
+		return "", nil
+	}
+
+	return "patch-id:" + id, nil
+}
+
+// patchID returns the patch ID of a commit, or an empty string for commits without a single parent or without diff.
+func patchID(commit *object.Commit) (string, error) {
+	if commit.NumParents() != 1 {
+		return "", nil
+	}
+
+	parent, err := commit.Parent(0)
+	if err != nil {
+		return "", err
+	}
+
+	patch, err := parent.Patch(commit)
+	if err != nil {
+		return "", err
+	}
+
+	if len(patch.FilePatches()) == 0 {
+		return "", nil
+	}
+
+	var normalized strings.Builder
+
+	for _, line := range strings.Split(hunkHeaderRegex.ReplaceAllString(patch.String(), "@@"), "\n") {
+		if strings.HasPrefix(line, "index ") {
+			continue
+		}
+
+		normalized.WriteString(strings.Map(func(r rune) rune {
+			if unicode.IsSpace(r) {
+				return -1
+			}
+			return r
+		}, line))
+	}
+
+	sum := sha1.Sum([]byte(normalized.String()))
+
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,80 @@
+package parser
+
+import (
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
+	"github.com/s0ders/go-semver-release/v6/pkg/gittest"
+)
+
+func TestParser_ComputeNewSemver_DeduplicateCommits(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(
+		gittest.Commit("fix"),
+		gittest.Tag("v1.0.0"),
+		gittest.Branch("feature"),
+		gittest.CommitFile("feat", "feature.txt", "feature"),
+		gittest.Checkout("master"),
+		gittest.CommitFile("feat", "feature.txt", "feature"),
+		gittest.Merge("feature", "Merge branch 'feature'"),
+		gittest.CommitMessage("fix: handle empty payloads\n\nChange-Id: I8473b95934b5732ac55d26311a706c9c2bde9940"),
+		gittest.CommitMessage("fix: handle empty payloads again\n\nChange-Id: I8473b95934b5732ac55d26311a706c9c2bde9940"),
+	)
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	th := NewTestHelper(t)
+
+	output, err := New(th.Ctx).ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("1.2.2", output.Semver.String(), "duplicates should be analyzed unless deduplication is enabled")
+
+	th.Ctx.DeduplicateCommitsFlag = true
+
+	output, err = New(th.Ctx).ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("1.1.1", output.Semver.String(), "commits with the same patch ID or Change-Id should be analyzed once")
+	assert.Len(output.Changes, 2)
+}
+
+func TestParser_PatchID(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(
+		gittest.CommitFile("feat", "a.txt", "a"),
+		gittest.Branch("feature"),
+		gittest.CommitFile("feat", "b.txt", "b"),
+		gittest.Checkout("master"),
+		gittest.Merge("feature", "Merge branch 'feature'"),
+	)
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	head, err := testRepository.Head()
+	checkErr(t, "fetching head", err)
+
+	merge, err := testRepository.CommitObject(head.Hash())
+	checkErr(t, "fetching merge commit", err)
+
+	id, err := patchID(merge)
+	checkErr(t, "computing patch ID", err)
+	assert.Empty(id, "merge commits should not have a patch ID")
+
+	feature, err := merge.Parent(1)
+	checkErr(t, "fetching merged commit", err)
+
+	id, err = patchID(feature)
+	checkErr(t, "computing patch ID", err)
+	assert.Len(id, 40)
+}
@@ -0,0 +1,174 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
+)
+
+var ErrTagNotFound = errors.New("semver tag not found")
+
+// Contribution is a commit that triggered a bump of the semantic version number.
+type Contribution struct {
+	Commit  *object.Commit
+	Release string
+	Semver  *semver.Version
+}
+
+// Explanation describes how the semantic version number of an existing tag is obtained from the previous tag and the
+// commits made in between.
+type Explanation struct {
+	Tag           *object.Tag
+	TagSemver     *semver.Version
+	PreviousTag   *object.Tag
+	Semver        *semver.Version
+	Contributions []Contribution
+}
+
+// Matches returns whether the version core computed under the current rules is the one of the tag.
+func (e Explanation) Matches() (bool, error) {
+	tagSemver := e.TagSemver
+
+	if tagSemver == nil {
+		var err error
+
+		tagSemver, err = semver.NewFromString(e.Tag.Name)
+		if err != nil {
+			return false, fmt.Errorf("building semver from git tag: %w", err)
+		}
+	}
+
+	return tagSemver.Major == e.Semver.Major && tagSemver.Minor == e.Semver.Minor && tagSemver.Patch == e.Semver.Patch, nil
+}
+
+// Explain reconstructs which commits, between the given tag and the one preceding it, bumped the semantic version
+// number and how, according to the current rules. The tag can be designated by its full name or by its semantic
+// version number.
+func (p *Parser) Explain(repository *git.Repository, tagName string, project monorepo.Project) (Explanation, error) {
+	var explanation Explanation
+
+	tags, err := p.semverTags(repository, project, "", false)
+	if err != nil {
+		return explanation, err
+	}
+
+	for _, tag := range tags {
+		name, _ := p.inTagNamespace(tag.Name)
+
+		if tag.Name == tagName || name == tagName || name == p.ctx.TagPrefixFlag+tagName {
+			explanation.Tag = tag
+			break
+		}
+	}
+
+	if explanation.Tag == nil {
+		return explanation, fmt.Errorf("%w: %q", ErrTagNotFound, tagName)
+	}
+
+	tagSemver, err := p.tagVersion(explanation.Tag.Name)
+	if err != nil {
+		return explanation, fmt.Errorf("building semver from git tag: %w", err)
+	}
+
+	explanation.TagSemver = tagSemver
+
+	var previousSemver *semver.Version
+
+	// Tags are sorted by precedence, the previous tag is therefore the last one with a lower precedence.
+	for _, tag := range tags {
+		currentSemver, err := p.tagVersion(tag.Name)
+		if err != nil {
+			return explanation, fmt.Errorf("building semver from git tag: %w", err)
+		}
+
+		if semver.Compare(currentSemver, tagSemver) != -1 {
+			break
+		}
+
+		previousSemver = currentSemver
+		explanation.PreviousTag = tag
+	}
+
+	tagCommit, err := explanation.Tag.Commit()
+	if err != nil {
+		return explanation, fmt.Errorf("fetching tag commit: %w", err)
+	}
+
+	logOptions := git.LogOptions{From: tagCommit.Hash}
+
+	if explanation.PreviousTag == nil {
+		previousSemver = &semver.Version{}
+	} else {
+		previousTagCommit, err := explanation.PreviousTag.Commit()
+		if err != nil {
+			return explanation, fmt.Errorf("fetching previous tag commit: %w", err)
+		}
+
+		since := previousTagCommit.Committer.When.Add(time.Second)
+		logOptions.Since = &since
+	}
+
+	// Only the version core is computed by commits, the prerelease and metadata depend on the release context.
+	explanation.Semver = &semver.Version{Major: previousSemver.Major, Minor: previousSemver.Minor, Patch: previousSemver.Patch}
+
+	history, err := p.commitHistory(repository, logOptions)
+	if err != nil {
+		return explanation, err
+	}
+
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].Committer.When.Before(history[j].Committer.When)
+	})
+
+	reverted := revertedMerges(history)
+
+	duplicates, err := p.duplicateCommits(history)
+	if err != nil {
+		return explanation, err
+	}
+
+	for _, commit := range history {
+		if reverted[commit.Hash] || duplicates[commit.Hash] {
+			continue
+		}
+
+		if project.Name != "" {
+			affectsProject, err := commitAffectsProject(commit, project)
+			if err != nil {
+				return explanation, fmt.Errorf("checking if commit affects project: %w", err)
+			}
+			if !affectsProject {
+				continue
+			}
+		}
+
+		release, err := p.releaseType(commit.Message)
+		if err != nil {
+			return explanation, fmt.Errorf("parsing commit history: %w", err)
+		}
+
+		if release == "" {
+			continue
+		}
+
+		err = bumpVersion(explanation.Semver, release)
+		if err != nil {
+			return explanation, fmt.Errorf("bumping version: %w", err)
+		}
+
+		explanation.Contributions = append(explanation.Contributions, Contribution{
+			Commit:  commit,
+			Release: release,
+			Semver:  &semver.Version{Major: explanation.Semver.Major, Minor: explanation.Semver.Minor, Patch: explanation.Semver.Patch},
+		})
+	}
+
+	return explanation, nil
+}
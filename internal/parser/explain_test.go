@@ -0,0 +1,94 @@
+package parser
+
+import (
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
+	"github.com/s0ders/go-semver-release/v6/pkg/gittest"
+)
+
+func TestParser_Explain(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	hash, err := testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+
+	err = testRepository.AddTag("v0.1.0", hash)
+	checkErr(t, "adding tag", err)
+
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+
+	_, err = testRepository.AddCommit("docs")
+	checkErr(t, "adding commit", err)
+
+	hash, err = testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+
+	// Tag created with rules that differ from the current ones
+	err = testRepository.AddTag("v1.0.0", hash)
+	checkErr(t, "adding tag", err)
+
+	th := NewTestHelper(t)
+	th.Ctx.TagPrefixFlag = "v"
+
+	explanation, err := New(th.Ctx).Explain(testRepository.Repository, "1.0.0", monorepo.Project{})
+	checkErr(t, "explaining tag", err)
+
+	assert.Equal("v0.1.0", explanation.PreviousTag.Name, "previous tag should be equal")
+	assert.Equal("0.2.0", explanation.Semver.String(), "computed version should be equal")
+	assert.Len(explanation.Contributions, 2, "only the commits triggering a release should contribute")
+	assert.Equal("patch", explanation.Contributions[0].Release)
+	assert.Equal("0.1.1", explanation.Contributions[0].Semver.String())
+	assert.Equal("minor", explanation.Contributions[1].Release)
+
+	matches, err := explanation.Matches()
+	checkErr(t, "comparing versions", err)
+
+	assert.False(matches, "computed version should differ from the tag")
+
+	_, err = New(th.Ctx).Explain(testRepository.Repository, "v2.0.0", monorepo.Project{})
+	assert.ErrorIs(err, ErrTagNotFound)
+}
+
+func TestParser_Explain_Monorepo(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	_, err = testRepository.AddCommitWithSpecificFile("feat!", "./bar/bar.txt")
+	checkErr(t, "adding commit", err)
+
+	hash, err := testRepository.AddCommitWithSpecificFile("fix", "./foo/foo.txt")
+	checkErr(t, "adding commit", err)
+
+	err = testRepository.AddTag("foo-v0.0.1", hash)
+	checkErr(t, "adding tag", err)
+
+	th := NewTestHelper(t)
+
+	explanation, err := New(th.Ctx).Explain(testRepository.Repository, "foo-v0.0.1", monorepo.Project{Name: "foo", Path: "foo"})
+	checkErr(t, "explaining tag", err)
+
+	assert.Nil(explanation.PreviousTag, "tag should have no predecessor")
+	assert.Len(explanation.Contributions, 1, "other projects commits should not contribute")
+
+	matches, err := explanation.Matches()
+	checkErr(t, "comparing versions", err)
+
+	assert.True(matches, "computed version should match the tag")
+}
@@ -0,0 +1,60 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/s0ders/go-semver-release/v6/internal/branch"
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
+)
+
+// freezeBaseVersion sets the version of a prerelease of a branch whose base version is frozen. Once a prerelease of
+// the branch has been tagged (e.g., "1.2.0-rc.1"), new releases keep its base version whatever the commits, and only
+// increment its counter (e.g., "1.2.0-rc.2"). The commits that would otherwise have changed the base version are
+// logged. Until then, the base version is computed from the commits as usual and the counter starts at 1.
+func (p *Parser) freezeBaseVersion(version, latest *semver.Version, newRelease bool, changes []Change, b branch.Branch) {
+	counter, frozen := prereleaseCounter(latest.Prerelease, b.Name)
+
+	if !frozen {
+		version.Prerelease = b.Name
+		if newRelease {
+			version.Prerelease = fmt.Sprintf("%s.%d", b.Name, 1)
+		}
+
+		return
+	}
+
+	for _, change := range changes {
+		p.ctx.Logger.Warn().Str("branch", b.Name).Str("commit", change.Hash.String()).Str("message", shortenMessage(change.Message)).Str("base-version", fmt.Sprintf("%d.%d.%d", latest.Major, latest.Minor, latest.Patch)).Msg("base version frozen, commit ignored for the base version")
+	}
+
+	version.Major = latest.Major
+	version.Minor = latest.Minor
+	version.Patch = latest.Patch
+	version.Prerelease = latest.Prerelease
+
+	if newRelease {
+		version.Prerelease = fmt.Sprintf("%s.%d", b.Name, counter+1)
+	}
+}
+
+// prereleaseCounter returns the counter of a prerelease of the given branch (e.g., 2 for "rc.2" on the "rc" branch, 0
+// for "rc") and whether the prerelease belongs to the branch at all.
+func prereleaseCounter(prerelease, branchName string) (int, bool) {
+	if prerelease == branchName {
+		return 0, true
+	}
+
+	suffix, ok := strings.CutPrefix(prerelease, branchName+".")
+	if !ok {
+		return 0, false
+	}
+
+	counter, err := strconv.Atoi(suffix)
+	if err != nil || counter < 0 {
+		return 0, false
+	}
+
+	return counter, true
+}
@@ -0,0 +1,81 @@
+package parser
+
+import (
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/branch"
+	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
+	"github.com/s0ders/go-semver-release/v6/pkg/gittest"
+)
+
+func TestParser_PrereleaseCounter(t *testing.T) {
+	assert := assertion.New(t)
+
+	type test struct {
+		prerelease string
+		counter    int
+		ok         bool
+	}
+
+	matrix := []test{
+		{prerelease: "rc", counter: 0, ok: true},
+		{prerelease: "rc.3", counter: 3, ok: true},
+		{prerelease: "rc.x", ok: false},
+		{prerelease: "rc2", ok: false},
+		{prerelease: "beta.1", ok: false},
+		{prerelease: "", ok: false},
+	}
+
+	for _, tc := range matrix {
+		counter, ok := prereleaseCounter(tc.prerelease, "rc")
+
+		assert.Equal(tc.ok, ok, "prerelease: %q", tc.prerelease)
+		assert.Equal(tc.counter, counter, "prerelease: %q", tc.prerelease)
+	}
+}
+
+func TestParser_ComputeNewSemver_FreezeBaseVersion(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(
+		gittest.Commit("fix"),
+		gittest.Tag("v1.1.0"),
+		gittest.Branch("rc"),
+		gittest.Commit("feat"),
+	)
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	th := NewTestHelper(t)
+	th.Ctx.Branches = []branch.Branch{{Name: "rc", Prerelease: true, FreezeBaseVersion: true}}
+	th.Ctx.MaxVersionSkipFlag = 1
+
+	output, err := New(th.Ctx).ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.True(output.NewRelease)
+	assert.Equal("1.2.0-rc.1", output.Semver.String(), "the base version should be computed until a prerelease is tagged")
+
+	err = testRepository.Apply(gittest.Tag("v1.2.0-rc.1"), gittest.Commit("feat!"), gittest.Commit("fix"))
+	checkErr(t, "adding commits", err)
+
+	output, err = New(th.Ctx).ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.True(output.NewRelease)
+	assert.Equal("1.2.0-rc.2", output.Semver.String(), "only the prerelease counter should be incremented")
+
+	err = testRepository.Apply(gittest.Tag("v1.2.0-rc.2"), gittest.Commit("chore"))
+	checkErr(t, "adding commits", err)
+
+	output, err = New(th.Ctx).ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.False(output.NewRelease)
+	assert.Equal("1.2.0-rc.2", output.Semver.String())
+}
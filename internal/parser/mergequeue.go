@@ -0,0 +1,110 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// borsMergeRegex matches the messages of the commits created by bors, e.g. "Merge #123 #124".
+	borsMergeRegex = regexp.MustCompile(`^Merge((?: #\d+)+)[ \t]*(?:\n|$)`)
+	// borsTitleRegex matches the lines of a bors commit message giving the title of each bundled pull request, e.g.
+	// "123: feat: add foo r=alice a=bob".
+	borsTitleRegex = regexp.MustCompile(`(?m)^(\d+): (.+?)(?: r=\S+)?(?: a=\S+)?[ \t]*$`)
+	// githubMergeRegex matches the messages of the merge commits created by GitHub, merge queue included.
+	githubMergeRegex = regexp.MustCompile(`^Merge pull request #(\d+) from \S+`)
+)
+
+// commitMessages returns the messages to analyze for a commit: the titles of the pull requests it bundles if it was
+// created by a merge queue and merge queue support is enabled, its own message otherwise.
+func (p *Parser) commitMessages(message string) ([]string, error) {
+	if !p.ctx.MergeQueueFlag {
+		return []string{message}, nil
+	}
+
+	messages, err := p.mergeQueueMessages(message)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(messages) == 0 {
+		return []string{message}, nil
+	}
+
+	return messages, nil
+}
+
+// mergeQueueMessages returns the titles of the pull requests bundled by a merge queue commit, or nil if the message is
+// not one of a merge queue commit. Titles are read from the forge API when available, which reflects their latest
+// edits, otherwise from the commit message itself.
+func (p *Parser) mergeQueueMessages(message string) ([]string, error) {
+	var (
+		numbers []int
+		titles  = make(map[int]string)
+	)
+
+	if match := borsMergeRegex.FindStringSubmatch(message); match != nil {
+		for _, field := range strings.Fields(match[1]) {
+			number, _ := strconv.Atoi(strings.TrimPrefix(field, "#"))
+			numbers = append(numbers, number)
+		}
+
+		for _, line := range borsTitleRegex.FindAllStringSubmatch(message, -1) {
+			number, _ := strconv.Atoi(line[1])
+			titles[number] = line[2]
+		}
+	} else if match := githubMergeRegex.FindStringSubmatch(message); match != nil {
+		number, _ := strconv.Atoi(match[1])
+		numbers = append(numbers, number)
+
+		// The title of the pull request is the body of the merge commit message.
+		if _, body, ok := strings.Cut(message, "\n"); ok {
+			titles[number] = strings.TrimSpace(body)
+		}
+	}
+
+	messages := make([]string, 0, len(numbers))
+
+	for _, number := range numbers {
+		title, err := p.pullRequestTitle(number, titles[number])
+		if err != nil {
+			return nil, err
+		}
+
+		if title != "" {
+			messages = append(messages, title)
+		}
+	}
+
+	return messages, nil
+}
+
+// pullRequestTitle returns the title of a pull request from the forge API if available, the given fallback otherwise.
+// Titles are cached since a commit may be analyzed for several branches and projects.
+func (p *Parser) pullRequestTitle(number int, fallback string) (string, error) {
+	if p.ctx.Forge == nil {
+		return fallback, nil
+	}
+
+	p.mu.Lock()
+	title, ok := p.pullRequestTitles[number]
+	p.mu.Unlock()
+
+	if ok {
+		return title, nil
+	}
+
+	title, err := p.ctx.Forge.PullRequestTitle(context.Background(), number)
+	if err != nil {
+		return "", fmt.Errorf("expanding merge queue commit: %w", err)
+	}
+
+	p.mu.Lock()
+	p.pullRequestTitles[number] = title
+	p.mu.Unlock()
+
+	return title, nil
+}
@@ -0,0 +1,145 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/forge"
+	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
+	"github.com/s0ders/go-semver-release/v6/pkg/gittest"
+)
+
+type fakeForge struct {
+	titles map[int]string
+	calls  int
+	err    error
+}
+
+func (f *fakeForge) Checks(_ context.Context, _ string) ([]forge.Check, error) {
+	return nil, nil
+}
+
+func (f *fakeForge) CommentPullRequest(_ context.Context, _ int, _ string) error {
+	return nil
+}
+
+func (f *fakeForge) CreateCheckRun(_ context.Context, _ forge.CheckRun) error {
+	return nil
+}
+
+func (f *fakeForge) CreateDeployment(_ context.Context, _ forge.Deployment) error {
+	return nil
+}
+
+func (f *fakeForge) CreateRelease(_ context.Context, _ forge.Release) error {
+	return nil
+}
+
+func (f *fakeForge) CommitPullRequests(_ context.Context, _ string) ([]forge.PullRequest, error) {
+	return nil, nil
+}
+
+func (f *fakeForge) DeleteRelease(_ context.Context, _ string) error {
+	return nil
+}
+
+func (f *fakeForge) PullRequestTitle(_ context.Context, number int) (string, error) {
+	f.calls++
+	return f.titles[number], f.err
+}
+
+func (f *fakeForge) UserHandle(_ context.Context, _ string) (string, error) {
+	return "", nil
+}
+
+func TestParser_MergeQueueMessages(t *testing.T) {
+	assert := assertion.New(t)
+
+	type test struct {
+		message  string
+		expected []string
+	}
+
+	matrix := []test{
+		{message: "Merge #12 #13\n\n12: feat: add foo r=alice a=bob\n\n13: fix: fix bar r=alice\n\nCo-authored-by: bob", expected: []string{"feat: add foo", "fix: fix bar"}},
+		{message: "Merge #12\n\n12: feat!: remove foo", expected: []string{"feat!: remove foo"}},
+		{message: "Merge pull request #42 from owner/feature\n\nfeat: add baz", expected: []string{"feat: add baz"}},
+		{message: "Merge pull request #42 from owner/feature", expected: []string{}},
+		{message: "Merge branch 'main' into feature", expected: nil},
+		{message: "feat: add foo", expected: nil},
+	}
+
+	parser := New(NewTestHelper(t).Ctx)
+
+	for _, tc := range matrix {
+		messages, err := parser.mergeQueueMessages(tc.message)
+		checkErr(t, "expanding merge queue commit", err)
+
+		assert.Equal(len(tc.expected), len(messages), "message: %q", tc.message)
+
+		if len(tc.expected) > 0 {
+			assert.Equal(tc.expected, messages, "message: %q", tc.message)
+		}
+	}
+}
+
+func TestParser_MergeQueueMessages_Forge(t *testing.T) {
+	assert := assertion.New(t)
+
+	client := &fakeForge{titles: map[int]string{12: "feat: add foo", 13: "fix: fix bar"}}
+
+	th := NewTestHelper(t)
+	th.Ctx.Forge = client
+
+	parser := New(th.Ctx)
+
+	messages, err := parser.mergeQueueMessages("Merge #12 #13\n\n12: fix: outdated title")
+	checkErr(t, "expanding merge queue commit", err)
+
+	assert.Equal([]string{"feat: add foo", "fix: fix bar"}, messages, "titles should be read from the forge")
+
+	_, err = parser.mergeQueueMessages("Merge #12 #13")
+	checkErr(t, "expanding merge queue commit", err)
+
+	assert.Equal(2, client.calls, "titles should be cached")
+
+	client.err = errors.New("API unavailable")
+
+	_, err = parser.mergeQueueMessages("Merge #14")
+	assert.ErrorIs(err, client.err)
+}
+
+func TestParser_ComputeNewSemver_MergeQueue(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(
+		gittest.Commit("fix"),
+		gittest.Tag("v0.1.0"),
+		gittest.CommitMessage("Merge #12 #13\n\n12: feat: add foo r=alice a=bob\n\n13: fix: fix bar r=alice"),
+	)
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	th := NewTestHelper(t)
+
+	output, err := New(th.Ctx).ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.False(output.NewRelease, "merge queue commits should be ignored unless enabled")
+
+	th.Ctx.MergeQueueFlag = true
+
+	output, err = New(th.Ctx).ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.True(output.NewRelease)
+	assert.Equal("0.2.1", output.Semver.String(), "every bundled pull request should bump the version")
+	assert.Len(output.Changes, 2, "every bundled pull request should be a change")
+	assert.Equal("feat: add foo", output.Changes[0].Message)
+}
@@ -0,0 +1,180 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/s0ders/go-semver-release/v6/internal/branch"
+	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
+)
+
+var ErrUnknownProject = errors.New("unknown project")
+
+// selectProjects returns the projects restricted to the ones named by the only-projects flag, if any, keeping their
+// order.
+func (p *Parser) selectProjects(projects []monorepo.Project) ([]monorepo.Project, error) {
+	if len(p.ctx.OnlyProjectsFlag) == 0 {
+		return projects, nil
+	}
+
+	for _, name := range p.ctx.OnlyProjectsFlag {
+		if !slices.ContainsFunc(projects, func(project monorepo.Project) bool { return project.Name == name }) {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownProject, name)
+		}
+	}
+
+	return slices.DeleteFunc(slices.Clone(projects), func(project monorepo.Project) bool {
+		return !slices.Contains(p.ctx.OnlyProjectsFlag, project.Name)
+	}), nil
+}
+
+// changedProjects returns the projects whose files changed on a branch since their latest release, along with the
+// projects depending on them if bumps cascade. A project is compared by the tree of its path at its latest tag and at
+// the head of the branch, so that the cost does not grow with the history. A project whose files did not change is
+// still considered changed if a commit made since its latest release names it in an "Affects:" trailer, the history of
+// the branch being then read once for all projects. A project that was never released, or whose tags come from another
+// repository, is always considered changed.
+func (p *Parser) changedProjects(repository *git.Repository, projects []monorepo.Project, branch branch.Branch) ([]monorepo.Project, error) {
+	head, err := p.branchHead(repository, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	headCommit, err := repository.CommitObject(head)
+	if err != nil {
+		return nil, fmt.Errorf("fetching branch %q head commit: %w", branch.Name, err)
+	}
+
+	tagPrefix, matchPrefix := p.tagPrefix(branch)
+
+	var history []*object.Commit
+
+	readHistory := func() ([]*object.Commit, error) {
+		if history != nil {
+			return history, nil
+		}
+
+		commits, err := p.commitHistory(repository, git.LogOptions{From: head})
+		if err != nil {
+			return nil, err
+		}
+
+		history = commits
+
+		return history, nil
+	}
+
+	changed := make([]monorepo.Project, 0, len(projects))
+
+	for _, project := range projects {
+		ok, err := p.projectChanged(repository, project, tagPrefix, matchPrefix, headCommit, readHistory)
+		if err != nil {
+			return nil, fmt.Errorf("checking project %q changes: %w", project.Name, err)
+		}
+
+		// Projects are in release order, so that a dependency is always checked before the projects depending on it.
+		if !ok && p.ctx.CascadeBumpsFlag {
+			ok = slices.ContainsFunc(changed, func(dependency monorepo.Project) bool {
+				return slices.Contains(project.DependsOn, dependency.Name)
+			})
+		}
+
+		if ok {
+			changed = append(changed, project)
+		}
+	}
+
+	return changed, nil
+}
+
+// projectChanged reports whether the files of a project differ between its latest tag and the given head commit, or
+// whether a commit of the history returned by readHistory made since its latest tag names it in an "Affects:" trailer.
+func (p *Parser) projectChanged(repository *git.Repository, project monorepo.Project, tagPrefix string, matchPrefix bool, head *object.Commit, readHistory func() ([]*object.Commit, error)) (bool, error) {
+	if project.TagSource != "" {
+		return true, nil
+	}
+
+	latestSemverTag, err := p.fetchLatestSemverTag(repository, project, tagPrefix, matchPrefix)
+	if err != nil {
+		return false, fmt.Errorf("fetching latest semver tag: %w", err)
+	}
+
+	if latestSemverTag == nil {
+		return true, nil
+	}
+
+	tagCommit, err := latestSemverTag.Commit()
+	if err != nil {
+		return false, fmt.Errorf("fetching latest semver tag commit: %w", err)
+	}
+
+	if tagCommit.Hash == head.Hash {
+		return false, nil
+	}
+
+	before, err := pathHash(tagCommit, project.Path)
+	if err != nil {
+		return false, err
+	}
+
+	after, err := pathHash(head, project.Path)
+	if err != nil {
+		return false, err
+	}
+
+	if before != after {
+		return true, nil
+	}
+
+	history, err := readHistory()
+	if err != nil {
+		return false, err
+	}
+
+	since := tagCommit.Committer.When.Add(time.Second)
+
+	for _, commit := range history {
+		if commit.Committer.When.Before(since) {
+			continue
+		}
+
+		names, ok := affectedProjects(commit.Message)
+		if ok && slices.ContainsFunc(names, func(name string) bool { return strings.EqualFold(name, project.Name) }) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// pathHash returns the hash of the tree or file found at a path of a commit, or the zero hash if the path does not
+// exist in that commit.
+func pathHash(commit *object.Commit, path string) (plumbing.Hash, error) {
+	tree, err := commit.Tree()
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("fetching commit %s tree: %w", commit.Hash, err)
+	}
+
+	path = strings.Trim(strings.TrimPrefix(filepath.ToSlash(path), "./"), "/")
+	if path == "" || path == "." {
+		return tree.Hash, nil
+	}
+
+	entry, err := tree.FindEntry(path)
+	if errors.Is(err, object.ErrEntryNotFound) || errors.Is(err, object.ErrDirectoryNotFound) {
+		return plumbing.ZeroHash, nil
+	}
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("looking up %q in commit %s: %w", path, commit.Hash, err)
+	}
+
+	return entry.Hash, nil
+}
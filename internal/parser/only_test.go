@@ -0,0 +1,146 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
+	"github.com/s0ders/go-semver-release/v6/pkg/gittest"
+)
+
+func projectNames(output []ComputeNewSemverOutput) []string {
+	names := make([]string, len(output))
+	for i, o := range output {
+		names[i] = o.Project.Name
+	}
+
+	return names
+}
+
+func TestParser_Run_OnlyProjects(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(
+		gittest.CommitFile("feat", "api/main.go", "api"),
+		gittest.CommitFile("fix", "web/index.html", "web"),
+		gittest.CommitFile("fix", "cli/main.go", "cli"),
+	)
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	th := NewTestHelper(t)
+	th.Ctx.Projects = []monorepo.Project{{Name: "api", Path: "api"}, {Name: "web", Path: "web"}, {Name: "cli", Path: "cli"}}
+	th.Ctx.OnlyProjectsFlag = []string{"cli", "api"}
+
+	output, err := New(th.Ctx).Run(context.Background(), testRepository.Repository)
+	checkErr(t, "computing projects new semver", err)
+
+	assert.Equal([]string{"api", "cli"}, projectNames(output), "selected projects should keep their order")
+	assert.Equal(1, output[0].ReleaseOrder)
+	assert.Equal(2, output[1].ReleaseOrder)
+
+	th.Ctx.OnlyProjectsFlag = []string{"api", "docs"}
+
+	_, err = New(th.Ctx).Run(context.Background(), testRepository.Repository)
+	assert.ErrorIs(err, ErrUnknownProject)
+}
+
+func TestParser_Run_OnlyChanged(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(
+		gittest.CommitFile("feat", "api/main.go", "api"),
+		gittest.CommitFile("feat", "web/index.html", "web"),
+		gittest.CommitFile("feat", "lib/lib.go", "lib"),
+		gittest.Tag("api-v1.0.0"),
+		gittest.Tag("web-v1.0.0"),
+		gittest.Tag("lib-v1.0.0"),
+		gittest.CommitFile("fix", "lib/lib.go", "lib fix"),
+		gittest.CommitFile("docs", "README.md", "readme"),
+		gittest.CommitFile("feat", "cli/main.go", "cli"),
+	)
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	th := NewTestHelper(t)
+	th.Ctx.OnlyChangedFlag = true
+	th.Ctx.Projects = []monorepo.Project{
+		{Name: "web", Path: "web", DependsOn: []string{"api"}},
+		{Name: "api", Path: "./api/", DependsOn: []string{"lib"}},
+		{Name: "lib", Path: "lib"},
+		{Name: "cli", Path: "cli"},
+	}
+
+	output, err := New(th.Ctx).Run(context.Background(), testRepository.Repository)
+	checkErr(t, "computing projects new semver", err)
+
+	assert.Equal([]string{"lib", "cli"}, projectNames(output), "only changed or never released projects should be analyzed")
+	assert.Equal("1.0.1", output[0].Semver.String())
+
+	th.Ctx.CascadeBumpsFlag = true
+
+	output, err = New(th.Ctx).Run(context.Background(), testRepository.Repository)
+	checkErr(t, "computing projects new semver", err)
+
+	assert.Equal([]string{"lib", "api", "web", "cli"}, projectNames(output), "dependents of changed projects should be analyzed")
+	assert.Equal("1.0.1", output[2].Semver.String(), "bumps should cascade to the selected dependents")
+}
+
+func TestParser_Run_OnlyChangedUnchanged(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(
+		gittest.CommitFile("feat", "api/main.go", "api"),
+		gittest.Tag("api-v1.0.0"),
+	)
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	th := NewTestHelper(t)
+	th.Ctx.OnlyChangedFlag = true
+	th.Ctx.Projects = []monorepo.Project{{Name: "api", Path: "api"}}
+
+	output, err := New(th.Ctx).Run(context.Background(), testRepository.Repository)
+	checkErr(t, "computing projects new semver", err)
+
+	assert.Empty(output, "project tagged on the head commit should be skipped")
+}
+
+func TestParser_Run_OnlyChangedAffectsTrailer(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(
+		gittest.CommitFile("feat", "api/main.go", "api"),
+		gittest.CommitFile("feat", "web/index.html", "web"),
+		gittest.CommitMessage("fix: fix web\n\nAffects: web"),
+		gittest.Tag("api-v1.0.0"),
+		gittest.Tag("web-v1.0.0"),
+		gittest.CommitMessage("fix: fix api configuration\n\nAffects: API"),
+	)
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	th := NewTestHelper(t)
+	th.Ctx.OnlyChangedFlag = true
+	th.Ctx.Projects = []monorepo.Project{{Name: "api", Path: "api"}, {Name: "web", Path: "web"}}
+
+	output, err := New(th.Ctx).Run(context.Background(), testRepository.Repository)
+	checkErr(t, "computing projects new semver", err)
+
+	assert.Equal([]string{"api"}, projectNames(output), "a project named by a trailer since its latest tag should be analyzed")
+	assert.Equal("1.0.1", output[0].Semver.String())
+}
@@ -8,76 +8,224 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
+	"path"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/s0ders/go-semver-release/v6/internal/apidiff"
 	"github.com/s0ders/go-semver-release/v6/internal/appcontext"
 	"github.com/s0ders/go-semver-release/v6/internal/branch"
 	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
+	"github.com/s0ders/go-semver-release/v6/internal/progress"
+	"github.com/s0ders/go-semver-release/v6/internal/remote"
+	"github.com/s0ders/go-semver-release/v6/internal/rule"
 	"github.com/s0ders/go-semver-release/v6/internal/semver"
+	"github.com/s0ders/go-semver-release/v6/internal/tag"
 )
 
-var conventionalCommitRegex = regexp.MustCompile(`^(build|chore|ci|docs|feat|fix|perf|refactor|revert|style|test)(\([\w\-.\\\/]+\))?(!)?: ([\w ]+[\s\S]*)`)
+var (
+	ErrVersionOutOfRange  = errors.New("new version is outside of the branch version range")
+	ErrInvalidReleaseType = errors.New("invalid release type")
+	ErrUnconfirmedMajor   = errors.New("major release requires confirmation")
+	ErrUntrustedTag       = errors.New("tag is not signed by a trusted key")
+
+	ErrInvalidUntrustedTagsPolicy = errors.New("invalid untrusted tags policy")
+)
+
+const (
+	UntrustedTagsFail   = "fail"
+	UntrustedTagsIgnore = "ignore"
+)
+
+// Reasons for which a new release is made.
+const (
+	// BumpReasonCommits is a release triggered by the commits of the branch or project.
+	BumpReasonCommits = "commits"
+	// BumpReasonForced is a release forced by the configuration or a commit marker.
+	BumpReasonForced = "forced"
+	// BumpReasonDependency is a release of a project cascading from the release of projects it depends on.
+	BumpReasonDependency = "dependency"
+)
+
+var (
+	conventionalCommitRegex = regexp.MustCompile(`^(build|chore|ci|docs|feat|fix|perf|refactor|revert|style|test)(\([\w\-.\\\/]+\))?(!)?: ([\w ]+[\s\S]*)`)
+	forceBumpMarkerRegex    = regexp.MustCompile(`(?i)\[release (patch|minor|major)\]`)
+	// breakingChangeRegex matches the "BREAKING CHANGE:" footers of a commit message, as parsed by changelogs.
+	breakingChangeRegex = regexp.MustCompile(`(?m)^BREAKING[ -]CHANGE: ?`)
+)
+
+var releaseTypePrecedence = map[string]int{"patch": 1, "minor": 2, "major": 3}
 
 type Parser struct {
-	ctx *appcontext.AppContext
-	mu  sync.Mutex
+	ctx               *appcontext.AppContext
+	clones            map[string]*git.Repository
+	pullRequestTitles map[int]string
+	boundaries        map[*git.Repository]*historyBoundary
+	// asOf, if not nil, is the point of the history as of which versions are computed.
+	asOf *asOf
+	mu   sync.Mutex
+	// commits and analyzed count the commits scanned and the branches and projects analyzed by a run when progress
+	// reporting is enabled, and are nil otherwise.
+	commits  *progress.Counter
+	analyzed *progress.Counter
 }
 
 func New(ctx *appcontext.AppContext) *Parser {
-	parser := &Parser{ctx: ctx}
+	parser := &Parser{ctx: ctx, clones: make(map[string]*git.Repository), pullRequestTitles: make(map[int]string), boundaries: make(map[*git.Repository]*historyBoundary)}
 
 	return parser
 }
 
 type ComputeNewSemverOutput struct {
-	Semver     *semver.Version
-	Project    monorepo.Project
-	Branch     string
-	CommitHash plumbing.Hash
-	NewRelease bool
+	Semver      *semver.Version
+	Project     monorepo.Project
+	Branch      string
+	Environment string
+	TagPrefix   string
+	CommitHash  plumbing.Hash
+	NewRelease  bool
+	Skipped     bool
+	Forced      bool
+	Changes     []Change
+	// Commits are all the commits of the branch or project since its previous release, whether they triggered a bump
+	// or not, so that their authors can be credited.
+	Commits []Change
+	// RuleStats, set only when rule statistics are enabled, counts the commits of the branch or project by the rule
+	// they matched.
+	RuleStats RuleStats
+	// ChangedPaths, set only when changed paths are enabled, are the files of the branch or project changed since its
+	// previous release.
+	ChangedPaths []string
+	// PreviousSemver is the latest version from which Semver was computed.
+	PreviousSemver *semver.Version
+	// BumpReason, set only for a new release, is the reason of the release: BumpReasonCommits, BumpReasonForced or
+	// BumpReasonDependency, in which case BumpedBy are the projects whose release cascaded to this one.
+	BumpReason string
+	BumpedBy   []string
+	// ReleaseOrder is the position of the project in the release order of its branch, starting at 1, in monorepo mode.
+	ReleaseOrder int
+	// Error, set only when continuing on error, is the error that prevented computing the new semver of the branch or
+	// project, in which case the other fields but Branch and Project are not to be relied upon.
+	Error error
+}
+
+// Change is a commit message that triggered a bump of the semantic version number. A merge queue commit gives one
+// change per pull request it bundles.
+type Change struct {
+	Hash    plumbing.Hash
+	Message string
+	// Author is the author of the commit, co-authors being given by the trailers of the message.
+	Author object.Signature
 }
 
 // Run execute a parser on a repository and analyze the given branches and projects contained inside the given
 // AppContext.
+//
+// Projects of a branch are analyzed concurrently, analysis having no side effect on the repository. Outputs are
+// however returned in release order, by branch in the configured order then by project in dependency order, a project
+// coming after the projects it depends on and projects otherwise keeping their configured order. Callers must create
+// and push tags serially in that order, so that the release of a project never precedes the release of its
+// dependencies, and should not release a project whose dependencies failed to be released.
+//
+// An error computing the new semver of a branch or project aborts the run unless continuing on error, in which case it
+// is reported by the Error field of the output of that branch or project.
 func (p *Parser) Run(ctx context.Context, repository *git.Repository) ([]ComputeNewSemverOutput, error) {
 	var output []ComputeNewSemverOutput
 
-	for _, branch := range p.ctx.Branches {
-		err := p.checkoutBranch(repository, branch.Name)
+	projects, err := monorepo.Order(p.ctx.Projects)
+	if err != nil {
+		return nil, fmt.Errorf("ordering monorepository projects: %w", err)
+	}
+
+	projects, err = p.selectProjects(projects)
+	if err != nil {
+		return nil, fmt.Errorf("selecting monorepository projects: %w", err)
+	}
+
+	// A project enabled on a branch that is not configured is likely a typo, leaving the project silently unreleased.
+	for _, project := range projects {
+		for _, name := range project.Branches {
+			if !slices.ContainsFunc(p.ctx.Branches, func(b branch.Branch) bool { return b.Name == name }) {
+				p.ctx.Logger.Warn().Str("project", project.Name).Str("branch", name).Msg("project enabled on a branch that is not configured")
+			}
+		}
+	}
+
+	if p.ctx.AsOfFlag != "" {
+		p.asOf, err = resolveAsOf(repository, p.ctx.AsOfFlag)
 		if err != nil {
-			return output, fmt.Errorf("checking out to branch %q: %w", branch.Name, err)
+			return nil, err
 		}
 
+		// A commit belongs to a given branch, whereas a date applies to the history of every branch.
+		if !p.asOf.commit.IsZero() && len(p.ctx.Branches) != 1 {
+			return nil, fmt.Errorf("%w: exactly one branch must be configured to compute versions as of a commit, got %d", ErrAmbiguousAsOf, len(p.ctx.Branches))
+		}
+	}
+
+	if p.ctx.ProgressFlag {
+		p.commits = progress.NewCounter(p.ctx.Logger, "scanning commits", 0, progress.Interval)
+		p.analyzed = progress.NewCounter(p.ctx.Logger, "analyzing branches and projects", analyzedTotal(p.ctx.Branches, projects), 0)
+	}
+
+	for _, branch := range p.ctx.Branches {
 		if len(p.ctx.Projects) == 0 {
 			computerNewSemverOutput, err := p.ComputeNewSemver(repository, monorepo.Project{}, branch)
 			if err != nil {
-				return nil, fmt.Errorf("computing new semver: %w", err)
+				if !p.ctx.ContinueOnErrorFlag {
+					return nil, fmt.Errorf("computing new semver: %w", err)
+				}
+
+				computerNewSemverOutput = ComputeNewSemverOutput{Branch: branch.Name, Error: err}
 			}
 
+			p.analyzed.Add(1)
 			output = append(output, computerNewSemverOutput)
 		}
 
-		outputBuf := make([]ComputeNewSemverOutput, len(p.ctx.Projects))
+		branchProjects := projectsOnBranch(projects, branch.Name)
+
+		if p.ctx.OnlyChangedFlag && len(branchProjects) > 0 {
+			enabled := len(branchProjects)
+
+			branchProjects, err = p.changedProjects(repository, branchProjects, branch)
+			if err != nil {
+				return nil, fmt.Errorf("selecting changed projects on branch %q: %w", branch.Name, err)
+			}
+
+			p.analyzed.Add(enabled - len(branchProjects))
+		}
+
+		outputBuf := make([]ComputeNewSemverOutput, len(branchProjects))
 
 		g, _ := errgroup.WithContext(ctx)
 
-		for i, project := range p.ctx.Projects {
+		for i, project := range branchProjects {
 			g.Go(func() error {
 				result, err := p.ComputeNewSemver(repository, project, branch)
 				if err != nil {
-					return fmt.Errorf("computing project %q new semver: %w", project.Name, err)
+					if !p.ctx.ContinueOnErrorFlag {
+						return fmt.Errorf("computing project %q new semver: %w", project.Name, err)
+					}
+
+					result = ComputeNewSemverOutput{Branch: branch.Name, Project: project, Error: err}
 				}
 
+				p.analyzed.Add(1)
 				outputBuf[i] = result
 				return nil
 			})
@@ -87,30 +235,130 @@ func (p *Parser) Run(ctx context.Context, repository *git.Repository) ([]Compute
 			return nil, fmt.Errorf("parsing monorepository projects: %w", err)
 		}
 
+		if p.ctx.CascadeBumpsFlag {
+			if err := p.cascadeBumps(repository, branch, outputBuf); err != nil {
+				return nil, err
+			}
+		}
+
+		for i := range outputBuf {
+			outputBuf[i].ReleaseOrder = i + 1
+		}
+
 		output = append(output, outputBuf...)
 	}
 
 	return output, nil
 }
 
+// projectsOnBranch returns the projects released from the given branch, keeping their order.
+func projectsOnBranch(projects []monorepo.Project, name string) []monorepo.Project {
+	return slices.DeleteFunc(slices.Clone(projects), func(project monorepo.Project) bool {
+		return !project.OnBranch(name)
+	})
+}
+
+// analyzedTotal returns the number of branches and projects analyzed by a run, a repository without projects being
+// analyzed once per branch.
+func analyzedTotal(branches []branch.Branch, projects []monorepo.Project) int {
+	if len(projects) == 0 {
+		return len(branches)
+	}
+
+	total := 0
+	for _, b := range branches {
+		total += len(projectsOnBranch(projects, b.Name))
+	}
+
+	return total
+}
+
 // ComputeNewSemver returns the next, if any, semantic version number from a given Git repository by parsing its commit
 // history.
 func (p *Parser) ComputeNewSemver(repository *git.Repository, project monorepo.Project, branch branch.Branch) (ComputeNewSemverOutput, error) {
+	return p.computeNewSemver(repository, project, branch, nil)
+}
+
+// cascadeBumps bumps the patch version of the projects none of whose commits triggered a release but that depend on a
+// project released by the run. Outputs are in release order, so that a bump cascades to the projects depending on a
+// bumped project. A bumped project is analyzed again, its release being tagged on the head commit of the branch.
+func (p *Parser) cascadeBumps(repository *git.Repository, branch branch.Branch, outputs []ComputeNewSemverOutput) error {
+	released := make(map[string]bool)
+
+	for i, output := range outputs {
+		if output.Error != nil || output.Skipped {
+			continue
+		}
+
+		if !output.NewRelease {
+			var bumpedBy []string
+
+			for _, dependency := range output.Project.DependsOn {
+				if released[dependency] {
+					bumpedBy = append(bumpedBy, dependency)
+				}
+			}
+
+			if len(bumpedBy) == 0 {
+				continue
+			}
+
+			result, err := p.computeNewSemver(repository, output.Project, branch, bumpedBy)
+			if err != nil {
+				if !p.ctx.ContinueOnErrorFlag {
+					return fmt.Errorf("computing project %q new semver: %w", output.Project.Name, err)
+				}
+
+				result = ComputeNewSemverOutput{Branch: branch.Name, Project: output.Project, Error: err}
+			}
+
+			outputs[i] = result
+			output = result
+		}
+
+		if output.NewRelease {
+			released[output.Project.Name] = true
+		}
+	}
+
+	return nil
+}
+
+// computeNewSemver computes the next semantic version of a branch or project. The release of a project that would not
+// be released otherwise cascades from the given projects it depends on, if any.
+func (p *Parser) computeNewSemver(repository *git.Repository, project monorepo.Project, branch branch.Branch, bumpedBy []string) (ComputeNewSemverOutput, error) {
+	var err error
+
 	output := ComputeNewSemverOutput{}
 
 	if project.Name != "" {
 		output.Project = project
 	}
 
-	latestSemverTag, err := p.FetchLatestSemverTag(repository, project)
+	tagRepository := repository
+
+	if project.TagSource != "" {
+		p.mu.Lock()
+		tagRepository, err = p.clone(project.TagSource)
+		p.mu.Unlock()
+
+		if err != nil {
+			return output, fmt.Errorf("cloning project %q tag source: %w", project.Name, err)
+		}
+	}
+
+	tagPrefix, matchPrefix := p.tagPrefix(branch)
+
+	latestSemverTag, err := p.fetchLatestSemverTag(tagRepository, project, tagPrefix, matchPrefix)
 	if err != nil {
 		return output, fmt.Errorf("fetching latest semver tag: %w", err)
 	}
 
 	var (
-		latestSemver *semver.Version
-		history      []*object.Commit
-		logOptions   git.LogOptions
+		latestSemver    *semver.Version
+		latestTagCommit *object.Commit
+		history         []*object.Commit
+		logOptions      git.LogOptions
 	)
 
 	if latestSemverTag == nil {
@@ -120,36 +368,69 @@ func (p *Parser) ComputeNewSemver(repository *git.Repository, project monorepo.P
 	} else {
 		p.ctx.Logger.Debug().Str("tag", latestSemverTag.Name).Msg("latest semver tag found")
 
-		latestSemver, err = semver.NewFromString(latestSemverTag.Name)
+		latestSemver, err = p.tagVersion(latestSemverTag.Name)
 		if err != nil {
 			return output, fmt.Errorf("building semver from git tag: %w", err)
 		}
 
 		p.mu.Lock()
-		latestSemverTagCommit, err := latestSemverTag.Commit()
+		latestTagCommit, err = latestSemverTag.Commit()
+		p.mu.Unlock()
+
 		if err != nil {
 			return output, fmt.Errorf("fetching latest semver tag commit: %w", err)
 		}
-		p.mu.Unlock()
 
 		// Show all commit that are at least one second older than the latest one pointed by SemVer tag
-		since := latestSemverTagCommit.Committer.When.Add(time.Second)
+		since := latestTagCommit.Committer.When.Add(time.Second)
 		logOptions.Since = &since
 	}
 
 	p.mu.Lock()
-	defer p.mu.Unlock()
 
-	repositoryLogs, err := repository.Log(&logOptions)
+	logOptions.From, err = p.branchHead(repository, branch)
 	if err != nil {
-		return output, fmt.Errorf("fetching commit history: %w", err)
+		p.mu.Unlock()
+		return output, err
 	}
 
-	// Create commit history
-	_ = repositoryLogs.ForEach(func(c *object.Commit) error {
-		history = append(history, c)
-		return nil
-	})
+	headCommit, err := repository.CommitObject(logOptions.From)
+	if err != nil {
+		p.mu.Unlock()
+		return output, fmt.Errorf("fetching head commit: %w", err)
+	}
+
+	// A skip marker on the head commit skips the whole run for the branch, as "[skip ci]" does for CI pipelines.
+	if p.hasSkipMarker(headCommit.Message) {
+		p.mu.Unlock()
+
+		p.ctx.Logger.Debug().Str("branch", branch.Name).Str("commit", headCommit.Hash.String()).Msg("skip marker found on head commit")
+
+		switch {
+		case branch.FreezeBaseVersion:
+			latest := *latestSemver
+			p.freezeBaseVersion(latestSemver, &latest, false, nil, branch)
+		case branch.Prerelease:
+			latestSemver.Prerelease = branch.Name
+		}
+
+		latestSemver.Metadata = p.ctx.BuildMetadataFlag
+
+		output.Semver = latestSemver
+		output.Branch = branch.Name
+		output.Environment = branch.Environment
+		output.TagPrefix = tagPrefix
+		output.Skipped = true
+
+		return output, nil
+	}
+
+	history, err = p.commitHistory(repository, logOptions)
+	p.mu.Unlock()
+
+	if err != nil {
+		return output, err
+	}
 
 	// Sort commit history from oldest to most recent
 	sort.Slice(history, func(i, j int) bool {
@@ -158,9 +439,64 @@ func (p *Parser) ComputeNewSemver(repository *git.Repository, project monorepo.P
 
 	var newRelease bool
 	var commitHash plumbing.Hash
+	var bumps int
+
+	previousSemver := &semver.Version{Major: latestSemver.Major, Minor: latestSemver.Minor, Patch: latestSemver.Patch}
+	latestTagSemver := *latestSemver
+
+	reverted := revertedMerges(history)
+
+	// The projects are analyzed concurrently, only the steps reading objects from the repository storage, which is not
+	// safe for concurrent use, being serialized.
+	p.mu.Lock()
+	duplicates, err := p.duplicateCommits(history)
+	p.mu.Unlock()
+
+	if err != nil {
+		return output, err
+	}
+
+	p.mu.Lock()
+	released, err := p.releasedElsewhere(repository, project, branch, headCommit)
+	p.mu.Unlock()
+
+	if err != nil {
+		return output, fmt.Errorf("detecting cherry-picked commits: %w", err)
+	}
+
+	if p.ctx.RuleStatsFlag {
+		output.RuleStats = make(RuleStats)
+	}
 
 	for _, commit := range history {
-		newReleaseFound, hash, err := p.ProcessCommit(commit, latestSemver, project)
+		output.Commits = append(output.Commits, Change{Hash: commit.Hash, Message: commit.Message, Author: commit.Author})
+
+		if reverted[commit.Hash] {
+			p.ctx.Logger.Debug().Str("commit", commit.Hash.String()).Msg("commit neutralized by the revert of a merge")
+			continue
+		}
+
+		if duplicates[commit.Hash] {
+			p.ctx.Logger.Debug().Str("commit", commit.Hash.String()).Msg("commit ignored as a duplicate of an older one")
+			continue
+		}
+
+		if len(released) > 0 {
+			p.mu.Lock()
+			key, err := changeKey(commit)
+			p.mu.Unlock()
+
+			if err != nil {
+				return output, err
+			}
+
+			if released[key] {
+				p.ctx.Logger.Debug().Str("commit", commit.Hash.String()).Msg("commit already released on another branch")
+				continue
+			}
+		}
+
+		newReleaseFound, hash, err := p.ProcessCommit(commit, latestSemver, project, output.RuleStats)
 		if err != nil {
 			return output, fmt.Errorf("parsing commit history: %w", err)
 		}
@@ -168,175 +504,957 @@ func (p *Parser) ComputeNewSemver(repository *git.Repository, project monorepo.P
 		if newReleaseFound {
 			newRelease = true
 			commitHash = hash
+			bumps++
+
+			changes, err := p.changes(commit)
+			if err != nil {
+				return output, fmt.Errorf("listing commit changes: %w", err)
+			}
+
+			output.Changes = append(output.Changes, changes...)
+		}
+
+		if !p.ctx.SubmoduleAnalysisFlag {
+			continue
+		}
+
+		p.mu.Lock()
+		newReleaseFound, hash, err = p.ProcessSubmoduleCommits(repository, commit, latestSemver, project)
+		p.mu.Unlock()
+
+		if err != nil {
+			return output, fmt.Errorf("parsing submodules commit history: %w", err)
+		}
+
+		if newReleaseFound {
+			newRelease = true
+			commitHash = hash
+			bumps++
+		}
+	}
+
+	if p.ctx.ChangedPathsFlag {
+		p.mu.Lock()
+		output.ChangedPaths, err = changedPaths(history, project.Path)
+		p.mu.Unlock()
+
+		if err != nil {
+			return output, fmt.Errorf("listing changed paths: %w", err)
+		}
+	}
+
+	if newRelease {
+		err = p.capRelease(previousSemver, latestSemver, branch, project)
+		if err != nil {
+			return output, fmt.Errorf("capping release: %w", err)
+		}
+	}
+
+	// A forced bump only applies when no commit triggered a release, e.g. to publish a rebuild of the same sources.
+	if !newRelease {
+		p.mu.Lock()
+		forcedRelease, hash, err := p.forcedBump(history, project, logOptions.From)
+		p.mu.Unlock()
+
+		if err != nil {
+			return output, fmt.Errorf("checking forced release: %w", err)
+		}
+
+		if forcedRelease != "" {
+			err = bumpVersion(latestSemver, forcedRelease)
+			if err != nil {
+				return output, fmt.Errorf("bumping version: %w", err)
+			}
+
+			newRelease = true
+			commitHash = hash
+			output.Forced = true
+		}
+	}
+
+	if !newRelease && len(bumpedBy) > 0 {
+		err = bumpVersion(latestSemver, "patch")
+		if err != nil {
+			return output, fmt.Errorf("bumping version: %w", err)
+		}
+
+		newRelease = true
+		commitHash = logOptions.From
+		output.BumpedBy = bumpedBy
+	}
+
+	switch {
+	case newRelease && output.Forced:
+		output.BumpReason = BumpReasonForced
+	case newRelease && len(output.BumpedBy) > 0:
+		output.BumpReason = BumpReasonDependency
+	case newRelease:
+		output.BumpReason = BumpReasonCommits
+	}
+
+	// Only the commits of a new release are checked, since nothing is released otherwise.
+	if newRelease && p.ctx.StrictAuthorshipFlag {
+		p.mu.Lock()
+		err = p.checkAuthorship(history, project, branch)
+		p.mu.Unlock()
+
+		if err != nil {
+			return output, err
+		}
+	}
+
+	// Freezing the base version before checking anomalies, since the base version of a frozen branch does not change.
+	if branch.FreezeBaseVersion {
+		p.freezeBaseVersion(latestSemver, &latestTagSemver, newRelease, output.Changes, branch)
+	}
+
+	err = p.checkAnomalies(previousSemver, latestSemver, bumps, branch, project)
+	if err != nil {
+		return output, err
+	}
+
+	if p.ctx.APIDiffFlag != "" && latestTagCommit != nil {
+		p.mu.Lock()
+		err = p.checkAPI(repository, latestTagCommit, logOptions.From, project, latestSemver.Major > previousSemver.Major)
+		p.mu.Unlock()
+
+		if err != nil {
+			return output, fmt.Errorf("checking API compatibility: %w", err)
 		}
 	}
 
-	if branch.Prerelease {
+	if branch.Prerelease && !branch.FreezeBaseVersion {
 		latestSemver.Prerelease = branch.Name
 	}
 
+	if newRelease && branch.VersionRange != nil && !branch.VersionRange.Check(latestSemver) {
+		return output, fmt.Errorf("%w: %s does not satisfy %q", ErrVersionOutOfRange, latestSemver, branch.VersionRange)
+	}
+
 	latestSemver.Metadata = p.ctx.BuildMetadataFlag
 
 	output.Semver = latestSemver
+	output.PreviousSemver = &latestTagSemver
 	output.Branch = branch.Name
+	output.Environment = branch.Environment
+	output.TagPrefix = tagPrefix
 	output.CommitHash = commitHash
 	output.NewRelease = newRelease
 
 	return output, nil
 }
 
-// ProcessCommit parse a commit message and bump the latest semantic version accordingly.
-func (p *Parser) ProcessCommit(commit *object.Commit, latestSemver *semver.Version, project monorepo.Project) (bool, plumbing.Hash, error) {
-	if !conventionalCommitRegex.MatchString(commit.Message) {
+// ProcessCommit parse a commit message and bump the latest semantic version accordingly. The commit is counted by the
+// given rule statistics, if not nil.
+func (p *Parser) ProcessCommit(commit *object.Commit, latestSemver *semver.Version, project monorepo.Project, stats RuleStats) (bool, plumbing.Hash, error) {
+	messages, err := p.commitMessages(commit.Message)
+	if err != nil {
+		return false, plumbing.ZeroHash, err
+	}
+
+	// Commits that are not conventional are only checked against the project when counted, since they are ignored
+	// otherwise.
+	conventional := slices.ContainsFunc(messages, conventionalCommitRegex.MatchString)
+	if !conventional && stats == nil {
 		return false, plumbing.ZeroHash, nil
 	}
 
 	if project.Name != "" {
-		containsProjectFiles, err := commitContainsProjectFiles(commit, project.Path)
+		p.mu.Lock()
+		affectsProject, err := commitAffectsProject(commit, project)
+		p.mu.Unlock()
+
 		if err != nil {
-			return false, plumbing.ZeroHash, fmt.Errorf("checking if commit contains project files: %w", err)
+			return false, plumbing.ZeroHash, fmt.Errorf("checking if commit affects project: %w", err)
 		}
-		if !containsProjectFiles {
+		if !affectsProject {
 			return false, plumbing.ZeroHash, nil
 		}
 	}
 
-	match := conventionalCommitRegex.FindStringSubmatch(commit.Message)
-	breakingChange := match[3] == "!" || strings.HasPrefix(commit.Message, "BREAKING CHANGE")
-	commitType := match[1]
-
-	if breakingChange {
-		latestSemver.BumpMajor()
-		return true, commit.Hash, nil
+	if !conventional {
+		stats.add(RuleIgnored)
+		return false, plumbing.ZeroHash, nil
 	}
 
-	releaseType, ok := p.ctx.Rules.Map[commitType]
-	if !ok {
-		return false, plumbing.ZeroHash, nil
+	var newRelease bool
+
+	for _, message := range messages {
+		stats.add(p.rule(message))
+
+		bumped, err := p.bump(message, latestSemver)
+		if err != nil {
+			return false, plumbing.ZeroHash, err
+		}
+
+		newRelease = newRelease || bumped
 	}
 
-	switch releaseType {
-	case "patch":
-		latestSemver.BumpPatch()
-	case "minor":
-		latestSemver.BumpMinor()
-	default:
-		return false, plumbing.ZeroHash, fmt.Errorf("unknown release type %q", releaseType)
+	if !newRelease {
+		return false, plumbing.ZeroHash, nil
 	}
 
 	return true, commit.Hash, nil
 }
 
-// FetchLatestSemverTag parses a Git repository to fetch the tag corresponding to the highest semantic version number
-// among all tags.
-func (p *Parser) FetchLatestSemverTag(repository *git.Repository, project monorepo.Project) (*object.Tag, error) {
-	p.mu.Lock()
-	defer p.mu.Unlock()
-
-	tags, err := repository.TagObjects()
+// changes returns the messages of a commit that trigger a bump.
+func (p *Parser) changes(commit *object.Commit) ([]Change, error) {
+	messages, err := p.commitMessages(commit.Message)
 	if err != nil {
-		return nil, fmt.Errorf("fetching tag objects: %w", err)
+		return nil, err
 	}
 
-	var (
-		latestSemver *semver.Version
-		latestTag    *object.Tag
-	)
-
-	err = tags.ForEach(func(tag *object.Tag) error {
-		if !semver.Regex.MatchString(tag.Name) {
-			return nil
-		}
-
-		if project.Name != "" && !strings.HasPrefix(tag.Name, project.Name+"-") {
-			return nil
-		}
+	var changes []Change
 
-		currentSemver, err := semver.NewFromString(tag.Name)
+	for _, message := range messages {
+		releaseType, err := p.releaseType(message)
 		if err != nil {
-			return fmt.Errorf("converting tag to semver: %w", err)
+			return nil, err
 		}
 
-		if latestSemver == nil || semver.Compare(latestSemver, currentSemver) == -1 {
-			latestSemver = currentSemver
-			latestTag = tag
+		if releaseType != "" {
+			changes = append(changes, Change{Hash: commit.Hash, Message: message, Author: commit.Author})
 		}
-		return nil
-	})
-
-	if err != nil {
-		return nil, fmt.Errorf("looping over tags: %w", err)
 	}
 
-	return latestTag, nil
+	return changes, nil
 }
 
-// checkoutBranch moves the HEAD pointer of the given repository to the given branch. This function expects the
-// repository to be a clone and have a remote to which it will set the branch being checkout to a remote reference to
-// the corresponding remote branch.
-func (p *Parser) checkoutBranch(repository *git.Repository, branchName string) error {
-	remoteBranchRef := plumbing.NewRemoteReferenceName(p.ctx.RemoteNameFlag, branchName)
-	_, err := repository.Reference(remoteBranchRef, true)
-	if err != nil {
-		return fmt.Errorf("remote branch %q not found: %w", remoteBranchRef, err)
-	}
-
-	localBranchRef := plumbing.NewBranchReferenceName(branchName)
-	ref := plumbing.NewSymbolicReference(localBranchRef, remoteBranchRef)
-	err = repository.Storer.SetReference(ref)
+// ProcessSubmoduleCommits parses the commit messages of the submodules whose pointer has been updated by the given
+// commit and bumps the latest semantic version accordingly. Only the submodules located inside the given project's path
+// are considered. The commits of a submodule are read from its own repository, which is cloned on first use. The caller
+// must hold the parser lock.
+func (p *Parser) ProcessSubmoduleCommits(repository *git.Repository, commit *object.Commit, latestSemver *semver.Version, project monorepo.Project) (bool, plumbing.Hash, error) {
+	updates, err := submoduleUpdates(commit, project.Path)
 	if err != nil {
-		return fmt.Errorf("error creating local branch %q: %w", localBranchRef, err)
+		return false, plumbing.ZeroHash, fmt.Errorf("listing submodule updates: %w", err)
 	}
 
-	// Checkout the new local branch
-	w, err := repository.Worktree()
-	if err != nil {
-		return fmt.Errorf("error getting worktree: %w", err)
-	}
+	var newRelease bool
 
-	err = w.Checkout(&git.CheckoutOptions{
-		Branch: localBranchRef,
-		Force:  true,
-	})
-	if err != nil {
-		if errors.Is(err, plumbing.ErrReferenceNotFound) {
-			return fmt.Errorf("branch %q does not exist: %w", branchName, err)
+	for _, update := range updates {
+		messages, err := p.submoduleMessages(repository, commit, update)
+		if err != nil {
+			return false, plumbing.ZeroHash, fmt.Errorf("reading submodule %q history: %w", update.path, err)
 		}
-		return fmt.Errorf("checking out to release branch: %w", err)
+
+		for _, message := range messages {
+			bumped, err := p.bump(message, latestSemver)
+			if err != nil {
+				return false, plumbing.ZeroHash, err
+			}
+
+			newRelease = newRelease || bumped
+		}
+	}
+
+	if !newRelease {
+		return false, plumbing.ZeroHash, nil
+	}
+
+	return true, commit.Hash, nil
+}
+
+// bump parses a commit message and bumps the latest semantic version according to the configured rules. It returns
+// whether the message triggered a release.
+func (p *Parser) bump(message string, latestSemver *semver.Version) (bool, error) {
+	releaseType, err := p.releaseType(message)
+	if err != nil || releaseType == "" {
+		return false, err
+	}
+
+	err = bumpVersion(latestSemver, releaseType)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func bumpVersion(version *semver.Version, releaseType string) error {
+	switch releaseType {
+	case "major":
+		return version.BumpMajor()
+	case "minor":
+		return version.BumpMinor()
+	default:
+		return version.BumpPatch()
+	}
+}
+
+// releaseType returns the type of release ("major", "minor" or "patch") triggered by a commit message according to the
+// configured rules, or an empty string if the message does not trigger any release.
+func (p *Parser) releaseType(message string) (string, error) {
+	match, ok := p.matchRule(message)
+	if !ok {
+		return "", nil
+	}
+
+	switch match.Release {
+	case "patch", "minor", "major":
+		return match.Release, nil
+	default:
+		return "", fmt.Errorf("unknown release type %q", match.Release)
+	}
+}
+
+// matchRule returns the release rule matched by a commit message, if any. Messages that are not conventional commits
+// or hold a skip marker match no rule.
+func (p *Parser) matchRule(message string) (rule.Match, bool) {
+	commit, ok := conventionalCommit(message)
+	if !ok || p.hasSkipMarker(message) {
+		return rule.Match{}, false
+	}
+
+	return p.ctx.Rules.Evaluate(commit)
+}
+
+// ValidateReleaseType checks that the given configured release type is supported, an empty type meaning the option is
+// disabled.
+func ValidateReleaseType(releaseType string) error {
+	if _, ok := releaseTypePrecedence[releaseType]; releaseType != "" && !ok {
+		return fmt.Errorf("%w: %q, must be \"patch\", \"minor\" or \"major\"", ErrInvalidReleaseType, releaseType)
+	}
+
+	return nil
+}
+
+// forcedBump returns the type of the release forced either by the configuration, in which case the release commit is
+// the head commit, or by a "[release <type>]" marker in the message of a commit of the history, in which case the
+// release commit is the most recent commit holding a marker. The highest type wins when several are forced.
+func (p *Parser) forcedBump(history []*object.Commit, project monorepo.Project, head plumbing.Hash) (string, plumbing.Hash, error) {
+	releaseType := p.ctx.ForceBumpFlag
+	commitHash := head
+
+	for _, commit := range history {
+		markers := forceBumpMarkerRegex.FindAllStringSubmatch(commit.Message, -1)
+		if len(markers) == 0 {
+			continue
+		}
+
+		if project.Name != "" {
+			affectsProject, err := commitAffectsProject(commit, project)
+			if err != nil {
+				return "", plumbing.ZeroHash, fmt.Errorf("checking if commit affects project: %w", err)
+			}
+			if !affectsProject {
+				continue
+			}
+		}
+
+		for _, marker := range markers {
+			markerType := strings.ToLower(marker[1])
+
+			if releaseTypePrecedence[markerType] > releaseTypePrecedence[releaseType] {
+				releaseType = markerType
+			}
+		}
+
+		if p.ctx.ForceBumpFlag == "" {
+			commitHash = commit.Hash
+		}
+	}
+
+	return releaseType, commitHash, nil
+}
+
+// capRelease lowers the release computed for a run from the previous version to the maximum release type allowed per
+// run, if configured, unless a major release was confirmed: a run whose commits release a higher type releases a
+// single bump of the maximum type instead.
+func (p *Parser) capRelease(previous, current *semver.Version, branch branch.Branch, project monorepo.Project) error {
+	maxBump := p.ctx.MaxBumpPerRunFlag
+
+	releaseType := "patch"
+	switch {
+	case current.Major > previous.Major:
+		releaseType = "major"
+	case current.Minor > previous.Minor:
+		releaseType = "minor"
+	}
+
+	if maxBump == "" || releaseTypePrecedence[releaseType] <= releaseTypePrecedence[maxBump] {
+		return nil
+	}
+
+	if releaseType == "major" && p.ctx.ConfirmMajorFlag {
+		return nil
+	}
+
+	p.ctx.Logger.Warn().Str("branch", branch.Name).Str("project", project.Name).Str("release", releaseType).Str("max-bump", maxBump).Msg("release type capped")
+
+	current.Major, current.Minor, current.Patch = previous.Major, previous.Minor, previous.Patch
+
+	return bumpVersion(current, maxBump)
+}
+
+// checkAnomalies reports a run that skips more versions than configured or crosses a major boundary, which may be the
+// sign of a misformatted commit (e.g., a squashed mega-merge). Skipped versions are only reported if a maximum number
+// of skipped versions is configured. Crossing a major boundary makes the run fail unless it was confirmed, as soon as
+// anomaly detection or a maximum release type per run is configured.
+func (p *Parser) checkAnomalies(previous, current *semver.Version, bumps int, branch branch.Branch, project monorepo.Project) error {
+	maxSkip := p.ctx.MaxVersionSkipFlag
+
+	if maxSkip <= 0 && p.ctx.MaxBumpPerRunFlag == "" {
+		return nil
+	}
+
+	if skipped := bumps - 1; maxSkip > 0 && skipped > maxSkip {
+		p.ctx.Logger.Warn().Str("branch", branch.Name).Str("project", project.Name).Int("skipped", skipped).Int("max-skip", maxSkip).Msg("anomaly: release skips more versions than expected")
+	}
+
+	if current.Major > previous.Major {
+		p.ctx.Logger.Warn().Str("branch", branch.Name).Str("project", project.Name).Str("from", previous.String()).Str("to", current.String()).Msg("anomaly: release crosses a major boundary")
+
+		if !p.ctx.ConfirmMajorFlag {
+			return fmt.Errorf("%w: %s to %s, use --confirm-major to proceed", ErrUnconfirmedMajor, previous, current)
+		}
+	}
+
+	return nil
+}
+
+// hasSkipMarker returns whether a commit message contains one of the configured skip markers, such as "[skip release]".
+// Markers are matched case-insensitively.
+func (p *Parser) hasSkipMarker(message string) bool {
+	message = strings.ToLower(message)
+
+	for _, marker := range p.ctx.SkipMarkersFlag {
+		if marker != "" && strings.Contains(message, strings.ToLower(marker)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkAPI compares the public API of a project between the commit of its latest semver tag and the tip of the analyzed
+// branch. Incompatible changes that were not announced by a breaking change commit are reported, and make the parsing
+// fail if the API diff is configured to do so. The caller must hold the parser lock.
+func (p *Parser) checkAPI(repository *git.Repository, latestTagCommit *object.Commit, head plumbing.Hash, project monorepo.Project, majorBumped bool) error {
+	if majorBumped {
+		return nil
+	}
+
+	analyzer, err := apidiff.Get(p.ctx.APIDiffAnalyzerFlag)
+	if err != nil {
+		return err
+	}
+
+	previousTree, err := latestTagCommit.Tree()
+	if err != nil {
+		return fmt.Errorf("fetching latest semver tag tree: %w", err)
+	}
+
+	headCommit, err := repository.CommitObject(head)
+	if err != nil {
+		return fmt.Errorf("fetching branch head commit: %w", err)
+	}
+
+	currentTree, err := headCommit.Tree()
+	if err != nil {
+		return fmt.Errorf("fetching branch head tree: %w", err)
+	}
+
+	// A project tag source is a split repository whose root is the project directory.
+	previousPath := project.Path
+	if project.TagSource != "" {
+		previousPath = "."
+	}
+
+	report, err := apidiff.Diff(analyzer, apidiff.Revision{Tree: previousTree, Path: previousPath}, apidiff.Revision{Tree: currentTree, Path: project.Path})
+	if err != nil {
+		return err
+	}
+
+	if !report.HasIncompatibleChanges() {
+		return nil
+	}
+
+	logEvent := p.ctx.Logger.Warn().Strs("changes", report.Incompatible)
+	if project.Name != "" {
+		logEvent.Str("project", project.Name)
+	}
+	logEvent.Msg("incompatible API changes found without a breaking change commit, a major release is suggested")
+
+	if p.ctx.APIDiffFlag == apidiff.ModeFail {
+		return apidiff.ErrIncompatibleAPI
+	}
+
+	return nil
+}
+
+// FetchLatestSemverTag parses a Git repository to fetch the tag corresponding to the highest semantic version number
+// among all tags. For a project with an external tag source, the given repository is expected to be that tag source.
+// Tags are selected by semantic version precedence only, never by creation date, so that backfilled tags do not take
+// precedence over more recent versions.
+func (p *Parser) FetchLatestSemverTag(repository *git.Repository, project monorepo.Project) (*object.Tag, error) {
+	return p.fetchLatestSemverTag(repository, project, "", false)
+}
+
+// fetchLatestSemverTag is like FetchLatestSemverTag, but only considers the tags using the given prefix if
+// matchPrefix is true.
+func (p *Parser) fetchLatestSemverTag(repository *git.Repository, project monorepo.Project, prefix string, matchPrefix bool) (*object.Tag, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	tags, err := p.semverTags(repository, project, prefix, matchPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	// The latest trusted tag is used, untrusted ones being either skipped or aborting the run depending on the policy.
+	for i := len(tags) - 1; i >= 0; i-- {
+		err = p.checkTagTrust(tags[i])
+		if err == nil {
+			return tags[i], nil
+		}
+
+		if p.ctx.UntrustedTagsFlag != UntrustedTagsIgnore {
+			return nil, err
+		}
+
+		p.ctx.Logger.Warn().Str("tag", tags[i].Name).Err(err).Msg("ignoring untrusted tag")
+	}
+
+	return nil, nil
+}
+
+// ValidateUntrustedTagsPolicy checks that the given policy applied to tags whose signature cannot be verified is
+// supported.
+func ValidateUntrustedTagsPolicy(policy string) error {
+	switch policy {
+	case UntrustedTagsFail, UntrustedTagsIgnore:
+		return nil
+	default:
+		return fmt.Errorf("%w: %q, must be %q or %q", ErrInvalidUntrustedTagsPolicy, policy, UntrustedTagsFail, UntrustedTagsIgnore)
+	}
+}
+
+// checkTagTrust verifies that a tag is signed by one of the trusted keys, if any is configured, so that a tag injected
+// on a compromised remote is not used as the base version.
+func (p *Parser) checkTagTrust(t *object.Tag) error {
+	if len(p.ctx.TrustedKeys) == 0 {
+		return nil
+	}
+
+	if _, err := tag.Verify(t, p.ctx.TrustedKeys); err != nil {
+		return fmt.Errorf("%w: %q: %w", ErrUntrustedTag, t.Name, err)
 	}
 
 	return nil
 }
 
+// tagPrefix returns the prefix of the tags of a branch and whether existing tags must use it to be considered. Tags
+// are only matched against their prefix if a branch overrides the global one, so that several tagging schemes can
+// coexist in a repository while repositories using a single scheme keep considering every semver tag.
+func (p *Parser) tagPrefix(b branch.Branch) (string, bool) {
+	matchPrefix := b.TagPrefix != nil
+
+	for _, configured := range p.ctx.Branches {
+		matchPrefix = matchPrefix || configured.TagPrefix != nil
+	}
+
+	if b.TagPrefix != nil {
+		return *b.TagPrefix, matchPrefix
+	}
+
+	return p.ctx.TagPrefixFlag, matchPrefix
+}
+
+// semverTags returns the semver tags of a repository that belong to the given project, or to any project if none is
+// given, sorted by ascending precedence. Tags read from a project's external tag source belong to that project only and
+// are not prefixed by its name. If matchPrefix is true, only the tags whose version directly follows the given prefix
+// are returned, along with the aliased tags.
+func (p *Parser) semverTags(repository *git.Repository, project monorepo.Project, prefix string, matchPrefix bool) ([]*object.Tag, error) {
+	tagObjects, err := repository.TagObjects()
+	if err != nil {
+		return nil, fmt.Errorf("fetching tag objects: %w", err)
+	}
+
+	// Tags read from an external tag source do not share the history of the repository.
+	var boundary *historyBoundary
+
+	if project.TagSource == "" {
+		boundary, err = p.historyBoundary(repository)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var tags []*object.Tag
+
+	err = tagObjects.ForEach(func(tag *object.Tag) error {
+		// Tags that are not valid semantic versions, including pathological ones, are not release tags.
+		if _, err := p.tagVersion(tag.Name); err != nil {
+			return nil
+		}
+
+		// Tags made before the history boundary belong to an unrelated history.
+		if boundary.excludesTag(tag) {
+			return nil
+		}
+
+		// Tags created after the as-of point did not exist yet.
+		if p.asOf.excludesTag(tag) {
+			return nil
+		}
+
+		// Tags created outside the configured namespace, e.g. ad-hoc tags of developers, are not release tags.
+		name, ok := p.inTagNamespace(tag.Name)
+		if !ok {
+			return nil
+		}
+
+		if project.Name != "" && project.TagSource == "" {
+			if !strings.HasPrefix(name, project.Name+"-") {
+				return nil
+			}
+
+			name = strings.TrimPrefix(name, project.Name+"-")
+		}
+
+		if matchPrefix && !hasVersionPrefix(name, prefix) && !p.isTagAlias(tag.Name) {
+			return nil
+		}
+
+		tags = append(tags, tag)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("looping over tags: %w", err)
+	}
+
+	err = sortTags(tags, p.tagVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// hasVersionPrefix returns whether a tag name is made of the given prefix directly followed by a version number.
+func hasVersionPrefix(name, prefix string) bool {
+	version, ok := strings.CutPrefix(name, prefix)
+
+	return ok && version != "" && version[0] >= '0' && version[0] <= '9'
+}
+
+// tagVersion returns the semantic version of a tag, which is either the version its name is aliased to or the version
+// contained in its name. Aliases are matched case-insensitively since configuration keys are case-insensitive.
+func (p *Parser) tagVersion(name string) (*semver.Version, error) {
+	// The aliased version is copied, since the returned version may be bumped by the caller.
+	if alias, ok := p.ctx.TagAliases[strings.ToLower(name)]; ok {
+		version := *alias
+		return &version, nil
+	}
+
+	return semver.NewFromString(name)
+}
+
+// inTagNamespace returns the name of a tag relative to the configured tag namespace and epoch and whether it belongs to
+// them.
+func (p *Parser) inTagNamespace(name string) (string, bool) {
+	return tag.InEpoch(p.ctx.TagNamespaceFlag, p.ctx.EpochFlag, name)
+}
+
+func (p *Parser) isTagAlias(name string) bool {
+	_, ok := p.ctx.TagAliases[strings.ToLower(name)]
+	return ok
+}
+
+// sortTags sorts semver tags by ascending precedence, using the given function to obtain their version. Tags of equal
+// precedence (e.g., only differing by their build metadata or prefix) are ordered by name, so that the order does not
+// depend on the order in which tags are read.
+func sortTags(tags []*object.Tag, tagVersion func(string) (*semver.Version, error)) error {
+	versions := make(map[*object.Tag]*semver.Version, len(tags))
+
+	for _, tag := range tags {
+		version, err := tagVersion(tag.Name)
+		if err != nil {
+			return fmt.Errorf("converting tag to semver: %w", err)
+		}
+
+		versions[tag] = version
+	}
+
+	slices.SortFunc(tags, func(a, b *object.Tag) int {
+		if c := semver.Compare(versions[a], versions[b]); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return nil
+}
+
+// branchHead returns the commit from which the history of a branch is analyzed: the analyzed commit if one is given,
+// or else the tip of the branch, or its most recent commit as of the as-of point if any.
+func (p *Parser) branchHead(repository *git.Repository, branch branch.Branch) (plumbing.Hash, error) {
+	var (
+		head plumbing.Hash
+		err  error
+	)
+
+	if p.ctx.AtFlag != "" {
+		head, err = resolveRevision(repository, p.ctx.AtFlag)
+	} else {
+		head, err = p.resolveBranch(repository, branch.Name)
+	}
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("resolving branch: %w", err)
+	}
+
+	if p.asOf != nil {
+		head, err = p.asOf.head(repository, head)
+		if err != nil {
+			return plumbing.ZeroHash, fmt.Errorf("resolving branch %q as-of commit: %w", branch.Name, err)
+		}
+	}
+
+	return head, nil
+}
+
+// resolveBranch returns the hash of the commit at the tip of the given branch. The remote reference of the branch is
+// preferred, which is what exists when the repository is a clone, otherwise the local branch reference is used. No
+// worktree is needed, so that bare repositories can be analyzed as well.
+func (p *Parser) resolveBranch(repository *git.Repository, branchName string) (plumbing.Hash, error) {
+	refNames := []plumbing.ReferenceName{
+		plumbing.NewRemoteReferenceName(p.ctx.RemoteNameFlag, branchName),
+		plumbing.NewBranchReferenceName(branchName),
+	}
+
+	for _, refName := range refNames {
+		ref, err := repository.Reference(refName, true)
+		if err == nil {
+			return ref.Hash(), nil
+		}
+
+		if !errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return plumbing.ZeroHash, fmt.Errorf("resolving reference %q: %w", refName, err)
+		}
+	}
+
+	return plumbing.ZeroHash, fmt.Errorf("branch %q does not exist: %w", branchName, plumbing.ErrReferenceNotFound)
+}
+
+// resolveRevision returns the hash of the commit designated by a revision (e.g., a full or abbreviated SHA-1), which
+// allows analyzing a commit that is not the tip of a branch, such as a detached HEAD checked out by a CI runner.
+func resolveRevision(repository *git.Repository, revision string) (plumbing.Hash, error) {
+	hash, err := repository.ResolveRevision(plumbing.Revision(revision))
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("revision %q: %w", revision, err)
+	}
+
+	return *hash, nil
+}
+
 // commitContainsProjectFiles checks if a given commit changes contain at least one file whose path belongs to the
-// given project's path.
+// given project's path. A submodule is attributed to a project according to its own path, since the submodule
+// directory itself may be the project directory.
 func commitContainsProjectFiles(commit *object.Commit, projectPath string) (bool, error) {
+	changes, err := commitChanges(commit)
+	if err != nil {
+		return false, err
+	}
+
+	for _, change := range changes {
+		dir := filepath.Dir(change.To.Name)
+		if change.To.TreeEntry.Mode == filemode.Submodule {
+			dir = change.To.Name
+		}
+
+		if strings.HasPrefix(dir, projectPath) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// commitChanges returns the changes introduced by a given commit compared to its first parent.
+func commitChanges(commit *object.Commit) (object.Changes, error) {
 	commitTree, err := commit.Tree()
 	if err != nil {
-		return false, fmt.Errorf("getting commit tree: %w", err)
+		return nil, fmt.Errorf("getting commit tree: %w", err)
 	}
 
 	var parentTree *object.Tree
 	if parent, err := commit.Parent(0); err == nil {
 		parentTree, err = parent.Tree()
 		if err != nil {
-			return false, fmt.Errorf("getting parent tree: %w", err)
+			return nil, fmt.Errorf("getting parent tree: %w", err)
 		}
 	}
 
 	changes, err := object.DiffTree(parentTree, commitTree)
 	if err != nil {
-		return false, fmt.Errorf("getting diff tree: %w", err)
+		return nil, fmt.Errorf("getting diff tree: %w", err)
+	}
+
+	return changes, nil
+}
+
+type submoduleUpdate struct {
+	path string
+	from plumbing.Hash
+	to   plumbing.Hash
+}
+
+// submoduleUpdates returns the submodules located inside the given project's path whose pointer has been moved by a
+// given commit. Newly added submodules are ignored since they have no previous commit to compare with.
+func submoduleUpdates(commit *object.Commit, projectPath string) ([]submoduleUpdate, error) {
+	changes, err := commitChanges(commit)
+	if err != nil {
+		return nil, err
 	}
 
+	var updates []submoduleUpdate
+
 	for _, change := range changes {
-		dir := filepath.Dir(change.To.Name)
-		if strings.HasPrefix(dir, projectPath) {
-			return true, nil
+		if change.From.TreeEntry.Mode != filemode.Submodule || change.To.TreeEntry.Mode != filemode.Submodule {
+			continue
 		}
+
+		if !strings.HasPrefix(change.To.Name, projectPath) {
+			continue
+		}
+
+		updates = append(updates, submoduleUpdate{
+			path: change.To.Name,
+			from: change.From.TreeEntry.Hash,
+			to:   change.To.TreeEntry.Hash,
+		})
 	}
 
-	return false, nil
+	return updates, nil
+}
+
+// submoduleMessages returns the messages, from oldest to most recent, of the commits of a submodule between its previous
+// and its new pointer.
+func (p *Parser) submoduleMessages(repository *git.Repository, commit *object.Commit, update submoduleUpdate) ([]string, error) {
+	url, err := p.submoduleURL(repository, commit, update.path)
+	if err != nil {
+		return nil, err
+	}
+
+	submodule, err := p.clone(url)
+	if err != nil {
+		return nil, fmt.Errorf("cloning submodule: %w", err)
+	}
+
+	commits, err := submodule.Log(&git.LogOptions{From: update.to})
+	if err != nil {
+		return nil, fmt.Errorf("fetching submodule commit history: %w", err)
+	}
+
+	var messages []string
+
+	err = commits.ForEach(func(c *object.Commit) error {
+		if c.Hash == update.from {
+			return storer.ErrStop
+		}
+
+		messages = append(messages, c.Message)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("looping over submodule commits: %w", err)
+	}
+
+	slices.Reverse(messages)
+
+	return messages, nil
+}
+
+// submoduleURL returns the URL of the submodule located at the given path, as declared in the .gitmodules file of the
+// given commit. Relative URLs are resolved against the URL of the repository remote.
+func (p *Parser) submoduleURL(repository *git.Repository, commit *object.Commit, path string) (string, error) {
+	file, err := commit.File(".gitmodules")
+	if err != nil {
+		return "", fmt.Errorf("reading .gitmodules: %w", err)
+	}
+
+	content, err := file.Contents()
+	if err != nil {
+		return "", fmt.Errorf("reading .gitmodules content: %w", err)
+	}
+
+	modules := config.NewModules()
+	if err = modules.Unmarshal([]byte(content)); err != nil {
+		return "", fmt.Errorf("parsing .gitmodules: %w", err)
+	}
+
+	for _, module := range modules.Submodules {
+		if module.Path != path {
+			continue
+		}
+
+		if !strings.HasPrefix(module.URL, "./") && !strings.HasPrefix(module.URL, "../") {
+			return module.URL, nil
+		}
+
+		origin, err := repository.Remote(p.ctx.RemoteNameFlag)
+		if err != nil {
+			return "", fmt.Errorf("resolving relative submodule URL: %w", err)
+		}
+
+		return resolveRelativeURL(origin.Config().URLs[0], module.URL), nil
+	}
+
+	return "", fmt.Errorf("submodule %q not declared in .gitmodules", path)
+}
+
+// clone returns a clone of the repository located at the given URL. Each repository is only cloned once per parser.
+// The caller must hold the parser lock.
+func (p *Parser) clone(url string) (*git.Repository, error) {
+	if repository, ok := p.clones[url]; ok {
+		return repository, nil
+	}
+
+	var options []remote.OptionFunc
+	if p.ctx.ProgressFlag {
+		options = append(options, remote.WithProgress(progress.NewWriter(p.ctx.Logger, "cloning repository", progress.Interval)))
+	}
+
+	origin := remote.New(p.ctx.RemoteNameFlag, p.ctx.AccessTokenFlag, options...)
+
+	var (
+		repository *git.Repository
+		err        error
+	)
+
+	if p.ctx.Workspace != nil {
+		var dir string
+
+		dir, err = p.ctx.Workspace.Create()
+		if err != nil {
+			return nil, err
+		}
+
+		repository, err = origin.CloneTo(url, dir)
+	} else {
+		repository, err = origin.Clone(url)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	p.clones[url] = repository
+
+	return repository, nil
+}
+
+// resolveRelativeURL resolves a submodule relative URL against the URL of its superproject, the same way Git does.
+func resolveRelativeURL(base, relative string) string {
+	u, err := url.Parse(base)
+	if err != nil || u.Scheme == "" {
+		return filepath.Join(base, relative)
+	}
+
+	u.Path = path.Join(u.Path, relative)
+
+	return u.String()
 }
 
 func shortenMessage(message string) string {
@@ -1,24 +1,33 @@
 package parser
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"github.com/rs/zerolog"
+	"github.com/s0ders/go-semver-release/v6/internal/apidiff"
 	"github.com/s0ders/go-semver-release/v6/internal/appcontext"
 	"github.com/s0ders/go-semver-release/v6/internal/branch"
 	"io"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
+	"testing/quick"
+	"time"
 
+	"github.com/ProtonMail/go-crypto/openpgp"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	assertion "github.com/stretchr/testify/assert"
 
-	"github.com/s0ders/go-semver-release/v6/internal/gittest"
 	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
 	"github.com/s0ders/go-semver-release/v6/internal/rule"
 	"github.com/s0ders/go-semver-release/v6/internal/semver"
+	"github.com/s0ders/go-semver-release/v6/internal/tag"
+	"github.com/s0ders/go-semver-release/v6/pkg/gittest"
 )
 
 func TestParser_CommitTypeRegex(t *testing.T) {
@@ -145,6 +154,150 @@ func TestParser_FetchLatestSemverTag_MultipleTags(t *testing.T) {
 	assert.Equal(want, latest.Name, "latest semver tag should be equal")
 }
 
+func TestParser_FetchLatestSemverTag_EqualPrecedence(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	head, err := testRepository.Head()
+	checkErr(t, "fetching head", err)
+
+	for _, v := range []string{"v1.0.0+b", "v1.0.0+c", "v1.0.0+a", "v1.0.0-rc"} {
+		err = testRepository.AddTag(v, head.Hash())
+		checkErr(t, "creating tag", err)
+	}
+
+	th := NewTestHelper(t)
+
+	latest, err := New(th.Ctx).FetchLatestSemverTag(testRepository.Repository, monorepo.Project{})
+	checkErr(t, "fetching latest semver tag", err)
+
+	assert.Equal("v1.0.0+c", latest.Name, "tags of equal precedence should be ordered by name")
+}
+
+func TestParser_FetchLatestSemverTag_SameCommitSkewedTaggers(t *testing.T) {
+	assert := assertion.New(t)
+
+	names := []string{"v1.2.0", "release-1.3.0", "v1.3.0", "1.3.0", "release-1.2.9"}
+
+	// Every rotation of the tagger dates is tried, so that no tag is selected for being the most or least recent.
+	for offset := range names {
+		testRepository, err := gittest.NewRepository()
+		checkErr(t, "creating repository", err)
+
+		head, err := testRepository.Head()
+		checkErr(t, "fetching head", err)
+
+		base := testRepository.When()
+
+		for i, name := range names {
+			when := base.Add(time.Duration((i+offset)%len(names)-2) * time.Hour)
+
+			_, err = testRepository.CreateTag(name, head.Hash(), &git.CreateTagOptions{
+				Message: name,
+				Tagger:  &object.Signature{Name: "Go Semver Release", Email: "go-semver@release.ci", When: when},
+			})
+			checkErr(t, "creating tag", err)
+		}
+
+		th := NewTestHelper(t)
+		p := New(th.Ctx)
+
+		latest, err := p.FetchLatestSemverTag(testRepository.Repository, monorepo.Project{})
+		checkErr(t, "fetching latest semver tag", err)
+
+		assert.Equal("v1.3.0", latest.Name, "tags of equal precedence should be ordered by name, whatever their date")
+
+		latest, err = p.fetchLatestSemverTag(testRepository.Repository, monorepo.Project{}, "release-", true)
+		checkErr(t, "fetching latest semver tag", err)
+
+		assert.Equal("release-1.3.0", latest.Name, "only the tags of the prefix should be considered, whatever their date")
+
+		err = testRepository.Remove()
+		checkErr(t, "removing repository", err)
+	}
+}
+
+func TestParser_SortTags_Properties(t *testing.T) {
+	type version struct {
+		Major, Minor, Patch uint8
+		Prerelease          uint8
+		Metadata            uint8
+	}
+
+	// Small components are generated so that equal precedences are frequent.
+	toTags := func(versions []version) []*object.Tag {
+		tags := make([]*object.Tag, len(versions))
+
+		for i, v := range versions {
+			name := fmt.Sprintf("v%d.%d.%d", v.Major%3, v.Minor%3, v.Patch%3)
+
+			if v.Prerelease%3 != 0 {
+				name += fmt.Sprintf("-rc%d", v.Prerelease%3)
+			}
+
+			if v.Metadata%3 != 0 {
+				name += fmt.Sprintf("+%d", v.Metadata%3)
+			}
+
+			tags[i] = &object.Tag{Name: name}
+		}
+
+		return tags
+	}
+
+	names := func(tags []*object.Tag) []string {
+		result := make([]string, len(tags))
+		for i, tag := range tags {
+			result[i] = tag.Name
+		}
+		return result
+	}
+
+	ordered := func(versions []version) bool {
+		tags := toTags(versions)
+		if err := sortTags(tags, semver.NewFromString); err != nil {
+			return false
+		}
+
+		for i := 1; i < len(tags); i++ {
+			previous, _ := semver.NewFromString(tags[i-1].Name)
+			current, _ := semver.NewFromString(tags[i].Name)
+
+			if semver.Compare(previous, current) == 1 {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	deterministic := func(versions []version) bool {
+		tags := toTags(versions)
+		reversed := slices.Clone(tags)
+		slices.Reverse(reversed)
+
+		if sortTags(tags, semver.NewFromString) != nil || sortTags(reversed, semver.NewFromString) != nil {
+			return false
+		}
+
+		return slices.Equal(names(tags), names(reversed))
+	}
+
+	if err := quick.Check(ordered, nil); err != nil {
+		t.Errorf("sorted tags should be ordered by precedence: %s", err)
+	}
+
+	if err := quick.Check(deterministic, nil); err != nil {
+		t.Errorf("sorted tags should not depend on the input order: %s", err)
+	}
+}
+
 func TestParser_ComputeNewSemver_UntaggedRepository_NoRelease(t *testing.T) {
 	assert := assertion.New(t)
 
@@ -382,7 +535,6 @@ func TestParser_ComputeNewSemver_Prerelease(t *testing.T) {
 	assert.Equal(true, output.NewRelease, "boolean should be equal")
 }
 
-// FIXME: the "origin" name is not set when calling parser.checkoutBranch leaving remoteRef like "ref/remote/<empty>/<branch>
 func TestParser_Run_NoMonorepoOutputLength(t *testing.T) {
 	assert := assertion.New(t)
 
@@ -415,6 +567,37 @@ func TestParser_Run_NoMonorepoOutputLength(t *testing.T) {
 	assert.Equal(want.String(), output[0].Semver.String(), "version should be equal")
 }
 
+func TestParser_Run_BareRepository(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	_, err = testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+
+	bareRepository, err := testRepository.BareClone()
+	checkErr(t, "cloning bare repository", err)
+
+	t.Cleanup(func() {
+		_ = bareRepository.Remove()
+	})
+
+	th := NewTestHelper(t)
+	parser := New(th.Ctx)
+
+	output, err := parser.Run(context.Background(), bareRepository.Repository)
+	checkErr(t, "computing new semver", err)
+
+	assert.Len(output, 1, "parser run output should contain one element")
+	assert.Equal("0.1.0", output[0].Semver.String(), "version should be equal")
+	assert.Equal(true, output[0].NewRelease, "boolean should be equal")
+}
+
 func TestParser_ShortMessage(t *testing.T) {
 	assert := assertion.New(t)
 
@@ -501,115 +684,760 @@ func TestMonorepoParser_CommitContainsProjectFiles_False(t *testing.T) {
 	assert.False(contains, "commit does not contain project files")
 }
 
-func TestParser_Run_Monorepo(t *testing.T) {
+func TestMonorepoParser_CommitContainsProjectFiles_Submodule(t *testing.T) {
 	assert := assertion.New(t)
 
+	submoduleRepository, err := gittest.NewRepository()
+	checkErr(t, "creating submodule repository", err)
+
 	testRepository, err := gittest.NewRepository()
 	checkErr(t, "creating repository", err)
 
 	t.Cleanup(func() {
+		_ = submoduleRepository.Remove()
 		_ = testRepository.Remove()
 	})
 
-	// Adding "foo" project commits
-	_, err = testRepository.AddCommitWithSpecificFile("feat!", "./foo/foo.txt")
-	checkErr(t, "adding commit", err)
-	_, err = testRepository.AddCommitWithSpecificFile("fix", "./foo/xyz/foo.txt")
-	checkErr(t, "adding commit", err)
+	submoduleHead, err := submoduleRepository.Head()
+	checkErr(t, "fetching submodule head", err)
 
-	// Adding "bar" project commits
-	_, err = testRepository.AddCommitWithSpecificFile("feat", "./bar/foo.txt")
-	checkErr(t, "adding commit", err)
-	_, err = testRepository.AddCommitWithSpecificFile("fix", "./bar/baz/xyz/foo.txt")
-	checkErr(t, "adding commit", err)
-	_, err = testRepository.AddCommitWithSpecificFile("fix", "./bar/baz/xyz/bar.txt")
-	checkErr(t, "adding commit", err)
+	hash, err := testRepository.AddSubmoduleCommit("chore", "lib", submoduleRepository.Path, submoduleHead.Hash())
+	checkErr(t, "adding submodule commit", err)
 
-	// Adding unrelated commits
-	_, err = testRepository.AddCommitWithSpecificFile("fix", "./unknown/a.txt")
-	checkErr(t, "adding commit", err)
-	_, err = testRepository.AddCommitWithSpecificFile("fix", "./temp/abc/b.txt")
-	checkErr(t, "adding commit", err)
+	commit, err := testRepository.CommitObject(hash)
+	checkErr(t, "getting commit", err)
+
+	contains, err := commitContainsProjectFiles(commit, "lib")
+	checkErr(t, "checking project files", err)
+
+	assert.True(contains, "submodule pointer update should belong to the project owning the submodule path")
+}
+
+func TestParser_ComputeNewSemver_SubmoduleAnalysis(t *testing.T) {
+	assert := assertion.New(t)
+
+	submoduleRepository, err := gittest.NewRepository()
+	checkErr(t, "creating submodule repository", err)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = submoduleRepository.Remove()
+		_ = testRepository.Remove()
+	})
+
+	firstPointer, err := submoduleRepository.AddCommit("fix")
+	checkErr(t, "adding submodule commit", err)
+
+	_, err = submoduleRepository.AddCommit("feat")
+	checkErr(t, "adding submodule commit", err)
+
+	secondPointer, err := submoduleRepository.AddCommit("fix")
+	checkErr(t, "adding submodule commit", err)
+
+	_, err = testRepository.AddSubmoduleCommit("chore", "lib", submoduleRepository.Path, firstPointer)
+	checkErr(t, "adding submodule commit", err)
+
+	_, err = testRepository.AddSubmoduleCommit("chore", "lib", submoduleRepository.Path, secondPointer)
+	checkErr(t, "adding submodule commit", err)
+
+	project := monorepo.Project{Name: "lib", Path: "lib"}
 
 	th := NewTestHelper(t)
-	th.Ctx.Projects = []monorepo.Project{
-		{Name: "foo", Path: "foo"},
-		{Name: "bar", Path: "bar"},
-	}
-	parser := New(th.Ctx)
 
-	clonedTestRepository, err := testRepository.Clone()
-	checkErr(t, "cloning test repository", err)
+	output, err := New(th.Ctx).ComputeNewSemver(testRepository.Repository, project, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
 
-	output, err := parser.Run(context.Background(), clonedTestRepository.Repository)
-	checkErr(t, "computing projects new semver", err)
+	assert.Equal(false, output.NewRelease, "chore commits should not trigger a release without submodule analysis")
 
-	assert.Len(output, 2, "parser run output should contain two elements")
+	th.Ctx.SubmoduleAnalysisFlag = true
 
-	gotSemver := []string{output[0].Semver.String(), output[1].Semver.String()}
+	output, err = New(th.Ctx).ComputeNewSemver(testRepository.Repository, project, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
 
-	assert.Contains(gotSemver, "1.0.1")
-	assert.Contains(gotSemver, "0.1.2")
+	assert.Equal(true, output.NewRelease, "submodule commits should trigger a release")
+	assert.Equal("0.1.1", output.Semver.String(), "version should be bumped by the submodule feat and fix commits")
 }
 
-func TestParser_Run_MonorepoWithPreexistingTags(t *testing.T) {
+func TestParser_ComputeNewSemver_ProjectTagSource(t *testing.T) {
 	assert := assertion.New(t)
 
+	splitRepository, err := gittest.NewRepository()
+	checkErr(t, "creating split repository", err)
+
 	testRepository, err := gittest.NewRepository()
 	checkErr(t, "creating repository", err)
 
 	t.Cleanup(func() {
+		_ = splitRepository.Remove()
 		_ = testRepository.Remove()
 	})
 
-	// Add previous "foo" tags
-	fooCommit, err := testRepository.AddCommitWithSpecificFile("feat!", "./foo/foo.txt") // foo-1.0.0
+	splitHash, err := splitRepository.AddCommit("feat")
+	checkErr(t, "adding split repository commit", err)
+
+	err = splitRepository.AddTag("v1.0.0", splitHash)
+	checkErr(t, "tagging split repository", err)
+
+	_, err = testRepository.AddCommitWithSpecificFile("feat", "./foo/foo.txt")
 	checkErr(t, "adding commit", err)
 
-	err = testRepository.AddTag("foo-1.0.0", fooCommit)
-	checkErr(t, "adding foo tag", err)
+	_, err = testRepository.AddCommitWithSpecificFile("fix", "./foo/foo.txt")
+	checkErr(t, "adding commit", err)
 
-	// Add previous "bar" tags
-	barCommit, err := testRepository.AddCommitWithSpecificFile("chore!", "./bar/foo.txt")
+	project := monorepo.Project{Name: "foo", Path: "foo", TagSource: splitRepository.Path}
+
+	th := NewTestHelper(t)
+
+	output, err := New(th.Ctx).ComputeNewSemver(testRepository.Repository, project, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal(true, output.NewRelease, "boolean should be equal")
+	assert.Equal("1.0.1", output.Semver.String(), "latest version should have been read from the tag source")
+}
+
+func TestParser_ComputeNewSemver_APIDiff(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	hash, err := testRepository.AddCommitWithContent("feat", "foo.go", "package foo\n\nfunc Foo(a string) {}\n")
 	checkErr(t, "adding commit", err)
 
-	err = testRepository.AddTag("bar-1.0.0", barCommit) // bar-1.0.0
-	checkErr(t, "adding bar tag", err)
+	err = testRepository.AddTag("v1.0.0", hash)
+	checkErr(t, "adding tag", err)
 
-	// Adding "foo" project commits
-	_, err = testRepository.AddCommitWithSpecificFile("feat!", "./foo/foo.txt") // foo-2.0.0
+	_, err = testRepository.AddCommitWithContent("fix", "foo.go", "package foo\n\nfunc Foo(a, b string) {}\n")
 	checkErr(t, "adding commit", err)
-	_, err = testRepository.AddCommitWithSpecificFile("fix", "./foo/xyz/foo.txt") // foo-2.0.1
+
+	th := NewTestHelper(t)
+	th.Ctx.APIDiffAnalyzerFlag = "go"
+
+	th.Ctx.APIDiffFlag = apidiff.ModeWarn
+
+	output, err := New(th.Ctx).ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("1.0.1", output.Semver.String(), "warn mode should not change the computed version")
+
+	th.Ctx.APIDiffFlag = apidiff.ModeFail
+
+	_, err = New(th.Ctx).ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	assert.ErrorIs(err, apidiff.ErrIncompatibleAPI, "fail mode should reject the release")
+
+	_, err = testRepository.AddCommit("feat!")
 	checkErr(t, "adding commit", err)
 
-	// Adding "bar" project commits
-	_, err = testRepository.AddCommitWithSpecificFile("feat", "./bar/foo.txt") // bar-1.1.0
+	output, err = New(th.Ctx).ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("2.0.0", output.Semver.String(), "breaking change commit should satisfy the API diff")
+}
+
+func TestParser_ComputeNewSemver_VersionRange(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	hash, err := testRepository.AddCommit("feat")
 	checkErr(t, "adding commit", err)
-	_, err = testRepository.AddCommitWithSpecificFile("fix", "./bar/baz/xyz/foo.txt") // bar-1.1.1
+
+	err = testRepository.AddTag("v1.0.0", hash)
+	checkErr(t, "adding tag", err)
+
+	_, err = testRepository.AddCommit("feat")
 	checkErr(t, "adding commit", err)
-	_, err = testRepository.AddCommitWithSpecificFile("fix", "./bar/baz/xyz/bar.txt") // bar-1.1.2
+
+	versionRange, err := semver.NewConstraint("^1")
+	checkErr(t, "parsing constraint", err)
+
+	th := NewTestHelper(t)
+	b := branch.Branch{Name: "master", VersionRange: versionRange}
+
+	output, err := New(th.Ctx).ComputeNewSemver(testRepository.Repository, monorepo.Project{}, b)
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("1.1.0", output.Semver.String(), "version should be equal")
+
+	_, err = testRepository.AddCommit("feat!")
 	checkErr(t, "adding commit", err)
 
-	// Adding unrelated commits
-	_, err = testRepository.AddCommitWithSpecificFile("fix", "./unknown/a.txt")
+	_, err = New(th.Ctx).ComputeNewSemver(testRepository.Repository, monorepo.Project{}, b)
+	assert.ErrorIs(err, ErrVersionOutOfRange, "major release should be outside of the branch version range")
+}
+
+func TestParser_ComputeNewSemver_BranchTagPrefix(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	hash, err := testRepository.AddCommit("feat")
 	checkErr(t, "adding commit", err)
-	_, err = testRepository.AddCommitWithSpecificFile("fix", "./temp/abc/b.txt")
+
+	err = testRepository.AddTag("v1.0.0", hash)
+	checkErr(t, "adding tag", err)
+
+	err = testRepository.AddTag("nightly-3.0.0", hash)
+	checkErr(t, "adding tag", err)
+
+	_, err = testRepository.AddCommit("fix")
 	checkErr(t, "adding commit", err)
 
+	nightlyPrefix := "nightly-"
+
 	th := NewTestHelper(t)
-	th.Ctx.Projects = []monorepo.Project{
-		{Name: "foo", Path: "foo"},
-		{Name: "bar", Path: "bar"},
-	}
+	th.Ctx.TagPrefixFlag = "v"
+	th.Ctx.Branches = []branch.Branch{{Name: "master"}, {Name: "master", TagPrefix: &nightlyPrefix}}
+
 	parser := New(th.Ctx)
 
-	clonedTestRepository, err := testRepository.Clone()
-	checkErr(t, "cloning test repository", err)
+	output, err := parser.ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
 
-	output, err := parser.Run(context.Background(), clonedTestRepository.Repository)
-	checkErr(t, "computing projects new semver", err)
+	assert.Equal("1.0.1", output.Semver.String(), "tags using another branch prefix should be ignored")
+	assert.Equal("v", output.TagPrefix)
 
-	assert.Len(output, 2, "parser run output should contain two elements")
+	output, err = parser.ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[1])
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("3.0.1", output.Semver.String(), "only tags using the branch prefix should be considered")
+	assert.Equal("nightly-", output.TagPrefix)
+}
+
+func TestParser_ComputeNewSemver_TagNamespace(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	hash, err := testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+
+	err = testRepository.AddTag("releases/v1.0.0", hash)
+	checkErr(t, "adding tag", err)
+
+	// An ad-hoc tag created by a developer outside the release namespace.
+	err = testRepository.AddTag("v5.0.0", hash)
+	checkErr(t, "adding tag", err)
+
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+
+	th := NewTestHelper(t)
+	th.Ctx.TagNamespaceFlag = "releases"
+
+	output, err := New(th.Ctx).ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("1.0.1", output.Semver.String(), "only tags in the namespace should be considered")
+}
+
+func TestParser_ComputeNewSemver_Epoch(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(
+		gittest.Commit("feat"),
+		gittest.Tag("v9.4.0"),
+		gittest.Commit("fix"),
+		gittest.Tag("epoch1/v2020.1.0"),
+		gittest.Commit("fix"),
+		gittest.Tag("epoch2/v10.0.0"),
+		gittest.Commit("feat"),
+	)
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	th := NewTestHelper(t)
+
+	output, err := New(th.Ctx).ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("9.5.0", output.Semver.String(), "tags of other epochs should not be compared with the epoch 0")
+
+	th.Ctx.EpochFlag = 1
+
+	output, err = New(th.Ctx).ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("2020.2.0", output.Semver.String())
+
+	th.Ctx.EpochFlag = 2
+
+	output, err = New(th.Ctx).ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("10.1.0", output.Semver.String(), "the latest tag of the active epoch should be selected")
+
+	th.Ctx.EpochFlag = 3
+
+	output, err = New(th.Ctx).ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("0.2.0", output.Semver.String(), "an epoch without tags should be computed from the whole history")
+}
+
+func TestParser_ComputeNewSemver_TagAliases(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	hash, err := testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+
+	err = testRepository.AddTag("v1.0.0", hash)
+	checkErr(t, "adding tag", err)
+
+	hash, err = testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+
+	err = testRepository.AddTag("RELEASE_2020_07", hash)
+	checkErr(t, "adding tag", err)
+
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+
+	th := NewTestHelper(t)
+	th.Ctx.TagAliases = map[string]*semver.Version{"release_2020_07": {Major: 3, Minor: 5}}
+
+	parser := New(th.Ctx)
+
+	latest, err := parser.FetchLatestSemverTag(testRepository.Repository, monorepo.Project{})
+	checkErr(t, "fetching latest tag", err)
+
+	assert.Equal("RELEASE_2020_07", latest.Name, "aliased tag should be the latest one")
+
+	output, err := parser.ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("3.5.1", output.Semver.String(), "only commits after the aliased tag should be analyzed")
+
+	output, err = parser.ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver again", err)
+
+	assert.Equal("3.5.1", output.Semver.String(), "bumping a version should not alter its alias")
+	assert.Equal("3.5.0", th.Ctx.TagAliases["release_2020_07"].String())
+}
+
+func TestParser_ComputeNewSemver_SkipMarkers(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(
+		gittest.Commit("fix"),
+		gittest.CommitMessage("feat: add foo [skip release]"),
+		gittest.CommitMessage("fix: fix bar\n\n[Release Skip]"),
+		gittest.Commit("chore"),
+	)
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	th := NewTestHelper(t)
+	th.Ctx.SkipMarkersFlag = []string{"[skip release]", "[release skip]"}
+
+	parser := New(th.Ctx)
+
+	output, err := parser.ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("0.0.1", output.Semver.String(), "commits with a skip marker should not bump the version")
+	assert.True(output.NewRelease)
+	assert.False(output.Skipped)
+
+	err = testRepository.Apply(gittest.CommitMessage("feat: add baz [skip release]"))
+	checkErr(t, "adding commit", err)
+
+	output, err = parser.ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.True(output.Skipped, "a skip marker on the head commit should skip the branch")
+	assert.False(output.NewRelease)
+	assert.Equal("0.0.0", output.Semver.String())
+}
+
+func TestParser_ComputeNewSemver_ForceBump(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(gittest.Commit("feat"), gittest.Tag("v0.1.0"), gittest.Commit("chore"))
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	head, err := testRepository.Head()
+	checkErr(t, "fetching head", err)
+
+	th := NewTestHelper(t)
+	th.Ctx.ForceBumpFlag = "minor"
+
+	parser := New(th.Ctx)
+
+	output, err := parser.ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("0.2.0", output.Semver.String())
+	assert.True(output.NewRelease)
+	assert.True(output.Forced)
+	assert.Equal(head.Hash(), output.CommitHash, "the head commit should be released")
+
+	err = testRepository.Apply(gittest.Commit("fix"))
+	checkErr(t, "adding commit", err)
+
+	output, err = parser.ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("0.1.1", output.Semver.String(), "a forced bump should not apply when a commit triggers a release")
+	assert.False(output.Forced)
+}
+
+func TestParser_ComputeNewSemver_ForceBumpMarker(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(
+		gittest.Commit("feat"),
+		gittest.Tag("v0.1.0"),
+		gittest.CommitMessage("ci: rebuild with new toolchain [release patch]"),
+		gittest.CommitMessage("chore: bump base image [Release Minor]"),
+		gittest.Commit("docs"),
+	)
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	th := NewTestHelper(t)
+
+	parser := New(th.Ctx)
+
+	output, err := parser.ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("0.2.0", output.Semver.String(), "the highest forced release type should win")
+	assert.True(output.Forced)
+
+	commit, err := testRepository.CommitObject(output.CommitHash)
+	checkErr(t, "fetching released commit", err)
+
+	assert.Equal("chore: bump base image [Release Minor]", commit.Message, "the most recent marked commit should be released")
+}
+
+func TestParser_ValidateReleaseType(t *testing.T) {
+	assert := assertion.New(t)
+
+	for _, releaseType := range []string{"", "patch", "minor", "major"} {
+		assert.NoError(ValidateReleaseType(releaseType), releaseType)
+	}
+
+	assert.ErrorIs(ValidateReleaseType("huge"), ErrInvalidReleaseType)
+}
+
+func TestParser_ComputeNewSemver_MaxBumpPerRun(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(gittest.Commit("feat"), gittest.Tag("v1.0.0"), gittest.Commit("feat!"), gittest.Commit("feat"), gittest.Commit("fix"))
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	buf := new(strings.Builder)
+
+	th := NewTestHelper(t)
+	th.Ctx.Logger = zerolog.New(buf)
+	th.Ctx.MaxBumpPerRunFlag = "minor"
+
+	output, err := New(th.Ctx).ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("1.1.0", output.Semver.String(), "the run should release a single minor bump")
+	assert.Contains(buf.String(), "release type capped")
+
+	th.Ctx.ConfirmMajorFlag = true
+
+	output, err = New(th.Ctx).ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("2.1.1", output.Semver.String(), "a confirmed major release should not be capped")
+
+	th.Ctx.MaxBumpPerRunFlag = "major"
+	th.Ctx.ConfirmMajorFlag = false
+
+	_, err = New(th.Ctx).ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	assert.ErrorIs(err, ErrUnconfirmedMajor, "crossing a major boundary should be confirmed without anomaly detection")
+}
+
+func TestParser_ComputeNewSemver_Anomalies(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(
+		gittest.Commit("feat"),
+		gittest.Tag("v1.0.0"),
+		gittest.Commit("fix"),
+		gittest.Commit("fix"),
+		gittest.Commit("fix"),
+		gittest.Commit("fix"),
+	)
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	buf := new(strings.Builder)
+
+	th := NewTestHelper(t)
+	th.Ctx.Logger = zerolog.New(buf)
+	th.Ctx.MaxVersionSkipFlag = 2
+
+	output, err := New(th.Ctx).ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("1.0.4", output.Semver.String())
+	assert.Contains(buf.String(), "anomaly: release skips more versions than expected")
+
+	err = testRepository.Apply(gittest.Commit("feat!"))
+	checkErr(t, "adding commit", err)
+
+	_, err = New(th.Ctx).ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	assert.ErrorIs(err, ErrUnconfirmedMajor)
+
+	th.Ctx.ConfirmMajorFlag = true
+
+	output, err = New(th.Ctx).ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("2.0.0", output.Semver.String())
+	assert.Contains(buf.String(), "anomaly: release crosses a major boundary")
+}
+
+func TestParser_ComputeNewSemver_TrustedKeys(t *testing.T) {
+	assert := assertion.New(t)
+
+	trusted, err := openpgp.NewEntity("Release Bot", "", "release@example.com", nil)
+	checkErr(t, "creating trusted key", err)
+
+	testRepository, err := gittest.New(gittest.Commit("feat"))
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	tagger := tag.NewTagger("Release Bot", "release@example.com", tag.WithSignKey(trusted))
+
+	head, err := testRepository.Head()
+	checkErr(t, "fetching head", err)
+
+	err = tagger.CreateTag(testRepository.Repository, "v0.1.0", head.Hash())
+	checkErr(t, "creating signed tag", err)
+
+	// A tag injected on a compromised remote, without a trusted signature.
+	err = testRepository.Apply(gittest.Commit("feat!"), gittest.Tag("v9.0.0"), gittest.Commit("fix"))
+	checkErr(t, "adding commits", err)
+
+	th := NewTestHelper(t)
+	th.Ctx.TrustedKeys = openpgp.EntityList{trusted}
+	th.Ctx.UntrustedTagsFlag = UntrustedTagsFail
+
+	_, err = New(th.Ctx).ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	assert.ErrorIs(err, ErrUntrustedTag)
+	assert.ErrorIs(err, tag.ErrUnsignedTag)
+
+	th.Ctx.UntrustedTagsFlag = UntrustedTagsIgnore
+
+	output, err := New(th.Ctx).ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("1.0.1", output.Semver.String(), "the untrusted tag should not be used as the base version")
+
+	th.Ctx.TrustedKeys = nil
+
+	output, err = New(th.Ctx).ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("9.0.1", output.Semver.String(), "tags should not be verified without trusted keys")
+}
+
+func TestParser_ValidateUntrustedTagsPolicy(t *testing.T) {
+	assert := assertion.New(t)
+
+	assert.NoError(ValidateUntrustedTagsPolicy(UntrustedTagsFail))
+	assert.NoError(ValidateUntrustedTagsPolicy(UntrustedTagsIgnore))
+	assert.ErrorIs(ValidateUntrustedTagsPolicy("warn"), ErrInvalidUntrustedTagsPolicy)
+}
+
+func TestParser_ComputeNewSemver_At(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	hash, err := testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+
+	_, err = testRepository.AddCommit("feat!")
+	checkErr(t, "adding commit", err)
+
+	th := NewTestHelper(t)
+	th.Ctx.AtFlag = hash.String()[:10]
+
+	output, err := New(th.Ctx).ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("0.1.0", output.Semver.String(), "commits after the analyzed one should be ignored")
+	assert.Equal(hash, output.CommitHash, "commit hash should be equal")
+
+	th.Ctx.AtFlag = "unknown"
+
+	_, err = New(th.Ctx).ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	assert.ErrorIs(err, plumbing.ErrReferenceNotFound, "unknown revision should not be analyzed")
+}
+
+func TestParser_Run_Monorepo(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	// Adding "foo" project commits
+	_, err = testRepository.AddCommitWithSpecificFile("feat!", "./foo/foo.txt")
+	checkErr(t, "adding commit", err)
+	_, err = testRepository.AddCommitWithSpecificFile("fix", "./foo/xyz/foo.txt")
+	checkErr(t, "adding commit", err)
+
+	// Adding "bar" project commits
+	_, err = testRepository.AddCommitWithSpecificFile("feat", "./bar/foo.txt")
+	checkErr(t, "adding commit", err)
+	_, err = testRepository.AddCommitWithSpecificFile("fix", "./bar/baz/xyz/foo.txt")
+	checkErr(t, "adding commit", err)
+	_, err = testRepository.AddCommitWithSpecificFile("fix", "./bar/baz/xyz/bar.txt")
+	checkErr(t, "adding commit", err)
+
+	// Adding unrelated commits
+	_, err = testRepository.AddCommitWithSpecificFile("fix", "./unknown/a.txt")
+	checkErr(t, "adding commit", err)
+	_, err = testRepository.AddCommitWithSpecificFile("fix", "./temp/abc/b.txt")
+	checkErr(t, "adding commit", err)
+
+	th := NewTestHelper(t)
+	th.Ctx.Projects = []monorepo.Project{
+		{Name: "foo", Path: "foo"},
+		{Name: "bar", Path: "bar"},
+	}
+	parser := New(th.Ctx)
+
+	clonedTestRepository, err := testRepository.Clone()
+	checkErr(t, "cloning test repository", err)
+
+	output, err := parser.Run(context.Background(), clonedTestRepository.Repository)
+	checkErr(t, "computing projects new semver", err)
+
+	assert.Len(output, 2, "parser run output should contain two elements")
+
+	gotSemver := []string{output[0].Semver.String(), output[1].Semver.String()}
+
+	assert.Contains(gotSemver, "1.0.1")
+	assert.Contains(gotSemver, "0.1.2")
+}
+
+func TestParser_Run_MonorepoWithPreexistingTags(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	// Add previous "foo" tags
+	fooCommit, err := testRepository.AddCommitWithSpecificFile("feat!", "./foo/foo.txt") // foo-1.0.0
+	checkErr(t, "adding commit", err)
+
+	err = testRepository.AddTag("foo-1.0.0", fooCommit)
+	checkErr(t, "adding foo tag", err)
+
+	// Add previous "bar" tags
+	barCommit, err := testRepository.AddCommitWithSpecificFile("chore!", "./bar/foo.txt")
+	checkErr(t, "adding commit", err)
+
+	err = testRepository.AddTag("bar-1.0.0", barCommit) // bar-1.0.0
+	checkErr(t, "adding bar tag", err)
+
+	// Adding "foo" project commits
+	_, err = testRepository.AddCommitWithSpecificFile("feat!", "./foo/foo.txt") // foo-2.0.0
+	checkErr(t, "adding commit", err)
+	_, err = testRepository.AddCommitWithSpecificFile("fix", "./foo/xyz/foo.txt") // foo-2.0.1
+	checkErr(t, "adding commit", err)
+
+	// Adding "bar" project commits
+	_, err = testRepository.AddCommitWithSpecificFile("feat", "./bar/foo.txt") // bar-1.1.0
+	checkErr(t, "adding commit", err)
+	_, err = testRepository.AddCommitWithSpecificFile("fix", "./bar/baz/xyz/foo.txt") // bar-1.1.1
+	checkErr(t, "adding commit", err)
+	_, err = testRepository.AddCommitWithSpecificFile("fix", "./bar/baz/xyz/bar.txt") // bar-1.1.2
+	checkErr(t, "adding commit", err)
+
+	// Adding unrelated commits
+	_, err = testRepository.AddCommitWithSpecificFile("fix", "./unknown/a.txt")
+	checkErr(t, "adding commit", err)
+	_, err = testRepository.AddCommitWithSpecificFile("fix", "./temp/abc/b.txt")
+	checkErr(t, "adding commit", err)
+
+	th := NewTestHelper(t)
+	th.Ctx.Projects = []monorepo.Project{
+		{Name: "foo", Path: "foo"},
+		{Name: "bar", Path: "bar"},
+	}
+	parser := New(th.Ctx)
+
+	clonedTestRepository, err := testRepository.Clone()
+	checkErr(t, "cloning test repository", err)
+
+	output, err := parser.Run(context.Background(), clonedTestRepository.Repository)
+	checkErr(t, "computing projects new semver", err)
+
+	assert.Len(output, 2, "parser run output should contain two elements")
 
 	gotSemver := []string{output[0].Semver.String(), output[1].Semver.String()}
 
@@ -617,6 +1445,211 @@ func TestParser_Run_MonorepoWithPreexistingTags(t *testing.T) {
 	assert.Contains(gotSemver, "1.1.2")
 }
 
+func TestParser_Run_Progress(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(
+		gittest.CommitFile("feat", "api/main.go", "api"),
+		gittest.CommitFile("fix", "web/index.html", "web"),
+	)
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	out := new(bytes.Buffer)
+
+	th := NewTestHelper(t)
+	th.Ctx.Logger = zerolog.New(out)
+	th.Ctx.ProgressFlag = true
+	th.Ctx.Projects = []monorepo.Project{{Name: "api", Path: "api"}, {Name: "web", Path: "web"}}
+
+	_, err = New(th.Ctx).Run(context.Background(), testRepository.Repository)
+	checkErr(t, "computing projects new semver", err)
+
+	assert.Contains(out.String(), `"message":"scanning commits"`)
+	assert.Contains(out.String(), `"count":2,"total":2,"message":"analyzing branches and projects"`)
+}
+
+func TestParser_Run_CascadeBumps(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(
+		gittest.CommitFile("feat", "lib/lib.go", "lib"),
+		gittest.Tag("lib-v0.1.0"),
+		gittest.Tag("api-v1.0.0"),
+		gittest.Tag("web-v2.0.0"),
+		gittest.Tag("cli-v3.0.0"),
+		gittest.CommitFile("fix", "lib/lib.go", "lib fix"),
+		gittest.CommitFile("docs", "api/README.md", "api"),
+	)
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	th := NewTestHelper(t)
+	th.Ctx.CascadeBumpsFlag = true
+	th.Ctx.Projects = []monorepo.Project{
+		{Name: "web", Path: "web", DependsOn: []string{"api"}},
+		{Name: "api", Path: "api", DependsOn: []string{"lib"}},
+		{Name: "lib", Path: "lib"},
+		{Name: "cli", Path: "cli"},
+	}
+
+	output, err := New(th.Ctx).Run(context.Background(), testRepository.Repository)
+	checkErr(t, "computing projects new semver", err)
+
+	head, err := testRepository.Head()
+	checkErr(t, "fetching head", err)
+
+	lib, api, web, cli := output[0], output[1], output[2], output[3]
+
+	assert.Equal("lib", lib.Project.Name)
+	assert.Equal("0.1.1", lib.Semver.String())
+	assert.Equal(BumpReasonCommits, lib.BumpReason)
+	assert.Equal(1, lib.ReleaseOrder)
+
+	assert.Equal("api", api.Project.Name)
+	assert.True(api.NewRelease, "project should be bumped along its dependency")
+	assert.Equal("1.0.1", api.Semver.String())
+	assert.Equal(BumpReasonDependency, api.BumpReason)
+	assert.Equal([]string{"lib"}, api.BumpedBy)
+	assert.Equal(head.Hash(), api.CommitHash, "cascaded release should be tagged on the head commit")
+	assert.Equal(2, api.ReleaseOrder)
+
+	assert.Equal("web", web.Project.Name)
+	assert.True(web.NewRelease, "bumps should cascade transitively")
+	assert.Equal("2.0.1", web.Semver.String())
+	assert.Equal([]string{"api"}, web.BumpedBy)
+	assert.Equal(3, web.ReleaseOrder)
+
+	assert.Equal("cli", cli.Project.Name)
+	assert.False(cli.NewRelease, "project without released dependency should not be bumped")
+	assert.Empty(cli.BumpReason)
+	assert.Equal(4, cli.ReleaseOrder)
+
+	th.Ctx.CascadeBumpsFlag = false
+
+	output, err = New(th.Ctx).Run(context.Background(), testRepository.Repository)
+	checkErr(t, "computing projects new semver", err)
+
+	assert.False(output[1].NewRelease, "bumps should not cascade unless enabled")
+}
+
+func TestParser_Run_ProjectBranches(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(
+		gittest.CommitFile("feat", "api/main.go", "api"),
+		gittest.CommitFile("feat", "web/index.html", "web"),
+		gittest.Branch("rc"),
+	)
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	out := new(bytes.Buffer)
+
+	th := NewTestHelper(t)
+	th.Ctx.Logger = zerolog.New(out)
+	th.Ctx.ProgressFlag = true
+	th.Ctx.Branches = []branch.Branch{{Name: "master"}, {Name: "rc", Prerelease: true}}
+	th.Ctx.Projects = []monorepo.Project{
+		{Name: "api", Path: "api", Branches: []string{"master", "rc"}},
+		{Name: "web", Path: "web", Branches: []string{"master", "beta"}},
+	}
+
+	output, err := New(th.Ctx).Run(context.Background(), testRepository.Repository)
+	checkErr(t, "computing projects new semver", err)
+
+	assert.Len(output, 3, "web should not be analyzed on rc")
+	assert.Equal("master", output[0].Branch)
+	assert.Equal("master", output[1].Branch)
+	assert.Equal("rc", output[2].Branch)
+	assert.Equal("api", output[2].Project.Name)
+	assert.Equal(1, output[2].ReleaseOrder)
+
+	assert.Contains(out.String(), `"count":3,"total":3,"message":"analyzing branches and projects"`)
+	assert.Contains(out.String(), `"project":"web","branch":"beta","message":"project enabled on a branch that is not configured"`)
+}
+
+func TestParser_Run_MonorepoDependencyOrder(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(
+		gittest.CommitFile("feat", "web/index.html", "web"),
+		gittest.CommitFile("feat", "api/main.go", "api"),
+		gittest.CommitFile("feat", "lib/lib.go", "lib"),
+	)
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	th := NewTestHelper(t)
+	th.Ctx.Projects = []monorepo.Project{
+		{Name: "web", Path: "web", DependsOn: []string{"api"}},
+		{Name: "api", Path: "api", DependsOn: []string{"lib"}},
+		{Name: "lib", Path: "lib"},
+	}
+
+	output, err := New(th.Ctx).Run(context.Background(), testRepository.Repository)
+	checkErr(t, "computing projects new semver", err)
+
+	var got []string
+	for _, o := range output {
+		got = append(got, o.Project.Name)
+	}
+
+	assert.Equal([]string{"lib", "api", "web"}, got, "projects should be returned after their dependencies")
+
+	th.Ctx.Projects[2].DependsOn = []string{"web"}
+
+	_, err = New(th.Ctx).Run(context.Background(), testRepository.Repository)
+	assert.ErrorIs(err, monorepo.ErrDependencyCycle)
+}
+
+func TestParser_Run_ContinueOnError(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(
+		gittest.CommitFile("feat", "foo/foo.txt", "foo"),
+		gittest.CommitFile("feat", "bar/bar.txt", "bar"),
+	)
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	th := NewTestHelper(t)
+	th.Ctx.Projects = []monorepo.Project{
+		{Name: "foo", Path: "foo", TagSource: filepath.Join(t.TempDir(), "missing")},
+		{Name: "bar", Path: "bar"},
+	}
+
+	_, err = New(th.Ctx).Run(context.Background(), testRepository.Repository)
+	assert.Error(err, "an error should abort the run by default")
+
+	th.Ctx.ContinueOnErrorFlag = true
+
+	output, err := New(th.Ctx).Run(context.Background(), testRepository.Repository)
+	checkErr(t, "computing projects new semver", err)
+
+	assert.Len(output, 2)
+	assert.Error(output[0].Error)
+	assert.Equal("foo", output[0].Project.Name)
+	assert.Equal("master", output[0].Branch)
+	assert.NoError(output[1].Error)
+	assert.Equal("0.1.0", output[1].Semver.String())
+}
+
 func TestParser_Run_InvalidBranch(t *testing.T) {
 	assert := assertion.New(t)
 
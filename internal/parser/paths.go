@@ -0,0 +1,51 @@
+package parser
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// changedPaths returns the sorted paths, relative to the root of the repository, of the files added, modified or
+// deleted by the commits of a history compared to their first parent. Only the files located inside the given project
+// path are returned, if any.
+func changedPaths(history []*object.Commit, projectPath string) ([]string, error) {
+	prefix := filepath.ToSlash(projectPath)
+
+	seen := make(map[string]bool)
+	paths := make([]string, 0)
+
+	for _, commit := range history {
+		changes, err := commitChanges(commit)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, change := range changes {
+			for _, name := range []string{change.From.Name, change.To.Name} {
+				if name == "" || seen[name] || !insidePath(name, prefix) {
+					continue
+				}
+
+				seen[name] = true
+				paths = append(paths, name)
+			}
+		}
+	}
+
+	slices.Sort(paths)
+
+	return paths, nil
+}
+
+// insidePath reports whether a slash-separated path is located inside the given directory, an empty directory or "."
+// being the root of the repository.
+func insidePath(name, dir string) bool {
+	if dir == "" || dir == "." {
+		return true
+	}
+
+	return name == dir || strings.HasPrefix(name, strings.TrimSuffix(dir, "/")+"/")
+}
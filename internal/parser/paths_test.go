@@ -0,0 +1,64 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
+	"github.com/s0ders/go-semver-release/v6/pkg/gittest"
+)
+
+func TestChangedPaths_Run(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(
+		gittest.CommitFile("feat", "api/main.go", "api"),
+		gittest.CommitFile("fix", "web/index.html", "web"),
+		gittest.Tag("v0.1.0"),
+		gittest.Tag("api-v0.1.0"),
+		gittest.Tag("web-v0.0.1"),
+		gittest.CommitFile("fix", "api/handler.go", "api"),
+		gittest.CommitFile("docs", "api/docs/README.md", "api"),
+		gittest.CommitFile("chore", "apis/main.go", "apis"),
+		gittest.CommitFile("fix", "api/handler.go", "api v2"),
+	)
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	th := NewTestHelper(t)
+	th.Ctx.ChangedPathsFlag = true
+
+	output, err := New(th.Ctx).Run(context.Background(), testRepository.Repository)
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal([]string{"api/docs/README.md", "api/handler.go", "apis/main.go"}, output[0].ChangedPaths, "only the paths changed since the previous release should be listed, once")
+
+	th.Ctx.Projects = []monorepo.Project{{Name: "api", Path: "api"}, {Name: "web", Path: "web"}}
+
+	output, err = New(th.Ctx).Run(context.Background(), testRepository.Repository)
+	checkErr(t, "computing projects new semver", err)
+
+	assert.Equal([]string{"api/docs/README.md", "api/handler.go"}, output[0].ChangedPaths, "only the paths of the project should be listed")
+	assert.Equal([]string{}, output[1].ChangedPaths, "a project without changes should list no paths")
+
+	th.Ctx.ChangedPathsFlag = false
+
+	output, err = New(th.Ctx).Run(context.Background(), testRepository.Repository)
+	checkErr(t, "computing projects new semver", err)
+
+	assert.Nil(output[0].ChangedPaths, "changed paths should be disabled by default")
+}
+
+func TestChangedPaths_InsidePath(t *testing.T) {
+	assert := assertion.New(t)
+
+	assert.True(insidePath("api/main.go", "api"))
+	assert.True(insidePath("api/main.go", "."))
+	assert.True(insidePath("api", "api"))
+	assert.False(insidePath("apis/main.go", "api"))
+}
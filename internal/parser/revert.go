@@ -0,0 +1,86 @@
+package parser
+
+import (
+	"regexp"
+	"slices"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// revertMergeRegex matches the message written by "git revert -m <parent>" when reverting a merge commit, e.g.
+// "This reverts commit <merge>, reversing\nchanges made to <parent>.".
+var revertMergeRegex = regexp.MustCompile(`This reverts commit ([0-9a-f]{40}),\s+reversing\s+changes\s+made\s+to\s+([0-9a-f]{40})`)
+
+// revertedMerges returns the commits of the history neutralized by the revert of a merge made in the same history,
+// i.e. before any release: the merge commit, the commits it brought in and the revert commit itself. Since the revert
+// cancels the merge, none of them should bump the version or appear in the changelog. Merges reverted after being
+// released are not part of the history, so their revert is analyzed as any other commit.
+func revertedMerges(history []*object.Commit) map[plumbing.Hash]bool {
+	commits := make(map[plumbing.Hash]*object.Commit, len(history))
+	for _, commit := range history {
+		commits[commit.Hash] = commit
+	}
+
+	reverted := make(map[plumbing.Hash]bool)
+
+	for _, commit := range history {
+		match := revertMergeRegex.FindStringSubmatch(commit.Message)
+		if match == nil {
+			continue
+		}
+
+		merge, ok := commits[plumbing.NewHash(match[1])]
+		if !ok || len(merge.ParentHashes) < 2 {
+			continue
+		}
+
+		mainline := plumbing.NewHash(match[2])
+		if !slices.Contains(merge.ParentHashes, mainline) {
+			mainline = merge.ParentHashes[0]
+		}
+
+		var merged []plumbing.Hash
+
+		for _, parent := range merge.ParentHashes {
+			if parent != mainline {
+				merged = append(merged, parent)
+			}
+		}
+
+		// Commits reachable from the mainline were already there before the merge.
+		before := ancestors(commits, mainline)
+
+		for hash := range ancestors(commits, merged...) {
+			if !before[hash] {
+				reverted[hash] = true
+			}
+		}
+
+		reverted[merge.Hash] = true
+		reverted[commit.Hash] = true
+	}
+
+	return reverted
+}
+
+// ancestors returns the given commits and their ancestors, restricted to the given commits of the history.
+func ancestors(commits map[plumbing.Hash]*object.Commit, from ...plumbing.Hash) map[plumbing.Hash]bool {
+	visited := make(map[plumbing.Hash]bool)
+	queue := append([]plumbing.Hash(nil), from...)
+
+	for len(queue) > 0 {
+		hash := queue[0]
+		queue = queue[1:]
+
+		commit, ok := commits[hash]
+		if !ok || visited[hash] {
+			continue
+		}
+
+		visited[hash] = true
+		queue = append(queue, commit.ParentHashes...)
+	}
+
+	return visited
+}
@@ -0,0 +1,98 @@
+package parser
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
+	"github.com/s0ders/go-semver-release/v6/pkg/gittest"
+)
+
+func TestParser_ComputeNewSemver_RevertedMerge(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(
+		gittest.Commit("fix"),
+		gittest.Tag("v1.0.0"),
+		gittest.Branch("feature"),
+		gittest.Commit("feat!"),
+		gittest.Commit("feat"),
+		gittest.Checkout("master"),
+		gittest.Merge("feature", "Merge branch 'feature'"),
+		gittest.Commit("fix"),
+	)
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	tag, err := testRepository.Reference("refs/tags/v1.0.0", true)
+	checkErr(t, "fetching tag", err)
+
+	tagObject, err := testRepository.TagObject(tag.Hash())
+	checkErr(t, "fetching tag object", err)
+
+	head, err := testRepository.Head()
+	checkErr(t, "fetching head", err)
+
+	headCommit, err := testRepository.CommitObject(head.Hash())
+	checkErr(t, "fetching head commit", err)
+
+	merge := headCommit.ParentHashes[0]
+
+	_, err = testRepository.AddCommitWithMessage(fmt.Sprintf("Revert \"Merge branch 'feature'\"\n\nThis reverts commit %s, reversing\nchanges made to %s.", merge, tagObject.Target))
+	checkErr(t, "reverting merge", err)
+
+	th := NewTestHelper(t)
+
+	output, err := New(th.Ctx).ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("1.0.1", output.Semver.String(), "the commits of the reverted merge should not bump the version")
+	assert.Len(output.Changes, 1, "the commits of the reverted merge should not be changes")
+}
+
+func TestParser_RevertedMerges(t *testing.T) {
+	assert := assertion.New(t)
+
+	hash := func(i int) plumbing.Hash {
+		return plumbing.NewHash(fmt.Sprintf("%040d", i))
+	}
+
+	revert := func(merge, mainline plumbing.Hash) string {
+		return fmt.Sprintf("Revert \"Merge\"\n\nThis reverts commit %s, reversing\nchanges made to %s.", merge, mainline)
+	}
+
+	base := &object.Commit{Hash: hash(1)}
+	feature := &object.Commit{Hash: hash(2), ParentHashes: []plumbing.Hash{hash(1)}}
+	merge := &object.Commit{Hash: hash(3), ParentHashes: []plumbing.Hash{hash(1), hash(2)}}
+	fix := &object.Commit{Hash: hash(4), ParentHashes: []plumbing.Hash{hash(3)}}
+
+	reverted := revertedMerges([]*object.Commit{
+		base, feature, merge, fix,
+		{Hash: hash(5), ParentHashes: []plumbing.Hash{hash(4)}, Message: revert(hash(3), hash(1))},
+	})
+
+	assert.Equal(map[plumbing.Hash]bool{hash(2): true, hash(3): true, hash(5): true}, reverted)
+
+	// The merge was released before being reverted, so the revert is analyzed as any other commit.
+	reverted = revertedMerges([]*object.Commit{
+		fix,
+		{Hash: hash(5), ParentHashes: []plumbing.Hash{hash(4)}, Message: revert(hash(3), hash(1))},
+	})
+
+	assert.Empty(reverted)
+
+	// Reverting a commit that is not a merge is not handled.
+	reverted = revertedMerges([]*object.Commit{
+		base, feature,
+		{Hash: hash(5), ParentHashes: []plumbing.Hash{hash(2)}, Message: revert(hash(2), hash(1))},
+	})
+
+	assert.Empty(reverted)
+}
@@ -0,0 +1,31 @@
+package parser
+
+const (
+	// RuleBreaking counts the breaking changes, which trigger a major release unless a rule evaluated before breaking
+	// changes matches them.
+	RuleBreaking = "breaking"
+	// RuleIgnored counts the commits that do not trigger a release: commits that are not conventional, whose type
+	// matches no rule or holding a skip marker.
+	RuleIgnored = "ignored"
+)
+
+// RuleStats counts the commits of a branch or project by the release rule they matched: their commit type (e.g.
+// "feat"), their commit type and scope (e.g. "fix(deps)"), RuleBreaking or RuleIgnored. A commit bundling several pull
+// requests, such as a merge queue commit, counts once per pull request. A nil RuleStats counts nothing.
+type RuleStats map[string]int
+
+func (s RuleStats) add(rule string) {
+	if s != nil {
+		s[rule]++
+	}
+}
+
+// rule returns the name of the release rule matched by a commit message, as counted by RuleStats.
+func (p *Parser) rule(message string) string {
+	match, ok := p.matchRule(message)
+	if !ok {
+		return RuleIgnored
+	}
+
+	return match.Rule
+}
@@ -0,0 +1,107 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
+	"github.com/s0ders/go-semver-release/v6/internal/rule"
+	"github.com/s0ders/go-semver-release/v6/pkg/gittest"
+)
+
+func TestRuleStats_Run(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(
+		gittest.Commit("feat"),
+		gittest.Commit("fix"),
+		gittest.Commit("fix"),
+		gittest.Commit("feat!"),
+		gittest.CommitMessage("refactor: drop v1 endpoints\n\nBREAKING CHANGE: v1 is removed"),
+		gittest.Commit("chore"),
+		gittest.CommitMessage("fix: skipped fix [skip release]"),
+		gittest.CommitMessage("Update README"),
+	)
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	th := NewTestHelper(t)
+	th.Ctx.RuleStatsFlag = true
+	th.Ctx.SkipMarkersFlag = []string{"[skip release]"}
+
+	output, err := New(th.Ctx).Run(context.Background(), testRepository.Repository)
+	checkErr(t, "computing new semver", err)
+
+	// The first commit of the test repository, which is not conventional, is ignored as well.
+	assert.Equal(RuleStats{"feat": 1, "fix": 2, RuleBreaking: 2, RuleIgnored: 4}, output[0].RuleStats)
+
+	th.Ctx.RuleStatsFlag = false
+
+	output, err = New(th.Ctx).Run(context.Background(), testRepository.Repository)
+	checkErr(t, "computing new semver", err)
+
+	assert.Nil(output[0].RuleStats, "rule statistics should be disabled by default")
+}
+
+func TestRuleStats_Run_RuleOrder(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(
+		gittest.Tag("v1.0.0"),
+		gittest.CommitMessage("feat(experimental)!: drop the preview endpoint"),
+		gittest.CommitMessage("chore(deps): bump dependencies"),
+	)
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	th := NewTestHelper(t)
+	th.Ctx.RuleStatsFlag = true
+	th.Ctx.Rules = rule.Rules{Map: map[string]string{"feat": "minor", "fix": "patch", "feat(experimental)": "patch", "chore(deps)": "patch"}}
+
+	output, err := New(th.Ctx).Run(context.Background(), testRepository.Repository)
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("2.0.1", output[0].Semver.String(), "breaking changes should be evaluated first by default")
+	assert.Equal(RuleStats{RuleBreaking: 1, "chore(deps)": 1}, output[0].RuleStats)
+
+	th.Ctx.Rules.Order = []rule.Kind{rule.KindScope, rule.KindBreaking, rule.KindType}
+
+	output, err = New(th.Ctx).Run(context.Background(), testRepository.Repository)
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("1.0.2", output[0].Semver.String(), "the scoped rule should take precedence over the breaking change")
+	assert.Equal(RuleStats{"feat(experimental)": 1, "chore(deps)": 1}, output[0].RuleStats)
+}
+
+func TestRuleStats_Run_Monorepo(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(
+		gittest.CommitFile("feat", "api/main.go", "api"),
+		gittest.CommitFile("fix", "web/index.html", "web"),
+		gittest.CommitFile("docs", "api/README.md", "api"),
+	)
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	th := NewTestHelper(t)
+	th.Ctx.RuleStatsFlag = true
+	th.Ctx.Projects = []monorepo.Project{{Name: "api", Path: "api"}, {Name: "web", Path: "web"}}
+
+	output, err := New(th.Ctx).Run(context.Background(), testRepository.Repository)
+	checkErr(t, "computing projects new semver", err)
+
+	assert.Equal(RuleStats{"feat": 1, RuleIgnored: 1}, output[0].RuleStats, "only the commits of the project should be counted")
+	assert.Equal(RuleStats{"fix": 1}, output[1].RuleStats, "only the commits of the project should be counted")
+}
@@ -0,0 +1,52 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+)
+
+var ErrSplitCommitNotFound = errors.New("release commit not found in the tag source")
+
+// SplitCommit returns the commit of a project's tag source split from the given monorepo commit, i.e. the most recent
+// commit of the tag source whose tree is the tree of the project path in the monorepo commit, as "git subtree split"
+// produces. ErrSplitCommitNotFound is returned if the release commit was not split to the tag source yet.
+func SplitCommit(source *git.Repository, commit *object.Commit, path string) (plumbing.Hash, error) {
+	projectTree, err := pathHash(commit, path)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	if projectTree.IsZero() {
+		return plumbing.ZeroHash, fmt.Errorf("%w: %q does not exist in commit %s", ErrSplitCommitNotFound, path, commit.Hash)
+	}
+
+	logs, err := source.Log(&git.LogOptions{All: true, Order: git.LogOrderCommitterTime})
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("fetching tag source history: %w", err)
+	}
+
+	split := plumbing.ZeroHash
+
+	err = logs.ForEach(func(c *object.Commit) error {
+		if c.TreeHash == projectTree {
+			split = c.Hash
+			return storer.ErrStop
+		}
+
+		return nil
+	})
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("iterating tag source history: %w", err)
+	}
+
+	if split.IsZero() {
+		return plumbing.ZeroHash, fmt.Errorf("%w: no commit has the tree of %q in commit %s", ErrSplitCommitNotFound, path, commit.Hash)
+	}
+
+	return split, nil
+}
@@ -0,0 +1,32 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicyFlag_String(t *testing.T) {
+	flag := Flag{{"name": "no-friday-major", "expression": "release.type == \"major\""}}
+
+	var emptyFlag Flag
+
+	assert.Equal(t, "[{\"expression\":\"release.type == \\\"major\\\"\",\"name\":\"no-friday-major\"}]", flag.String())
+	assert.Equal(t, "[]", emptyFlag.String())
+}
+
+func TestPolicyFlag_Set(t *testing.T) {
+	var flag Flag
+
+	err := flag.Set("[{\"name\": \"no-friday-major\", \"expression\": \"release.type == 'major'\"}]")
+	assert.NoError(t, err, "should not have errored")
+
+	err = flag.Set("{\"name\": \"no-friday-major\"}")
+	assert.Error(t, err, "should have errored, invalid JSON string")
+}
+
+func TestPolicyFlag_Type(t *testing.T) {
+	var f Flag
+
+	assert.Equal(t, FlagType, f.Type())
+}
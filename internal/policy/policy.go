@@ -0,0 +1,183 @@
+// Package policy provides functions to evaluate the policies that veto or hold releases, written as CEL expressions.
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/cel-go/cel"
+
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
+)
+
+const (
+	// ActionDeny vetoes the releases a policy applies to.
+	ActionDeny = "deny"
+	// ActionRequireApproval holds the releases a policy applies to until the policy is approved.
+	ActionRequireApproval = "require-approval"
+)
+
+var (
+	ErrNoName        = errors.New("policy has no name")
+	ErrNoExpression  = errors.New("policy has no expression")
+	ErrInvalidAction = errors.New("invalid policy action")
+	ErrNotBoolean    = errors.New("policy expression does not evaluate to a boolean")
+)
+
+// Policy is a CEL expression evaluated against every release found by a run, the release being denied, or held until
+// approved, when the expression evaluates to true.
+type Policy struct {
+	Name       string
+	Expression string
+	Action     string
+	// Message, if set, explains why the policy applies, e.g. "no major releases on Fridays".
+	Message string
+	program cel.Program
+}
+
+// Release is the release a policy is evaluated against, given to the expressions as the "release" variable.
+type Release struct {
+	Project     string
+	Branch      string
+	Environment string
+	Version     *semver.Version
+	Previous    *semver.Version
+	Forced      bool
+}
+
+// Unmarshall takes a raw Viper configuration and returns a slice of Policy whose expressions are compiled.
+func Unmarshall(input []map[string]string) ([]Policy, error) {
+	env, err := newEnv()
+	if err != nil {
+		return nil, fmt.Errorf("creating CEL environment: %w", err)
+	}
+
+	policies := make([]Policy, len(input))
+
+	for i, p := range input {
+		name, ok := p["name"]
+		if !ok {
+			return nil, ErrNoName
+		}
+
+		expression, ok := p["expression"]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrNoExpression, name)
+		}
+
+		action := p["action"]
+		if action == "" {
+			action = ActionDeny
+		}
+
+		if action != ActionDeny && action != ActionRequireApproval {
+			return nil, fmt.Errorf("%w: %q for policy %q, must be %q or %q", ErrInvalidAction, action, name, ActionDeny, ActionRequireApproval)
+		}
+
+		ast, issues := env.Compile(expression)
+		if issues.Err() != nil {
+			return nil, fmt.Errorf("compiling policy %q: %w", name, issues.Err())
+		}
+
+		// Fields of the release are dynamically typed, an expression such as "release.forced" being only checked when
+		// evaluated.
+		if t := ast.OutputType(); !t.IsExactType(cel.BoolType) && !t.IsExactType(cel.DynType) {
+			return nil, fmt.Errorf("%w: %q", ErrNotBoolean, name)
+		}
+
+		program, err := env.Program(ast)
+		if err != nil {
+			return nil, fmt.Errorf("creating program of policy %q: %w", name, err)
+		}
+
+		policies[i] = Policy{
+			Name:       name,
+			Expression: expression,
+			Action:     action,
+			Message:    p["message"],
+			program:    program,
+		}
+	}
+
+	return policies, nil
+}
+
+// Evaluate evaluates the policies in their configured order against a release and returns the first one that denies
+// it, or that holds it because it is not among the approved policies. A nil policy means the release is allowed.
+func Evaluate(policies []Policy, release Release, now time.Time, approved []string) (*Policy, error) {
+	vars := map[string]any{
+		"release": release.vars(),
+		"now":     now,
+	}
+
+	for i, p := range policies {
+		out, _, err := p.program.Eval(vars)
+		if err != nil {
+			return nil, fmt.Errorf("evaluating policy %q: %w", p.Name, err)
+		}
+
+		applies, ok := out.Value().(bool)
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrNotBoolean, p.Name)
+		}
+
+		if !applies {
+			continue
+		}
+
+		if p.Action == ActionRequireApproval && slices.Contains(approved, p.Name) {
+			continue
+		}
+
+		return &policies[i], nil
+	}
+
+	return nil, nil
+}
+
+// Type returns the type of a release, "major", "minor" or "patch" depending on the most significant number bumped
+// from the previous version, or "prerelease" if none was bumped. A missing previous version is the zero version.
+func Type(previous, next *semver.Version) string {
+	if previous == nil {
+		previous = &semver.Version{}
+	}
+
+	switch {
+	case next.Major != previous.Major:
+		return "major"
+	case next.Minor != previous.Minor:
+		return "minor"
+	case next.Patch != previous.Patch:
+		return "patch"
+	default:
+		return "prerelease"
+	}
+}
+
+// vars returns the release as given to the expressions.
+func (r Release) vars() map[string]any {
+	previous := ""
+	if r.Previous != nil {
+		previous = r.Previous.String()
+	}
+
+	return map[string]any{
+		"project":     r.Project,
+		"branch":      r.Branch,
+		"environment": r.Environment,
+		"version":     r.Version.String(),
+		"previous":    previous,
+		"type":        Type(r.Previous, r.Version),
+		"forced":      r.Forced,
+		"prerelease":  r.Version.Prerelease != "",
+	}
+}
+
+func newEnv() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable("release", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("now", cel.TimestampType),
+	)
+}
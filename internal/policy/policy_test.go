@@ -0,0 +1,101 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
+)
+
+func TestUnmarshall(t *testing.T) {
+	assert := assertion.New(t)
+
+	policies, err := Unmarshall([]map[string]string{
+		{"name": "no-friday-major", "expression": `release.type == "major"`, "message": "no major releases on Fridays"},
+		{"name": "api-approval", "expression": `release.project == "api"`, "action": "require-approval"},
+	})
+	assert.NoError(err, "should not have errored")
+
+	assert.Len(policies, 2)
+	assert.Equal(ActionDeny, policies[0].Action, "deny should be the default action")
+	assert.Equal("no major releases on Fridays", policies[0].Message)
+	assert.Equal(ActionRequireApproval, policies[1].Action)
+
+	_, err = Unmarshall([]map[string]string{{"expression": "true"}})
+	assert.ErrorIs(err, ErrNoName)
+
+	_, err = Unmarshall([]map[string]string{{"name": "foo"}})
+	assert.ErrorIs(err, ErrNoExpression)
+
+	_, err = Unmarshall([]map[string]string{{"name": "foo", "expression": "true", "action": "modify"}})
+	assert.ErrorIs(err, ErrInvalidAction)
+
+	_, err = Unmarshall([]map[string]string{{"name": "foo", "expression": `"major"`}})
+	assert.ErrorIs(err, ErrNotBoolean)
+
+	_, err = Unmarshall([]map[string]string{{"name": "foo", "expression": "release.type =="}})
+	assert.Error(err, "should have errored, invalid expression")
+}
+
+func TestEvaluate(t *testing.T) {
+	assert := assertion.New(t)
+
+	policies, err := Unmarshall([]map[string]string{
+		{"name": "no-friday-major", "expression": `release.type == "major" && now.getDayOfWeek() == 5`},
+		{"name": "api-approval", "expression": `release.project == "api" && !release.prerelease`, "action": "require-approval"},
+	})
+	assert.NoError(err, "should not have errored")
+
+	friday := time.Date(2024, 3, 15, 10, 0, 0, 0, time.UTC)
+	monday := time.Date(2024, 3, 18, 10, 0, 0, 0, time.UTC)
+
+	major := Release{Project: "web", Version: &semver.Version{Major: 2}, Previous: &semver.Version{Major: 1, Minor: 4}}
+
+	denied, err := Evaluate(policies, major, friday, nil)
+	assert.NoError(err, "should not have errored")
+	if assert.NotNil(denied) {
+		assert.Equal("no-friday-major", denied.Name)
+	}
+
+	denied, err = Evaluate(policies, major, monday, nil)
+	assert.NoError(err, "should not have errored")
+	assert.Nil(denied, "a major release should be allowed on Mondays")
+
+	api := Release{Project: "api", Version: &semver.Version{Minor: 2}, Previous: &semver.Version{Minor: 1}}
+
+	held, err := Evaluate(policies, api, monday, nil)
+	assert.NoError(err, "should not have errored")
+	if assert.NotNil(held) {
+		assert.Equal("api-approval", held.Name)
+	}
+
+	held, err = Evaluate(policies, api, monday, []string{"api-approval"})
+	assert.NoError(err, "should not have errored")
+	assert.Nil(held, "an approved release should be allowed")
+
+	api.Version.Prerelease = "rc"
+
+	held, err = Evaluate(policies, api, monday, nil)
+	assert.NoError(err, "should not have errored")
+	assert.Nil(held, "a prerelease should not require an approval")
+}
+
+func TestEvaluate_NotBoolean(t *testing.T) {
+	policies, err := Unmarshall([]map[string]string{{"name": "foo", "expression": "release.version"}})
+	assertion.NoError(t, err, "should not have errored, the release fields being dynamically typed")
+
+	_, err = Evaluate(policies, Release{Version: &semver.Version{Major: 1}}, time.Now(), nil)
+	assertion.ErrorIs(t, err, ErrNotBoolean)
+}
+
+func TestType(t *testing.T) {
+	assert := assertion.New(t)
+
+	assert.Equal("major", Type(&semver.Version{Major: 1, Minor: 2}, &semver.Version{Major: 2}))
+	assert.Equal("minor", Type(&semver.Version{Major: 1, Minor: 2, Patch: 3}, &semver.Version{Major: 1, Minor: 3}))
+	assert.Equal("patch", Type(&semver.Version{Major: 1}, &semver.Version{Major: 1, Patch: 1}))
+	assert.Equal("prerelease", Type(&semver.Version{Major: 1, Prerelease: "rc"}, &semver.Version{Major: 1, Prerelease: "rc"}))
+	assert.Equal("minor", Type(nil, &semver.Version{Minor: 1}))
+}
@@ -0,0 +1,118 @@
+// Package progress reports the progress of long operations, such as cloning a repository or scanning its history, as
+// periodic log events.
+package progress
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Interval is the minimum duration between two events reporting the progress of the same operation.
+const Interval = time.Second
+
+// Counter counts the steps of an operation and logs their number at most once per interval, and when the last step
+// is counted if the total is known. A nil Counter counts nothing, so that progress reporting can be disabled by not
+// creating one.
+type Counter struct {
+	logger   zerolog.Logger
+	message  string
+	total    int
+	interval time.Duration
+	count    int
+	last     time.Time
+	mu       sync.Mutex
+}
+
+// NewCounter returns a counter logging the given message, with the total number of steps if known, i.e. positive.
+func NewCounter(logger zerolog.Logger, message string, total int, interval time.Duration) *Counter {
+	return &Counter{logger: logger, message: message, total: total, interval: interval}
+}
+
+// Add counts n steps. It is safe for concurrent use.
+func (c *Counter) Add(n int) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.count += n
+
+	now := time.Now()
+	done := c.total > 0 && c.count >= c.total
+
+	if !done && now.Sub(c.last) < c.interval {
+		return
+	}
+
+	c.last = now
+
+	event := c.logger.Info().Int("count", c.count)
+	if c.total > 0 {
+		event.Int("total", c.total)
+	}
+
+	event.Msg(c.message)
+}
+
+// Writer logs the progress reported by a Git remote through the sideband, e.g. "Receiving objects:  45% (450/1000)",
+// at most once per interval, the final line of each stage being always logged.
+type Writer struct {
+	logger   zerolog.Logger
+	message  string
+	interval time.Duration
+	buf      []byte
+	last     time.Time
+}
+
+// NewWriter returns a writer logging the progress of a Git remote with the given message.
+func NewWriter(logger zerolog.Logger, message string, interval time.Duration) *Writer {
+	return &Writer{logger: logger, message: message, interval: interval}
+}
+
+// Write buffers the sideband progress and logs every complete line, lines being terminated by a carriage return when
+// they are updated in place.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+
+	for {
+		i := bytes.IndexAny(w.buf, "\r\n")
+		if i < 0 {
+			break
+		}
+
+		w.log(string(w.buf[:i]))
+		w.buf = w.buf[i+1:]
+	}
+
+	return len(p), nil
+}
+
+func (w *Writer) log(line string) {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return
+	}
+
+	now := time.Now()
+	done := strings.HasSuffix(line, "done.")
+
+	if !done && now.Sub(w.last) < w.interval {
+		return
+	}
+
+	w.last = now
+
+	stage, status, ok := strings.Cut(line, ":")
+	if !ok {
+		w.logger.Info().Str("status", line).Msg(w.message)
+		return
+	}
+
+	w.logger.Info().Str("stage", strings.TrimSpace(stage)).Str("status", strings.TrimSpace(status)).Msg(w.message)
+}
@@ -0,0 +1,71 @@
+package progress
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestCounter_Add(t *testing.T) {
+	assert := assertion.New(t)
+
+	out := new(bytes.Buffer)
+
+	counter := NewCounter(zerolog.New(out), "commits scanned", 0, time.Hour)
+
+	for range 10 {
+		counter.Add(1)
+	}
+
+	assert.Equal(1, strings.Count(out.String(), `"message":"commits scanned"`), "events should be throttled")
+	assert.Contains(out.String(), `"count":1`)
+
+	out.Reset()
+
+	counter = NewCounter(zerolog.New(out), "projects analyzed", 3, time.Hour)
+
+	var wg sync.WaitGroup
+
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			counter.Add(1)
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Contains(out.String(), `"count":3,"total":3`, "the last step should always be logged")
+}
+
+func TestCounter_Nil(t *testing.T) {
+	var counter *Counter
+
+	assertion.NotPanics(t, func() { counter.Add(1) })
+}
+
+func TestWriter_Write(t *testing.T) {
+	assert := assertion.New(t)
+
+	out := new(bytes.Buffer)
+
+	w := NewWriter(zerolog.New(out), "cloning", time.Hour)
+
+	_, err := w.Write([]byte("Enumerating objects: 10, done.\nCounting obj"))
+	assert.NoError(err)
+
+	_, err = w.Write([]byte("ects:  50% (5/10)\rCounting objects: 100% (10/10)\rCounting objects: 100% (10/10), done.\n"))
+	assert.NoError(err)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+
+	assert.Len(lines, 2, "in-place updates should be throttled")
+	assert.Contains(lines[0], `"stage":"Enumerating objects","status":"10, done."`)
+	assert.Contains(lines[1], `"stage":"Counting objects","status":"100% (10/10), done."`)
+}
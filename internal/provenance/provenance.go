@@ -0,0 +1,299 @@
+// Package provenance builds in-toto attestations of the SLSA provenance of releases, describing the tag and commit a
+// release was made of, the CI run that made it and the previous release it was computed from.
+//
+// Attestations are wrapped in DSSE envelopes, signed with a GPG key if one is given, and written as JSON lines so that
+// the attestations of a run form an in-toto bundle.
+package provenance
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+)
+
+const (
+	StatementType = "https://in-toto.io/Statement/v1"
+	PredicateType = "https://slsa.dev/provenance/v1"
+	// BuildType identifies how a release is made, i.e. by tagging a commit whose version is computed from its history.
+	BuildType = "https://github.com/s0ders/go-semver-release/release/v1"
+	// PayloadType is the type of the payload of the DSSE envelopes, an in-toto statement.
+	PayloadType = "application/vnd.in-toto+json"
+	// BundleName and BundleContentType are the name and content type of the bundle of the attestations of a run when
+	// attached to a forge release.
+	BundleName        = "provenance.intoto.jsonl"
+	BundleContentType = "application/vnd.in-toto+jsonl"
+)
+
+var (
+	ErrInvalidPayload = errors.New("invalid attestation payload")
+	ErrUnsigned       = errors.New("attestation is not signed")
+)
+
+// Release is a release whose provenance is attested.
+type Release struct {
+	Repository string
+	Branch     string
+	Project    string
+	Version    string
+	Tag        string
+	Commit     string
+	// PreviousTag and PreviousCommit, if set, are the tag of the release the version was computed from and its commit.
+	PreviousTag    string
+	PreviousCommit string
+}
+
+// Statement is an in-toto statement attesting the SLSA provenance of its subjects.
+type Statement struct {
+	Type          string       `json:"_type"`
+	Subject       []Descriptor `json:"subject"`
+	PredicateType string       `json:"predicateType"`
+	Predicate     Predicate    `json:"predicate"`
+}
+
+// Descriptor is an in-toto resource descriptor, such as a tag identified by the digest of its commit.
+type Descriptor struct {
+	Name   string            `json:"name,omitempty"`
+	URI    string            `json:"uri,omitempty"`
+	Digest map[string]string `json:"digest"`
+}
+
+// Predicate is a SLSA provenance predicate.
+type Predicate struct {
+	BuildDefinition BuildDefinition `json:"buildDefinition"`
+	RunDetails      RunDetails      `json:"runDetails"`
+}
+
+type BuildDefinition struct {
+	BuildType            string            `json:"buildType"`
+	ExternalParameters   map[string]string `json:"externalParameters"`
+	ResolvedDependencies []Descriptor      `json:"resolvedDependencies,omitempty"`
+}
+
+type RunDetails struct {
+	Builder  Builder  `json:"builder"`
+	Metadata Metadata `json:"metadata"`
+}
+
+// Builder identifies the platform that made a release, such as a GitHub Actions workflow.
+type Builder struct {
+	ID string `json:"id"`
+}
+
+type Metadata struct {
+	InvocationID string    `json:"invocationId,omitempty"`
+	FinishedOn   time.Time `json:"finishedOn"`
+}
+
+// Envelope is a DSSE envelope wrapping a statement, whose signatures cover both the payload and its type.
+type Envelope struct {
+	PayloadType string      `json:"payloadType"`
+	Payload     string      `json:"payload"`
+	Signatures  []Signature `json:"signatures"`
+}
+
+type Signature struct {
+	KeyID string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+// New returns the statement attesting the provenance of a release made by the given run.
+func New(release Release, run RunDetails) Statement {
+	parameters := map[string]string{
+		"repository": release.Repository,
+		"ref":        "refs/heads/" + release.Branch,
+		"version":    release.Version,
+	}
+
+	if release.Project != "" {
+		parameters["project"] = release.Project
+	}
+
+	var dependencies []Descriptor
+
+	if release.PreviousTag != "" && release.PreviousCommit != "" {
+		dependencies = append(dependencies, Descriptor{
+			Name:   release.PreviousTag,
+			URI:    "git+" + release.Repository + "@refs/tags/" + release.PreviousTag,
+			Digest: map[string]string{"gitCommit": release.PreviousCommit},
+		})
+	}
+
+	return Statement{
+		Type:          StatementType,
+		Subject:       []Descriptor{{Name: release.Tag, Digest: map[string]string{"gitCommit": release.Commit}}},
+		PredicateType: PredicateType,
+		Predicate: Predicate{
+			BuildDefinition: BuildDefinition{
+				BuildType:            BuildType,
+				ExternalParameters:   parameters,
+				ResolvedDependencies: dependencies,
+			},
+			RunDetails: run,
+		},
+	}
+}
+
+// Run returns the details of the current run, identifying its builder from well known CI environment variables. Runs
+// outside of GitHub Actions and GitLab CI are attributed to the local host.
+func Run(finishedOn time.Time) RunDetails {
+	run := RunDetails{Metadata: Metadata{FinishedOn: finishedOn.UTC()}}
+
+	switch {
+	case os.Getenv("GITHUB_ACTIONS") == "true":
+		server, repository := os.Getenv("GITHUB_SERVER_URL"), os.Getenv("GITHUB_REPOSITORY")
+
+		run.Builder.ID = server + "/" + repository + "/actions"
+		if workflow := os.Getenv("GITHUB_WORKFLOW_REF"); workflow != "" {
+			run.Builder.ID = server + "/" + workflow
+		}
+
+		run.Metadata.InvocationID = fmt.Sprintf("%s/%s/actions/runs/%s/attempts/%s", server, repository, os.Getenv("GITHUB_RUN_ID"), os.Getenv("GITHUB_RUN_ATTEMPT"))
+	case os.Getenv("GITLAB_CI") == "true":
+		run.Builder.ID = fmt.Sprintf("%s/%s/-/runners/%s", os.Getenv("CI_SERVER_URL"), os.Getenv("CI_PROJECT_PATH"), os.Getenv("CI_RUNNER_ID"))
+		run.Metadata.InvocationID = os.Getenv("CI_JOB_URL")
+	default:
+		host, err := os.Hostname()
+		if err != nil || host == "" {
+			host = "localhost"
+		}
+
+		run.Builder.ID = "local://" + host
+	}
+
+	return run
+}
+
+// Seal wraps a statement in a DSSE envelope, signed with the given key if not nil.
+func Seal(statement Statement, key *openpgp.Entity) (Envelope, error) {
+	payload, err := json.Marshal(statement)
+	if err != nil {
+		return Envelope{}, fmt.Errorf("marshalling statement: %w", err)
+	}
+
+	envelope := Envelope{
+		PayloadType: PayloadType,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signatures:  []Signature{},
+	}
+
+	if key == nil {
+		return envelope, nil
+	}
+
+	var signature bytes.Buffer
+
+	if err = openpgp.DetachSign(&signature, key, bytes.NewReader(pae(PayloadType, payload)), nil); err != nil {
+		return Envelope{}, fmt.Errorf("signing statement: %w", err)
+	}
+
+	envelope.Signatures = append(envelope.Signatures, Signature{
+		KeyID: fmt.Sprintf("%X", key.PrimaryKey.Fingerprint),
+		Sig:   base64.StdEncoding.EncodeToString(signature.Bytes()),
+	})
+
+	return envelope, nil
+}
+
+// Open returns the statement wrapped by an envelope. If a keyring is given, the envelope must be signed by one of its
+// keys.
+func Open(envelope Envelope, keyring openpgp.KeyRing) (Statement, error) {
+	var statement Statement
+
+	if envelope.PayloadType != PayloadType {
+		return statement, fmt.Errorf("%w: unexpected payload type %q", ErrInvalidPayload, envelope.PayloadType)
+	}
+
+	payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+	if err != nil {
+		return statement, fmt.Errorf("%w: %w", ErrInvalidPayload, err)
+	}
+
+	if keyring != nil {
+		if err = verify(envelope, payload, keyring); err != nil {
+			return statement, err
+		}
+	}
+
+	if err = json.Unmarshal(payload, &statement); err != nil {
+		return statement, fmt.Errorf("%w: %w", ErrInvalidPayload, err)
+	}
+
+	return statement, nil
+}
+
+// verify checks that one of the signatures of an envelope is made by a key of the keyring.
+func verify(envelope Envelope, payload []byte, keyring openpgp.KeyRing) error {
+	if len(envelope.Signatures) == 0 {
+		return ErrUnsigned
+	}
+
+	var errs []error
+
+	for _, signature := range envelope.Signatures {
+		sig, err := base64.StdEncoding.DecodeString(signature.Sig)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("decoding signature: %w", err))
+			continue
+		}
+
+		_, err = openpgp.CheckDetachedSignature(keyring, bytes.NewReader(pae(envelope.PayloadType, payload)), bytes.NewReader(sig), nil)
+		if err == nil {
+			return nil
+		}
+
+		errs = append(errs, err)
+	}
+
+	return fmt.Errorf("checking attestation signature: %w", errors.Join(errs...))
+}
+
+// Append writes envelopes as JSON lines at the end of the given file, creating it if needed.
+func Append(path string, envelopes ...Envelope) (err error) {
+	content, err := Bundle(envelopes)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("opening provenance file: %w", err)
+	}
+
+	defer func() {
+		err = errors.Join(err, f.Close())
+	}()
+
+	if _, err = f.Write(content); err != nil {
+		return fmt.Errorf("writing provenance file: %w", err)
+	}
+
+	return nil
+}
+
+// Bundle returns envelopes as JSON lines.
+func Bundle(envelopes []Envelope) ([]byte, error) {
+	var b bytes.Buffer
+
+	for _, envelope := range envelopes {
+		line, err := json.Marshal(envelope)
+		if err != nil {
+			return nil, fmt.Errorf("marshalling envelope: %w", err)
+		}
+
+		b.Write(line)
+		b.WriteByte('\n')
+	}
+
+	return b.Bytes(), nil
+}
+
+// pae returns the DSSE pre-authentication encoding of a payload, which is what signatures cover.
+func pae(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
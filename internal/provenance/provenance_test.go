@@ -0,0 +1,171 @@
+package provenance
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func checkErr(t *testing.T, err error, message string) {
+	t.Helper()
+
+	if err != nil {
+		t.Fatalf("%s: %s", message, err)
+	}
+}
+
+func TestProvenance_New(t *testing.T) {
+	assert := assertion.New(t)
+
+	finishedOn := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	statement := New(Release{
+		Repository:     "https://github.com/acme/app.git",
+		Branch:         "main",
+		Project:        "api",
+		Version:        "1.4.0",
+		Tag:            "api-v1.4.0",
+		Commit:         "3f1c2a9",
+		PreviousTag:    "api-v1.3.2",
+		PreviousCommit: "a81d0be",
+	}, RunDetails{Builder: Builder{ID: "local://ci"}, Metadata: Metadata{FinishedOn: finishedOn}})
+
+	assert.Equal(StatementType, statement.Type)
+	assert.Equal(PredicateType, statement.PredicateType)
+	assert.Equal([]Descriptor{{Name: "api-v1.4.0", Digest: map[string]string{"gitCommit": "3f1c2a9"}}}, statement.Subject)
+	assert.Equal(map[string]string{
+		"repository": "https://github.com/acme/app.git",
+		"ref":        "refs/heads/main",
+		"project":    "api",
+		"version":    "1.4.0",
+	}, statement.Predicate.BuildDefinition.ExternalParameters)
+	assert.Equal([]Descriptor{{
+		Name:   "api-v1.3.2",
+		URI:    "git+https://github.com/acme/app.git@refs/tags/api-v1.3.2",
+		Digest: map[string]string{"gitCommit": "a81d0be"},
+	}}, statement.Predicate.BuildDefinition.ResolvedDependencies)
+	assert.Equal("local://ci", statement.Predicate.RunDetails.Builder.ID)
+
+	first := New(Release{Repository: "app", Branch: "main", Version: "0.1.0", Tag: "v0.1.0", Commit: "3f1c2a9"}, RunDetails{})
+	assert.Empty(first.Predicate.BuildDefinition.ResolvedDependencies, "first release should have no previous release")
+	assert.NotContains(first.Predicate.BuildDefinition.ExternalParameters, "project")
+}
+
+func TestProvenance_Run(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Setenv("GITLAB_CI", "")
+	t.Setenv("GITHUB_ACTIONS", "true")
+	t.Setenv("GITHUB_SERVER_URL", "https://github.com")
+	t.Setenv("GITHUB_REPOSITORY", "acme/app")
+	t.Setenv("GITHUB_WORKFLOW_REF", "acme/app/.github/workflows/release.yml@refs/heads/main")
+	t.Setenv("GITHUB_RUN_ID", "42")
+	t.Setenv("GITHUB_RUN_ATTEMPT", "2")
+
+	run := Run(time.Now())
+	assert.Equal("https://github.com/acme/app/.github/workflows/release.yml@refs/heads/main", run.Builder.ID)
+	assert.Equal("https://github.com/acme/app/actions/runs/42/attempts/2", run.Metadata.InvocationID)
+
+	t.Setenv("GITHUB_ACTIONS", "")
+	t.Setenv("GITLAB_CI", "true")
+	t.Setenv("CI_SERVER_URL", "https://gitlab.com")
+	t.Setenv("CI_PROJECT_PATH", "acme/app")
+	t.Setenv("CI_RUNNER_ID", "7")
+	t.Setenv("CI_JOB_URL", "https://gitlab.com/acme/app/-/jobs/99")
+
+	run = Run(time.Now())
+	assert.Equal("https://gitlab.com/acme/app/-/runners/7", run.Builder.ID)
+	assert.Equal("https://gitlab.com/acme/app/-/jobs/99", run.Metadata.InvocationID)
+
+	t.Setenv("GITLAB_CI", "")
+
+	run = Run(time.Now())
+	assert.Contains(run.Builder.ID, "local://")
+}
+
+func TestProvenance_SealAndOpen(t *testing.T) {
+	assert := assertion.New(t)
+
+	entity, err := openpgp.NewEntity("John Doe", "", "john.doe@example.com", &packet.Config{Algorithm: packet.PubKeyAlgoEdDSA})
+	checkErr(t, err, "creating entity")
+
+	otherEntity, err := openpgp.NewEntity("Jane Doe", "", "jane.doe@example.com", &packet.Config{Algorithm: packet.PubKeyAlgoEdDSA})
+	checkErr(t, err, "creating other entity")
+
+	statement := New(Release{Repository: "app", Branch: "main", Version: "1.0.0", Tag: "v1.0.0", Commit: "3f1c2a9"}, RunDetails{Builder: Builder{ID: "local://ci"}})
+
+	envelope, err := Seal(statement, entity)
+	checkErr(t, err, "sealing statement")
+
+	assert.Equal(PayloadType, envelope.PayloadType)
+	assert.Len(envelope.Signatures, 1)
+
+	opened, err := Open(envelope, openpgp.EntityList{entity})
+	checkErr(t, err, "opening envelope")
+
+	assert.Equal(statement, opened)
+
+	_, err = Open(envelope, openpgp.EntityList{otherEntity})
+	assert.Error(err, "envelope signed by another key should be rejected")
+
+	unsigned, err := Seal(statement, nil)
+	checkErr(t, err, "sealing statement")
+
+	assert.Empty(unsigned.Signatures)
+
+	_, err = Open(unsigned, nil)
+	assert.NoError(err, "unsigned envelope should be opened without keyring")
+
+	_, err = Open(unsigned, openpgp.EntityList{entity})
+	assert.ErrorIs(err, ErrUnsigned)
+
+	tampered := envelope
+	tampered.PayloadType = "application/json"
+
+	_, err = Open(tampered, nil)
+	assert.ErrorIs(err, ErrInvalidPayload)
+}
+
+func TestProvenance_Append(t *testing.T) {
+	assert := assertion.New(t)
+
+	path := filepath.Join(t.TempDir(), "provenance.intoto.jsonl")
+
+	first, err := Seal(New(Release{Tag: "api-v1.0.0", Commit: "3f1c2a9"}, RunDetails{}), nil)
+	checkErr(t, err, "sealing statement")
+
+	second, err := Seal(New(Release{Tag: "web-v2.0.0", Commit: "a81d0be"}, RunDetails{}), nil)
+	checkErr(t, err, "sealing statement")
+
+	checkErr(t, Append(path, first), "appending envelope")
+	checkErr(t, Append(path, second), "appending envelope")
+
+	f, err := os.Open(path)
+	checkErr(t, err, "opening provenance file")
+
+	defer func() {
+		_ = f.Close()
+	}()
+
+	var tags []string
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var envelope Envelope
+		checkErr(t, json.Unmarshal(scanner.Bytes(), &envelope), "unmarshalling envelope")
+
+		statement, err := Open(envelope, nil)
+		checkErr(t, err, "opening envelope")
+
+		tags = append(tags, statement.Subject[0].Name)
+	}
+
+	assert.Equal([]string{"api-v1.0.0", "web-v2.0.0"}, tags)
+}
@@ -0,0 +1,113 @@
+package remote
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	nethttp "net/http"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+
+	"github.com/s0ders/go-semver-release/v6/internal/cloud"
+)
+
+// GoogleSourceHost is the host of Google Cloud Source Repositories.
+const GoogleSourceHost = "source.developers.google.com"
+
+const codeCommitTimeFormat = "20060102T150405"
+
+// codeCommitHostRegex matches the hosts of the HTTPS endpoints of AWS CodeCommit, capturing their region, e.g.
+// "git-codecommit.eu-west-1.amazonaws.com" or "git-codecommit-fips.us-east-1.amazonaws.com".
+var codeCommitHostRegex = regexp.MustCompile(`^git-codecommit(?:-fips)?\.([a-z0-9-]+)\.amazonaws\.com(?:\.cn)?$`)
+
+// providerAuth returns the authentication of an HTTP(S) remote hosted by a cloud provider not accepting a static access
+// token, or nil if the remote is not hosted by one of them:
+//   - AWS CodeCommit, authenticated by requests signed with AWS Signature Version 4 from the credentials given by the
+//     AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN environment variables, as done by the credential
+//     helper of the AWS CLI. Without these variables, the access token is used.
+//   - Google Cloud Source Repositories, authenticated by an OAuth access token: the access token if any, else the one
+//     given by cloud.GoogleAccessToken.
+func (r *Remote) providerAuth(normalized string) (transport.AuthMethod, error) {
+	u, err := url.Parse(normalized)
+	if err != nil {
+		return nil, fmt.Errorf("parsing repository URL: %w", err)
+	}
+
+	if match := codeCommitHostRegex.FindStringSubmatch(u.Hostname()); match != nil {
+		credentials, ok := cloud.AWSCredentialsFromEnv()
+		if !ok {
+			return nil, nil
+		}
+
+		return &CodeCommitAuth{
+			Credentials: credentials,
+			Region:      match[1],
+			Host:        u.Host,
+			Path:        u.Path,
+		}, nil
+	}
+
+	if u.Hostname() == GoogleSourceHost {
+		token := r.tokenAuth.Password
+		if token == "" {
+			token, err = cloud.GoogleAccessToken(context.Background())
+			if err != nil {
+				return nil, fmt.Errorf("fetching Google Cloud access token: %w", err)
+			}
+		}
+
+		return &http.TokenAuth{Token: token}, nil
+	}
+
+	return nil, nil
+}
+
+// CodeCommitAuth authenticates the requests made to an AWS CodeCommit repository with AWS Signature Version 4, the
+// password of each request being a signature of its repository valid for a few minutes.
+type CodeCommitAuth struct {
+	Credentials cloud.AWSCredentials
+	Region      string
+	// Host and Path are the ones of the URL of the repository, e.g. "/v1/repos/app".
+	Host string
+	Path string
+}
+
+func (a *CodeCommitAuth) Name() string {
+	return "aws-sigv4"
+}
+
+func (a *CodeCommitAuth) String() string {
+	return fmt.Sprintf("%s - %s:%s", a.Name(), a.Credentials.AccessKeyID, "*******")
+}
+
+// SetAuth signs the request with the current time.
+func (a *CodeCommitAuth) SetAuth(r *nethttp.Request) {
+	username, password := a.Sign(time.Now())
+	r.SetBasicAuth(username, password)
+}
+
+// Sign returns the username and password authenticating the requests made at the given time, as computed by the
+// credential helper of the AWS CLI.
+func (a *CodeCommitAuth) Sign(t time.Time) (string, string) {
+	timestamp := t.UTC().Format(codeCommitTimeFormat)
+	date := timestamp[:8]
+
+	canonicalRequest := fmt.Sprintf("GIT\n%s\n\nhost:%s\n\nhost\n", a.Path, a.Host)
+	canonicalHash := sha256.Sum256([]byte(canonicalRequest))
+	scope := fmt.Sprintf("%s/%s/codecommit/aws4_request", date, a.Region)
+	stringToSign := fmt.Sprintf("AWS4-HMAC-SHA256\n%s\n%s\n%s", timestamp, scope, hex.EncodeToString(canonicalHash[:]))
+
+	key := a.Credentials.SigningKey(date, a.Region, "codecommit")
+
+	username := a.Credentials.AccessKeyID
+	if a.Credentials.SessionToken != "" {
+		username += "%" + a.Credentials.SessionToken
+	}
+
+	return username, timestamp + "Z" + hex.EncodeToString(cloud.HMACSHA256(key, stringToSign))
+}
@@ -0,0 +1,79 @@
+package remote
+
+import (
+	"testing"
+	"time"
+
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/cloud"
+)
+
+func TestRemote_CodeCommitAuth(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "wJalrXUtnFEMI/K7MDENG/bPxRfiCYEXAMPLEKEY")
+	t.Setenv("AWS_SESSION_TOKEN", "session")
+
+	r := New("origin", "token")
+
+	_, err := r.endpoint("https://git-codecommit.eu-west-1.amazonaws.com/v1/repos/app")
+	checkErr(t, err, "selecting authentication")
+
+	auth, ok := r.auth.(*CodeCommitAuth)
+	if !ok {
+		t.Fatalf("expected CodeCommit authentication, got %T", r.auth)
+	}
+
+	assert.Equal("eu-west-1", auth.Region)
+
+	username, password := auth.Sign(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC))
+
+	assert.Equal("AKIDEXAMPLE%session", username, "temporary credentials should carry their session token")
+	assert.Equal("20240301T120000Zc0fab3b515c19e2e39a2345680f7c4ed1e7f90762076b17930609f49c67fa3bf", password)
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+
+	_, err = r.endpoint("https://git-codecommit.eu-west-1.amazonaws.com/v1/repos/app")
+	checkErr(t, err, "selecting authentication")
+
+	assert.Equal(r.tokenAuth, r.auth, "the access token should be used without AWS credentials")
+}
+
+func TestRemote_GoogleSourceAuth(t *testing.T) {
+	assert := assertion.New(t)
+
+	url := "https://source.developers.google.com/p/project/r/app"
+
+	t.Setenv(cloud.GoogleAccessTokenEnv, "env-token")
+
+	r := New("origin", "token")
+
+	_, err := r.endpoint(url)
+	checkErr(t, err, "selecting authentication")
+
+	assert.Equal(&githttp.TokenAuth{Token: "token"}, r.auth, "the access token should be used as an OAuth token")
+
+	r = New("origin", "")
+
+	_, err = r.endpoint(url)
+	checkErr(t, err, "selecting authentication")
+
+	assert.Equal(&githttp.TokenAuth{Token: "env-token"}, r.auth, "Google Cloud credentials should be used without access token")
+}
+
+func TestRemote_ProviderAuth_OtherHosts(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+
+	r := New("origin", "token")
+
+	_, err := r.endpoint("https://github.com/owner/name.git")
+	checkErr(t, err, "selecting authentication")
+
+	assert.Equal(r.tokenAuth, r.auth)
+}
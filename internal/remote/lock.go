@@ -0,0 +1,223 @@
+package remote
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+const (
+	lockRefPrefix     = "refs/semver-release/lock/"
+	lockExpiresPrefix = "Expires: "
+	lockOwnerPrefix   = "Owner: "
+)
+
+var ErrLocked = errors.New("release lock is held by another process")
+
+// Lock is a distributed lock held on a branch of the remote repository, so that concurrent releases of the same
+// branch do not compute and push the same version.
+//
+// The lock is a ref pointing to a commit describing its owner and expiration. The ref is pushed without force when it
+// does not exist and with the expected old value when an expired lock is taken over, so that pushing it acts as a
+// compare-and-swap: only one process can acquire the lock. A lock whose expiration has passed is considered abandoned
+// and can be taken over.
+type Lock struct {
+	remote  *Remote
+	refName plumbing.ReferenceName
+	hash    plumbing.Hash
+}
+
+// Lock acquires the release lock of a branch on the previously cloned repository's remote for the given duration.
+func (r *Remote) Lock(branch, owner string, ttl time.Duration) (*Lock, error) {
+	refName := plumbing.ReferenceName(lockRefPrefix + branch)
+
+	current, err := r.remoteReference(refName)
+	if err != nil {
+		return nil, fmt.Errorf("reading lock reference: %w", err)
+	}
+
+	if current != nil {
+		err = r.checkExpiredLock(refName, current.Hash())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+
+	hash, err := r.lockCommit(branch, owner, now, now.Add(ttl))
+	if err != nil {
+		return nil, fmt.Errorf("creating lock commit: %w", err)
+	}
+
+	err = r.repository.Storer.SetReference(plumbing.NewHashReference(refName, hash))
+	if err != nil {
+		return nil, fmt.Errorf("creating lock reference: %w", err)
+	}
+
+	pushOptions := &git.PushOptions{
+		RemoteName: r.name,
+		RefSpecs:   []config.RefSpec{config.RefSpec(fmt.Sprintf("%s:%s", refName, refName))},
+		Auth:       r.auth,
+		Progress:   io.Discard,
+	}
+
+	// Without force, creating the lock reference is rejected if another process created it meanwhile. Taking over an
+	// expired lock replaces an unrelated commit and must be forced, so the reference is required to still point to the
+	// expired lock: the remote then only accepts the update if nobody took the lock over since it was read.
+	if current != nil {
+		pushOptions.RefSpecs = []config.RefSpec{config.RefSpec(fmt.Sprintf("+%s:%s", refName, refName))}
+		pushOptions.RequireRemoteRefs = []config.RefSpec{config.RefSpec(fmt.Sprintf("%s:%s", current.Hash(), refName))}
+	}
+
+	err = r.repository.Push(pushOptions)
+	if err != nil {
+		return nil, fmt.Errorf("%w: pushing lock reference: %w", ErrLocked, err)
+	}
+
+	return &Lock{remote: r, refName: refName, hash: hash}, nil
+}
+
+// Release removes the lock from the remote, unless it has expired and been taken over by another process meanwhile.
+func (l *Lock) Release() error {
+	// The reference is required to still point to the lock, so that deleting it acts as a compare-and-swap: a lock taken
+	// over by another process since it was acquired is left untouched.
+	err := l.remote.repository.Push(&git.PushOptions{
+		RemoteName:        l.remote.name,
+		RefSpecs:          []config.RefSpec{config.RefSpec(":" + l.refName)},
+		RequireRemoteRefs: []config.RefSpec{config.RefSpec(fmt.Sprintf("%s:%s", l.hash, l.refName))},
+		Auth:              l.remote.auth,
+		Progress:          io.Discard,
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		current, refErr := l.remote.remoteReference(l.refName)
+		if refErr == nil && (current == nil || current.Hash() != l.hash) {
+			return nil
+		}
+
+		return fmt.Errorf("deleting lock reference: %w", err)
+	}
+
+	return l.remote.repository.Storer.RemoveReference(l.refName)
+}
+
+// Refresh fetches the branches and tags of the remote, so that the repository reflects what was pushed since it was
+// cloned, e.g. by a process that released the lock.
+func (r *Remote) Refresh() error {
+	refSpecs, err := r.fetchRefSpecs()
+	if err != nil {
+		return fmt.Errorf("fetching remote: %w", err)
+	}
+
+	err = r.repository.Fetch(&git.FetchOptions{
+		RemoteName: r.name,
+		RefSpecs:   refSpecs,
+		Auth:       r.auth,
+		Progress:   r.progress,
+		Force:      true,
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("fetching remote: %w", err)
+	}
+
+	return nil
+}
+
+// remoteReference returns the given reference as advertised by the remote, or nil if it does not exist.
+func (r *Remote) remoteReference(refName plumbing.ReferenceName) (*plumbing.Reference, error) {
+	origin, err := r.repository.Remote(r.name)
+	if err != nil {
+		return nil, err
+	}
+
+	refs, err := origin.List(&git.ListOptions{Auth: r.auth})
+	if err != nil {
+		return nil, fmt.Errorf("listing remote references: %w", err)
+	}
+
+	for _, ref := range refs {
+		if ref.Name() == refName {
+			return ref, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// checkExpiredLock fetches an existing lock and returns an error unless it has expired.
+func (r *Remote) checkExpiredLock(refName plumbing.ReferenceName, hash plumbing.Hash) error {
+	err := r.repository.Fetch(&git.FetchOptions{
+		RemoteName: r.name,
+		RefSpecs:   []config.RefSpec{config.RefSpec(fmt.Sprintf("+%s:%s", refName, refName))},
+		Auth:       r.auth,
+		Progress:   io.Discard,
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("fetching lock reference: %w", err)
+	}
+
+	commit, err := r.repository.CommitObject(hash)
+	if err != nil {
+		return fmt.Errorf("reading lock commit: %w", err)
+	}
+
+	var owner, expires string
+
+	for _, line := range strings.Split(commit.Message, "\n") {
+		switch {
+		case strings.HasPrefix(line, lockOwnerPrefix):
+			owner = strings.TrimPrefix(line, lockOwnerPrefix)
+		case strings.HasPrefix(line, lockExpiresPrefix):
+			expires = strings.TrimPrefix(line, lockExpiresPrefix)
+		}
+	}
+
+	expiration, err := time.Parse(time.RFC3339, expires)
+	if err != nil {
+		return fmt.Errorf("parsing lock expiration: %w", err)
+	}
+
+	if time.Now().Before(expiration) {
+		return fmt.Errorf("%w: held by %q until %s", ErrLocked, owner, expiration.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// lockCommit stores a parentless commit with an empty tree describing the lock.
+func (r *Remote) lockCommit(branch, owner string, now, expiration time.Time) (plumbing.Hash, error) {
+	storer := r.repository.Storer
+
+	treeObject := storer.NewEncodedObject()
+	if err := (&object.Tree{}).Encode(treeObject); err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	treeHash, err := storer.SetEncodedObject(treeObject)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	signature := object.Signature{Name: "Go Semver Release", Email: "go-semver@release.ci", When: now}
+
+	commit := &object.Commit{
+		Author:    signature,
+		Committer: signature,
+		Message:   fmt.Sprintf("Release lock of branch %s\n\n%s%s\n%s%s\n", branch, lockOwnerPrefix, owner, lockExpiresPrefix, expiration.UTC().Format(time.RFC3339)),
+		TreeHash:  treeHash,
+	}
+
+	commitObject := storer.NewEncodedObject()
+	if err = commit.Encode(commitObject); err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	return storer.SetEncodedObject(commitObject)
+}
@@ -2,51 +2,302 @@
 package remote
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"slices"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/client"
 	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/storage/memory"
 )
 
+var (
+	ErrStaleLease      = errors.New("remote reference moved since it was fetched")
+	ErrReferenceExists = errors.New("remote reference already exists")
+)
+
+type OptionFunc func(r *Remote)
+
+// WithBranches restricts clones and fetches to the given branches and the tags, instead of every branch of the remote,
+// to cut the transfer size of repositories with many branches.
+func WithBranches(branches ...string) OptionFunc {
+	return func(r *Remote) {
+		r.branches = branches
+	}
+}
+
+// WithProgress writes the progress reported by the remote when cloning and fetching to the given writer.
+func WithProgress(w io.Writer) OptionFunc {
+	return func(r *Remote) {
+		r.progress = w
+	}
+}
+
 type Remote struct {
-	auth       *http.BasicAuth
+	auth       transport.AuthMethod
+	tokenAuth  *http.BasicAuth
 	repository *git.Repository
+	progress   io.Writer
 	name       string
+	branches   []string
+}
+
+func New(name string, token string, options ...OptionFunc) *Remote {
+	tokenAuth := &http.BasicAuth{
+		Username: "go-semver-release",
+		Password: token,
+	}
+
+	r := &Remote{
+		name:      name,
+		auth:      tokenAuth,
+		tokenAuth: tokenAuth,
+		progress:  io.Discard,
+	}
+
+	for _, option := range options {
+		option(r)
+	}
+
+	return r
 }
 
-func New(name string, token string) *Remote {
-	return &Remote{
-		name: name,
-		auth: &http.BasicAuth{
-			Username: "go-semver-release",
-			Password: token,
-		},
+// endpoint returns the normalized form of the URL of a repository and selects the authentication used with it: the
+// access token only authenticates HTTP(S) remotes, SSH remotes being authenticated by the SSH agent. Remotes hosted by
+// AWS CodeCommit and Google Cloud Source Repositories use the credentials of their provider, see providerAuth.
+func (r *Remote) endpoint(url string) (string, error) {
+	normalized, err := NormalizeURL(url)
+	if err != nil {
+		return "", err
+	}
+
+	if !IsHTTP(normalized) {
+		r.auth = nil
+		return normalized, nil
 	}
+
+	auth, err := r.providerAuth(normalized)
+	if err != nil {
+		return "", err
+	}
+
+	if auth != nil {
+		r.auth = auth
+	} else {
+		r.auth = r.tokenAuth
+	}
+
+	return normalized, nil
 }
 
-// Clone clones a given remote repository to a temporary directory.
+// List returns the references advertised by a remote repository without cloning it, e.g. to check that the access
+// token authenticates against it and that branches exist.
+func (r *Remote) List(url string) ([]*plumbing.Reference, error) {
+	url, err := r.endpoint(url)
+	if err != nil {
+		return nil, err
+	}
+
+	origin := git.NewRemote(memory.NewStorage(), &config.RemoteConfig{Name: r.name, URLs: []string{url}})
+
+	refs, err := origin.List(&git.ListOptions{Auth: r.auth})
+	if err != nil {
+		return nil, fmt.Errorf("listing remote references: %w", err)
+	}
+
+	return refs, nil
+}
+
+// Clone clones a given remote repository to a temporary directory. The clone is bare since analyzing the history and
+// creating tags do not require a worktree.
 func (r *Remote) Clone(url string) (*git.Repository, error) {
 	tempDir, err := os.MkdirTemp("", "*")
 	if err != nil {
 		return nil, fmt.Errorf("creating temporary directory: %w", err)
 	}
 
-	r.repository, err = git.PlainClone(tempDir, false, &git.CloneOptions{
+	repository, err := r.CloneTo(url, tempDir)
+	if err != nil {
+		_ = os.RemoveAll(tempDir)
+		return nil, err
+	}
+
+	return repository, nil
+}
+
+// CloneTo clones a given remote repository to the given directory, e.g. a workspace whose removal is managed by the
+// caller. The clone is bare, as with Clone. The URL of the repository is normalized first, see NormalizeURL.
+func (r *Remote) CloneTo(url, dir string) (*git.Repository, error) {
+	url, err := r.endpoint(url)
+	if err != nil {
+		return nil, err
+	}
+
+	r.repository, err = r.clone(url, dir)
+	if err != nil {
+		return nil, fmt.Errorf("cloning repository: %w", err)
+	}
+
+	return r.repository, nil
+}
+
+// CloneCached clones a given remote repository to a cache directory, or updates the clone made there by a previous run
+// so that only the objects created since are fetched. Branches and tags are updated as they are on the remote, those
+// deleted from it being deleted from the clone, so that tags created by a run but never pushed are not seen by the
+// next one. Each remote is cloned to its own subdirectory of the cache directory.
+func (r *Remote) CloneCached(url, cacheDir string) (*git.Repository, error) {
+	url, err := r.endpoint(url)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Join(cacheDir, r.cacheKey(url))
+
+	repository, err := git.PlainOpen(dir)
+	if errors.Is(err, git.ErrRepositoryNotExists) {
+		if err = os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("creating cache directory: %w", err)
+		}
+
+		r.repository, err = r.clone(url, dir)
+		if err != nil {
+			// A partial clone would be reused by the next run.
+			_ = os.RemoveAll(dir)
+			return nil, fmt.Errorf("cloning repository: %w", err)
+		}
+
+		return r.repository, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("opening cached repository: %w", err)
+	}
+
+	r.repository = repository
+
+	refSpecs, err := r.fetchRefSpecs()
+	if err != nil {
+		return nil, fmt.Errorf("fetching cached repository: %w", err)
+	}
+
+	err = repository.Fetch(&git.FetchOptions{
 		RemoteName: r.name,
+		RefSpecs:   refSpecs,
 		Auth:       r.auth,
-		URL:        url,
-		Progress:   io.Discard,
+		Progress:   r.progress,
+		Prune:      true,
+		Force:      true,
 	})
-	if err != nil {
-		return nil, fmt.Errorf("cloning repository: %w", err)
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return nil, fmt.Errorf("fetching cached repository: %w", err)
 	}
 
 	return r.repository, nil
 }
 
+// clone clones a remote repository to the given directory. Restricted to some branches, the clone is made by fetching
+// them and the tags into a new repository, since go-git only clones either a single branch or all of them.
+func (r *Remote) clone(url, dir string) (*git.Repository, error) {
+	if len(r.branches) == 0 {
+		return git.PlainClone(dir, true, &git.CloneOptions{
+			RemoteName: r.name,
+			Auth:       r.auth,
+			URL:        url,
+			Progress:   r.progress,
+		})
+	}
+
+	repository, err := git.PlainInit(dir, true)
+	if err != nil {
+		return nil, fmt.Errorf("initializing repository: %w", err)
+	}
+
+	_, err = repository.CreateRemote(&config.RemoteConfig{
+		Name:  r.name,
+		URLs:  []string{url},
+		Fetch: r.branchRefSpecs(r.branches),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating remote: %w", err)
+	}
+
+	r.repository = repository
+
+	refSpecs, err := r.fetchRefSpecs()
+	if err != nil {
+		return nil, err
+	}
+
+	err = repository.Fetch(&git.FetchOptions{
+		RemoteName: r.name,
+		RefSpecs:   refSpecs,
+		Auth:       r.auth,
+		Progress:   r.progress,
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return nil, fmt.Errorf("fetching branches: %w", err)
+	}
+
+	return repository, nil
+}
+
+// fetchRefSpecs returns the refspecs fetching the branches and tags of the remote. When restricted to some branches,
+// those missing from the remote are left out, since fetching a missing reference fails.
+func (r *Remote) fetchRefSpecs() ([]config.RefSpec, error) {
+	if len(r.branches) == 0 {
+		return []config.RefSpec{
+			config.RefSpec(fmt.Sprintf("+refs/heads/*:refs/remotes/%s/*", r.name)),
+			"+refs/tags/*:refs/tags/*",
+		}, nil
+	}
+
+	origin, err := r.repository.Remote(r.name)
+	if err != nil {
+		return nil, fmt.Errorf("fetching remote %q: %w", r.name, err)
+	}
+
+	refs, err := origin.List(&git.ListOptions{Auth: r.auth})
+	if err != nil {
+		return nil, fmt.Errorf("listing remote references: %w", err)
+	}
+
+	var branches []string
+
+	for _, ref := range refs {
+		if ref.Name().IsBranch() && slices.Contains(r.branches, ref.Name().Short()) {
+			branches = append(branches, ref.Name().Short())
+		}
+	}
+
+	return r.branchRefSpecs(branches), nil
+}
+
+// branchRefSpecs returns the refspecs fetching the given branches and the tags.
+func (r *Remote) branchRefSpecs(branches []string) []config.RefSpec {
+	refSpecs := make([]config.RefSpec, 0, len(branches)+1)
+
+	for _, branch := range branches {
+		refSpecs = append(refSpecs, config.RefSpec(fmt.Sprintf("+refs/heads/%s:refs/remotes/%s/%s", branch, r.name, branch)))
+	}
+
+	return append(refSpecs, "+refs/tags/*:refs/tags/*")
+}
+
+// cacheKey returns the name of the cache subdirectory of a remote repository.
+func (r *Remote) cacheKey(url string) string {
+	sum := sha256.Sum256([]byte(r.name + " " + url))
+
+	return hex.EncodeToString(sum[:16])
+}
+
 // PushTag pushes a given tag to the previously cloned repository's remote.
 func (r *Remote) PushTag(tagName string) error {
 	po := &git.PushOptions{
@@ -63,3 +314,190 @@ func (r *Remote) PushTag(tagName string) error {
 
 	return nil
 }
+
+// PushBranch updates a branch of the previously cloned repository's remote to the commit with the given hash. The
+// remote rejects the update unless the commit descends from the current tip of the branch.
+func (r *Remote) PushBranch(branch string, hash plumbing.Hash) error {
+	refName := plumbing.NewBranchReferenceName(branch)
+
+	err := r.repository.Storer.SetReference(plumbing.NewHashReference(refName, hash))
+	if err != nil {
+		return fmt.Errorf("updating branch %q: %w", branch, err)
+	}
+
+	po := &git.PushOptions{
+		RemoteName: r.name,
+		RefSpecs:   []config.RefSpec{config.RefSpec(fmt.Sprintf("%s:%s", refName, refName))},
+		Auth:       r.auth,
+		Progress:   io.Discard,
+	}
+
+	err = r.repository.Push(po)
+	if err != nil {
+		return fmt.Errorf("pushing branch %q: %w", branch, err)
+	}
+
+	return nil
+}
+
+// ForcePushTag pushes a given tag to the previously cloned repository's remote, replacing the remote tag as long as it
+// still references the given hash, so that a tag moved by someone else in the meantime is not overwritten. A zero hash
+// expects the tag to be missing from the remote.
+func (r *Remote) ForcePushTag(tagName string, expected plumbing.Hash) error {
+	return r.ForcePushReference(plumbing.NewTagReferenceName(tagName), expected)
+}
+
+// ForcePushReference pushes a given reference to the previously cloned repository's remote, replacing the remote
+// reference as long as it still references the given hash. A zero hash expects the reference to be missing from the
+// remote.
+func (r *Remote) ForcePushReference(refName plumbing.ReferenceName, expected plumbing.Hash) error {
+	// The lease option of go-git only resolves remote-tracking branches, the remote reference is therefore checked
+	// here first, so that a stale lease is reported as such.
+	ref, err := r.remoteReference(refName)
+	if err != nil {
+		return fmt.Errorf("force pushing %q: %w", refName.Short(), err)
+	}
+
+	current := plumbing.ZeroHash
+	if ref != nil {
+		current = ref.Hash()
+	}
+
+	if current != expected {
+		return fmt.Errorf("force pushing %q: %w: remote references %s, expected %s", refName.Short(), ErrStaleLease, current, expected)
+	}
+
+	po := &git.PushOptions{
+		RemoteName: r.name,
+		RefSpecs:   []config.RefSpec{config.RefSpec(fmt.Sprintf("%s:%s", refName, refName))},
+		Auth:       r.auth,
+		Progress:   io.Discard,
+	}
+
+	// The reference can still be moved between the check and the push. Without force, creating it is rejected if it
+	// was created meanwhile. Replacing it must be forced, so it is required to still have the expected hash: the
+	// remote then only accepts the update if the reference was not moved since it was checked.
+	if !expected.IsZero() {
+		po.RefSpecs = []config.RefSpec{config.RefSpec(fmt.Sprintf("+%s:%s", refName, refName))}
+		po.RequireRemoteRefs = []config.RefSpec{config.RefSpec(fmt.Sprintf("%s:%s", expected, refName))}
+	}
+
+	err = r.repository.Push(po)
+	if err != nil {
+		return fmt.Errorf("force pushing %q: %w", refName.Short(), err)
+	}
+
+	return nil
+}
+
+// FetchReference fetches a given reference of the previously cloned repository's remote, e.g. a branch left out of
+// the clone, and returns its hash, or a zero hash if the remote does not have it.
+func (r *Remote) FetchReference(refName plumbing.ReferenceName) (plumbing.Hash, error) {
+	ref, err := r.remoteReference(refName)
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("fetching %q: %w", refName.Short(), err)
+	}
+
+	if ref == nil {
+		return plumbing.ZeroHash, nil
+	}
+
+	err = r.repository.Fetch(&git.FetchOptions{
+		RemoteName: r.name,
+		RefSpecs:   []config.RefSpec{config.RefSpec(fmt.Sprintf("+%s:%s", refName, refName))},
+		Auth:       r.auth,
+		Progress:   r.progress,
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return plumbing.ZeroHash, fmt.Errorf("fetching %q: %w", refName.Short(), err)
+	}
+
+	return ref.Hash(), nil
+}
+
+// DeleteTag deletes a given tag from the previously cloned repository and from its remote. A tag missing from the
+// remote is not an error.
+func (r *Remote) DeleteTag(tagName string) error {
+	err := r.repository.DeleteTag(tagName)
+	if err != nil && !errors.Is(err, git.ErrTagNotFound) {
+		return fmt.Errorf("deleting tag %q: %w", tagName, err)
+	}
+
+	po := &git.PushOptions{
+		RemoteName: r.name,
+		RefSpecs:   []config.RefSpec{config.RefSpec(fmt.Sprintf(":refs/tags/%s", tagName))},
+		Auth:       r.auth,
+		Progress:   io.Discard,
+	}
+
+	err = r.repository.Push(po)
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("deleting remote tag %q: %w", tagName, err)
+	}
+
+	return nil
+}
+
+// FetchCommit fetches a commit that is not reachable from the branches and tags of the previously cloned repository,
+// e.g. the detached commit of a pull request. The remote must allow fetching commits by their SHA-1.
+func (r *Remote) FetchCommit(hash plumbing.Hash) error {
+	err := r.repository.Fetch(&git.FetchOptions{
+		RemoteName: r.name,
+		RefSpecs:   []config.RefSpec{config.RefSpec(fmt.Sprintf("%s:refs/semver-release/at", hash))},
+		Auth:       r.auth,
+		Progress:   r.progress,
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("fetching commit %q: %w", hash, err)
+	}
+
+	return nil
+}
+
+// CheckPush checks, without pushing anything, that the previously cloned repository's remote accepts pushes with the
+// current credentials and that none of the given references already exist on it. The references are listed as for a
+// push, which the remote only allows if the credentials can push, e.g. GitHub answers 403 Forbidden to a token
+// without the permission to write contents.
+func (r *Remote) CheckPush(refNames ...plumbing.ReferenceName) error {
+	origin, err := r.repository.Remote(r.name)
+	if err != nil {
+		return fmt.Errorf("fetching remote %q: %w", r.name, err)
+	}
+
+	endpoint, err := transport.NewEndpoint(origin.Config().URLs[0])
+	if err != nil {
+		return fmt.Errorf("parsing remote %q URL: %w", r.name, err)
+	}
+
+	c, err := client.NewClient(endpoint)
+	if err != nil {
+		return fmt.Errorf("creating remote %q client: %w", r.name, err)
+	}
+
+	session, err := c.NewReceivePackSession(endpoint, r.auth)
+	if err != nil {
+		return fmt.Errorf("checking push access: %w", err)
+	}
+
+	defer func() {
+		_ = session.Close()
+	}()
+
+	advertised, err := session.AdvertisedReferences()
+	if err != nil {
+		return fmt.Errorf("checking push access: %w", err)
+	}
+
+	refs, err := advertised.AllReferences()
+	if err != nil {
+		return fmt.Errorf("reading remote references: %w", err)
+	}
+
+	for _, refName := range refNames {
+		if _, ok := refs[refName]; ok {
+			return fmt.Errorf("checking push of %q: %w", refName.Short(), ErrReferenceExists)
+		}
+	}
+
+	return nil
+}
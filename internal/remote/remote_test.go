@@ -1,15 +1,21 @@
 package remote
 
 import (
-	"github.com/go-git/go-git/v5"
-	"github.com/go-git/go-git/v5/plumbing/object"
-	"github.com/s0ders/go-semver-release/v6/internal/tag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
 	assertion "github.com/stretchr/testify/assert"
 
-	"github.com/s0ders/go-semver-release/v6/internal/gittest"
+	"github.com/s0ders/go-semver-release/v6/internal/tag"
+	"github.com/s0ders/go-semver-release/v6/pkg/gittest"
 )
 
 func TestRemote_Clone_HappyScenario(t *testing.T) {
@@ -42,6 +48,38 @@ func TestRemote_Clone_NonExistingPath(t *testing.T) {
 	assert.Error(err)
 }
 
+func TestRemote_Clone_UnsupportedScheme(t *testing.T) {
+	remote := New("origin", "")
+
+	_, err := remote.Clone("ftp://example.com/repo.git")
+	assertion.ErrorIs(t, err, ErrUnsupportedScheme)
+}
+
+func TestRemote_List(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(gittest.Commit("feat"), gittest.Branch("rc"))
+	checkErr(t, err, "creating test repository")
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	refs, err := New("origin", "").List(testRepository.Path)
+	checkErr(t, err, "listing references")
+
+	var names []string
+	for _, ref := range refs {
+		names = append(names, ref.Name().String())
+	}
+
+	assert.Contains(names, "refs/heads/master")
+	assert.Contains(names, "refs/heads/rc")
+
+	_, err = New("origin", "").List(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(err, "should have failed listing a missing repository")
+}
+
 func TestRemote_PushTag(t *testing.T) {
 	assert := assertion.New(t)
 
@@ -79,6 +117,103 @@ func TestRemote_PushTag(t *testing.T) {
 	assert.True(tag.Exists(testRepository.Repository, tagName))
 }
 
+func TestRemote_ForcePushTag(t *testing.T) {
+	assert := assertion.New(t)
+
+	tagName := "v1.0.0"
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, err, "creating test repository")
+
+	defer func() {
+		err = testRepository.Remove()
+		checkErr(t, err, "removing test repository")
+	}()
+
+	firstHash, err := testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit to test repository")
+
+	err = testRepository.AddTag(tagName, firstHash)
+	checkErr(t, err, "adding tag to test repository")
+
+	secondHash, err := testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit to test repository")
+
+	remote := New("origin", "password")
+
+	clonedRepository, err := remote.Clone(testRepository.Path)
+	checkErr(t, err, "cloning repository")
+
+	lease, err := clonedRepository.Tag(tagName)
+	checkErr(t, err, "fetching tag")
+
+	err = clonedRepository.DeleteTag(tagName)
+	checkErr(t, err, "deleting tag")
+
+	_, err = clonedRepository.CreateTag(tagName, secondHash, &git.CreateTagOptions{
+		Message: tagName,
+		Tagger: &object.Signature{
+			Name:  "Go Semver Release",
+			Email: "go-semver@release.ci",
+			When:  time.Now(),
+		},
+	})
+	checkErr(t, err, "recreating tag on cloned repository")
+
+	err = remote.ForcePushTag(tagName, plumbing.ZeroHash)
+	assert.ErrorIs(err, ErrStaleLease, "a tag whose remote hash differs from the lease should not be pushed")
+
+	err = remote.ForcePushTag(tagName, lease.Hash())
+	checkErr(t, err, "force pushing tag")
+
+	ref, err := testRepository.Tag(tagName)
+	checkErr(t, err, "fetching remote tag")
+
+	tagObject, err := testRepository.TagObject(ref.Hash())
+	checkErr(t, err, "fetching remote tag object")
+
+	assert.Equal(secondHash, tagObject.Target, "remote tag should have been moved")
+}
+
+func TestRemote_DeleteTag(t *testing.T) {
+	assert := assertion.New(t)
+
+	tagName := "v1.0.0"
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, err, "creating test repository")
+
+	defer func() {
+		err = testRepository.Remove()
+		checkErr(t, err, "removing test repository")
+	}()
+
+	commitHash, err := testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit to test repository")
+
+	err = testRepository.AddTag(tagName, commitHash)
+	checkErr(t, err, "adding tag to test repository")
+
+	remote := New("origin", "password")
+
+	clonedRepository, err := remote.Clone(testRepository.Path)
+	checkErr(t, err, "cloning repository")
+
+	err = remote.DeleteTag(tagName)
+	checkErr(t, err, "deleting tag")
+
+	exists, err := tag.Exists(clonedRepository, tagName)
+	checkErr(t, err, "checking local tag")
+	assert.False(exists, "tag should have been deleted locally")
+
+	exists, err = tag.Exists(testRepository.Repository, tagName)
+	checkErr(t, err, "checking remote tag")
+	assert.False(exists, "tag should have been deleted from the remote")
+
+	err = remote.DeleteTag(tagName)
+	assert.NoError(err, "deleting a missing tag should not fail")
+}
+
 func TestRemote_PushTag_UnavailableRemote(t *testing.T) {
 	assert := assertion.New(t)
 
@@ -120,3 +255,561 @@ func checkErr(t *testing.T, err error, msg string) {
 		t.Fatalf("%s: %s", msg, err)
 	}
 }
+
+func TestRemote_Lock(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, err, "creating test repository")
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit to test repository")
+
+	first := New("origin", "")
+	_, err = first.Clone(testRepository.Path)
+	checkErr(t, err, "cloning repository")
+
+	second := New("origin", "")
+	_, err = second.Clone(testRepository.Path)
+	checkErr(t, err, "cloning repository")
+
+	lock, err := first.Lock("master", "first", time.Minute)
+	checkErr(t, err, "acquiring lock")
+
+	_, err = second.Lock("master", "second", time.Minute)
+	assert.ErrorIs(err, ErrLocked, "lock should already be held")
+
+	otherLock, err := second.Lock("rc", "second", time.Minute)
+	checkErr(t, err, "acquiring lock on another branch")
+
+	err = lock.Release()
+	checkErr(t, err, "releasing lock")
+
+	_, err = testRepository.Reference("refs/semver-release/lock/master", false)
+	assert.Error(err, "lock reference should have been deleted")
+
+	lock, err = second.Lock("master", "second", time.Minute)
+	checkErr(t, err, "acquiring released lock")
+
+	checkErr(t, lock.Release(), "releasing lock")
+	checkErr(t, otherLock.Release(), "releasing lock")
+}
+
+func TestRemote_Lock_Expired(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, err, "creating test repository")
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit to test repository")
+
+	first := New("origin", "")
+	_, err = first.Clone(testRepository.Path)
+	checkErr(t, err, "cloning repository")
+
+	second := New("origin", "")
+	_, err = second.Clone(testRepository.Path)
+	checkErr(t, err, "cloning repository")
+
+	abandonedLock, err := first.Lock("master", "first", -time.Minute)
+	checkErr(t, err, "acquiring lock")
+
+	lock, err := second.Lock("master", "second", time.Minute)
+	checkErr(t, err, "taking over expired lock")
+
+	checkErr(t, abandonedLock.Release(), "releasing lock taken over")
+
+	ref, err := testRepository.Reference("refs/semver-release/lock/master", false)
+	checkErr(t, err, "lock taken over should not have been released by its previous owner")
+
+	assert.Equal(lock.hash, ref.Hash())
+}
+
+func TestRemote_Lock_TakenOverBeforeRelease(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, err, "creating test repository")
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	head, err := testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit to test repository")
+
+	remote := New("origin", "")
+	_, err = remote.Clone(testRepository.Path)
+	checkErr(t, err, "cloning repository")
+
+	lock, err := remote.Lock("master", "first", time.Minute)
+	checkErr(t, err, "acquiring lock")
+
+	// Another process takes the lock over on the remote before it is released.
+	refName := plumbing.ReferenceName("refs/semver-release/lock/master")
+
+	err = testRepository.Storer.SetReference(plumbing.NewHashReference(refName, head))
+	checkErr(t, err, "taking over lock")
+
+	checkErr(t, lock.Release(), "releasing lock taken over")
+
+	ref, err := testRepository.Reference(refName, false)
+	checkErr(t, err, "lock taken over should not have been deleted")
+
+	assert.Equal(head, ref.Hash())
+}
+
+func TestRemote_Lock_Concurrent(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(gittest.Commit("feat"), gittest.Serve())
+	checkErr(t, err, "creating test repository")
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	remotes := make([]*Remote, 2)
+	for i := range remotes {
+		remotes[i] = New("origin", "")
+		_, err = remotes[i].Clone(testRepository.RemoteURL)
+		checkErr(t, err, "cloning repository")
+	}
+
+	race := func(branch string) []error {
+		errs := make([]error, len(remotes))
+
+		var wg sync.WaitGroup
+		for i, remote := range remotes {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, errs[i] = remote.Lock(branch, fmt.Sprintf("run-%d", i), time.Minute)
+			}()
+		}
+		wg.Wait()
+
+		return errs
+	}
+
+	checkRace := func(errs []error, msg string) {
+		var acquired int
+		for _, err := range errs {
+			if err == nil {
+				acquired++
+				continue
+			}
+			assert.ErrorIs(err, ErrLocked, msg)
+		}
+		assert.Equal(1, acquired, msg)
+	}
+
+	checkRace(race("master"), "exactly one run should create the lock")
+
+	_, err = remotes[0].Lock("rc", "abandoned", -time.Minute)
+	checkErr(t, err, "acquiring expired lock")
+
+	checkRace(race("rc"), "exactly one run should take over the expired lock")
+}
+
+func TestRemote_ForcePushTag_Concurrent(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(gittest.Commit("fix"), gittest.Tag("v1.0.0"), gittest.Commit("feat"), gittest.Serve())
+	checkErr(t, err, "creating test repository")
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	lease, err := testRepository.Tag("v1.0.0")
+	checkErr(t, err, "fetching tag")
+
+	remotes := make([]*Remote, 8)
+	for i := range remotes {
+		remotes[i] = New("origin", "")
+
+		cloned, err := remotes[i].Clone(testRepository.RemoteURL)
+		checkErr(t, err, "cloning repository")
+
+		head, err := cloned.Head()
+		checkErr(t, err, "fetching head")
+
+		err = cloned.DeleteTag("v1.0.0")
+		checkErr(t, err, "deleting tag")
+
+		// Each run creates different tag objects, so that the remote tags tell which run pushed them.
+		for _, name := range []string{"v1.0.0", "v2.0.0"} {
+			_, err = cloned.CreateTag(name, head.Hash(), &git.CreateTagOptions{
+				Message: fmt.Sprintf("run-%d", i),
+				Tagger:  &object.Signature{Name: "Go Semver Release", Email: "go-semver@release.ci", When: time.Now()},
+			})
+			checkErr(t, err, "creating tag")
+		}
+	}
+
+	race := func(name string, expected plumbing.Hash) int {
+		errs := make([]error, len(remotes))
+
+		var wg sync.WaitGroup
+		for i, remote := range remotes {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs[i] = remote.ForcePushTag(name, expected)
+			}()
+		}
+		wg.Wait()
+
+		var pushed int
+		for _, err := range errs {
+			if err == nil {
+				pushed++
+			}
+		}
+
+		return pushed
+	}
+
+	assert.Equal(1, race("v1.0.0", lease.Hash()), "exactly one run should move the tag")
+	assert.Equal(1, race("v2.0.0", plumbing.ZeroHash), "exactly one run should create the tag")
+}
+
+func TestRemote_Refresh(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, err, "creating test repository")
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit to test repository")
+
+	remote := New("origin", "")
+	clonedRepository, err := remote.Clone(testRepository.Path)
+	checkErr(t, err, "cloning repository")
+
+	hash, err := testRepository.AddCommit("feat")
+	checkErr(t, err, "adding commit to test repository")
+
+	err = testRepository.AddTag("v1.0.0", hash)
+	checkErr(t, err, "adding tag to test repository")
+
+	checkErr(t, remote.Refresh(), "refreshing repository")
+
+	assert.True(tag.Exists(clonedRepository, "v1.0.0"))
+
+	ref, err := clonedRepository.Reference("refs/remotes/origin/master", true)
+	checkErr(t, err, "reading remote branch")
+
+	assert.Equal(hash, ref.Hash())
+}
+
+func TestRemote_CloneCached(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, err, "creating test repository")
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	hash, err := testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit to test repository")
+
+	err = testRepository.AddTag("v0.1.0", hash)
+	checkErr(t, err, "adding tag to test repository")
+
+	cacheDir := t.TempDir()
+
+	clonedRepository, err := New("origin", "").CloneCached(testRepository.Path, cacheDir)
+	checkErr(t, err, "cloning repository to cache")
+
+	assert.True(tag.Exists(clonedRepository, "v0.1.0"))
+
+	// A tag created by a run but never pushed should not be seen by the next one.
+	err = createTag(clonedRepository, "v0.2.0", hash)
+	checkErr(t, err, "creating unpushed tag")
+
+	hash, err = testRepository.AddCommit("feat")
+	checkErr(t, err, "adding commit to test repository")
+
+	err = testRepository.AddTag("v1.0.0", hash)
+	checkErr(t, err, "adding tag to test repository")
+
+	clonedRepository, err = New("origin", "").CloneCached(testRepository.Path, cacheDir)
+	checkErr(t, err, "updating cached repository")
+
+	exists, err := tag.Exists(clonedRepository, "v1.0.0")
+	checkErr(t, err, "checking tag existence")
+	assert.True(exists, "new tags should be fetched")
+
+	exists, err = tag.Exists(clonedRepository, "v0.2.0")
+	checkErr(t, err, "checking tag existence")
+	assert.False(exists, "tags missing from the remote should be pruned")
+
+	ref, err := clonedRepository.Reference("refs/remotes/origin/master", true)
+	checkErr(t, err, "reading remote branch")
+
+	assert.Equal(hash, ref.Hash(), "new commits should be fetched")
+
+	entries, err := os.ReadDir(cacheDir)
+	checkErr(t, err, "reading cache directory")
+
+	assert.Len(entries, 1, "the repository should be cloned once")
+}
+
+func TestRemote_CloneTo_Branches(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(
+		gittest.Commit("feat"),
+		gittest.Tag("v0.1.0"),
+		gittest.Branch("rc"),
+		gittest.Commit("fix"),
+		gittest.Branch("feature"),
+		gittest.Commit("feat"),
+		gittest.Checkout("master"),
+	)
+	checkErr(t, err, "creating test repository")
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	origin := New("origin", "", WithBranches("master", "rc", "missing"))
+
+	clonedRepository, err := origin.CloneTo(testRepository.Path, t.TempDir())
+	checkErr(t, err, "cloning repository")
+
+	for _, branch := range []string{"master", "rc"} {
+		_, err = clonedRepository.Reference(plumbing.NewRemoteReferenceName("origin", branch), true)
+		assert.NoError(err, "branch %q should have been fetched", branch)
+	}
+
+	_, err = clonedRepository.Reference(plumbing.NewRemoteReferenceName("origin", "feature"), true)
+	assert.ErrorIs(err, plumbing.ErrReferenceNotFound, "other branches should not be fetched")
+
+	exists, err := tag.Exists(clonedRepository, "v0.1.0")
+	checkErr(t, err, "checking tag existence")
+	assert.True(exists, "tags should be fetched")
+
+	err = origin.Refresh()
+	assert.NoError(err, "refreshing should ignore missing branches")
+
+	cacheDir := t.TempDir()
+
+	_, err = New("origin", "", WithBranches("rc")).CloneCached(testRepository.Path, cacheDir)
+	checkErr(t, err, "cloning repository to cache")
+
+	clonedRepository, err = New("origin", "", WithBranches("rc")).CloneCached(testRepository.Path, cacheDir)
+	checkErr(t, err, "updating cached repository")
+
+	_, err = clonedRepository.Reference(plumbing.NewRemoteReferenceName("origin", "master"), true)
+	assert.ErrorIs(err, plumbing.ErrReferenceNotFound, "other branches should not be fetched")
+}
+
+func TestRemote_CloneCached_NonExistingPath(t *testing.T) {
+	assert := assertion.New(t)
+
+	cacheDir := t.TempDir()
+
+	_, err := New("origin", "").CloneCached(filepath.Join(cacheDir, "missing"), cacheDir)
+	assert.Error(err)
+
+	entries, err := os.ReadDir(cacheDir)
+	checkErr(t, err, "reading cache directory")
+
+	assert.Empty(entries, "a failed clone should not be kept")
+}
+
+func TestRemote_HTTP_CloneAndPushTag(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(gittest.Commit("feat"), gittest.Serve(gittest.WithBasicAuth("go-semver-release", "token")))
+	checkErr(t, err, "creating test repository")
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	remote := New("origin", "token")
+
+	clonedRepository, err := remote.Clone(testRepository.RemoteURL)
+	checkErr(t, err, "cloning repository over HTTP")
+
+	head, err := clonedRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	err = createTag(clonedRepository, "v0.1.0", head.Hash())
+	checkErr(t, err, "creating tag")
+
+	err = remote.PushTag("v0.1.0")
+	checkErr(t, err, "pushing tag over HTTP")
+
+	assert.True(tag.Exists(testRepository.Origin.Repository, "v0.1.0"))
+}
+
+func TestRemote_HTTP_WrongToken(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(gittest.Serve(gittest.WithBasicAuth("go-semver-release", "token")))
+	checkErr(t, err, "creating test repository")
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	_, err = New("origin", "wrong").Clone(testRepository.RemoteURL)
+	assert.ErrorIs(err, transport.ErrAuthenticationRequired)
+}
+
+func TestRemote_HTTP_TransientFailure(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(gittest.Serve(gittest.WithFailures(1)))
+	checkErr(t, err, "creating test repository")
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	remote := New("origin", "")
+
+	_, err = remote.Clone(testRepository.RemoteURL)
+	assert.Error(err, "clone should fail while the server is unavailable")
+
+	_, err = remote.Clone(testRepository.RemoteURL)
+	assert.NoError(err, "clone should succeed once the server is available again")
+}
+
+func TestRemote_HTTP_RejectedPush(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(gittest.Serve(gittest.WithReadOnly()))
+	checkErr(t, err, "creating test repository")
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	remote := New("origin", "")
+
+	clonedRepository, err := remote.Clone(testRepository.RemoteURL)
+	checkErr(t, err, "cloning repository over HTTP")
+
+	head, err := clonedRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	err = createTag(clonedRepository, "v0.1.0", head.Hash())
+	checkErr(t, err, "creating tag")
+
+	err = remote.PushTag("v0.1.0")
+	assert.ErrorIs(err, transport.ErrAuthorizationFailed)
+
+	exists, err := tag.Exists(testRepository.Origin.Repository, "v0.1.0")
+	checkErr(t, err, "checking tag existence")
+	assert.False(exists, "rejected tag should not exist on the remote")
+}
+
+func TestRemote_HTTP_ConflictingTag(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(gittest.Serve())
+	checkErr(t, err, "creating test repository")
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	remote := New("origin", "")
+
+	clonedRepository, err := remote.Clone(testRepository.RemoteURL)
+	checkErr(t, err, "cloning repository over HTTP")
+
+	// Another process releases the same version on a new commit meanwhile.
+	err = testRepository.Apply(gittest.RemoteCommit("master", "feat"))
+	checkErr(t, err, "adding remote commit")
+
+	originHead, err := testRepository.Origin.Head()
+	checkErr(t, err, "fetching origin head")
+
+	err = testRepository.Origin.AddTag("v0.1.0", originHead.Hash())
+	checkErr(t, err, "adding remote tag")
+
+	head, err := clonedRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	err = createTag(clonedRepository, "v0.1.0", head.Hash())
+	checkErr(t, err, "creating tag")
+
+	err = remote.PushTag("v0.1.0")
+	assert.Error(err, "pushing a tag that already exists on the remote should be rejected")
+}
+
+func TestRemote_CheckPush(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(gittest.Commit("feat"), gittest.Tag("v0.1.0"))
+	checkErr(t, err, "creating test repository")
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	remote := New("origin", "")
+
+	_, err = remote.Clone(testRepository.Path)
+	checkErr(t, err, "cloning repository")
+
+	err = remote.CheckPush(plumbing.NewTagReferenceName("v0.2.0"))
+	assert.NoError(err, "a missing tag should be accepted")
+
+	err = remote.CheckPush(plumbing.NewTagReferenceName("v0.2.0"), plumbing.NewTagReferenceName("v0.1.0"))
+	assert.ErrorIs(err, ErrReferenceExists)
+}
+
+func TestRemote_HTTP_CheckPush(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(gittest.Commit("feat"), gittest.Serve(gittest.WithReadOnly()))
+	checkErr(t, err, "creating test repository")
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	remote := New("origin", "")
+
+	_, err = remote.Clone(testRepository.RemoteURL)
+	checkErr(t, err, "cloning repository over HTTP")
+
+	err = remote.CheckPush(plumbing.NewTagReferenceName("v0.1.0"))
+	assert.ErrorIs(err, transport.ErrAuthorizationFailed)
+}
+
+func createTag(repository *git.Repository, name string, hash plumbing.Hash) error {
+	_, err := repository.CreateTag(name, hash, &git.CreateTagOptions{
+		Message: name,
+		Tagger: &object.Signature{
+			Name:  "Go Semver Release",
+			Email: "go-semver@release.ci",
+			When:  time.Now(),
+		},
+	})
+
+	return err
+}
@@ -0,0 +1,125 @@
+package remote
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+var (
+	ErrEmptyURL          = errors.New("empty repository URL")
+	ErrUnsupportedScheme = errors.New("unsupported repository URL scheme")
+)
+
+// schemes are the supported URL schemes of repositories and their default port, aliases being mapped to their scheme.
+var schemes = map[string]struct {
+	name string
+	port string
+}{
+	"http":    {"http", "80"},
+	"https":   {"https", "443"},
+	"ssh":     {"ssh", "22"},
+	"git+ssh": {"ssh", "22"},
+	"ssh+git": {"ssh", "22"},
+	"git":     {"git", "9418"},
+	"file":    {"file", ""},
+}
+
+// scpLikeURL matches the scp-like syntax of SSH URLs, such as "git@github.com:org/repo.git". A single letter host is
+// not matched, so that Windows paths such as "C:\repo" are not mistaken for it.
+var scpLikeURL = regexp.MustCompile(`^(?:([^@/\\\s]+)@)?([^@:/\\\s]{2,}):([^/\\].*|/.+)$`)
+
+// hostProfile converts internationalized domain names to their ASCII form, without enforcing the rules of DNS names
+// so that hosts such as SSH aliases are kept as they are.
+var hostProfile = idna.New(idna.MapForLookup(), idna.StrictDomainName(false), idna.Transitional(false))
+
+// NormalizeURL returns the canonical form of the URL or path of a repository, so that equivalent URLs designate the
+// same remote: the scheme and host are lowercased, internationalized hosts are converted to their ASCII form, default
+// ports and trailing slashes are removed. The scp-like syntax is kept, since its path is relative to the home
+// directory of the user whereas the path of an SSH URL is absolute. Local paths are returned as they are.
+func NormalizeURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+
+	if raw == "" {
+		return "", ErrEmptyURL
+	}
+
+	if strings.Contains(raw, "://") {
+		return normalizeURL(raw)
+	}
+
+	if m := scpLikeURL.FindStringSubmatch(raw); m != nil {
+		host, err := normalizeHost(m[2])
+		if err != nil {
+			return "", err
+		}
+
+		normalized := host + ":" + strings.TrimRight(m[3], "/")
+		if m[1] != "" {
+			normalized = m[1] + "@" + normalized
+		}
+
+		return normalized, nil
+	}
+
+	return raw, nil
+}
+
+// IsHTTP returns whether a normalized URL is an HTTP(S) URL, the only ones authenticated with the access token.
+func IsHTTP(normalized string) bool {
+	return strings.HasPrefix(normalized, "http://") || strings.HasPrefix(normalized, "https://")
+}
+
+func normalizeURL(raw string) (string, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("parsing repository URL: %w", err)
+	}
+
+	scheme, ok := schemes[strings.ToLower(u.Scheme)]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedScheme, u.Scheme)
+	}
+
+	u.Scheme = scheme.name
+
+	if scheme.name == "file" {
+		return u.String(), nil
+	}
+
+	if u.Hostname() == "" {
+		return "", fmt.Errorf("parsing repository URL %q: missing host", raw)
+	}
+
+	host, err := normalizeHost(u.Hostname())
+	if err != nil {
+		return "", err
+	}
+
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+
+	if port := u.Port(); port != "" && port != scheme.port {
+		host += ":" + port
+	}
+
+	u.Host = host
+	u.Path = strings.TrimRight(u.Path, "/")
+	u.RawPath = ""
+
+	return u.String(), nil
+}
+
+func normalizeHost(host string) (string, error) {
+	ascii, err := hostProfile.ToASCII(strings.ToLower(host))
+	if err != nil {
+		return "", fmt.Errorf("normalizing host %q: %w", host, err)
+	}
+
+	return ascii, nil
+}
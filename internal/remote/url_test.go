@@ -0,0 +1,63 @@
+package remote
+
+import (
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeURL(t *testing.T) {
+	assert := assertion.New(t)
+
+	type test struct {
+		raw  string
+		want string
+	}
+
+	tests := []test{
+		{raw: " https://GitHub.com/org/repo.git/ ", want: "https://github.com/org/repo.git"},
+		{raw: "HTTPS://github.com:443/org/repo", want: "https://github.com/org/repo"},
+		{raw: "http://git.example.com:8080/org/repo", want: "http://git.example.com:8080/org/repo"},
+		{raw: "https://Bücher.example/org/repo.git", want: "https://xn--bcher-kva.example/org/repo.git"},
+		{raw: "https://[::1]:8080/repo", want: "https://[::1]:8080/repo"},
+		{raw: "ssh://git@GitHub.com:22/org/repo.git", want: "ssh://git@github.com/org/repo.git"},
+		{raw: "git+ssh://git@github.com:2222/org/repo.git", want: "ssh://git@github.com:2222/org/repo.git"},
+		{raw: "git://github.com/org/repo.git", want: "git://github.com/org/repo.git"},
+		{raw: "file:///srv/git/repo.git", want: "file:///srv/git/repo.git"},
+		{raw: "git@GitHub.com:org/repo.git", want: "git@github.com:org/repo.git"},
+		{raw: "git@bücher.example:org/repo.git/", want: "git@xn--bcher-kva.example:org/repo.git"},
+		{raw: "gitlab:repo.git", want: "gitlab:repo.git"},
+		{raw: "./repo", want: "./repo"},
+		{raw: "/srv/git/repo.git", want: "/srv/git/repo.git"},
+		{raw: `C:\repo`, want: `C:\repo`},
+	}
+
+	for _, tc := range tests {
+		got, err := NormalizeURL(tc.raw)
+		assert.NoError(err, "should not have errored normalizing %q", tc.raw)
+		assert.Equal(tc.want, got)
+	}
+}
+
+func TestNormalizeURL_Invalid(t *testing.T) {
+	assert := assertion.New(t)
+
+	_, err := NormalizeURL("ftp://example.com/repo.git")
+	assert.ErrorIs(err, ErrUnsupportedScheme)
+
+	_, err = NormalizeURL("  ")
+	assert.ErrorIs(err, ErrEmptyURL)
+
+	_, err = NormalizeURL("https:///org/repo.git")
+	assert.Error(err, "should have errored, missing host")
+}
+
+func TestIsHTTP(t *testing.T) {
+	assert := assertion.New(t)
+
+	assert.True(IsHTTP("https://github.com/org/repo.git"))
+	assert.True(IsHTTP("http://github.com/org/repo.git"))
+	assert.False(IsHTTP("git@github.com:org/repo.git"))
+	assert.False(IsHTTP("ssh://git@github.com/org/repo.git"))
+	assert.False(IsHTTP("./repo"))
+}
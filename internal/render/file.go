@@ -0,0 +1,117 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/template"
+)
+
+var (
+	ErrNoTemplate = errors.New("no template in render configuration")
+	ErrNoOutput   = errors.New("no output in render configuration")
+)
+
+// File is a template file rendered with the version of every new release, e.g. "version.go.tmpl" rendered to
+// "version.go", so that generated source files match the released version.
+type File struct {
+	// Template is the path of the template file.
+	Template string
+	// Output is the path of the rendered file, which can be a template using the FileData, e.g. "{{.Project}}/version.go".
+	Output string
+	// Project, if set, restricts the rendering to the releases of the given project in monorepo mode.
+	Project string
+}
+
+// FileData is the data of the rendered files and of their output path.
+type FileData struct {
+	// Tag is the name of the tag, e.g. "api-v1.2.3".
+	Tag string
+	// Version is the semantic version of the release, e.g. "1.2.3".
+	Version string
+	// Major, Minor and Patch are the components of the semantic version.
+	Major, Minor, Patch int
+	// Prerelease is the prerelease identifier of the semantic version, if any, e.g. "rc.1".
+	Prerelease string
+	// Branch is the name of the released branch.
+	Branch string
+	// Project is the name of the released project in monorepo mode, empty otherwise.
+	Project string
+	// Commit is the hash of the released commit.
+	Commit string
+}
+
+// UnmarshallFiles takes a raw Viper configuration and returns a slice of File representing a render configuration.
+func UnmarshallFiles(input []map[string]string) ([]File, error) {
+	files := make([]File, len(input))
+
+	for i, f := range input {
+		file := File{Template: f["template"], Output: f["output"], Project: f["project"]}
+
+		if file.Template == "" {
+			return nil, ErrNoTemplate
+		}
+
+		if file.Output == "" {
+			return nil, fmt.Errorf("rendering %q: %w", file.Template, ErrNoOutput)
+		}
+
+		files[i] = file
+	}
+
+	return files, nil
+}
+
+// Matches returns whether the file is rendered for the releases of the given project.
+func (f File) Matches(project string) bool {
+	return f.Project == "" || f.Project == project
+}
+
+// Render renders the template file and its output path with the given data, and returns the path and the content of
+// the rendered file.
+func (f File) Render(data FileData) (string, []byte, error) {
+	output, err := Inline(f.Output, data)
+	if err != nil {
+		return "", nil, fmt.Errorf("rendering output path of %q: %w", f.Template, err)
+	}
+
+	tmpl, err := template.New(filepath.Base(f.Template)).Option("missingkey=error").ParseFiles(f.Template)
+	if err != nil {
+		return "", nil, fmt.Errorf("parsing template %q: %w", f.Template, err)
+	}
+
+	var b bytes.Buffer
+
+	if err = tmpl.Execute(&b, data); err != nil {
+		return "", nil, fmt.Errorf("executing template %q: %w", f.Template, err)
+	}
+
+	return output, b.Bytes(), nil
+}
+
+// Write renders the template file with the given data and writes it to its output path, creating the missing parent
+// directories. The rendered file keeps the permissions of the template, e.g. so that rendered scripts are executable.
+// It returns the path and the content of the rendered file.
+func (f File) Write(data FileData) (string, []byte, error) {
+	info, err := os.Stat(f.Template)
+	if err != nil {
+		return "", nil, fmt.Errorf("reading template %q: %w", f.Template, err)
+	}
+
+	output, content, err := f.Render(data)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(output), 0o755); err != nil {
+		return "", nil, fmt.Errorf("creating directory of %q: %w", output, err)
+	}
+
+	if err = os.WriteFile(output, content, info.Mode().Perm()); err != nil {
+		return "", nil, fmt.Errorf("writing rendered file %q: %w", output, err)
+	}
+
+	return output, content, nil
+}
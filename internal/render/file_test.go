@@ -0,0 +1,85 @@
+package render
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestFile_UnmarshallFiles(t *testing.T) {
+	assert := assertion.New(t)
+
+	files, err := UnmarshallFiles([]map[string]string{
+		{"template": "version.go.tmpl", "output": "version.go"},
+		{"template": "api/version.txt.tmpl", "output": "api/version.txt", "project": "api"},
+	})
+	checkErr(t, "unmarshalling files", err)
+
+	assert.Equal([]File{
+		{Template: "version.go.tmpl", Output: "version.go"},
+		{Template: "api/version.txt.tmpl", Output: "api/version.txt", Project: "api"},
+	}, files)
+
+	_, err = UnmarshallFiles([]map[string]string{{"output": "version.go"}})
+	assert.ErrorIs(err, ErrNoTemplate, "files without template should be rejected")
+
+	_, err = UnmarshallFiles([]map[string]string{{"template": "version.go.tmpl"}})
+	assert.ErrorIs(err, ErrNoOutput, "files without output should be rejected")
+}
+
+func TestFile_Matches(t *testing.T) {
+	assert := assertion.New(t)
+
+	assert.True(File{}.Matches(""), "files without project should match every release")
+	assert.True(File{}.Matches("api"), "files without project should match every release")
+	assert.True(File{Project: "api"}.Matches("api"), "files should match the releases of their project")
+	assert.False(File{Project: "api"}.Matches("web"), "files should not match the releases of other projects")
+}
+
+func TestFile_Write(t *testing.T) {
+	assert := assertion.New(t)
+
+	dir := t.TempDir()
+	templatePath := filepath.Join(dir, "version.sh.tmpl")
+
+	err := os.WriteFile(templatePath, []byte("echo {{ .Version }} {{ .Major }}.{{ .Minor }}.{{ .Patch }} {{ .Tag }}"), 0o755)
+	checkErr(t, "writing template", err)
+
+	file := File{Template: templatePath, Output: filepath.Join(dir, "{{ .Project }}", "version.sh")}
+
+	output, rendered, err := file.Write(FileData{Tag: "api-v1.2.3", Version: "1.2.3", Major: 1, Minor: 2, Patch: 3, Project: "api"})
+	checkErr(t, "writing file", err)
+
+	assert.Equal(filepath.Join(dir, "api", "version.sh"), output, "output path should be rendered")
+
+	content, err := os.ReadFile(output)
+	checkErr(t, "reading rendered file", err)
+
+	assert.Equal("echo 1.2.3 1.2.3 api-v1.2.3", string(content))
+	assert.Equal(content, rendered, "the rendered content should be returned")
+
+	info, err := os.Stat(output)
+	checkErr(t, "reading rendered file info", err)
+
+	assert.Equal(os.FileMode(0o755), info.Mode().Perm(), "rendered file should keep the permissions of the template")
+}
+
+func TestFile_Write_Errors(t *testing.T) {
+	assert := assertion.New(t)
+
+	dir := t.TempDir()
+
+	_, _, err := File{Template: filepath.Join(dir, "missing.tmpl"), Output: filepath.Join(dir, "out")}.Write(FileData{})
+	assert.ErrorContains(err, "reading template", "missing templates should be reported")
+
+	templatePath := filepath.Join(dir, "version.tmpl")
+	writeTemplate(t, templatePath, "{{ .Versoin }}")
+
+	_, _, err = File{Template: templatePath, Output: filepath.Join(dir, "out")}.Write(FileData{})
+	assert.ErrorContains(err, "executing template", "unknown fields should be reported")
+
+	_, _, err = File{Template: templatePath, Output: "{{ .Projet }}"}.Write(FileData{})
+	assert.ErrorContains(err, "rendering output path", "unknown fields of the output path should be reported")
+}
@@ -0,0 +1,32 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderFlag_String(t *testing.T) {
+	flag := Flag{{"template": "version.go.tmpl", "output": "version.go"}}
+
+	var emptyFlag Flag
+
+	assert.Equal(t, "[{\"output\":\"version.go\",\"template\":\"version.go.tmpl\"}]", flag.String())
+	assert.Equal(t, "[]", emptyFlag.String())
+}
+
+func TestRenderFlag_Set(t *testing.T) {
+	var flag Flag
+
+	err := flag.Set("[{\"template\": \"version.go.tmpl\", \"output\": \"version.go\"}]")
+	assert.NoError(t, err, "should not have errored")
+
+	err = flag.Set("{\"template\": \"version.go.tmpl\"}")
+	assert.Error(t, err, "should have errored, invalid JSON string")
+}
+
+func TestRenderFlag_Type(t *testing.T) {
+	var f Flag
+
+	assert.Equal(t, FlagType, f.Type())
+}
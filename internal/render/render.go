@@ -0,0 +1,261 @@
+// Package render renders the artifacts produced along a release, such as tag messages and changelogs, from text
+// templates. Built-in templates can be overridden by files with the same name in a templates directory.
+package render
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Names of the templates, which are also the names of the files overriding them in a templates directory.
+const (
+	TagMessage     = "tag-message.tmpl"
+	Changelog      = "changelog.md.tmpl"
+	ReleaseSummary = "release-summary.md.tmpl"
+)
+
+var ErrUnknownTemplate = errors.New("unknown template")
+
+//go:embed templates/*.tmpl
+var builtins embed.FS
+
+// TagMessageData is the data of the TagMessage template, rendered as the message of annotated tags.
+type TagMessageData struct {
+	// Tag is the name of the tag, e.g. "api-v1.2.3".
+	Tag string
+	// Version is the semantic version of the release, e.g. "1.2.3".
+	Version string
+	// Branch is the name of the released branch.
+	Branch string
+	// Project is the name of the released project in monorepo mode, empty otherwise.
+	Project string
+	// Commit is the hash of the tagged commit.
+	Commit string
+}
+
+// ChangelogData is the data of the Changelog template, rendered as the Markdown changelog of a release.
+type ChangelogData struct {
+	// Version is the semantic version of the release, e.g. "1.2.3".
+	Version string
+	// Date is the date of the release.
+	Date time.Time
+	// Sections are the non-empty Keep a Changelog sections of the release, in their conventional order.
+	Sections []ChangelogSection
+	// Deprecations are the deprecations announced by the "DEPRECATED:" footers of the commits of the release.
+	Deprecations []ChangelogDeprecation
+	// Contributors are the unique authors and co-authors of the changes of the release, in order of first appearance.
+	Contributors []ChangelogContributor
+}
+
+// ChangelogContributor is an author or a co-author of the changes of a release.
+type ChangelogContributor struct {
+	// Name is the name of the contributor, as written in the commits.
+	Name string
+	// Email is the email of the contributor, as written in the commits.
+	Email string
+	// Handle is the name of the forge account of the contributor, e.g. "octocat", if contributor handles are enabled
+	// and the account was found.
+	Handle string
+}
+
+// ChangelogSection is a section of a changelog, such as "Added" or "Fixed".
+type ChangelogSection struct {
+	// Title is the name of the section.
+	Title string
+	// Entries are the changes listed in the section, from the oldest to the most recent.
+	Entries []ChangelogEntry
+}
+
+// ChangelogEntry is a change listed in a changelog, made by a conventional commit.
+type ChangelogEntry struct {
+	// Type is the conventional commit type, e.g. "feat".
+	Type string
+	// Scope is the conventional commit scope, if any.
+	Scope string
+	// Subject is the description of the change.
+	Subject string
+	// Breaking tells whether the change is a breaking change.
+	Breaking bool
+	// Hash is the hash of the commit making the change.
+	Hash string
+	// ShortHash is the abbreviated hash of the commit making the change.
+	ShortHash string
+	// CommitURL is the URL of the page of the commit making the change, if links are configured.
+	CommitURL string
+	// PullRequest is the number of the pull request of the change, given by a "(#123)" suffix of its subject which is
+	// then removed from the subject, or 0.
+	PullRequest int
+	// PullRequestURL is the URL of the page of the pull request of the change, if links are configured.
+	PullRequestURL string
+}
+
+// ChangelogDeprecation is a deprecation announced by the footer of a commit, e.g. "DEPRECATED: use v2 endpoints".
+type ChangelogDeprecation struct {
+	// Scope is the conventional commit scope of the commit announcing the deprecation, if any.
+	Scope string
+	// Text is the description of the deprecation.
+	Text string
+	// Hash is the hash of the commit announcing the deprecation.
+	Hash string
+	// ShortHash is the abbreviated hash of the commit announcing the deprecation.
+	ShortHash string
+	// CommitURL is the URL of the page of the commit announcing the deprecation, if links are configured.
+	CommitURL string
+}
+
+// ReleaseSummaryData is the data of the ReleaseSummary template, rendered as the summary of all the releases of a run.
+type ReleaseSummaryData struct {
+	// Date is the date of the run.
+	Date time.Time
+	// Releases are the new releases of the run, in the order of the command output.
+	Releases []ReleaseSummaryEntry
+	// Preview tells that the run is a dry-run not pushing its tags, hence that the releases have not been made yet.
+	Preview bool
+	// Counts, if set, count the outcomes of all the branches and projects of the run, including the ones left out of
+	// Releases.
+	Counts *ReleaseCounts
+}
+
+// ReleaseCounts count the branches and projects of a run by outcome.
+type ReleaseCounts struct {
+	// Released counts the new releases, made or previewed by a dry-run.
+	Released int
+	// Unreleased counts the branches and projects without new release, including releases held by a policy.
+	Unreleased int
+	// Skipped counts the releases skipped by a commit marker or because a dependency failed to be released.
+	Skipped int
+	// Failed counts the branches and projects whose version could not be computed or whose release failed.
+	Failed int
+}
+
+// ReleaseSummaryEntry is a new release listed in a release summary.
+type ReleaseSummaryEntry struct {
+	// Project is the name of the released project in monorepo mode, empty otherwise.
+	Project string
+	// Branch is the name of the released branch.
+	Branch string
+	// Version is the semantic version of the release, e.g. "1.2.3".
+	Version string
+	// Tag is the name of the tag of the release, e.g. "api-v1.2.3".
+	Tag string
+	// Sections are the non-empty changelog sections of the release, as in ChangelogData.
+	Sections []ChangelogSection
+	// Deprecations are the deprecations announced by the release, as in ChangelogData.
+	Deprecations []ChangelogDeprecation
+	// Contributors are the contributors of the release, as in ChangelogData.
+	Contributors []ChangelogContributor
+}
+
+// Renderer renders templates by name.
+type Renderer struct {
+	templates map[string]*template.Template
+}
+
+// New returns a renderer of the built-in templates, overridden by the templates found in the given directory if any.
+// A template file whose name is not one of a built-in template is rejected so that typos do not go unnoticed.
+func New(dir string) (*Renderer, error) {
+	r := &Renderer{templates: make(map[string]*template.Template)}
+
+	entries, err := builtins.ReadDir("templates")
+	if err != nil {
+		return nil, fmt.Errorf("reading built-in templates: %w", err)
+	}
+
+	for _, entry := range entries {
+		content, err := builtins.ReadFile("templates/" + entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("reading built-in template %q: %w", entry.Name(), err)
+		}
+
+		if err = r.parse(entry.Name(), string(content)); err != nil {
+			return nil, err
+		}
+	}
+
+	if dir == "" {
+		return r, nil
+	}
+
+	overrides, err := filepath.Glob(filepath.Join(dir, "*.tmpl"))
+	if err != nil {
+		return nil, fmt.Errorf("listing templates: %w", err)
+	}
+
+	for _, path := range overrides {
+		name := filepath.Base(path)
+
+		if _, ok := r.templates[name]; !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownTemplate, name)
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading template %q: %w", name, err)
+		}
+
+		if err = r.parse(name, string(content)); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+func (r *Renderer) parse(name, content string) error {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(content)
+	if err != nil {
+		return fmt.Errorf("parsing template %q: %w", name, err)
+	}
+
+	r.templates[name] = tmpl
+
+	return nil
+}
+
+// Render writes the named template executed with the given data.
+func (r *Renderer) Render(w io.Writer, name string, data any) error {
+	tmpl, ok := r.templates[name]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownTemplate, name)
+	}
+
+	if err := tmpl.Execute(w, data); err != nil {
+		return fmt.Errorf("executing template %q: %w", name, err)
+	}
+
+	return nil
+}
+
+// Inline returns the given template text, e.g. a template given on the command line, executed with the given data.
+func Inline(text string, data any) (string, error) {
+	tmpl, err := template.New("inline").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("parsing template %q: %w", text, err)
+	}
+
+	var b strings.Builder
+
+	if err = tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("executing template %q: %w", text, err)
+	}
+
+	return b.String(), nil
+}
+
+// String returns the named template executed with the given data.
+func (r *Renderer) String(name string, data any) (string, error) {
+	var b strings.Builder
+
+	if err := r.Render(&b, name, data); err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
@@ -0,0 +1,110 @@
+package render
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestRender_BuiltIn(t *testing.T) {
+	assert := assertion.New(t)
+
+	renderer, err := New("")
+	checkErr(t, "creating renderer", err)
+
+	message, err := renderer.String(TagMessage, TagMessageData{Tag: "v1.2.3", Version: "1.2.3"})
+	checkErr(t, "rendering tag message", err)
+
+	assert.Equal("v1.2.3", message)
+
+	data := ChangelogData{
+		Version: "1.2.3",
+		Date:    time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		Sections: []ChangelogSection{
+			{Title: "Added", Entries: []ChangelogEntry{{Scope: "api", Subject: "add v2 endpoints", ShortHash: "3f1c2a9"}}},
+			{Title: "Changed", Entries: []ChangelogEntry{{Subject: "remove v1 endpoints", Breaking: true}}},
+		},
+	}
+
+	changelog, err := renderer.String(Changelog, data)
+	checkErr(t, "rendering changelog", err)
+
+	expected := `## [1.2.3] - 2024-03-01
+
+### Added
+
+- **api:** add v2 endpoints (3f1c2a9)
+
+### Changed
+
+- **BREAKING:** remove v1 endpoints
+`
+
+	assert.Equal(expected, changelog)
+
+	_, err = renderer.String("notification.tmpl", nil)
+	assert.ErrorIs(err, ErrUnknownTemplate)
+}
+
+func TestRender_Override(t *testing.T) {
+	assert := assertion.New(t)
+
+	dir := t.TempDir()
+
+	writeTemplate(t, filepath.Join(dir, TagMessage), "Release {{ .Version }} of {{ .Project }} from {{ .Branch }}")
+
+	renderer, err := New(dir)
+	checkErr(t, "creating renderer", err)
+
+	message, err := renderer.String(TagMessage, TagMessageData{Version: "1.2.3", Project: "api", Branch: "main"})
+	checkErr(t, "rendering tag message", err)
+
+	assert.Equal("Release 1.2.3 of api from main", message)
+
+	changelog, err := renderer.String(Changelog, ChangelogData{Version: "1.2.3"})
+	checkErr(t, "rendering changelog", err)
+
+	assert.Contains(changelog, "## [1.2.3]", "templates that are not overridden should be the built-in ones")
+}
+
+func TestRender_InvalidTemplates(t *testing.T) {
+	assert := assertion.New(t)
+
+	dir := t.TempDir()
+	writeTemplate(t, filepath.Join(dir, "tag-mesage.tmpl"), "{{ .Tag }}")
+
+	_, err := New(dir)
+	assert.ErrorIs(err, ErrUnknownTemplate, "misnamed templates should be rejected")
+
+	dir = t.TempDir()
+	writeTemplate(t, filepath.Join(dir, TagMessage), "{{ .Tag ")
+
+	_, err = New(dir)
+	assert.ErrorContains(err, "parsing template")
+
+	dir = t.TempDir()
+	writeTemplate(t, filepath.Join(dir, TagMessage), "{{ .Tga }}")
+
+	renderer, err := New(dir)
+	checkErr(t, "creating renderer", err)
+
+	_, err = renderer.String(TagMessage, TagMessageData{Tag: "v1.2.3"})
+	assert.ErrorContains(err, "executing template")
+}
+
+func writeTemplate(t *testing.T, path, content string) {
+	t.Helper()
+
+	err := os.WriteFile(path, []byte(content), 0o644)
+	checkErr(t, "writing template", err)
+}
+
+func checkErr(t *testing.T, msg string, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %s", msg, err)
+	}
+}
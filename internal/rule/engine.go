@@ -0,0 +1,106 @@
+package rule
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Kind is a kind of release rule. The kinds of rules are evaluated in a configured order, the first kind whose rule
+// matches a commit giving its release type, the commit falling through to the next kind otherwise.
+type Kind string
+
+const (
+	// KindBreaking matches breaking changes, which trigger a major release.
+	KindBreaking Kind = "breaking"
+	// KindScope matches the rules given for a commit type and scope, such as "fix(deps)".
+	KindScope Kind = "scope"
+	// KindType matches the rules given for a commit type, such as "fix".
+	KindType Kind = "type"
+)
+
+// DefaultOrder evaluates breaking changes first, then scoped rules, then commit type rules, so that a breaking change
+// always triggers a major release and a scoped rule overrides the rule of its commit type.
+var DefaultOrder = []Kind{KindBreaking, KindScope, KindType}
+
+var ErrInvalidOrder = errors.New("invalid rule order")
+
+// ParseOrder returns the evaluation order given by a list of kinds, which must list every kind exactly once, or nil,
+// standing for the default order, if the list is empty.
+func ParseOrder(kinds []string) ([]Kind, error) {
+	if len(kinds) == 0 {
+		return nil, nil
+	}
+
+	order := make([]Kind, 0, len(kinds))
+
+	for _, name := range kinds {
+		kind := Kind(name)
+
+		if !slices.Contains(DefaultOrder, kind) {
+			return nil, fmt.Errorf("%w: unknown kind %q", ErrInvalidOrder, name)
+		}
+
+		if slices.Contains(order, kind) {
+			return nil, fmt.Errorf("%w: kind %q is listed more than once", ErrInvalidOrder, name)
+		}
+
+		order = append(order, kind)
+	}
+
+	if len(order) != len(DefaultOrder) {
+		return nil, fmt.Errorf("%w: every kind of %v must be listed", ErrInvalidOrder, DefaultOrder)
+	}
+
+	return order, nil
+}
+
+// Commit is a conventional commit as evaluated by the rules.
+type Commit struct {
+	Type     string
+	Scope    string
+	Breaking bool
+}
+
+// Match is the rule matched by a commit, named after its kind for breaking changes and after its commit type, and
+// scope if any, otherwise (e.g. "breaking", "fix" or "fix(deps)"), along with the type of release it triggers.
+type Match struct {
+	Rule    string
+	Release string
+}
+
+// Evaluate returns the rule matched by a commit, evaluating the kinds of rules in order, and whether one matched.
+func (r Rules) Evaluate(commit Commit) (Match, bool) {
+	order := r.Order
+	if order == nil {
+		order = DefaultOrder
+	}
+
+	for _, kind := range order {
+		switch kind {
+		case KindBreaking:
+			if commit.Breaking {
+				return Match{Rule: string(KindBreaking), Release: "major"}, true
+			}
+		case KindScope:
+			if commit.Scope == "" {
+				continue
+			}
+
+			name := scopedRule(commit.Type, commit.Scope)
+			if release, ok := r.Map[name]; ok {
+				return Match{Rule: name, Release: release}, true
+			}
+		case KindType:
+			if release, ok := r.Map[commit.Type]; ok {
+				return Match{Rule: commit.Type, Release: release}, true
+			}
+		}
+	}
+
+	return Match{}, false
+}
+
+func scopedRule(commitType, scope string) string {
+	return commitType + "(" + scope + ")"
+}
@@ -0,0 +1,58 @@
+package rule
+
+import (
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestRule_Evaluate(t *testing.T) {
+	assert := assertion.New(t)
+
+	rules := Rules{Map: map[string]string{"feat": "minor", "fix": "patch", "feat(experimental)": "patch", "chore(deps)": "patch"}}
+
+	type test struct {
+		order  []Kind
+		commit Commit
+		want   Match
+		ok     bool
+	}
+
+	tests := []test{
+		{commit: Commit{Type: "feat"}, want: Match{Rule: "feat", Release: "minor"}, ok: true},
+		{commit: Commit{Type: "feat", Scope: "api"}, want: Match{Rule: "feat", Release: "minor"}, ok: true},
+		{commit: Commit{Type: "feat", Scope: "experimental"}, want: Match{Rule: "feat(experimental)", Release: "patch"}, ok: true},
+		{commit: Commit{Type: "chore", Scope: "deps"}, want: Match{Rule: "chore(deps)", Release: "patch"}, ok: true},
+		{commit: Commit{Type: "chore"}, ok: false},
+		{commit: Commit{Type: "feat", Scope: "experimental", Breaking: true}, want: Match{Rule: "breaking", Release: "major"}, ok: true},
+		{order: []Kind{KindScope, KindBreaking, KindType}, commit: Commit{Type: "feat", Scope: "experimental", Breaking: true}, want: Match{Rule: "feat(experimental)", Release: "patch"}, ok: true},
+		{order: []Kind{KindScope, KindBreaking, KindType}, commit: Commit{Type: "feat", Breaking: true}, want: Match{Rule: "breaking", Release: "major"}, ok: true},
+		{order: []Kind{KindType, KindScope, KindBreaking}, commit: Commit{Type: "feat", Scope: "experimental", Breaking: true}, want: Match{Rule: "feat", Release: "minor"}, ok: true},
+		{order: []Kind{KindType, KindScope, KindBreaking}, commit: Commit{Type: "docs", Breaking: true}, want: Match{Rule: "breaking", Release: "major"}, ok: true},
+	}
+
+	for _, tc := range tests {
+		rules.Order = tc.order
+
+		got, ok := rules.Evaluate(tc.commit)
+		assert.Equal(tc.ok, ok, "%v %+v", tc.order, tc.commit)
+		assert.Equal(tc.want, got, "%v %+v", tc.order, tc.commit)
+	}
+}
+
+func TestRule_ParseOrder(t *testing.T) {
+	assert := assertion.New(t)
+
+	order, err := ParseOrder(nil)
+	assert.NoError(err)
+	assert.Nil(order, "no order should stand for the default one")
+
+	order, err = ParseOrder([]string{"scope", "breaking", "type"})
+	assert.NoError(err)
+	assert.Equal([]Kind{KindScope, KindBreaking, KindType}, order)
+
+	for _, kinds := range [][]string{{"scope", "type"}, {"scope", "type", "type"}, {"scope", "breaking", "unknown"}} {
+		_, err = ParseOrder(kinds)
+		assert.ErrorIs(err, ErrInvalidOrder, kinds)
+	}
+}
@@ -3,10 +3,14 @@ package rule
 
 import (
 	"errors"
+	"strings"
 )
 
+// Rules maps commit types, or commit types and scopes such as "fix(deps)", to the type of release they trigger. Order
+// is the order in which the kinds of rules are evaluated, the default order if nil.
 type Rules struct {
-	Map map[string]string
+	Map   map[string]string
+	Order []Kind
 }
 
 var Default = Rules{
@@ -59,7 +63,7 @@ func Unmarshall(input map[string][]string) (Rules, error) {
 		}
 
 		for _, commitType := range commitTypes {
-			if _, ok := validCommitTypes[commitType]; !ok {
+			if _, ok := validCommitTypes[typeOf(commitType)]; !ok {
 				return rules, ErrInvalidCommitType
 			}
 
@@ -73,3 +77,17 @@ func Unmarshall(input map[string][]string) (Rules, error) {
 
 	return rules, nil
 }
+
+// typeOf returns the commit type of a rule, without its scope if any, or an empty string if the scope is malformed.
+func typeOf(rule string) string {
+	commitType, scope, scoped := strings.Cut(rule, "(")
+	if !scoped {
+		return rule
+	}
+
+	if scope, ok := strings.CutSuffix(scope, ")"); !ok || scope == "" || strings.ContainsAny(scope, "()") {
+		return ""
+	}
+
+	return commitType
+}
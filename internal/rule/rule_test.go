@@ -38,6 +38,10 @@ func TestRule_UnmarshallError(t *testing.T) {
 		{have: map[string][]string{"unknown": {"feat"}, "patch": {"perf"}}, want: ErrInvalidReleaseType},
 		{have: map[string][]string{"minor": {"unknown"}, "patch": {"perf"}}, want: ErrInvalidCommitType},
 		{have: map[string][]string{"minor": {"feat"}, "patch": {"fix", "feat"}}, want: ErrDuplicateReleaseRule},
+		{have: map[string][]string{"minor": {"feat"}, "patch": {"fix", "feat(experimental)", "chore(deps)"}}, want: nil},
+		{have: map[string][]string{"minor": {"feat"}, "patch": {"unknown(deps)"}}, want: ErrInvalidCommitType},
+		{have: map[string][]string{"minor": {"feat"}, "patch": {"fix()"}}, want: ErrInvalidCommitType},
+		{have: map[string][]string{"minor": {"feat"}, "patch": {"fix(deps"}}, want: ErrInvalidCommitType},
 		{have: map[string][]string{}, want: ErrNoRules},
 	}
 
@@ -0,0 +1,80 @@
+// Package secret provides functions to decrypt secrets stored in configuration files.
+//
+// Secrets are encrypted with age (https://age-encryption.org) and stored ASCII armored, so that they can be written as
+// multiline strings in a configuration file. The decryption keys are read from the environment.
+package secret
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+)
+
+const (
+	KeyEnv     = "GO_SEMVER_RELEASE_AGE_KEY"
+	KeyFileEnv = "GO_SEMVER_RELEASE_AGE_KEY_FILE"
+
+	// Environment variables used by SOPS, so that existing age keys can be reused as they are.
+	sopsKeyEnv     = "SOPS_AGE_KEY"
+	sopsKeyFileEnv = "SOPS_AGE_KEY_FILE"
+)
+
+var ErrNoIdentity = errors.New("no age identity found in environment")
+
+// IsEncrypted returns whether a value is an ASCII armored age encrypted secret.
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(strings.TrimSpace(value), armor.Header)
+}
+
+// IdentitiesFromEnv reads the age identities used to decrypt secrets, either directly from an environment variable or
+// from a file whose path is given by an environment variable.
+func IdentitiesFromEnv() ([]age.Identity, error) {
+	for _, env := range []string{KeyEnv, sopsKeyEnv} {
+		if key := os.Getenv(env); key != "" {
+			identities, err := age.ParseIdentities(strings.NewReader(key))
+			if err != nil {
+				return nil, fmt.Errorf("parsing %s: %w", env, err)
+			}
+			return identities, nil
+		}
+	}
+
+	for _, env := range []string{KeyFileEnv, sopsKeyFileEnv} {
+		if path := os.Getenv(env); path != "" {
+			f, err := os.Open(path)
+			if err != nil {
+				return nil, fmt.Errorf("opening %s: %w", env, err)
+			}
+
+			identities, err := age.ParseIdentities(f)
+			err = errors.Join(err, f.Close())
+			if err != nil {
+				return nil, fmt.Errorf("parsing %s: %w", env, err)
+			}
+
+			return identities, nil
+		}
+	}
+
+	return nil, ErrNoIdentity
+}
+
+// Decrypt decrypts an ASCII armored age encrypted secret with one of the given identities.
+func Decrypt(value string, identities ...age.Identity) (string, error) {
+	r, err := age.Decrypt(armor.NewReader(strings.NewReader(strings.TrimSpace(value))), identities...)
+	if err != nil {
+		return "", fmt.Errorf("decrypting secret: %w", err)
+	}
+
+	plaintext, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("reading decrypted secret: %w", err)
+	}
+
+	return string(plaintext), nil
+}
@@ -0,0 +1,98 @@
+package secret
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestSecret_Decrypt(t *testing.T) {
+	assert := assertion.New(t)
+
+	identity, err := age.GenerateX25519Identity()
+	checkErr(t, "generating identity", err)
+
+	encrypted := encrypt(t, "s3cr3t", identity.Recipient())
+
+	assert.True(IsEncrypted(encrypted))
+	assert.False(IsEncrypted("s3cr3t"))
+
+	plain, err := Decrypt(encrypted, identity)
+	checkErr(t, "decrypting", err)
+
+	assert.Equal("s3cr3t", plain)
+
+	other, err := age.GenerateX25519Identity()
+	checkErr(t, "generating identity", err)
+
+	var noMatchErr *age.NoIdentityMatchError
+
+	_, err = Decrypt(encrypted, other)
+	assert.ErrorAs(err, &noMatchErr)
+}
+
+func TestSecret_IdentitiesFromEnv(t *testing.T) {
+	assert := assertion.New(t)
+
+	identity, err := age.GenerateX25519Identity()
+	checkErr(t, "generating identity", err)
+
+	t.Setenv(KeyEnv, "")
+	t.Setenv(KeyFileEnv, "")
+	t.Setenv(sopsKeyEnv, "")
+	t.Setenv(sopsKeyFileEnv, "")
+
+	_, err = IdentitiesFromEnv()
+	assert.ErrorIs(err, ErrNoIdentity)
+
+	keyPath := filepath.Join(t.TempDir(), "keys.txt")
+	err = os.WriteFile(keyPath, []byte("# created: 2024-01-01\n"+identity.String()+"\n"), 0o600)
+	checkErr(t, "writing key file", err)
+
+	t.Setenv(sopsKeyFileEnv, keyPath)
+
+	identities, err := IdentitiesFromEnv()
+	checkErr(t, "reading identities from SOPS key file", err)
+	assert.Equal(identity.String(), identities[0].(*age.X25519Identity).String())
+
+	t.Setenv(KeyEnv, identity.String())
+
+	identities, err = IdentitiesFromEnv()
+	checkErr(t, "reading identities from key", err)
+	assert.Len(identities, 1)
+
+	t.Setenv(KeyEnv, "not-a-key")
+
+	_, err = IdentitiesFromEnv()
+	assert.ErrorContains(err, KeyEnv)
+}
+
+func encrypt(t *testing.T, plaintext string, recipient age.Recipient) string {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	armored := armor.NewWriter(buf)
+
+	w, err := age.Encrypt(armored, recipient)
+	checkErr(t, "creating encrypter", err)
+
+	_, err = io.WriteString(w, plaintext)
+	checkErr(t, "writing plaintext", err)
+	checkErr(t, "closing encrypter", w.Close())
+	checkErr(t, "closing armor", armored.Close())
+
+	return buf.String()
+}
+
+func checkErr(t *testing.T, msg string, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %s", msg, err)
+	}
+}
@@ -0,0 +1,274 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+)
+
+var ErrInvalidConstraint = errors.New("invalid version constraint")
+
+var partialRegex = regexp.MustCompile(`^v?(0|[1-9]\d*|[xX*])(?:\.(0|[1-9]\d*|[xX*]))?(?:\.(0|[1-9]\d*|[xX*]))?(?:-([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?(?:\+[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?$`)
+
+type comparator struct {
+	operator string
+	version  *Version
+}
+
+func (c comparator) check(v *Version) bool {
+	result := Compare(v, c.version)
+
+	switch c.operator {
+	case "=":
+		return result == 0
+	case "!=":
+		return result != 0
+	case ">":
+		return result > 0
+	case ">=":
+		return result >= 0
+	case "<":
+		return result < 0
+	default:
+		return result <= 0
+	}
+}
+
+// Constraint is a set of version ranges. A version satisfies a constraint if it belongs to at least one of its ranges.
+//
+// Ranges are separated by "||" and are made of comparators separated by spaces or commas, all of which must be
+// satisfied (e.g., ">=1.0.0 <2.0.0 || ^3.1"). Comparators support the =, !=, >, >=, < and <= operators as well as
+// caret (^1.2.3 := >=1.2.3 <2.0.0) and tilde (~1.2.3 := >=1.2.3 <1.3.0) ranges. Versions may be partial or contain
+// wildcards, in which case missing components are treated as wildcards (1.2 := 1.2.x := >=1.2.0 <1.3.0).
+//
+// Exclusive upper bounds exclude the prereleases of the bound as well, so that 2.0.0-rc does not satisfy <2.0.0.
+type Constraint struct {
+	ranges [][]comparator
+	raw    string
+}
+
+// NewConstraint parses a version constraint.
+func NewConstraint(str string) (*Constraint, error) {
+	constraint := &Constraint{raw: str}
+
+	for _, rawRange := range strings.Split(str, "||") {
+		fields := strings.FieldsFunc(rawRange, func(r rune) bool {
+			return r == ' ' || r == ',' || r == '\t'
+		})
+
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("%w %q: empty range", ErrInvalidConstraint, str)
+		}
+
+		var comparators []comparator
+
+		for i := 0; i < len(fields); i++ {
+			field := fields[i]
+
+			// An operator may be separated from its version by a space (e.g., ">= 1.0.0").
+			if strings.Trim(field, "^~<>=!") == "" && i+1 < len(fields) {
+				i++
+				field += fields[i]
+			}
+
+			parsed, err := parseComparator(field)
+			if err != nil {
+				return nil, fmt.Errorf("%w %q: %w", ErrInvalidConstraint, str, err)
+			}
+
+			comparators = append(comparators, parsed...)
+		}
+
+		constraint.ranges = append(constraint.ranges, comparators)
+	}
+
+	return constraint, nil
+}
+
+// Check returns whether a version satisfies the constraint.
+func (c *Constraint) Check(v *Version) bool {
+	for _, comparators := range c.ranges {
+		satisfied := true
+
+		for _, comparator := range comparators {
+			if !comparator.check(v) {
+				satisfied = false
+				break
+			}
+		}
+
+		if satisfied {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *Constraint) String() string {
+	return c.raw
+}
+
+// Satisfies returns whether a version satisfies a constraint, see Constraint for the supported syntax.
+func Satisfies(v *Version, constraint string) (bool, error) {
+	c, err := NewConstraint(constraint)
+	if err != nil {
+		return false, err
+	}
+
+	return c.Check(v), nil
+}
+
+// parseComparator parses a single comparator and turns it into the equivalent set of basic comparators.
+func parseComparator(str string) ([]comparator, error) {
+	operator := str[:len(str)-len(strings.TrimLeft(str, "^~<>=!"))]
+	rawVersion := str[len(operator):]
+
+	switch operator {
+	case "", "=", "!=", ">", ">=", "<", "<=", "^", "~":
+	default:
+		return nil, fmt.Errorf("unknown operator %q", operator)
+	}
+
+	submatch := partialRegex.FindStringSubmatch(rawVersion)
+	if submatch == nil {
+		return nil, fmt.Errorf("invalid version %q", rawVersion)
+	}
+
+	// Number of components given before the first wildcard or missing one.
+	var (
+		components int
+		values     [3]int
+	)
+
+	for ; components < 3; components++ {
+		value := submatch[components+1]
+		if value == "" || value == "x" || value == "X" || value == "*" {
+			break
+		}
+
+		component, err := parseComponent(value)
+		if err != nil {
+			return nil, fmt.Errorf("parsing version %q: %w", rawVersion, err)
+		}
+
+		values[components] = component
+	}
+
+	prerelease := submatch[4]
+
+	if prerelease != "" && components < 3 {
+		return nil, fmt.Errorf("invalid version %q: a prerelease requires a complete version", rawVersion)
+	}
+
+	lower := &Version{Major: values[0], Minor: values[1], Patch: values[2], Prerelease: prerelease}
+
+	if components == 3 {
+		return completeComparators(operator, lower)
+	}
+
+	return partialComparators(operator, lower, components)
+}
+
+func completeComparators(operator string, v *Version) ([]comparator, error) {
+	switch operator {
+	case "", "=":
+		return []comparator{{"=", v}}, nil
+	case "<":
+		return []comparator{{"<", exclusiveBound(v)}}, nil
+	case "^":
+		upper, err := caretUpperBound(v, 3)
+		if err != nil {
+			return nil, err
+		}
+		return []comparator{{">=", v}, {"<", upper}}, nil
+	case "~":
+		upper, err := bump(v, 2)
+		if err != nil {
+			return nil, err
+		}
+		return []comparator{{">=", v}, {"<", upper}}, nil
+	default:
+		return []comparator{{operator, v}}, nil
+	}
+}
+
+func partialComparators(operator string, lower *Version, components int) ([]comparator, error) {
+	if components == 0 {
+		switch operator {
+		case "", "=", ">=", "<=", "^", "~":
+			return nil, nil
+		default:
+			return nil, fmt.Errorf("operator %q cannot be used with a wildcard", operator)
+		}
+	}
+
+	upper, err := bump(lower, components)
+	if err != nil {
+		return nil, err
+	}
+
+	switch operator {
+	case "", "=", "~":
+		return []comparator{{">=", lower}, {"<", upper}}, nil
+	case "^":
+		upper, err = caretUpperBound(lower, components)
+		if err != nil {
+			return nil, err
+		}
+		return []comparator{{">=", lower}, {"<", upper}}, nil
+	case ">":
+		return []comparator{{">=", &Version{Major: upper.Major, Minor: upper.Minor, Patch: upper.Patch}}}, nil
+	case ">=":
+		return []comparator{{">=", lower}}, nil
+	case "<":
+		return []comparator{{"<", exclusiveBound(lower)}}, nil
+	case "<=":
+		return []comparator{{"<", upper}}, nil
+	default:
+		return nil, fmt.Errorf("operator %q cannot be used with a partial version", operator)
+	}
+}
+
+// caretUpperBound returns the exclusive upper bound of a caret range, which allows changes that do not modify the
+// left-most non-zero component among the given ones.
+func caretUpperBound(v *Version, components int) (*Version, error) {
+	switch {
+	case v.Major > 0 || components == 1:
+		return bump(v, 1)
+	case v.Minor > 0 || components == 2:
+		return bump(v, 2)
+	default:
+		return bump(v, 3)
+	}
+}
+
+// bump returns the exclusive bound obtained by incrementing the component at the given position (1 being the major
+// component) and resetting the following ones.
+func bump(v *Version, position int) (*Version, error) {
+	values := [3]int{v.Major, v.Minor, v.Patch}
+
+	if values[position-1] == math.MaxInt {
+		return nil, ErrOverflow
+	}
+
+	values[position-1]++
+
+	for i := position; i < 3; i++ {
+		values[i] = 0
+	}
+
+	return exclusiveBound(&Version{Major: values[0], Minor: values[1], Patch: values[2]}), nil
+}
+
+// exclusiveBound returns the lowest version of the given version core, so that its prereleases are excluded by a "<"
+// comparator.
+func exclusiveBound(v *Version) *Version {
+	if v.Prerelease != "" {
+		return v
+	}
+
+	return &Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch, Prerelease: "0"}
+}
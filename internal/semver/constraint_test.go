@@ -0,0 +1,79 @@
+package semver
+
+import (
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestConstraint_Check(t *testing.T) {
+	assert := assertion.New(t)
+
+	type test struct {
+		constraint string
+		satisfied  []string
+		rejected   []string
+	}
+
+	tests := []test{
+		{"1.2.3", []string{"1.2.3", "1.2.3+build"}, []string{"1.2.4", "1.2.3-rc"}},
+		{"!=1.2.3", []string{"1.2.4"}, []string{"1.2.3"}},
+		{">=1.0.0 <2.0.0", []string{"1.0.0", "1.9.9"}, []string{"0.9.9", "2.0.0", "2.0.0-rc", "1.0.0-rc"}},
+		{">= 1.0.0, < 2.0.0", []string{"1.5.0"}, []string{"2.0.0"}},
+		{"^1.2.3", []string{"1.2.3", "1.9.0"}, []string{"1.2.2", "2.0.0-rc.1", "2.0.0"}},
+		{"^1.2", []string{"1.2.0", "1.99.0"}, []string{"1.1.9", "2.0.0"}},
+		{"^0.2.3", []string{"0.2.3", "0.2.9"}, []string{"0.3.0"}},
+		{"^0.0.3", []string{"0.0.3"}, []string{"0.0.4"}},
+		{"^0.0", []string{"0.0.9"}, []string{"0.1.0"}},
+		{"~1.2.3", []string{"1.2.3", "1.2.9"}, []string{"1.3.0", "1.2.2"}},
+		{"~1", []string{"1.0.0", "1.9.0"}, []string{"2.0.0"}},
+		{"1.2.x", []string{"1.2.0", "1.2.9"}, []string{"1.3.0"}},
+		{"v1", []string{"1.0.0", "1.5.5"}, []string{"2.0.0", "0.9.0"}},
+		{"*", []string{"0.0.0", "99.0.0"}, nil},
+		{">1.2", []string{"1.3.0"}, []string{"1.2.9"}},
+		{"<=1.2", []string{"1.2.9"}, []string{"1.3.0"}},
+		{"<1.2", []string{"1.1.9"}, []string{"1.2.0-rc", "1.2.0"}},
+		{">=2.0.0-rc.1", []string{"2.0.0-rc.2", "2.0.0"}, []string{"2.0.0-beta"}},
+		{"^1 || ^3.1", []string{"1.0.0", "3.2.0"}, []string{"2.0.0", "3.0.0"}},
+	}
+
+	for _, tc := range tests {
+		constraint, err := NewConstraint(tc.constraint)
+		checkErr(t, "parsing constraint", err)
+
+		for _, str := range tc.satisfied {
+			version, err := NewFromString(str)
+			checkErr(t, "parsing version", err)
+
+			assert.True(constraint.Check(version), "%q should satisfy %q", str, tc.constraint)
+		}
+
+		for _, str := range tc.rejected {
+			version, err := NewFromString(str)
+			checkErr(t, "parsing version", err)
+
+			assert.False(constraint.Check(version), "%q should not satisfy %q", str, tc.constraint)
+		}
+	}
+}
+
+func TestConstraint_Invalid(t *testing.T) {
+	assert := assertion.New(t)
+
+	for _, str := range []string{"", "1.0.0 ||", "=>1.0.0", "^foo", "1.2-rc", "!=1.2", ">*", "^99999999999999999999"} {
+		_, err := NewConstraint(str)
+		assert.ErrorIs(err, ErrInvalidConstraint, "%q should be invalid", str)
+	}
+}
+
+func TestSemver_Satisfies(t *testing.T) {
+	assert := assertion.New(t)
+
+	satisfied, err := Satisfies(&Version{Major: 1, Minor: 4}, "^1.2")
+	checkErr(t, "checking constraint", err)
+
+	assert.True(satisfied, "version should satisfy constraint")
+
+	_, err = Satisfies(&Version{Major: 1}, "~>1.2")
+	assert.ErrorIs(err, ErrInvalidConstraint)
+}
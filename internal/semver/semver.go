@@ -2,8 +2,12 @@
 package semver
 
 import (
+	"cmp"
+	"errors"
 	"fmt"
+	"math"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 )
@@ -12,6 +16,11 @@ var (
 	Regex = regexp.MustCompile(`(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
 )
 
+var (
+	ErrInvalidVersion = errors.New("string cannot be converted to a valid semver")
+	ErrOverflow       = errors.New("version component overflows")
+)
+
 type Version struct {
 	Major      int
 	Minor      int
@@ -20,25 +29,49 @@ type Version struct {
 	Metadata   string
 }
 
-func (v *Version) BumpMajor() {
+// BumpMajor increments the major component and resets the others. The version is left unchanged if the major component
+// cannot be incremented without overflowing.
+func (v *Version) BumpMajor() error {
+	if v.Major == math.MaxInt {
+		return fmt.Errorf("bumping major component: %w", ErrOverflow)
+	}
+
 	v.Major++
 	v.Minor = 0
 	v.Patch = 0
 	v.Prerelease = ""
 	v.Metadata = ""
+
+	return nil
 }
 
-func (v *Version) BumpMinor() {
+// BumpMinor increments the minor component and resets the patch one. The version is left unchanged if the minor
+// component cannot be incremented without overflowing.
+func (v *Version) BumpMinor() error {
+	if v.Minor == math.MaxInt {
+		return fmt.Errorf("bumping minor component: %w", ErrOverflow)
+	}
+
 	v.Minor++
 	v.Patch = 0
 	v.Prerelease = ""
 	v.Metadata = ""
+
+	return nil
 }
 
-func (v *Version) BumpPatch() {
+// BumpPatch increments the patch component. The version is left unchanged if the patch component cannot be incremented
+// without overflowing.
+func (v *Version) BumpPatch() error {
+	if v.Patch == math.MaxInt {
+		return fmt.Errorf("bumping patch component: %w", ErrOverflow)
+	}
+
 	v.Patch++
 	v.Prerelease = ""
 	v.Metadata = ""
+
+	return nil
 }
 
 // IsZero checks if all component of a semantic version number are equal to zero.
@@ -61,58 +94,150 @@ func (v *Version) String() string {
 	return str
 }
 
-// NewFromString returns a semver struct corresponding to the string used as an input.
+// NewFromString returns a semver struct corresponding to the string used as an input. The version may be prefixed
+// (e.g., "v1.2.3" or "foo-1.2.3"), but strings in which the version would only be a part of a larger number sequence,
+// such as "01.2.3" or "1.2.3.4", are rejected rather than parsed as another version.
 func NewFromString(str string) (*Version, error) {
-	submatch := Regex.FindStringSubmatch(str)
+	submatch := Regex.FindStringSubmatchIndex(str)
 
-	if len(submatch) < 4 {
-		return nil, fmt.Errorf("string cannot be converted to a valid semver")
+	if submatch == nil {
+		return nil, ErrInvalidVersion
 	}
 
-	major, err := strconv.Atoi(submatch[1])
+	if prefix := strings.TrimSuffix(str[:submatch[0]], "."); prefix != "" && isDigit(prefix[len(prefix)-1]) {
+		return nil, fmt.Errorf("%w: %q is preceded by a number", ErrInvalidVersion, str[submatch[0]:])
+	}
+
+	major, err := parseComponent(str[submatch[2]:submatch[3]])
 	if err != nil {
 		return nil, fmt.Errorf("converting major component: %w", err)
 	}
-	minor, err := strconv.Atoi(submatch[2])
+	minor, err := parseComponent(str[submatch[4]:submatch[5]])
 	if err != nil {
 		return nil, fmt.Errorf("converting minor component: %w", err)
 	}
-	patch, err := strconv.Atoi(submatch[3])
+	patch, err := parseComponent(str[submatch[6]:submatch[7]])
 	if err != nil {
 		return nil, fmt.Errorf("converting patch component: %w", err)
 	}
 
-	prerelease := submatch[4]
-	buildMetadata := submatch[5]
+	var prerelease, buildMetadata string
+
+	if submatch[8] != -1 {
+		prerelease = str[submatch[8]:submatch[9]]
+	}
+
+	if submatch[10] != -1 {
+		buildMetadata = str[submatch[10]:submatch[11]]
+	}
 
 	semver := &Version{Major: major, Minor: minor, Patch: patch, Prerelease: prerelease, Metadata: buildMetadata}
 
 	return semver, nil
 }
 
-// Compare returns an integer representing the precedence of two semantic versions. The result will be 0 if a == b,
-// -1 if a < b, and +1 if a > b.
+func parseComponent(str string) (int, error) {
+	component, err := strconv.Atoi(str)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, ErrOverflow
+		}
+		return 0, err
+	}
+
+	return component, nil
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+// Compare returns an integer representing the precedence of two semantic versions as defined by the SemVer
+// specification. The result will be 0 if a == b, -1 if a < b, and +1 if a > b. Build metadata is ignored, and a
+// prerelease version has a lower precedence than its associated release version. Compare can be used as a comparison
+// function with the slices package, e.g. slices.SortFunc(versions, semver.Compare).
 func Compare(a, b *Version) int {
+	if c := cmp.Compare(a.Major, b.Major); c != 0 {
+		return c
+	}
+
+	if c := cmp.Compare(a.Minor, b.Minor); c != 0 {
+		return c
+	}
+
+	if c := cmp.Compare(a.Patch, b.Patch); c != 0 {
+		return c
+	}
+
 	switch {
-	case a.Major > b.Major:
-		return 1
-	case a.Major < b.Major:
-		return -1
-	case a.Minor > b.Minor:
+	case a.Prerelease == b.Prerelease:
+		return 0
+	case a.Prerelease == "":
 		return 1
-	case a.Minor < b.Minor:
+	case b.Prerelease == "":
 		return -1
-	case a.Patch > b.Patch:
-		return 1
-	case a.Patch < b.Patch:
+	default:
+		return comparePrerelease(a.Prerelease, b.Prerelease)
+	}
+}
+
+// Compare returns the precedence of v compared to other, see Compare.
+func (v *Version) Compare(other *Version) int {
+	return Compare(v, other)
+}
+
+// Sort sorts versions by ascending precedence. The order of versions of equal precedence is preserved.
+func Sort(versions []*Version) {
+	slices.SortStableFunc(versions, Compare)
+}
+
+// comparePrerelease compares two dot separated prerelease identifier chains as defined by the SemVer specification:
+// numeric identifiers are compared numerically and have a lower precedence than alphanumeric identifiers, which are
+// compared lexically, and a chain whose identifiers are all equal to the beginning of a longer one has a lower
+// precedence.
+func comparePrerelease(a, b string) int {
+	aIdentifiers := strings.Split(a, ".")
+	bIdentifiers := strings.Split(b, ".")
+
+	for i := 0; i < len(aIdentifiers) && i < len(bIdentifiers); i++ {
+		if c := compareIdentifier(aIdentifiers[i], bIdentifiers[i]); c != 0 {
+			return c
+		}
+	}
+
+	return cmp.Compare(len(aIdentifiers), len(bIdentifiers))
+}
+
+func compareIdentifier(a, b string) int {
+	aNumeric := isNumeric(a)
+	bNumeric := isNumeric(b)
+
+	switch {
+	case aNumeric && bNumeric:
+		// Numeric identifiers have no leading zeros, a longer one is therefore greater, which avoids overflowing.
+		if c := cmp.Compare(len(a), len(b)); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	case aNumeric:
 		return -1
-	case a.Prerelease == "" && b.Prerelease != "":
+	case bNumeric:
 		return 1
-	case a.Prerelease != "" && b.Prerelease == "":
-		return -1
-	case a.Prerelease != "" && b.Prerelease != "":
-		return strings.Compare(a.Prerelease, b.Prerelease)
 	default:
-		return 0
+		return strings.Compare(a, b)
+	}
+}
+
+func isNumeric(str string) bool {
+	if str == "" {
+		return false
 	}
+
+	for i := 0; i < len(str); i++ {
+		if !isDigit(str[i]) {
+			return false
+		}
+	}
+
+	return true
 }
@@ -1,6 +1,10 @@
 package semver
 
 import (
+	"cmp"
+	"errors"
+	"math"
+	"strings"
 	"testing"
 
 	assertion "github.com/stretchr/testify/assert"
@@ -40,6 +44,114 @@ func TestSemver_Compare(t *testing.T) {
 	}
 }
 
+func TestSemver_Compare_PrereleaseIdentifiers(t *testing.T) {
+	assert := assertion.New(t)
+
+	// Ordered by ascending precedence, as in the SemVer specification and with foreign dotted identifiers.
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-beta.nightly.5",
+		"1.0.0-beta.nightly.10",
+		"1.0.0-rc.1",
+		"1.0.0-rc.1.2",
+		"1.0.0-rc.1.10",
+		"1.0.0-rc.99999999999999999999",
+		"1.0.0",
+	}
+
+	for i := range ordered {
+		for j := range ordered {
+			a, err := NewFromString(ordered[i])
+			checkErr(t, "parsing version", err)
+
+			b, err := NewFromString(ordered[j])
+			checkErr(t, "parsing version", err)
+
+			assert.Equal(cmp.Compare(i, j), Compare(a, b), "precedence of %q and %q is not correct", ordered[i], ordered[j])
+		}
+	}
+}
+
+func TestSemver_Compare_Matrix(t *testing.T) {
+	assert := assertion.New(t)
+
+	// Groups of versions of equal precedence, ordered by ascending precedence.
+	groups := [][]string{
+		{"0.0.0-0", "0.0.0-0+build"},
+		{"0.0.0"},
+		{"0.0.1-rc", "0.0.1-rc+1", "0.0.1-rc+2"},
+		{"0.0.1"},
+		{"0.1.0-alpha"},
+		{"0.1.0", "0.1.0+build.1"},
+		{"0.1.1"},
+		{"0.2.0"},
+		{"1.0.0-0"},
+		{"1.0.0-9"},
+		{"1.0.0-10"},
+		{"1.0.0-alpha"},
+		{"1.0.0-rc.1"},
+		{"1.0.0", "1.0.0+exp.sha.5114f85"},
+		{"1.0.10"},
+		{"1.9.0"},
+		{"1.10.0"},
+		{"2.0.0-rc"},
+		{"2.0.0"},
+		{"10.0.0"},
+	}
+
+	type entry struct {
+		version *Version
+		group   int
+	}
+
+	var entries []entry
+
+	for i, group := range groups {
+		for _, str := range group {
+			version, err := NewFromString(str)
+			checkErr(t, "parsing version", err)
+
+			entries = append(entries, entry{version: version, group: i})
+		}
+	}
+
+	for _, a := range entries {
+		for _, b := range entries {
+			want := cmp.Compare(a.group, b.group)
+
+			assert.Equal(want, Compare(a.version, b.version), "precedence of %q and %q is not correct", a.version, b.version)
+			assert.Equal(want, a.version.Compare(b.version), "precedence of %q and %q is not correct", a.version, b.version)
+		}
+	}
+}
+
+func TestSemver_Sort(t *testing.T) {
+	assert := assertion.New(t)
+
+	var versions []*Version
+
+	for _, str := range []string{"2.0.0", "1.0.0+b", "1.0.0-rc.2", "1.0.0+a", "1.0.0-rc.10", "0.9.9"} {
+		version, err := NewFromString(str)
+		checkErr(t, "parsing version", err)
+
+		versions = append(versions, version)
+	}
+
+	Sort(versions)
+
+	var got []string
+	for _, version := range versions {
+		got = append(got, version.String())
+	}
+
+	assert.Equal([]string{"0.9.9", "1.0.0-rc.2", "1.0.0-rc.10", "1.0.0+b", "1.0.0+a", "2.0.0"}, got, "versions should be sorted by precedence")
+}
+
 func TestSemver_IsZero(t *testing.T) {
 	assert := assertion.New(t)
 
@@ -96,6 +208,9 @@ func TestSemver_NewFromString_HappyScenario(t *testing.T) {
 		{"1.2.3-rc", Version{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc"}},
 		{"1.2.3+metadata", Version{Major: 1, Minor: 2, Patch: 3, Metadata: "metadata"}},
 		{"1.2.3-rc+metadata", Version{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc", Metadata: "metadata"}},
+		{"v1.2.3", Version{Major: 1, Minor: 2, Patch: 3}},
+		{"foo-v1.2.3", Version{Major: 1, Minor: 2, Patch: 3}},
+		{"版本-1.2.3", Version{Major: 1, Minor: 2, Patch: 3}},
 	}
 
 	for _, tt := range matrix {
@@ -115,6 +230,12 @@ func TestSemver_NewFromString_BadScenario(t *testing.T) {
 		"-1.-1.-1",
 		"1.0.0+$@",
 		"1.0.0-$@",
+		"01.2.3",
+		"v1.02.3",
+		"1.2.3.4",
+		"1.2.3-ré",
+		"１.２.３",
+		"99999999999999999999.0.0",
 	}
 
 	for _, str := range invalidStrings {
@@ -143,3 +264,61 @@ func TestSemver_Bump(t *testing.T) {
 	assert.Empty(s.Prerelease, "version prerelease should be empty after bump")
 	assert.Empty(s.Metadata, "version metadata should be empty after bump")
 }
+
+func TestSemver_Bump_Overflow(t *testing.T) {
+	assert := assertion.New(t)
+
+	s := &Version{Major: math.MaxInt, Minor: math.MaxInt, Patch: math.MaxInt}
+
+	assert.ErrorIs(s.BumpPatch(), ErrOverflow, "patch component should not overflow")
+	assert.ErrorIs(s.BumpMinor(), ErrOverflow, "minor component should not overflow")
+	assert.ErrorIs(s.BumpMajor(), ErrOverflow, "major component should not overflow")
+	assert.Equal(Version{Major: math.MaxInt, Minor: math.MaxInt, Patch: math.MaxInt}, *s, "version should be unchanged")
+
+	_, err := NewFromString("1.0.99999999999999999999")
+	assert.ErrorIs(err, ErrOverflow, "huge component should be rejected")
+}
+
+func FuzzNewFromString(f *testing.F) {
+	for _, seed := range []string{"1.2.3", "v1.2.3-rc.1+build.5", "foo-0.0.1", "01.2.3", "1.2.3.4", "9223372036854775807.0.0", "1.2.3-é"} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, str string) {
+		version, err := NewFromString(str)
+		if err != nil {
+			return
+		}
+
+		if version.Major < 0 || version.Minor < 0 || version.Patch < 0 {
+			t.Fatalf("%q parsed with a negative component: %+v", str, version)
+		}
+
+		// The parsed version must be the exact end of the input, not a reinterpretation of it.
+		if !strings.HasSuffix(str, version.String()) {
+			t.Fatalf("%q parsed as %q", str, version.String())
+		}
+
+		roundTrip, err := NewFromString(version.String())
+		if err != nil {
+			t.Fatalf("%q does not round-trip: %s", version.String(), err)
+		}
+
+		if *roundTrip != *version {
+			t.Fatalf("%q round-tripped as %+v instead of %+v", str, roundTrip, version)
+		}
+
+		for _, bump := range []func() error{version.BumpPatch, version.BumpMinor, version.BumpMajor} {
+			if err = bump(); err != nil && !errors.Is(err, ErrOverflow) {
+				t.Fatalf("bumping %q: %s", str, err)
+			}
+		}
+	})
+}
+
+func checkErr(t *testing.T, msg string, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %s", msg, err)
+	}
+}
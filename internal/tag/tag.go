@@ -4,9 +4,14 @@ package tag
 import (
 	"errors"
 	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
@@ -14,7 +19,14 @@ import (
 	"github.com/s0ders/go-semver-release/v6/internal/semver"
 )
 
-var ErrTagAlreadyExists = errors.New("tag already exists")
+var (
+	ErrTagAlreadyExists = errors.New("tag already exists")
+	ErrUnsignedTag      = errors.New("tag is not signed")
+	ErrInvalidEpoch     = errors.New("invalid epoch")
+)
+
+// epochRegex matches the names of the tags of an epoch other than 0, relative to their tag namespace.
+var epochRegex = regexp.MustCompile(`^epoch[0-9]+/`)
 
 type OptionFunc func(t *Tagger)
 
@@ -24,6 +36,13 @@ func WithTagPrefix(prefix string) OptionFunc {
 	}
 }
 
+// WithNamespace creates the tags under the given namespace, i.e. under refs/tags/<namespace>/ instead of refs/tags/.
+func WithNamespace(namespace string) OptionFunc {
+	return func(t *Tagger) {
+		t.Namespace = namespace
+	}
+}
+
 func WithSignKey(key *openpgp.Entity) OptionFunc {
 	return func(t *Tagger) {
 		t.SignKey = key
@@ -32,9 +51,11 @@ func WithSignKey(key *openpgp.Entity) OptionFunc {
 
 type Tagger struct {
 	TagPrefix    string
+	Namespace    string
 	ProjectName  string
 	GitSignature object.Signature
 	SignKey      *openpgp.Entity
+	Message      string
 }
 
 func NewTagger(name, email string, options ...OptionFunc) *Tagger {
@@ -57,6 +78,25 @@ func (t *Tagger) SetProjectName(name string) {
 	t.ProjectName = name
 }
 
+func (t *Tagger) SetTagPrefix(prefix string) {
+	t.TagPrefix = prefix
+}
+
+// SetIdentity sets the name and email of the tagger of the tags created next.
+func (t *Tagger) SetIdentity(name, email string) {
+	t.GitSignature.Name = name
+	t.GitSignature.Email = email
+}
+
+func (t *Tagger) SetSignKey(key *openpgp.Entity) {
+	t.SignKey = key
+}
+
+// SetMessage sets the message of the annotated tags created next, the tag name being used if empty.
+func (t *Tagger) SetMessage(message string) {
+	t.Message = message
+}
+
 // TagFromSemver creates a new Git annotated tag from a semantic version number.
 func (t *Tagger) TagFromSemver(semver *semver.Version, hash plumbing.Hash) *object.Tag {
 	tag := &object.Tag{
@@ -83,6 +123,61 @@ func Exists(repository *git.Repository, tagName string) (bool, error) {
 	return exists, nil
 }
 
+// Verify checks that an annotated tag is signed by one of the keys of the given keyring and returns the signing key.
+func Verify(tag *object.Tag, keyring openpgp.EntityList) (*openpgp.Entity, error) {
+	if tag.PGPSignature == "" {
+		return nil, ErrUnsignedTag
+	}
+
+	encoded := &plumbing.MemoryObject{}
+
+	if err := tag.EncodeWithoutSignature(encoded); err != nil {
+		return nil, fmt.Errorf("encoding tag: %w", err)
+	}
+
+	reader, err := encoded.Reader()
+	if err != nil {
+		return nil, fmt.Errorf("reading encoded tag: %w", err)
+	}
+
+	entity, err := openpgp.CheckArmoredDetachedSignature(keyring, reader, strings.NewReader(tag.PGPSignature), nil)
+	if err != nil {
+		return nil, fmt.Errorf("checking tag signature: %w", err)
+	}
+
+	return entity, nil
+}
+
+// Signer returns the fingerprint of the key that signed an annotated tag, as given by its signature, or its key ID if
+// the signature does not give the fingerprint.
+func Signer(tag *object.Tag) (string, error) {
+	if tag.PGPSignature == "" {
+		return "", ErrUnsignedTag
+	}
+
+	block, err := armor.Decode(strings.NewReader(tag.PGPSignature))
+	if err != nil {
+		return "", fmt.Errorf("decoding tag signature: %w", err)
+	}
+
+	p, err := packet.Read(block.Body)
+	if err != nil {
+		return "", fmt.Errorf("reading tag signature: %w", err)
+	}
+
+	signature, ok := p.(*packet.Signature)
+	switch {
+	case !ok:
+		return "", fmt.Errorf("reading tag signature: unexpected %T packet", p)
+	case len(signature.IssuerFingerprint) > 0:
+		return fmt.Sprintf("%X", signature.IssuerFingerprint), nil
+	case signature.IssuerKeyId != nil:
+		return fmt.Sprintf("%016X", *signature.IssuerKeyId), nil
+	default:
+		return "", fmt.Errorf("reading tag signature: no issuer")
+	}
+}
+
 // TagRepository AddTagToRepository create a new annotated tag on the repository with a name corresponding to the semver passed as a
 // parameter.
 func (t *Tagger) TagRepository(repository *git.Repository, semver *semver.Version, commitHash plumbing.Hash) error {
@@ -90,21 +185,29 @@ func (t *Tagger) TagRepository(repository *git.Repository, semver *semver.Versio
 		return fmt.Errorf("semver is nil")
 	}
 
-	tagMessage := t.Format(semver)
+	return t.CreateTag(repository, t.Format(semver), commitHash)
+}
+
+// CreateTag creates a new annotated tag with the given name on the given commit, signed if the tagger has a sign key.
+func (t *Tagger) CreateTag(repository *git.Repository, name string, commitHash plumbing.Hash) error {
+	message := t.Message
+	if message == "" {
+		message = name
+	}
 
 	tagOpts := &git.CreateTagOptions{
-		Message: tagMessage,
+		Message: message,
 		SignKey: t.SignKey,
 		Tagger:  &t.GitSignature,
 	}
 
-	if exists, err := Exists(repository, tagOpts.Message); err != nil {
+	if exists, err := Exists(repository, name); err != nil {
 		return fmt.Errorf("checking if tag exists: %w", err)
 	} else if exists {
 		return ErrTagAlreadyExists
 	}
 
-	if _, err := repository.CreateTag(tagOpts.Message, commitHash, tagOpts); err != nil {
+	if _, err := repository.CreateTag(name, commitHash, tagOpts); err != nil {
 		return fmt.Errorf("creating tag on repository: %w", err)
 	}
 
@@ -112,11 +215,106 @@ func (t *Tagger) TagRepository(repository *git.Repository, semver *semver.Versio
 }
 
 func (t *Tagger) Format(semver *semver.Version) string {
-	tag := t.TagPrefix + semver.String()
+	return t.format(semver.String())
+}
+
+// format returns the name of the tag of the given version string, prefixed by the tag prefix and project name, and
+// qualified by the namespace of the tagger.
+func (t *Tagger) format(version string) string {
+	tag := t.TagPrefix + version
 
 	if t.ProjectName != "" {
 		tag = t.ProjectName + "-" + tag
 	}
 
-	return tag
+	return Qualify(t.Namespace, tag)
+}
+
+// FloatingTags returns the names of the floating tags tracking the latest release of the major and minor versions of
+// the given version, e.g. "v1" and "v1.4" for "v1.4.2", formatted like the release tags.
+func (t *Tagger) FloatingTags(semver *semver.Version) []string {
+	return []string{
+		t.format(strconv.Itoa(semver.Major)),
+		t.format(fmt.Sprintf("%d.%d", semver.Major, semver.Minor)),
+	}
+}
+
+// Version returns the version of a release tag formatted by the tagger, i.e. using its namespace, project name and
+// prefix, and whether the tag is one.
+func (t *Tagger) Version(name string) (*semver.Version, bool) {
+	name, ok := InNamespace(t.Namespace, name)
+	if !ok {
+		return nil, false
+	}
+
+	if t.ProjectName != "" {
+		if name, ok = strings.CutPrefix(name, t.ProjectName+"-"); !ok {
+			return nil, false
+		}
+	}
+
+	name, ok = strings.CutPrefix(name, t.TagPrefix)
+	if !ok || name == "" || name[0] < '0' || name[0] > '9' {
+		return nil, false
+	}
+
+	version, err := semver.NewFromString(name)
+	if err != nil || version.String() != name {
+		return nil, false
+	}
+
+	return version, true
+}
+
+// Qualify returns the full name of a tag created under the given namespace, e.g. "releases/v1.2.3" for the tag
+// "v1.2.3" stored as refs/tags/releases/v1.2.3. The name is returned as is if the namespace is empty.
+func Qualify(namespace, name string) string {
+	namespace = strings.Trim(namespace, "/")
+	if namespace == "" {
+		return name
+	}
+
+	return namespace + "/" + name
+}
+
+// InNamespace returns the name of a tag relative to the given namespace and whether the tag belongs to it. Every tag
+// belongs to the empty namespace.
+func InNamespace(namespace, name string) (string, bool) {
+	namespace = strings.Trim(namespace, "/")
+	if namespace == "" {
+		return name, true
+	}
+
+	return strings.CutPrefix(name, namespace+"/")
+}
+
+// ValidateEpoch checks that an epoch is not negative.
+func ValidateEpoch(epoch int) error {
+	if epoch < 0 {
+		return fmt.Errorf("%w: %d, must not be negative", ErrInvalidEpoch, epoch)
+	}
+
+	return nil
+}
+
+// EpochNamespace returns the namespace of the tags of an epoch under the given namespace, e.g. "releases/epoch2" for
+// the epoch 2 of the "releases" namespace. The tags of the epoch 0, the default, are in the namespace itself.
+func EpochNamespace(namespace string, epoch int) string {
+	if epoch == 0 {
+		return namespace
+	}
+
+	return Qualify(namespace, "epoch"+strconv.Itoa(epoch))
+}
+
+// InEpoch returns the name of a tag relative to the given namespace and epoch and whether the tag belongs to them. The
+// tags of the namespace belonging to another epoch do not belong to the epoch 0, so that versions of different epochs
+// are never compared.
+func InEpoch(namespace string, epoch int, name string) (string, bool) {
+	name, ok := InNamespace(EpochNamespace(namespace, epoch), name)
+	if !ok || epoch != 0 {
+		return name, ok
+	}
+
+	return name, !epochRegex.MatchString(name)
 }
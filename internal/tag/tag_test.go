@@ -1,6 +1,7 @@
 package tag
 
 import (
+	"fmt"
 	"os"
 	"testing"
 
@@ -11,8 +12,8 @@ import (
 	"github.com/go-git/go-git/v5/plumbing/object"
 	assertion "github.com/stretchr/testify/assert"
 
-	"github.com/s0ders/go-semver-release/v6/internal/gittest"
 	"github.com/s0ders/go-semver-release/v6/internal/semver"
+	"github.com/s0ders/go-semver-release/v6/pkg/gittest"
 )
 
 var (
@@ -76,6 +77,40 @@ func TestTag_AddTagToRepository(t *testing.T) {
 	assert.Equal(tagExists, true, "tag should have been found")
 }
 
+func TestTag_Message(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	head, err := testRepository.Head()
+	checkErr(t, "fetching head", err)
+
+	tagger := NewTagger(taggerName, taggerEmail, WithTagPrefix("v"))
+
+	err = tagger.CreateTag(testRepository.Repository, "v1.0.0", head.Hash())
+	checkErr(t, "tagging repository", err)
+
+	tagger.SetMessage("Release 1.1.0")
+
+	err = tagger.CreateTag(testRepository.Repository, "v1.1.0", head.Hash())
+	checkErr(t, "tagging repository", err)
+
+	for name, message := range map[string]string{"v1.0.0": "v1.0.0\n", "v1.1.0": "Release 1.1.0\n"} {
+		reference, err := testRepository.Tag(name)
+		checkErr(t, "fetching tag reference", err)
+
+		tag, err := testRepository.TagObject(reference.Hash())
+		checkErr(t, "fetching tag object", err)
+
+		assert.Equal(message, tag.Message)
+	}
+}
+
 func TestTag_AddExistingTagToRepository(t *testing.T) {
 	assert := assertion.New(t)
 
@@ -180,6 +215,100 @@ func TestTag_SignKey(t *testing.T) {
 	assert.NotEqual("", actualTag.PGPSignature, "PGP signature should not be empty")
 }
 
+func TestTag_Verify(t *testing.T) {
+	assert := assertion.New(t)
+
+	trusted, err := openpgp.NewEntity("John Doe", "", "john.doe@example.com", nil)
+	checkErr(t, "creating openpgp entity", err)
+
+	untrusted, err := openpgp.NewEntity("Jane Doe", "", "jane.doe@example.com", nil)
+	checkErr(t, "creating openpgp entity", err)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	head, err := testRepository.Head()
+	checkErr(t, "fetching head", err)
+
+	tagger := NewTagger(taggerName, taggerEmail)
+
+	for name, key := range map[string]*openpgp.Entity{"trusted": trusted, "untrusted": untrusted, "unsigned": nil} {
+		tagger.SetSignKey(key)
+
+		err = tagger.CreateTag(testRepository.Repository, name, head.Hash())
+		checkErr(t, "creating tag", err)
+	}
+
+	keyring := openpgp.EntityList{trusted}
+
+	verify := func(name string) (*openpgp.Entity, error) {
+		reference, err := testRepository.Tag(name)
+		checkErr(t, "fetching tag reference", err)
+
+		tagObject, err := testRepository.TagObject(reference.Hash())
+		checkErr(t, "fetching tag object", err)
+
+		return Verify(tagObject, keyring)
+	}
+
+	signer, err := verify("trusted")
+	checkErr(t, "verifying trusted tag", err)
+	assert.Equal(trusted.PrimaryKey.KeyId, signer.PrimaryKey.KeyId)
+
+	_, err = verify("untrusted")
+	assert.Error(err, "a tag signed by an unknown key should not be verified")
+
+	_, err = verify("unsigned")
+	assert.ErrorIs(err, ErrUnsignedTag)
+}
+
+func TestTag_Signer(t *testing.T) {
+	assert := assertion.New(t)
+
+	entity, err := openpgp.NewEntity("John Doe", "", "john.doe@example.com", nil)
+	checkErr(t, "creating openpgp entity", err)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	head, err := testRepository.Head()
+	checkErr(t, "fetching head", err)
+
+	tagger := NewTagger(taggerName, taggerEmail)
+
+	for name, key := range map[string]*openpgp.Entity{"signed": entity, "unsigned": nil} {
+		tagger.SetSignKey(key)
+
+		err = tagger.CreateTag(testRepository.Repository, name, head.Hash())
+		checkErr(t, "creating tag", err)
+	}
+
+	signer := func(name string) (string, error) {
+		reference, err := testRepository.Tag(name)
+		checkErr(t, "fetching tag reference", err)
+
+		tagObject, err := testRepository.TagObject(reference.Hash())
+		checkErr(t, "fetching tag object", err)
+
+		return Signer(tagObject)
+	}
+
+	fingerprint, err := signer("signed")
+	checkErr(t, "fetching tag signer", err)
+	assert.Equal(fmt.Sprintf("%X", entity.PrimaryKey.Fingerprint), fingerprint)
+
+	_, err = signer("unsigned")
+	assert.ErrorIs(err, ErrUnsignedTag)
+}
+
 func TestTag_Format(t *testing.T) {
 	assert := assertion.New(t)
 
@@ -192,6 +321,10 @@ func TestTag_Format(t *testing.T) {
 	got := tagger.Format(version)
 
 	assert.Equal(want, got)
+
+	tagger.SetTagPrefix("nightly-")
+
+	assert.Equal("nightly-1.2.3", tagger.Format(version), "tag prefix should be overridden")
 }
 
 func TestTag_AddTagToRepositoryWithProject(t *testing.T) {
@@ -225,9 +358,134 @@ func TestTag_AddTagToRepositoryWithProject(t *testing.T) {
 	assert.Equal(tagExists, true, "tag should have been found")
 }
 
+func TestTag_Namespace(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	head, err := testRepository.Head()
+	checkErr(t, "fetching head", err)
+
+	tagger := NewTagger(taggerName, taggerEmail, WithTagPrefix("v"), WithNamespace("releases"))
+	tagger.SetProjectName("foo")
+
+	version := &semver.Version{Major: 1, Minor: 2, Patch: 3}
+
+	assert.Equal("releases/foo-v1.2.3", tagger.Format(version))
+
+	err = tagger.TagRepository(testRepository.Repository, version, head.Hash())
+	checkErr(t, "tagging repository", err)
+
+	_, err = testRepository.Reference("refs/tags/releases/foo-v1.2.3", true)
+	checkErr(t, "tag should be created under the namespace", err)
+}
+
+func TestTag_InNamespace(t *testing.T) {
+	assert := assertion.New(t)
+
+	type test struct {
+		namespace string
+		name      string
+		expected  string
+		ok        bool
+	}
+
+	matrix := []test{
+		{namespace: "", name: "v1.0.0", expected: "v1.0.0", ok: true},
+		{namespace: "", name: "releases/v1.0.0", expected: "releases/v1.0.0", ok: true},
+		{namespace: "releases", name: "releases/v1.0.0", expected: "v1.0.0", ok: true},
+		{namespace: "releases/", name: "releases/v1.0.0", expected: "v1.0.0", ok: true},
+		{namespace: "team/releases", name: "team/releases/foo-v1.0.0", expected: "foo-v1.0.0", ok: true},
+		{namespace: "releases", name: "v1.0.0", ok: false},
+		{namespace: "releases", name: "releases-v1.0.0", ok: false},
+	}
+
+	for _, tc := range matrix {
+		name, ok := InNamespace(tc.namespace, tc.name)
+
+		assert.Equal(tc.ok, ok, "namespace: %q, name: %q", tc.namespace, tc.name)
+
+		if tc.ok {
+			assert.Equal(tc.expected, name, "namespace: %q, name: %q", tc.namespace, tc.name)
+			assert.Equal(tc.name, Qualify(tc.namespace, name), "namespace: %q, name: %q", tc.namespace, tc.name)
+		}
+	}
+}
+
+func TestTag_InEpoch(t *testing.T) {
+	assert := assertion.New(t)
+
+	type test struct {
+		namespace string
+		epoch     int
+		name      string
+		expected  string
+		ok        bool
+	}
+
+	matrix := []test{
+		{namespace: "", epoch: 0, name: "v9.4.0", expected: "v9.4.0", ok: true},
+		{namespace: "", epoch: 0, name: "epoch1/v2020.1.0", ok: false},
+		{namespace: "", epoch: 1, name: "epoch1/v2020.1.0", expected: "v2020.1.0", ok: true},
+		{namespace: "", epoch: 1, name: "v9.4.0", ok: false},
+		{namespace: "", epoch: 2, name: "epoch1/v2020.1.0", ok: false},
+		{namespace: "releases", epoch: 0, name: "releases/epoch1/foo-v1.0.0", ok: false},
+		{namespace: "releases", epoch: 1, name: "releases/epoch1/foo-v1.0.0", expected: "foo-v1.0.0", ok: true},
+		{namespace: "releases", epoch: 1, name: "epoch1/foo-v1.0.0", ok: false},
+	}
+
+	for _, tc := range matrix {
+		name, ok := InEpoch(tc.namespace, tc.epoch, tc.name)
+
+		assert.Equal(tc.ok, ok, "namespace: %q, epoch: %d, name: %q", tc.namespace, tc.epoch, tc.name)
+
+		if tc.ok {
+			assert.Equal(tc.expected, name, "namespace: %q, epoch: %d, name: %q", tc.namespace, tc.epoch, tc.name)
+			assert.Equal(tc.name, Qualify(EpochNamespace(tc.namespace, tc.epoch), name), "namespace: %q, epoch: %d, name: %q", tc.namespace, tc.epoch, tc.name)
+		}
+	}
+
+	assert.NoError(ValidateEpoch(2))
+	assert.ErrorIs(ValidateEpoch(-1), ErrInvalidEpoch)
+}
+
 func checkErr(t *testing.T, msg string, err error) {
 	t.Helper()
 	if err != nil {
 		t.Fatalf("%s: %s", msg, err)
 	}
 }
+
+func TestTag_FloatingTags(t *testing.T) {
+	assert := assertion.New(t)
+
+	tagger := NewTagger(taggerName, taggerEmail, WithTagPrefix("v"))
+
+	assert.Equal([]string{"v1", "v1.4"}, tagger.FloatingTags(&semver.Version{Major: 1, Minor: 4, Patch: 2}))
+
+	tagger = NewTagger(taggerName, taggerEmail, WithTagPrefix("v"), WithNamespace("releases"))
+	tagger.SetProjectName("api")
+
+	assert.Equal([]string{"releases/api-v2", "releases/api-v2.0"}, tagger.FloatingTags(&semver.Version{Major: 2}))
+}
+
+func TestTag_Version(t *testing.T) {
+	assert := assertion.New(t)
+
+	tagger := NewTagger(taggerName, taggerEmail, WithTagPrefix("v"), WithNamespace("releases"))
+	tagger.SetProjectName("api")
+
+	version, ok := tagger.Version("releases/api-v1.4.2-rc.1")
+	assert.True(ok)
+	assert.Equal(&semver.Version{Major: 1, Minor: 4, Patch: 2, Prerelease: "rc.1"}, version)
+
+	for _, name := range []string{"api-v1.4.2", "releases/api-gateway-v1.4.2", "releases/api-1.4.2", "releases/api-v1", "releases/api-v1.4.2.1"} {
+		_, ok = tagger.Version(name)
+		assert.False(ok, "%q should not be a release tag of the tagger", name)
+	}
+}
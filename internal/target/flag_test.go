@@ -0,0 +1,32 @@
+package target
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTargetFlag_String(t *testing.T) {
+	flag := Flag{{"repository": "./foo", "branches": "main"}}
+
+	var emptyFlag Flag
+
+	assert.Equal(t, "[{\"branches\":\"main\",\"repository\":\"./foo\"}]", flag.String())
+	assert.Equal(t, "[]", emptyFlag.String())
+}
+
+func TestTargetFlag_Set(t *testing.T) {
+	var flag Flag
+
+	err := flag.Set("[{\"repository\": \"./foo\", \"branches\": \"main\"}]")
+	assert.NoError(t, err, "should not have errored")
+
+	err = flag.Set("{\"repository\": \"./foo\"}")
+	assert.Error(t, err, "should have errored, invalid JSON string")
+}
+
+func TestTargetFlag_Type(t *testing.T) {
+	var f Flag
+
+	assert.Equal(t, FlagType, f.Type())
+}
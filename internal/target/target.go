@@ -0,0 +1,78 @@
+// Package target provides functions to handle the configuration of the repositories released by a single run.
+package target
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/s0ders/go-semver-release/v6/internal/branch"
+	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
+)
+
+var ErrNoRepository = errors.New("no repository in target configuration")
+
+// Target is a repository released along others by the same configuration, with its own branches and projects.
+type Target struct {
+	// Repository is the path or URL of the repository.
+	Repository string
+	// Branches are the branches of the repository, as given to the branches flag.
+	Branches branch.Flag
+	// Projects, if set, are the projects of the repository, as given to the monorepo flag.
+	Projects monorepo.Flag
+}
+
+// Unmarshall takes a raw Viper configuration and returns a slice of Target representing a targets configuration. The
+// branches and projects of a target accept the same forms as their flag, the shorthand of the branches included.
+func Unmarshall(input []map[string]any) ([]Target, error) {
+	targets := make([]Target, len(input))
+
+	for i, t := range input {
+		repository, ok := t["repository"]
+		if !ok {
+			return nil, ErrNoRepository
+		}
+
+		stringRepository, ok := repository.(string)
+		if !ok {
+			return nil, fmt.Errorf("could not assert that the \"repository\" property of the target configuration is a string")
+		}
+
+		target := Target{Repository: stringRepository}
+
+		branches, ok := t["branches"]
+		if !ok {
+			return nil, fmt.Errorf("configuring target %q: %w", stringRepository, branch.ErrNoBranch)
+		}
+
+		if err := target.Branches.Set(flagValue(branches)); err != nil {
+			return nil, fmt.Errorf("configuring target %q branches: %w", stringRepository, err)
+		}
+
+		projects, ok := t["monorepo"]
+		if ok {
+			if err := target.Projects.Set(flagValue(projects)); err != nil {
+				return nil, fmt.Errorf("configuring target %q projects: %w", stringRepository, err)
+			}
+		}
+
+		targets[i] = target
+	}
+
+	return targets, nil
+}
+
+// flagValue returns the flag form of a configuration value: strings are given as is, e.g. the shorthand of the
+// branches, and other values as JSON.
+func flagValue(value any) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+
+	return string(b)
+}
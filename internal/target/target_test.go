@@ -0,0 +1,51 @@
+package target
+
+import (
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/branch"
+	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
+)
+
+func TestTarget_Unmarshall(t *testing.T) {
+	assert := assertion.New(t)
+
+	have := []map[string]any{
+		{"repository": "git@github.com:org/foo.git", "branches": "main,rc:prerelease"},
+		{
+			"repository": "./bar",
+			"branches":   []any{map[string]any{"name": "master"}},
+			"monorepo":   []any{map[string]any{"name": "api", "path": "api"}},
+		},
+	}
+
+	want := []Target{
+		{Repository: "git@github.com:org/foo.git", Branches: branch.Flag{{"name": "main"}, {"name": "rc", "prerelease": true}}},
+		{Repository: "./bar", Branches: branch.Flag{{"name": "master"}}, Projects: monorepo.Flag{{"name": "api", "path": "api"}}},
+	}
+
+	targets, err := Unmarshall(have)
+	if err != nil {
+		t.Fatalf("unmarshalling targets: %s", err)
+	}
+
+	assert.Equal(want, targets)
+}
+
+func TestTarget_UnmarshallErrors(t *testing.T) {
+	assert := assertion.New(t)
+
+	_, err := Unmarshall([]map[string]any{{"branches": "main"}})
+	assert.ErrorIs(err, ErrNoRepository)
+
+	_, err = Unmarshall([]map[string]any{{"repository": 42, "branches": "main"}})
+	assert.ErrorContains(err, "could not assert that the \"repository\" property")
+
+	_, err = Unmarshall([]map[string]any{{"repository": "./foo"}})
+	assert.ErrorIs(err, branch.ErrNoBranch)
+
+	_, err = Unmarshall([]map[string]any{{"repository": "./foo", "branches": "main", "monorepo": "api"}})
+	assert.ErrorContains(err, "configuring target \"./foo\" projects")
+}
@@ -0,0 +1,155 @@
+// Package terraform provides functions to publish the versions of Terraform modules released from a monorepo to a
+// private module registry, such as the ones of HCP Terraform and Terraform Enterprise.
+package terraform
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// PublishTag is the publishing mode of the modules published by their registry from their release tags, which must
+	// therefore be bare semantic versions.
+	PublishTag = "tag"
+	// PublishAPI is the publishing mode of the modules whose versions are published with the API of their registry,
+	// as done for the modules using branch-based publishing.
+	PublishAPI = "api"
+)
+
+var (
+	ErrInvalidModule  = errors.New("invalid Terraform module address")
+	ErrInvalidPublish = errors.New("invalid Terraform publishing mode")
+	ErrInvalidTag     = errors.New("tag is not a version accepted by Terraform registries")
+)
+
+// tagRegex matches the tags accepted by Terraform registries: a semantic version, optionally prefixed by "v".
+var tagRegex = regexp.MustCompile(`^v?(0|[1-9][0-9]*)\.(0|[1-9][0-9]*)\.(0|[1-9][0-9]*)(-[0-9A-Za-z.-]+)?$`)
+
+// Module is a module of a private registry, addressed by "<host>/<namespace>/<name>/<provider>", e.g.
+// "app.terraform.io/acme/vpc/aws", the namespace being the organization owning the registry.
+type Module struct {
+	Host      string
+	Namespace string
+	Name      string
+	Provider  string
+}
+
+// ParseModule parses the address of a module of a private registry.
+func ParseModule(address string) (Module, error) {
+	parts := strings.Split(address, "/")
+	if len(parts) != 4 || slices.Contains(parts, "") {
+		return Module{}, fmt.Errorf("%w %q, must be <host>/<namespace>/<name>/<provider>", ErrInvalidModule, address)
+	}
+
+	return Module{Host: parts[0], Namespace: parts[1], Name: parts[2], Provider: parts[3]}, nil
+}
+
+func (m Module) String() string {
+	return strings.Join([]string{m.Host, m.Namespace, m.Name, m.Provider}, "/")
+}
+
+// ValidatePublish returns an error if the publishing mode is neither PublishTag nor PublishAPI.
+func ValidatePublish(publish string) error {
+	switch publish {
+	case PublishTag, PublishAPI:
+		return nil
+	default:
+		return fmt.Errorf("%w %q, must be %q or %q", ErrInvalidPublish, publish, PublishTag, PublishAPI)
+	}
+}
+
+// ValidateTag returns an error if a tag would not be published by a registry publishing modules from their tags,
+// e.g. a tag prefixed by the name of its project.
+func ValidateTag(tag string) error {
+	if !tagRegex.MatchString(tag) {
+		return fmt.Errorf("%w: %q", ErrInvalidTag, tag)
+	}
+
+	return nil
+}
+
+// TokenFromEnv returns the API token of a registry host given by the TF_TOKEN_<host> environment variable also read by
+// the Terraform CLI, periods being encoded as underscores and hyphens as double underscores, e.g.
+// TF_TOKEN_app_terraform_io.
+func TokenFromEnv(host string) string {
+	name := strings.NewReplacer("-", "__", ".", "_").Replace(host)
+
+	return os.Getenv("TF_TOKEN_" + name)
+}
+
+// APIURL returns the base URL of the API of a registry host, e.g. "https://app.terraform.io/api/v2". It is a variable
+// so that tests can target a local server.
+var APIURL = func(host string) string {
+	return "https://" + host + "/api/v2"
+}
+
+// Client is a client of the API of HCP Terraform or Terraform Enterprise.
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+	token      string
+}
+
+// NewClient returns a client of the API at the given base URL, authenticated with the given token.
+func NewClient(baseURL, token string) *Client {
+	return &Client{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		token:      token,
+	}
+}
+
+// PublishVersion publishes a version of a module of the private registry from the given commit of its repository.
+func (c *Client) PublishVersion(ctx context.Context, module Module, version, commit string) error {
+	body, err := json.Marshal(map[string]any{
+		"data": map[string]any{
+			"type": "registry-module-versions",
+			"attributes": map[string]string{
+				"version":    version,
+				"commit-sha": commit,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("encoding module version: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/organizations/%s/registry-modules/private/%s/%s/%s/versions", c.baseURL,
+		url.PathEscape(module.Namespace), url.PathEscape(module.Namespace), url.PathEscape(module.Name), url.PathEscape(module.Provider))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/vnd.api+json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("publishing version %s of %s: %w", version, module, err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		content, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+
+		return fmt.Errorf("publishing version %s of %s: unexpected status %q: %s", version, module, resp.Status, strings.TrimSpace(string(content)))
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package terraform
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestTerraform_ParseModule(t *testing.T) {
+	assert := assertion.New(t)
+
+	module, err := ParseModule("app.terraform.io/acme/vpc/aws")
+	checkErr(t, err, "parsing module")
+
+	assert.Equal(Module{Host: "app.terraform.io", Namespace: "acme", Name: "vpc", Provider: "aws"}, module)
+	assert.Equal("app.terraform.io/acme/vpc/aws", module.String())
+
+	for _, address := range []string{"acme/vpc/aws", "app.terraform.io/acme//aws", "app.terraform.io/acme/vpc/aws/extra"} {
+		_, err = ParseModule(address)
+		assert.ErrorIs(err, ErrInvalidModule, address)
+	}
+}
+
+func TestTerraform_ValidatePublish(t *testing.T) {
+	assert := assertion.New(t)
+
+	assert.NoError(ValidatePublish(PublishTag))
+	assert.NoError(ValidatePublish(PublishAPI))
+	assert.ErrorIs(ValidatePublish("webhook"), ErrInvalidPublish)
+}
+
+func TestTerraform_ValidateTag(t *testing.T) {
+	assert := assertion.New(t)
+
+	for _, tag := range []string{"v1.2.3", "1.2.3", "v1.2.3-rc.1"} {
+		assert.NoError(ValidateTag(tag), tag)
+	}
+
+	for _, tag := range []string{"vpc-v1.2.3", "release-1.2.3", "v1.2.3+build.1", "releases/v1.2.3", "v01.2.3"} {
+		assert.ErrorIs(ValidateTag(tag), ErrInvalidTag, tag)
+	}
+}
+
+func TestTerraform_TokenFromEnv(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Setenv("TF_TOKEN_app_terraform_io", "token")
+	t.Setenv("TF_TOKEN_tfe__prod_acme_com", "prod-token")
+
+	assert.Equal("token", TokenFromEnv("app.terraform.io"))
+	assert.Equal("prod-token", TokenFromEnv("tfe-prod.acme.com"), "hyphens should be encoded as double underscores")
+	assert.Empty(TokenFromEnv("tfe.acme.com"))
+}
+
+func TestTerraform_PublishVersion(t *testing.T) {
+	assert := assertion.New(t)
+
+	var body struct {
+		Data struct {
+			Type       string            `json:"type"`
+			Attributes map[string]string `json:"attributes"`
+		} `json:"data"`
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v2/organizations/acme/registry-modules/private/acme/vpc/aws/versions", func(w http.ResponseWriter, r *http.Request) {
+		checkErr(t, json.NewDecoder(r.Body).Decode(&body), "decoding body")
+
+		assert.Equal("Bearer token", r.Header.Get("Authorization"))
+		assert.Equal("application/vnd.api+json", r.Header.Get("Content-Type"))
+
+		w.WriteHeader(http.StatusCreated)
+	})
+	mux.HandleFunc("POST /api/v2/organizations/acme/registry-modules/private/acme/dns/aws/versions", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"errors": [{"status": "422", "title": "version already exists"}]}`, http.StatusUnprocessableEntity)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewClient(server.URL+"/api/v2", "token")
+
+	err := client.PublishVersion(context.Background(), Module{Host: "app.terraform.io", Namespace: "acme", Name: "vpc", Provider: "aws"}, "1.2.3", "abc")
+	checkErr(t, err, "publishing version")
+
+	assert.Equal("registry-module-versions", body.Data.Type)
+	assert.Equal(map[string]string{"version": "1.2.3", "commit-sha": "abc"}, body.Data.Attributes)
+
+	err = client.PublishVersion(context.Background(), Module{Host: "app.terraform.io", Namespace: "acme", Name: "dns", Provider: "aws"}, "1.2.3", "abc")
+	assert.ErrorContains(err, "version already exists")
+}
+
+func checkErr(t *testing.T, err error, msg string) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %s", msg, err)
+	}
+}
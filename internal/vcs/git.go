@@ -0,0 +1,505 @@
+package vcs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/s0ders/go-semver-release/v6/internal/remote"
+	"github.com/s0ders/go-semver-release/v6/internal/tag"
+)
+
+// GitBackend clones Git repositories.
+type GitBackend struct{}
+
+func (GitBackend) Name() string {
+	return "git"
+}
+
+func (GitBackend) Clone(url string, options Options) (Repository, error) {
+	remoteOptions := []remote.OptionFunc{remote.WithBranches(options.Branches...)}
+	if options.Progress != nil {
+		remoteOptions = append(remoteOptions, remote.WithProgress(options.Progress))
+	}
+
+	origin := remote.New(options.RemoteName, options.Token, remoteOptions...)
+
+	var (
+		repository *git.Repository
+		err        error
+	)
+
+	switch {
+	case options.CacheDir != "":
+		repository, err = origin.CloneCached(url, options.CacheDir)
+	case options.Workspace != nil:
+		var dir string
+
+		dir, err = options.Workspace.Create()
+		if err != nil {
+			return nil, err
+		}
+
+		repository, err = origin.CloneTo(url, dir)
+	default:
+		repository, err = origin.Clone(url)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return NewGitRepository(repository, origin, options), nil
+}
+
+// GitRepository is a Git repository cloned from a remote. Analyzing the history of a repository still relies on Git
+// specific features (e.g., trees for monorepo projects, submodules and API diffs), the underlying Git repository and
+// remote are therefore exposed.
+type GitRepository struct {
+	repository *git.Repository
+	origin     *remote.Remote
+	remoteName string
+	tagger     *tag.Tagger
+}
+
+// NewGitRepository wraps a Git repository cloned from the given remote.
+func NewGitRepository(repository *git.Repository, origin *remote.Remote, options Options) *GitRepository {
+	tagger := options.Tagger
+	if tagger == nil {
+		tagger = tag.NewTagger("", "")
+	}
+
+	return &GitRepository{
+		repository: repository,
+		origin:     origin,
+		remoteName: options.RemoteName,
+		tagger:     tagger,
+	}
+}
+
+// Git returns the underlying Git repository.
+func (r *GitRepository) Git() *git.Repository {
+	return r.repository
+}
+
+// Remote returns the remote the repository was cloned from.
+func (r *GitRepository) Remote() *remote.Remote {
+	return r.origin
+}
+
+func (r *GitRepository) Tags() ([]Tag, error) {
+	references, err := r.repository.Tags()
+	if err != nil {
+		return nil, fmt.Errorf("fetching tags: %w", err)
+	}
+
+	var tags []Tag
+
+	err = references.ForEach(func(reference *plumbing.Reference) error {
+		hash := reference.Hash()
+
+		// Annotated tags reference a tag object, lightweight ones directly reference the commit.
+		if tagObject, err := r.repository.TagObject(hash); err == nil {
+			hash = tagObject.Target
+		} else if !errors.Is(err, plumbing.ErrObjectNotFound) {
+			return fmt.Errorf("fetching tag %q: %w", reference.Name().Short(), err)
+		}
+
+		commit, err := r.repository.CommitObject(hash)
+		if err != nil {
+			return fmt.Errorf("fetching tag %q commit: %w", reference.Name().Short(), err)
+		}
+
+		tags = append(tags, Tag{Name: reference.Name().Short(), Commit: commit.Hash.String(), When: commit.Committer.When})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+func (r *GitRepository) CreateTag(name, commit string) error {
+	return r.tagger.CreateTag(r.repository, name, plumbing.NewHash(commit))
+}
+
+func (r *GitRepository) DescribeTag(name string) (TagObject, error) {
+	reference, err := r.repository.Tag(name)
+	if err != nil {
+		return TagObject{}, fmt.Errorf("fetching tag %q: %w", name, err)
+	}
+
+	tagObject, err := r.repository.TagObject(reference.Hash())
+	if err != nil {
+		return TagObject{}, fmt.Errorf("fetching tag %q object: %w", name, err)
+	}
+
+	described := TagObject{Hash: tagObject.Hash.String(), Message: tagObject.Message, Signed: tagObject.PGPSignature != ""}
+
+	if described.Signed {
+		described.Signer, err = tag.Signer(tagObject)
+		if err != nil {
+			return TagObject{}, fmt.Errorf("fetching tag %q signer: %w", name, err)
+		}
+	}
+
+	return described, nil
+}
+
+func (r *GitRepository) PushTag(name string) error {
+	if r.origin == nil {
+		return fmt.Errorf("pushing tag %q: repository has no remote", name)
+	}
+
+	return r.origin.PushTag(name)
+}
+
+func (r *GitRepository) DeleteTag(name string) error {
+	if r.origin == nil {
+		return fmt.Errorf("deleting tag %q: repository has no remote", name)
+	}
+
+	return r.origin.DeleteTag(name)
+}
+
+func (r *GitRepository) MoveTag(name, commit string) (string, error) {
+	var previous string
+
+	ref, err := r.repository.Tag(name)
+	switch {
+	case err == nil:
+		previous = ref.Hash().String()
+
+		err = r.repository.DeleteTag(name)
+		if err != nil {
+			return "", fmt.Errorf("deleting tag %q: %w", name, err)
+		}
+	case !errors.Is(err, git.ErrTagNotFound):
+		return "", fmt.Errorf("fetching tag %q: %w", name, err)
+	}
+
+	err = r.tagger.CreateTag(r.repository, name, plumbing.NewHash(commit))
+	if err != nil {
+		return "", err
+	}
+
+	return previous, nil
+}
+
+func (r *GitRepository) ForcePushTag(name, expected string) error {
+	if r.origin == nil {
+		return fmt.Errorf("pushing tag %q: repository has no remote", name)
+	}
+
+	hash := plumbing.ZeroHash
+	if expected != "" {
+		hash = plumbing.NewHash(expected)
+	}
+
+	return r.origin.ForcePushTag(name, hash)
+}
+
+func (r *GitRepository) UpdateReference(name, commit string) (string, error) {
+	refName := plumbing.ReferenceName(name)
+	hash := plumbing.NewHash(commit)
+
+	var previous plumbing.Hash
+
+	if r.origin != nil {
+		fetched, err := r.origin.FetchReference(refName)
+		if err != nil {
+			return "", err
+		}
+
+		previous = fetched
+	} else if ref, err := r.repository.Reference(refName, true); err == nil {
+		previous = ref.Hash()
+	} else if !errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return "", fmt.Errorf("resolving reference %q: %w", name, err)
+	}
+
+	if refName.IsBranch() && !previous.IsZero() && previous != hash {
+		previousCommit, err := r.repository.CommitObject(previous)
+		if err != nil {
+			return "", fmt.Errorf("fetching commit %s: %w", previous, err)
+		}
+
+		commitObject, err := r.repository.CommitObject(hash)
+		if err != nil {
+			return "", fmt.Errorf("fetching commit %s: %w", commit, err)
+		}
+
+		ancestor, err := previousCommit.IsAncestor(commitObject)
+		if err != nil {
+			return "", fmt.Errorf("checking ancestry of %s: %w", commit, err)
+		}
+
+		if !ancestor {
+			return "", fmt.Errorf("updating branch %q to %s: %w", refName.Short(), commit, ErrNotFastForward)
+		}
+	}
+
+	err := r.repository.Storer.SetReference(plumbing.NewHashReference(refName, hash))
+	if err != nil {
+		return "", fmt.Errorf("updating reference %q: %w", name, err)
+	}
+
+	if previous.IsZero() {
+		return "", nil
+	}
+
+	return previous.String(), nil
+}
+
+func (r *GitRepository) PushReference(name, expected string) error {
+	if r.origin == nil {
+		return fmt.Errorf("pushing reference %q: repository has no remote", name)
+	}
+
+	hash := plumbing.ZeroHash
+	if expected != "" {
+		hash = plumbing.NewHash(expected)
+	}
+
+	return r.origin.ForcePushReference(plumbing.ReferenceName(name), hash)
+}
+
+func (r *GitRepository) Head(branch string) (string, error) {
+	hash, err := r.resolveBranch(branch)
+	if err != nil {
+		return "", err
+	}
+
+	return hash.String(), nil
+}
+
+func (r *GitRepository) ResolveRevision(revision string) (string, error) {
+	hash, err := r.repository.ResolveRevision(plumbing.Revision(revision))
+	if err != nil {
+		return "", fmt.Errorf("resolving revision %q: %w", revision, err)
+	}
+
+	return hash.String(), nil
+}
+
+func (r *GitRepository) ReadFile(commit, path string) ([]byte, error) {
+	commitObject, err := r.repository.CommitObject(plumbing.NewHash(commit))
+	if err != nil {
+		return nil, fmt.Errorf("fetching commit %s: %w", commit, err)
+	}
+
+	file, err := commitObject.File(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %q: %w", path, err)
+	}
+
+	content, err := file.Contents()
+	if err != nil {
+		return nil, fmt.Errorf("reading %q: %w", path, err)
+	}
+
+	return []byte(content), nil
+}
+
+// CommitFiles creates the commit with the identity of the tagger, signed if the tagger has a sign key. The commit is
+// only stored, no branch referencing it.
+func (r *GitRepository) CommitFiles(parent string, files map[string][]byte, message string) (string, error) {
+	parentCommit, err := r.repository.CommitObject(plumbing.NewHash(parent))
+	if err != nil {
+		return "", fmt.Errorf("fetching commit %s: %w", parent, err)
+	}
+
+	treeHash := parentCommit.TreeHash
+
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		treeHash, err = r.writeTree(treeHash, strings.Split(path, "/"), files[path])
+		if err != nil {
+			return "", fmt.Errorf("writing %q: %w", path, err)
+		}
+	}
+
+	signature := r.tagger.GitSignature
+	signature.When = time.Now()
+
+	commit := &object.Commit{
+		Author:       signature,
+		Committer:    signature,
+		Message:      message,
+		TreeHash:     treeHash,
+		ParentHashes: []plumbing.Hash{parentCommit.Hash},
+	}
+
+	if r.tagger.SignKey != nil {
+		encoded := &plumbing.MemoryObject{}
+		if err = commit.EncodeWithoutSignature(encoded); err != nil {
+			return "", fmt.Errorf("encoding commit: %w", err)
+		}
+
+		reader, err := encoded.Reader()
+		if err != nil {
+			return "", fmt.Errorf("reading encoded commit: %w", err)
+		}
+
+		var pgpSignature bytes.Buffer
+		if err = openpgp.ArmoredDetachSign(&pgpSignature, r.tagger.SignKey, reader, nil); err != nil {
+			return "", fmt.Errorf("signing commit: %w", err)
+		}
+
+		commit.PGPSignature = pgpSignature.String()
+	}
+
+	hash, err := r.storeObject(commit)
+	if err != nil {
+		return "", fmt.Errorf("storing commit: %w", err)
+	}
+
+	return hash.String(), nil
+}
+
+func (r *GitRepository) PushBranch(branch, commit string) error {
+	if r.origin == nil {
+		return fmt.Errorf("pushing branch %q: repository has no remote", branch)
+	}
+
+	return r.origin.PushBranch(branch, plumbing.NewHash(commit))
+}
+
+// writeTree stores a copy of the tree with the given hash in which the file at the given path, split in its
+// components, has the given content, and returns the hash of the copy. Missing directories are created.
+func (r *GitRepository) writeTree(treeHash plumbing.Hash, path []string, content []byte) (plumbing.Hash, error) {
+	tree := &object.Tree{}
+
+	if !treeHash.IsZero() {
+		existing, err := r.repository.TreeObject(treeHash)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+
+		tree.Entries = slices.Clone(existing.Entries)
+	}
+
+	index := slices.IndexFunc(tree.Entries, func(entry object.TreeEntry) bool {
+		return entry.Name == path[0]
+	})
+
+	entry := object.TreeEntry{Name: path[0], Mode: filemode.Regular}
+	if index >= 0 {
+		entry = tree.Entries[index]
+	}
+
+	var err error
+
+	if len(path) == 1 {
+		entry.Hash, err = r.storeBlob(content)
+	} else {
+		subtree := plumbing.ZeroHash
+		if index >= 0 && entry.Mode == filemode.Dir {
+			subtree = entry.Hash
+		}
+
+		entry.Mode = filemode.Dir
+		entry.Hash, err = r.writeTree(subtree, path[1:], content)
+	}
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	if index >= 0 {
+		tree.Entries[index] = entry
+	} else {
+		tree.Entries = append(tree.Entries, entry)
+	}
+
+	// Git sorts the entries of a tree by name, the name of a directory being compared as if it ended with a slash.
+	sort.Slice(tree.Entries, func(i, j int) bool {
+		return treeEntryKey(tree.Entries[i]) < treeEntryKey(tree.Entries[j])
+	})
+
+	return r.storeObject(tree)
+}
+
+// storeBlob stores a blob with the given content in the storage of the repository and returns its hash.
+func (r *GitRepository) storeBlob(content []byte) (plumbing.Hash, error) {
+	blob := r.repository.Storer.NewEncodedObject()
+	blob.SetType(plumbing.BlobObject)
+
+	writer, err := blob.Writer()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	if _, err = writer.Write(content); err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	if err = writer.Close(); err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	return r.repository.Storer.SetEncodedObject(blob)
+}
+
+// storeObject encodes an object in the storage of the repository and returns its hash.
+func (r *GitRepository) storeObject(o object.Object) (plumbing.Hash, error) {
+	encoded := r.repository.Storer.NewEncodedObject()
+	if err := o.Encode(encoded); err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	return r.repository.Storer.SetEncodedObject(encoded)
+}
+
+func treeEntryKey(entry object.TreeEntry) string {
+	if entry.Mode == filemode.Dir {
+		return entry.Name + "/"
+	}
+
+	return entry.Name
+}
+
+var (
+	_ Committer        = (*GitRepository)(nil)
+	_ TagMover         = (*GitRepository)(nil)
+	_ ReferenceUpdater = (*GitRepository)(nil)
+	_ TagDescriber     = (*GitRepository)(nil)
+	_ RevisionResolver = (*GitRepository)(nil)
+)
+
+// resolveBranch returns the hash of the commit at the tip of the given branch, preferring the remote reference of the
+// branch which is what exists in a clone.
+func (r *GitRepository) resolveBranch(branch string) (plumbing.Hash, error) {
+	refNames := []plumbing.ReferenceName{
+		plumbing.NewRemoteReferenceName(r.remoteName, branch),
+		plumbing.NewBranchReferenceName(branch),
+	}
+
+	for _, refName := range refNames {
+		ref, err := r.repository.Reference(refName, true)
+		if err == nil {
+			return ref.Hash(), nil
+		}
+
+		if !errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return plumbing.ZeroHash, fmt.Errorf("resolving reference %q: %w", refName, err)
+		}
+	}
+
+	return plumbing.ZeroHash, fmt.Errorf("branch %q does not exist: %w", branch, plumbing.ErrReferenceNotFound)
+}
@@ -0,0 +1,337 @@
+package vcs
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/go-git/go-git/v5/plumbing"
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/remote"
+	"github.com/s0ders/go-semver-release/v6/internal/tag"
+	"github.com/s0ders/go-semver-release/v6/pkg/gittest"
+)
+
+func TestGitRepository_Tags(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(
+		gittest.Commit("feat"),
+		gittest.Tag("v1.0.0"),
+		gittest.Commit("fix"),
+		gittest.Commit("chore"),
+	)
+	checkErr(t, err, "creating test repository")
+
+	defer func() {
+		err = testRepository.Remove()
+		checkErr(t, err, "removing test repository")
+	}()
+
+	repository, err := GitBackend{}.Clone(testRepository.Path, Options{RemoteName: "origin"})
+	checkErr(t, err, "cloning repository")
+
+	tags, err := repository.Tags()
+	checkErr(t, err, "fetching tags")
+
+	if assert.Len(tags, 1) {
+		assert.Equal("v1.0.0", tags[0].Name)
+	}
+}
+
+func TestGitRepository_CreateAndPushTag(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(gittest.Commit("feat"))
+	checkErr(t, err, "creating test repository")
+
+	defer func() {
+		err = testRepository.Remove()
+		checkErr(t, err, "removing test repository")
+	}()
+
+	head, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	tagger := tag.NewTagger("Go Semver Release", "go-semver@release.ci")
+
+	repository, err := GitBackend{}.Clone(testRepository.Path, Options{RemoteName: "origin", Tagger: tagger})
+	checkErr(t, err, "cloning repository")
+
+	err = repository.CreateTag("v1.0.0", head.Hash().String())
+	checkErr(t, err, "creating tag")
+
+	err = repository.CreateTag("v1.0.0", head.Hash().String())
+	assert.ErrorIs(err, tag.ErrTagAlreadyExists)
+
+	err = repository.PushTag("v1.0.0")
+	checkErr(t, err, "pushing tag")
+
+	exists, err := tag.Exists(testRepository.Repository, "v1.0.0")
+	checkErr(t, err, "checking if tag exists")
+
+	assert.True(exists, "tag should have been pushed to the origin")
+
+	err = repository.DeleteTag("v1.0.0")
+	checkErr(t, err, "deleting tag")
+
+	exists, err = tag.Exists(testRepository.Repository, "v1.0.0")
+	checkErr(t, err, "checking if tag exists")
+
+	assert.False(exists, "tag should have been deleted from the origin")
+}
+
+func TestGitRepository_DescribeTag(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(gittest.Commit("feat"))
+	checkErr(t, err, "creating test repository")
+
+	defer func() {
+		err = testRepository.Remove()
+		checkErr(t, err, "removing test repository")
+	}()
+
+	head, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	entity, err := openpgp.NewEntity("Go Semver Release", "", "go-semver@release.ci", nil)
+	checkErr(t, err, "creating openpgp entity")
+
+	tagger := tag.NewTagger("Go Semver Release", "go-semver@release.ci")
+
+	repository, err := GitBackend{}.Clone(testRepository.Path, Options{RemoteName: "origin", Tagger: tagger})
+	checkErr(t, err, "cloning repository")
+
+	err = repository.CreateTag("v1.0.0", head.Hash().String())
+	checkErr(t, err, "creating tag")
+
+	tagger.SetSignKey(entity)
+
+	err = repository.CreateTag("v1.0.1", head.Hash().String())
+	checkErr(t, err, "creating signed tag")
+
+	unsigned, err := repository.(TagDescriber).DescribeTag("v1.0.0")
+	checkErr(t, err, "describing tag")
+
+	assert.False(unsigned.Signed)
+	assert.Empty(unsigned.Signer)
+	assert.Equal("v1.0.0\n", unsigned.Message)
+	assert.NotEqual(head.Hash().String(), unsigned.Hash, "the hash of the tag object should be given, not the commit's")
+
+	signed, err := repository.(TagDescriber).DescribeTag("v1.0.1")
+	checkErr(t, err, "describing signed tag")
+
+	assert.True(signed.Signed)
+	assert.Equal(fmt.Sprintf("%X", entity.PrimaryKey.Fingerprint), signed.Signer)
+
+	_, err = repository.(TagDescriber).DescribeTag("v2.0.0")
+	assert.Error(err, "describing a missing tag should fail")
+}
+
+func TestGitRepository_ResolveRevision(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(gittest.Commit("feat"))
+	checkErr(t, err, "creating test repository")
+
+	defer func() {
+		err = testRepository.Remove()
+		checkErr(t, err, "removing test repository")
+	}()
+
+	head, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	repository, err := GitBackend{}.Clone(testRepository.Path, Options{RemoteName: "origin"})
+	checkErr(t, err, "cloning repository")
+
+	hash, err := repository.(RevisionResolver).ResolveRevision(head.Hash().String()[:7])
+	checkErr(t, err, "resolving abbreviated hash")
+
+	assert.Equal(head.Hash().String(), hash)
+
+	_, err = repository.(RevisionResolver).ResolveRevision("unknown")
+	assert.Error(err, "resolving an unknown revision should fail")
+}
+
+func TestGitRepository_MoveAndForcePushTag(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(gittest.Commit("feat"), gittest.Tag("v1"), gittest.Commit("fix"))
+	checkErr(t, err, "creating test repository")
+
+	defer func() {
+		err = testRepository.Remove()
+		checkErr(t, err, "removing test repository")
+	}()
+
+	head, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	floating, err := testRepository.Tag("v1")
+	checkErr(t, err, "fetching tag")
+
+	tagger := tag.NewTagger("Go Semver Release", "go-semver@release.ci")
+
+	cloned, err := GitBackend{}.Clone(testRepository.Path, Options{RemoteName: "origin", Tagger: tagger})
+	checkErr(t, err, "cloning repository")
+
+	repository := cloned.(TagMover)
+
+	previous, err := repository.MoveTag("v1", head.Hash().String())
+	checkErr(t, err, "moving tag")
+
+	assert.Equal(floating.Hash().String(), previous, "the reference of the replaced tag should be returned")
+
+	err = repository.ForcePushTag("v1", "")
+	assert.ErrorIs(err, remote.ErrStaleLease, "a tag expected to be missing should not replace the remote one")
+
+	err = repository.ForcePushTag("v1", previous)
+	checkErr(t, err, "force pushing tag")
+
+	tags, err := cloned.Tags()
+	checkErr(t, err, "fetching tags")
+
+	assert.Equal([]Tag{{Name: "v1", Commit: head.Hash().String(), When: tags[0].When}}, tags)
+
+	moved, err := testRepository.Tag("v1")
+	checkErr(t, err, "fetching tag")
+
+	assert.NotEqual(floating.Hash(), moved.Hash(), "the tag should have been moved on the origin")
+
+	previous, err = repository.MoveTag("v1.0", head.Hash().String())
+	checkErr(t, err, "creating tag")
+
+	assert.Empty(previous, "no reference should be returned for a new tag")
+}
+
+func TestGitRepository_UpdateAndPushReference(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(gittest.Commit("feat"))
+	checkErr(t, err, "creating test repository")
+
+	defer func() {
+		err = testRepository.Remove()
+		checkErr(t, err, "removing test repository")
+	}()
+
+	first, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	err = testRepository.Storer.SetReference(plumbing.NewHashReference("refs/heads/latest-release", first.Hash()))
+	checkErr(t, err, "creating branch")
+
+	second, err := testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit")
+
+	cloned, err := GitBackend{}.Clone(testRepository.Path, Options{RemoteName: "origin", Branches: []string{"master"}})
+	checkErr(t, err, "cloning repository")
+
+	repository := cloned.(ReferenceUpdater)
+
+	previous, err := repository.UpdateReference("refs/heads/latest-release", second.String())
+	checkErr(t, err, "updating branch")
+
+	assert.Equal(first.Hash().String(), previous, "the remote hash of the branch should be returned, even if it was not cloned")
+
+	err = repository.PushReference("refs/heads/latest-release", previous)
+	checkErr(t, err, "pushing branch")
+
+	ref, err := testRepository.Reference("refs/heads/latest-release", true)
+	checkErr(t, err, "fetching branch")
+
+	assert.Equal(second, ref.Hash(), "the branch should have been fast-forwarded on the origin")
+
+	_, err = repository.UpdateReference("refs/heads/latest-release", first.Hash().String())
+	assert.ErrorIs(err, ErrNotFastForward, "a branch should not be moved backward")
+
+	previous, err = repository.UpdateReference("refs/releases/latest", second.String())
+	checkErr(t, err, "creating reference")
+
+	assert.Empty(previous, "no hash should be returned for a new reference")
+
+	err = repository.PushReference("refs/releases/latest", "")
+	checkErr(t, err, "pushing reference")
+
+	_, err = repository.UpdateReference("refs/releases/latest", first.Hash().String())
+	assert.NoError(err, "references other than branches can be moved to any commit")
+}
+
+func TestGitRepository_CommitAndPushFiles(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.New(gittest.Commit("feat"))
+	checkErr(t, err, "creating test repository")
+
+	defer func() {
+		err = testRepository.Remove()
+		checkErr(t, err, "removing test repository")
+	}()
+
+	parent, err := testRepository.AddCommitWithContent("feat", "charts/api/Chart.yaml", "version: 0.1.0\n")
+	checkErr(t, err, "adding commit")
+
+	// A branch checked out by the origin cannot be pushed.
+	origin, err := testRepository.BareClone()
+	checkErr(t, err, "cloning bare repository")
+
+	defer func() {
+		err = origin.Remove()
+		checkErr(t, err, "removing bare repository")
+	}()
+
+	tagger := tag.NewTagger("Go Semver Release", "go-semver@release.ci")
+
+	cloned, err := GitBackend{}.Clone(origin.Path, Options{RemoteName: "origin", Tagger: tagger})
+	checkErr(t, err, "cloning repository")
+
+	repository := cloned.(Committer)
+
+	tip, err := repository.Head("master")
+	checkErr(t, err, "fetching branch head")
+
+	assert.Equal(parent.String(), tip)
+
+	content, err := repository.ReadFile(parent.String(), "charts/api/Chart.yaml")
+	checkErr(t, err, "reading file")
+
+	assert.Equal("version: 0.1.0\n", string(content))
+
+	commit, err := repository.CommitFiles(parent.String(), map[string][]byte{
+		"charts/api/Chart.yaml": []byte("version: 0.2.0\n"),
+		"charts/web/Chart.yaml": []byte("version: 1.0.0\n"),
+	}, "chore(release): bump charts")
+	checkErr(t, err, "committing files")
+
+	content, err = repository.ReadFile(commit, "charts/api/Chart.yaml")
+	checkErr(t, err, "reading file")
+
+	assert.Equal("version: 0.2.0\n", string(content))
+
+	_, err = repository.ReadFile(commit, "charts/web/Chart.yaml")
+	assert.NoError(err, "missing directories should be created")
+
+	err = repository.PushBranch("master", commit)
+	checkErr(t, err, "pushing branch")
+
+	head, err := origin.Reference(plumbing.NewBranchReferenceName("master"), true)
+	checkErr(t, err, "fetching branch")
+
+	assert.Equal(commit, head.Hash().String(), "the branch of the origin should have been updated")
+
+	pushed, err := origin.CommitObject(head.Hash())
+	checkErr(t, err, "fetching pushed commit")
+
+	assert.Equal("chore(release): bump charts", pushed.Message)
+	assert.Equal([]plumbing.Hash{parent}, pushed.ParentHashes)
+	assert.Equal("Go Semver Release", pushed.Author.Name)
+
+	stale, err := repository.CommitFiles(parent.String(), map[string][]byte{"charts/api/Chart.yaml": []byte("version: 0.3.0\n")}, "chore(release): bump api chart")
+	checkErr(t, err, "committing files")
+
+	err = repository.PushBranch("master", stale)
+	assert.Error(err, "a commit not descending from the tip of the branch should be rejected")
+}
@@ -0,0 +1,154 @@
+// Package vcs provides an abstraction of the version control systems hosting the repositories to release.
+//
+// Backends are registered by name, so that other version control systems than Git can be supported without changing
+// the code relying on them. Only Git is supported for now.
+package vcs
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/s0ders/go-semver-release/v6/internal/tag"
+	"github.com/s0ders/go-semver-release/v6/internal/workspace"
+)
+
+var (
+	ErrUnknownBackend = errors.New("unknown VCS backend")
+	ErrUnsupported    = errors.New("operation not supported by the VCS backend")
+	ErrNotFastForward = errors.New("update is not a fast-forward")
+)
+
+var (
+	registry   = map[string]Backend{}
+	registryMu sync.RWMutex
+)
+
+func init() {
+	Register(GitBackend{})
+}
+
+// Tag is a tag of a repository, Commit being the hash of the tagged commit.
+type Tag struct {
+	Name   string
+	Commit string
+	When   time.Time
+}
+
+// TagObject describes the object of an annotated tag. Signer, set only if the tag is signed, is the fingerprint of the
+// signing key.
+type TagObject struct {
+	Hash    string
+	Message string
+	Signed  bool
+	Signer  string
+}
+
+// Options configures how a repository is cloned and how tags are created and pushed.
+type Options struct {
+	RemoteName string
+	Token      string
+	Tagger     *tag.Tagger
+	// CacheDir, if set, is a directory in which clones are kept between runs to only fetch what changed since.
+	CacheDir string
+	// Workspace, if set, creates the temporary directories in which repositories are cloned and removes them.
+	Workspace *workspace.Manager
+	// Branches, if set, restricts the clone to the given branches and the tags.
+	Branches []string
+	// Progress, if set, receives the progress reported by the remote when cloning.
+	Progress io.Writer
+}
+
+// Repository is a local copy of a remote repository.
+type Repository interface {
+	// Tags returns the tags of the repository.
+	Tags() ([]Tag, error)
+	// CreateTag creates a tag with the given name on the commit with the given hash.
+	CreateTag(name, commit string) error
+	// PushTag pushes the tag with the given name to the remote the repository was cloned from.
+	PushTag(name string) error
+	// DeleteTag deletes the tag with the given name from the repository and from the remote it was cloned from.
+	DeleteTag(name string) error
+}
+
+// Committer is implemented by the repositories in which a release can commit files before being tagged, e.g. the
+// manifests whose version is bumped.
+type Committer interface {
+	// Head returns the hash of the commit at the tip of the given branch.
+	Head(branch string) (string, error)
+	// ReadFile returns the content of the file at the given path, relative to the root of the repository, in the
+	// commit with the given hash.
+	ReadFile(commit, path string) ([]byte, error)
+	// CommitFiles creates a commit on top of the commit with the given hash, replacing the content of the given files,
+	// and returns its hash.
+	CommitFiles(parent string, files map[string][]byte, message string) (string, error)
+	// PushBranch updates the given branch of the remote the repository was cloned from to the commit with the given
+	// hash, which must descend from the current tip of the branch.
+	PushBranch(branch, commit string) error
+}
+
+// TagMover is implemented by the repositories whose tags can be moved, e.g. the floating tags tracking the latest
+// release of a major version.
+type TagMover interface {
+	// MoveTag creates the tag with the given name on the commit with the given hash, replacing the existing tag if
+	// any, and returns the hash of the reference of the replaced tag, empty if there was none.
+	MoveTag(name, commit string) (string, error)
+	// ForcePushTag pushes the tag with the given name to the remote the repository was cloned from, replacing the
+	// remote tag as long as it still has the given reference hash, empty if the tag is expected to be missing.
+	ForcePushTag(name, expected string) error
+}
+
+// ReferenceUpdater is implemented by the repositories whose references other than tags can be updated, e.g. a branch
+// tracking the latest release.
+type ReferenceUpdater interface {
+	// UpdateReference points the reference with the given full name, e.g. "refs/heads/latest-release", to the commit
+	// with the given hash, and returns the hash the remote reference pointed to, empty if it did not exist. A branch
+	// is only fast-forwarded, ErrNotFastForward being returned otherwise.
+	UpdateReference(name, commit string) (string, error)
+	// PushReference pushes the reference with the given full name to the remote the repository was cloned from,
+	// replacing the remote reference as long as it still has the given hash, empty if it is expected to be missing.
+	PushReference(name, expected string) error
+}
+
+// TagDescriber is implemented by the repositories whose tags are objects, e.g. to report the signature of a created
+// tag.
+type TagDescriber interface {
+	// DescribeTag returns the object of the annotated tag with the given name.
+	DescribeTag(name string) (TagObject, error)
+}
+
+// RevisionResolver is implemented by the repositories in which a commit can be designated by a revision, e.g. an
+// abbreviated hash given by a user.
+type RevisionResolver interface {
+	// ResolveRevision returns the hash of the commit designated by the given revision.
+	ResolveRevision(revision string) (string, error)
+}
+
+// Backend clones repositories hosted by a given version control system.
+type Backend interface {
+	Name() string
+	Clone(url string, options Options) (Repository, error)
+}
+
+// Register makes a backend available by its name, replacing any backend previously registered under that name.
+func Register(backend Backend) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	registry[backend.Name()] = backend
+}
+
+// Get returns the backend registered under the given name.
+func Get(name string) (Backend, error) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	backend, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownBackend, name)
+	}
+
+	return backend, nil
+}
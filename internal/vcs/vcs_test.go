@@ -0,0 +1,78 @@
+package vcs
+
+import (
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+type fakeBackend struct{}
+
+func (fakeBackend) Name() string {
+	return "fake"
+}
+
+func (fakeBackend) Clone(_ string, _ Options) (Repository, error) {
+	return fakeRepository{}, nil
+}
+
+type fakeRepository struct{}
+
+func (fakeRepository) Tags() ([]Tag, error) {
+	return nil, nil
+}
+
+func (fakeRepository) CreateTag(_, _ string) error {
+	return nil
+}
+
+func (fakeRepository) PushTag(_ string) error {
+	return nil
+}
+
+func (fakeRepository) DeleteTag(_ string) error {
+	return nil
+}
+
+func TestVCS_Get_Git(t *testing.T) {
+	assert := assertion.New(t)
+
+	backend, err := Get("git")
+	checkErr(t, err, "getting git backend")
+
+	assert.Equal("git", backend.Name())
+}
+
+func TestVCS_Get_Unknown(t *testing.T) {
+	assert := assertion.New(t)
+
+	_, err := Get("hg")
+	assert.ErrorIs(err, ErrUnknownBackend)
+}
+
+func TestVCS_Register(t *testing.T) {
+	assert := assertion.New(t)
+
+	Register(fakeBackend{})
+
+	defer func() {
+		registryMu.Lock()
+		delete(registry, "fake")
+		registryMu.Unlock()
+	}()
+
+	backend, err := Get("fake")
+	checkErr(t, err, "getting fake backend")
+
+	repository, err := backend.Clone("", Options{})
+	checkErr(t, err, "cloning fake repository")
+
+	assert.IsType(fakeRepository{}, repository)
+}
+
+func checkErr(t *testing.T, err error, msg string) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %s", msg, err)
+	}
+}
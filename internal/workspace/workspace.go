@@ -0,0 +1,150 @@
+// Package workspace manages the temporary directories in which repositories are cloned during a run.
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Prefix is the prefix of the name of every workspace directory, so that stale ones can be told apart from the other
+// content of the temporary directory.
+const Prefix = "go-semver-release-"
+
+type OptionFunc func(m *Manager)
+
+// WithRoot sets the directory in which workspaces are created, the default temporary directory being used otherwise.
+func WithRoot(root string) OptionFunc {
+	return func(m *Manager) {
+		m.root = root
+	}
+}
+
+// WithKeep keeps the workspaces once the run is over instead of removing them, e.g. to debug a failed run.
+func WithKeep(keep bool) OptionFunc {
+	return func(m *Manager) {
+		m.keep = keep
+	}
+}
+
+// WithTTL sets the age after which a workspace left behind by a previous run is considered stale and swept.
+func WithTTL(ttl time.Duration) OptionFunc {
+	return func(m *Manager) {
+		m.ttl = ttl
+	}
+}
+
+// Manager creates the workspaces of a run and removes them once it is over, whether it succeeded or not.
+type Manager struct {
+	root string
+	keep bool
+	ttl  time.Duration
+
+	mu   sync.Mutex
+	dirs []string
+}
+
+func New(options ...OptionFunc) *Manager {
+	m := &Manager{
+		root: os.TempDir(),
+	}
+
+	for _, option := range options {
+		option(m)
+	}
+
+	return m
+}
+
+// Create creates a new workspace directory and registers it for removal by Cleanup.
+func (m *Manager) Create() (string, error) {
+	dir, err := os.MkdirTemp(m.root, Prefix+"*")
+	if err != nil {
+		return "", fmt.Errorf("creating workspace: %w", err)
+	}
+
+	m.mu.Lock()
+	m.dirs = append(m.dirs, dir)
+	m.mu.Unlock()
+
+	return dir, nil
+}
+
+// Dirs returns the workspaces created so far.
+func (m *Manager) Dirs() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]string(nil), m.dirs...)
+}
+
+// Cleanup removes every workspace created so far, unless they are to be kept.
+func (m *Manager) Cleanup() error {
+	if m.keep {
+		return nil
+	}
+
+	m.mu.Lock()
+	dirs := m.dirs
+	m.dirs = nil
+	m.mu.Unlock()
+
+	var errs []error
+
+	for _, dir := range dirs {
+		if err := os.RemoveAll(dir); err != nil {
+			errs = append(errs, fmt.Errorf("removing workspace %q: %w", dir, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Sweep removes the workspaces older than the TTL, left behind by runs that could not clean up after themselves
+// (e.g., killed ones), and returns their paths. Nothing is swept if no TTL is set.
+func (m *Manager) Sweep() ([]string, error) {
+	if m.ttl <= 0 {
+		return nil, nil
+	}
+
+	entries, err := os.ReadDir(m.root)
+	if err != nil {
+		return nil, fmt.Errorf("reading workspaces root: %w", err)
+	}
+
+	var (
+		swept []string
+		errs  []error
+	)
+
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), Prefix) {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			// The workspace was removed in the meantime, e.g. by a concurrent run.
+			continue
+		}
+
+		if time.Since(info.ModTime()) < m.ttl {
+			continue
+		}
+
+		dir := filepath.Join(m.root, entry.Name())
+
+		if err = os.RemoveAll(dir); err != nil {
+			errs = append(errs, fmt.Errorf("removing stale workspace %q: %w", dir, err))
+			continue
+		}
+
+		swept = append(swept, dir)
+	}
+
+	return swept, errors.Join(errs...)
+}
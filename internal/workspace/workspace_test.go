@@ -0,0 +1,86 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestManager_Cleanup(t *testing.T) {
+	assert := assertion.New(t)
+
+	root := t.TempDir()
+	manager := New(WithRoot(root))
+
+	first, err := manager.Create()
+	checkErr(t, err, "creating workspace")
+
+	second, err := manager.Create()
+	checkErr(t, err, "creating workspace")
+
+	assert.NotEqual(first, second)
+	assert.True(strings.HasPrefix(filepath.Base(first), Prefix))
+	assert.Equal([]string{first, second}, manager.Dirs())
+
+	checkErr(t, manager.Cleanup(), "cleaning up workspaces")
+
+	assert.NoDirExists(first)
+	assert.NoDirExists(second)
+	assert.Empty(manager.Dirs())
+}
+
+func TestManager_Keep(t *testing.T) {
+	assert := assertion.New(t)
+
+	manager := New(WithRoot(t.TempDir()), WithKeep(true))
+
+	dir, err := manager.Create()
+	checkErr(t, err, "creating workspace")
+
+	checkErr(t, manager.Cleanup(), "cleaning up workspaces")
+
+	assert.DirExists(dir, "kept workspaces should not be removed")
+}
+
+func TestManager_Sweep(t *testing.T) {
+	assert := assertion.New(t)
+
+	root := t.TempDir()
+	old := time.Now().Add(-48 * time.Hour)
+
+	stale := filepath.Join(root, Prefix+"stale")
+	recent := filepath.Join(root, Prefix+"recent")
+	foreign := filepath.Join(root, "foreign")
+
+	for _, dir := range []string{stale, recent, foreign} {
+		checkErr(t, os.Mkdir(dir, 0o755), "creating directory")
+	}
+
+	for _, dir := range []string{stale, foreign} {
+		checkErr(t, os.Chtimes(dir, old, old), "aging directory")
+	}
+
+	swept, err := New(WithRoot(root), WithTTL(24*time.Hour)).Sweep()
+	checkErr(t, err, "sweeping workspaces")
+
+	assert.Equal([]string{stale}, swept)
+	assert.NoDirExists(stale)
+	assert.DirExists(recent, "workspaces younger than the TTL should be kept")
+	assert.DirExists(foreign, "directories that are not workspaces should be kept")
+
+	swept, err = New(WithRoot(root)).Sweep()
+	checkErr(t, err, "sweeping workspaces")
+
+	assert.Empty(swept, "nothing should be swept without a TTL")
+}
+
+func checkErr(t *testing.T, err error, msg string) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %s", msg, err)
+	}
+}
@@ -0,0 +1,566 @@
+// Package gittest provides types and functions to build Git repositories for testing operations related to them, such as
+// integration tests of custom release policies.
+//
+// A repository history can either be built with the methods of TestRepository or described as a sequence of steps:
+//
+//	repository, err := gittest.New(
+//		gittest.Commit("feat"),
+//		gittest.Tag("v1.0.0"),
+//		gittest.Origin(),
+//		gittest.RemoteCommit("master", "fix"),
+//	)
+package gittest
+
+import (
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+const sampleFile = "sample.txt"
+
+var referenceTime = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+type TestRepository struct {
+	*git.Repository
+	// Origin is the bare repository used as the "origin" remote, if any.
+	Origin       *TestRepository
+	RemoteServer *http.Server
+	RemoteURL    string
+	Path         string
+	Counter      uint
+}
+
+// NewRepository creates a new TestRepository.
+func NewRepository() (*TestRepository, error) {
+	testRepository := &TestRepository{}
+
+	path, err := os.MkdirTemp("", "gittest-*")
+	if err != nil {
+		return testRepository, fmt.Errorf("creating temporary directory: %w", err)
+	}
+
+	testRepository.Path = path
+
+	repository, err := git.PlainInit(path, false)
+	if err != nil {
+		return testRepository, fmt.Errorf("initializing repository: %s", err)
+	}
+
+	testRepository.Repository = repository
+
+	tempFilePath := filepath.Join(path, sampleFile)
+
+	commitFile, err := os.Create(tempFilePath)
+	if err != nil {
+		return testRepository, fmt.Errorf("creating first commit file: %s", err)
+	}
+
+	defer func() {
+		err = commitFile.Close()
+	}()
+
+	_, err = commitFile.WriteString("...")
+	if err != nil {
+		return testRepository, err
+	}
+
+	worktree, err := repository.Worktree()
+	if err != nil {
+		return testRepository, fmt.Errorf("fetching worktree: %w", err)
+	}
+
+	_, err = worktree.Add(sampleFile)
+	if err != nil {
+		return testRepository, fmt.Errorf("adding commit file to worktree: %w", err)
+	}
+
+	_, err = worktree.Commit("First commit", &git.CommitOptions{
+		Author: &object.Signature{
+			Name:  "Go Semver Release",
+			Email: "go-semver@release.ci",
+			When:  referenceTime,
+		},
+	})
+	if err != nil {
+		return testRepository, fmt.Errorf("creating commit: %w", err)
+	}
+
+	return testRepository, err
+}
+
+// Clone clones the current TestRepository to a temporary directory and returns the clone of that repository. This
+// method is useful when testing on repository that are expected to have a configured remote.
+func (r *TestRepository) Clone() (*TestRepository, error) {
+	testRepository := &TestRepository{}
+
+	tempDir, err := os.MkdirTemp("", "*")
+	if err != nil {
+		return nil, fmt.Errorf("creating temporary directory: %w", err)
+	}
+
+	testRepository.Path = tempDir
+	testRepository.Repository, err = git.PlainClone(tempDir, false, &git.CloneOptions{
+		URL:      r.Path,
+		Progress: io.Discard,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cloning repository: %w", err)
+	}
+
+	return testRepository, nil
+}
+
+// BareClone clones the current TestRepository as a bare repository to a temporary directory. Branches are kept as
+// local references, the same way they are stored in a repository hosted on a Git server.
+func (r *TestRepository) BareClone() (*TestRepository, error) {
+	testRepository := &TestRepository{}
+
+	tempDir, err := os.MkdirTemp("", "*.git")
+	if err != nil {
+		return nil, fmt.Errorf("creating temporary directory: %w", err)
+	}
+
+	testRepository.Path = tempDir
+	testRepository.Repository, err = git.PlainClone(tempDir, true, &git.CloneOptions{
+		URL:      r.Path,
+		Mirror:   true,
+		Progress: io.Discard,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cloning repository: %w", err)
+	}
+
+	return testRepository, nil
+}
+
+// AddCommit adds a new commit with a given conventional commit type to the underlying Git repository.
+func (r *TestRepository) AddCommit(commitType string) (plumbing.Hash, error) {
+	return r.AddCommitWithMessage(fmt.Sprintf("%s: this a test commit", commitType))
+}
+
+// AddCommitWithMessage adds a new commit with the given message to the underlying Git repository, e.g. to test
+// trailers or markers in commit messages.
+func (r *TestRepository) AddCommitWithMessage(commitMessage string) (plumbing.Hash, error) {
+	var commitHash plumbing.Hash
+
+	worktree, err := r.Worktree()
+	if err != nil {
+		return commitHash, fmt.Errorf("fetching worktree: %w", err)
+	}
+
+	commitFilePath := filepath.Join(r.Path, sampleFile)
+
+	err = os.WriteFile(commitFilePath, []byte(strconv.Itoa(rand.IntN(10000))), 0o644)
+	if err != nil {
+		return commitHash, fmt.Errorf("writing commit file: %w", err)
+	}
+
+	_, err = worktree.Add(sampleFile)
+	if err != nil {
+		return commitHash, fmt.Errorf("adding commit file to worktree: %w", err)
+	}
+
+	when := r.When()
+
+	commitOpts := &git.CommitOptions{
+		Committer: &object.Signature{
+			Name:  "Go Semver Release",
+			Email: "go-semver@release.ci",
+			When:  when,
+		},
+		Author: &object.Signature{
+			Name:  "Go Semver Release",
+			Email: "go-semver@release.ci",
+			When:  when,
+		},
+	}
+
+	commitHash, err = worktree.Commit(commitMessage, commitOpts)
+	if err != nil {
+		return commitHash, fmt.Errorf("creating commit: %w", err)
+	}
+
+	return commitHash, nil
+}
+
+// AddCommitWithSpecificFile adds a new commit with a given conventional commit type that writes random content to the
+// file located at the given path.
+func (r *TestRepository) AddCommitWithSpecificFile(commitType, filePath string) (plumbing.Hash, error) {
+	return r.AddCommitWithContent(commitType, filePath, strconv.Itoa(rand.IntN(10000)))
+}
+
+// AddCommitWithContent adds a new commit with a given conventional commit type that writes the given content to the file
+// located at the given path.
+func (r *TestRepository) AddCommitWithContent(commitType, filePath, content string) (plumbing.Hash, error) {
+	var commitHash plumbing.Hash
+
+	worktree, err := r.Worktree()
+	if err != nil {
+		return commitHash, fmt.Errorf("fetching worktree: %w", err)
+	}
+
+	commitFilePath := filepath.Clean(filepath.Join(r.Path, filePath))
+	dirs := filepath.Dir(commitFilePath)
+
+	err = os.MkdirAll(dirs, os.ModePerm)
+	if err != nil {
+		return commitHash, fmt.Errorf("creating parent directory: %w", err)
+	}
+
+	err = os.WriteFile(commitFilePath, []byte(content), 0o644)
+	if err != nil {
+		return commitHash, fmt.Errorf("writing commit file: %w", err)
+	}
+
+	_, err = worktree.Add(filepath.Clean(filePath))
+	if err != nil {
+		return commitHash, fmt.Errorf("adding commit file to worktree: %w", err)
+	}
+
+	commitMessage := fmt.Sprintf("%s: this a test commit", commitType)
+
+	when := r.When()
+
+	commitOpts := &git.CommitOptions{
+		Committer: &object.Signature{
+			Name:  "Go Semver Release",
+			Email: "go-semver@release.ci",
+			When:  when,
+		},
+		Author: &object.Signature{
+			Name:  "Go Semver Release",
+			Email: "go-semver@release.ci",
+			When:  when,
+		},
+	}
+
+	commitHash, err = worktree.Commit(commitMessage, commitOpts)
+	if err != nil {
+		return commitHash, fmt.Errorf("creating commit: %w", err)
+	}
+
+	return commitHash, nil
+}
+
+// AddSubmoduleCommit adds a new commit with a given conventional commit type that points the submodule located at the
+// given path to the given commit hash. The submodule is declared in the .gitmodules file with the given URL.
+func (r *TestRepository) AddSubmoduleCommit(commitType, path, url string, hash plumbing.Hash) (plumbing.Hash, error) {
+	head, err := r.Head()
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("fetching head: %w", err)
+	}
+
+	headCommit, err := r.CommitObject(head.Hash())
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("fetching head commit: %w", err)
+	}
+
+	tree, err := headCommit.Tree()
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("fetching head tree: %w", err)
+	}
+
+	modules := config.NewModules()
+	modules.Submodules[path] = &config.Submodule{Name: path, Path: path, URL: url}
+
+	modulesContent, err := modules.Marshal()
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("marshalling submodules configuration: %w", err)
+	}
+
+	modulesHash, err := r.storeBlob(modulesContent)
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("storing submodules configuration: %w", err)
+	}
+
+	treeHash, err := r.updateTree(tree, []string{".gitmodules"}, object.TreeEntry{Mode: filemode.Regular, Hash: modulesHash})
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("adding submodules configuration to tree: %w", err)
+	}
+
+	tree, err = r.TreeObject(treeHash)
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("fetching tree: %w", err)
+	}
+
+	treeHash, err = r.updateTree(tree, strings.Split(path, "/"), object.TreeEntry{Mode: filemode.Submodule, Hash: hash})
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("adding submodule to tree: %w", err)
+	}
+
+	signature := object.Signature{
+		Name:  "Go Semver Release",
+		Email: "go-semver@release.ci",
+		When:  r.When(),
+	}
+
+	commit := &object.Commit{
+		Author:       signature,
+		Committer:    signature,
+		Message:      fmt.Sprintf("%s: this a test commit", commitType),
+		TreeHash:     treeHash,
+		ParentHashes: []plumbing.Hash{head.Hash()},
+	}
+
+	encodedCommit := r.Storer.NewEncodedObject()
+
+	err = commit.Encode(encodedCommit)
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("encoding commit: %w", err)
+	}
+
+	commitHash, err := r.Storer.SetEncodedObject(encodedCommit)
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("storing commit: %w", err)
+	}
+
+	headRef := head.Name()
+	if headRef == plumbing.HEAD {
+		return plumbing.ZeroHash, fmt.Errorf("cannot add commit on a detached head")
+	}
+
+	err = r.Storer.SetReference(plumbing.NewHashReference(headRef, commitHash))
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("updating head reference: %w", err)
+	}
+
+	return commitHash, nil
+}
+
+// updateTree stores a copy of the given tree in which the entry located at the given path has been replaced by the given
+// entry, creating intermediate trees if needed, and returns the hash of the new tree.
+func (r *TestRepository) updateTree(tree *object.Tree, path []string, entry object.TreeEntry) (plumbing.Hash, error) {
+	var entries []object.TreeEntry
+	if tree != nil {
+		entries = append(entries, tree.Entries...)
+	}
+
+	entry.Name = path[0]
+
+	if len(path) > 1 {
+		var subtree *object.Tree
+
+		for _, e := range entries {
+			if e.Name == path[0] && e.Mode == filemode.Dir {
+				var err error
+
+				subtree, err = r.TreeObject(e.Hash)
+				if err != nil {
+					return plumbing.ZeroHash, err
+				}
+			}
+		}
+
+		subtreeHash, err := r.updateTree(subtree, path[1:], entry)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+
+		entry = object.TreeEntry{Name: path[0], Mode: filemode.Dir, Hash: subtreeHash}
+	}
+
+	replaced := false
+
+	for i, e := range entries {
+		if e.Name == entry.Name {
+			entries[i] = entry
+			replaced = true
+		}
+	}
+
+	if !replaced {
+		entries = append(entries, entry)
+	}
+
+	// Git expects tree entries to be sorted, directories being compared as if their name ended with a slash.
+	sort.Slice(entries, func(i, j int) bool {
+		return treeEntrySortName(entries[i]) < treeEntrySortName(entries[j])
+	})
+
+	newTree := &object.Tree{Entries: entries}
+
+	encodedTree := r.Storer.NewEncodedObject()
+
+	if err := newTree.Encode(encodedTree); err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	return r.Storer.SetEncodedObject(encodedTree)
+}
+
+func (r *TestRepository) storeBlob(content []byte) (plumbing.Hash, error) {
+	encodedBlob := r.Storer.NewEncodedObject()
+	encodedBlob.SetType(plumbing.BlobObject)
+
+	writer, err := encodedBlob.Writer()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	if _, err = writer.Write(content); err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	if err = writer.Close(); err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	return r.Storer.SetEncodedObject(encodedBlob)
+}
+
+func treeEntrySortName(entry object.TreeEntry) string {
+	if entry.Mode == filemode.Dir {
+		return entry.Name + "/"
+	}
+
+	return entry.Name
+}
+
+// AddTag adds a new tag to the underlying Git repository with a given name and pointing to a given hash.
+func (r *TestRepository) AddTag(tagName string, hash plumbing.Hash) error {
+	commit, err := r.CommitObject(hash)
+	if err != nil {
+		return fmt.Errorf("getting commit: %w", err)
+	}
+
+	tagOpts := &git.CreateTagOptions{
+		Message: tagName,
+		Tagger: &object.Signature{
+			Name:  "Go Semver Release",
+			Email: "go-semver@release.ci",
+			When:  commit.Committer.When,
+		},
+	}
+
+	_, err = r.CreateTag(tagName, hash, tagOpts)
+
+	return err
+}
+
+// AddMergeCommit adds a merge commit of the given branch into the current one, with the given message. The merge
+// commit takes the content of the merged branch, as a merge of a branch the current one has not diverged from would.
+func (r *TestRepository) AddMergeCommit(branch, message string) (plumbing.Hash, error) {
+	head, err := r.Head()
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("fetching head: %w", err)
+	}
+
+	merged, err := r.Reference(plumbing.NewBranchReferenceName(branch), true)
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("fetching merged branch: %w", err)
+	}
+
+	mergedCommit, err := r.CommitObject(merged.Hash())
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("fetching merged commit: %w", err)
+	}
+
+	signature := object.Signature{
+		Name:  "Go Semver Release",
+		Email: "go-semver@release.ci",
+		When:  r.When(),
+	}
+
+	commit := &object.Commit{
+		Author:       signature,
+		Committer:    signature,
+		Message:      message,
+		TreeHash:     mergedCommit.TreeHash,
+		ParentHashes: []plumbing.Hash{head.Hash(), merged.Hash()},
+	}
+
+	encodedCommit := r.Storer.NewEncodedObject()
+
+	err = commit.Encode(encodedCommit)
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("encoding commit: %w", err)
+	}
+
+	commitHash, err := r.Storer.SetEncodedObject(encodedCommit)
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("storing commit: %w", err)
+	}
+
+	worktree, err := r.Worktree()
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("fetching worktree: %w", err)
+	}
+
+	// Moves the current branch to the merge commit and updates the worktree accordingly.
+	err = worktree.Reset(&git.ResetOptions{Commit: commitHash, Mode: git.HardReset})
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("updating current branch: %w", err)
+	}
+
+	return commitHash, nil
+}
+
+// Remove removes the underlying Git repository, along with its origin and the server serving it if any.
+func (r *TestRepository) Remove() error {
+	if r.RemoteServer != nil {
+		if err := r.RemoteServer.Close(); err != nil {
+			return err
+		}
+	}
+
+	if r.Origin != nil {
+		if err := r.Origin.Remove(); err != nil {
+			return err
+		}
+	}
+
+	return os.RemoveAll(r.Path)
+}
+
+// CheckoutBranch creates a new branch with the given name and checkout to it.
+func (r *TestRepository) CheckoutBranch(name string) error {
+	head, err := r.Head()
+	if err != nil {
+		return err
+	}
+
+	refName := "refs/heads/" + name
+	ref := plumbing.NewHashReference(plumbing.ReferenceName(refName), head.Hash())
+
+	err = r.Storer.SetReference(ref)
+	if err != nil {
+		return err
+	}
+
+	branchCoOpts := &git.CheckoutOptions{
+		Branch: plumbing.ReferenceName(refName),
+		Force:  true,
+	}
+
+	worktree, err := r.Worktree()
+	if err != nil {
+		return err
+	}
+
+	err = worktree.Checkout(branchCoOpts)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// When returns a time.Time starting at 2000/01/01 00:00:00 and increasing of 10 second every new call.
+func (r *TestRepository) When() time.Time {
+	r.Counter++
+	return referenceTime.Add(time.Duration(r.Counter*10) * time.Second)
+}
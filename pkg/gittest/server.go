@@ -0,0 +1,137 @@
+package gittest
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/cgi"
+	"net/http/httptest"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+type serverConfig struct {
+	username string
+	password string
+	failures int
+	readOnly bool
+}
+
+// ServerOption configures the behavior of the HTTP Git server started by Serve.
+type ServerOption func(*serverConfig)
+
+// WithBasicAuth requires every request to be authenticated with the given credentials.
+func WithBasicAuth(username, password string) ServerOption {
+	return func(c *serverConfig) {
+		c.username = username
+		c.password = password
+	}
+}
+
+// WithFailures makes the server answer the first n requests with a 503 Service Unavailable status, simulating a
+// transient outage of the Git server.
+func WithFailures(n int) ServerOption {
+	return func(c *serverConfig) {
+		c.failures = n
+	}
+}
+
+// WithReadOnly makes the server reject every push with a 403 Forbidden status, simulating a protected repository.
+func WithReadOnly() ServerOption {
+	return func(c *serverConfig) {
+		c.readOnly = true
+	}
+}
+
+// Serve starts a smart HTTP Git server serving the origin of the repository, which is created if needed. The URL of
+// the served repository is stored in RemoteURL and the server in RemoteServer, so that remote clones and pushes can be
+// tested end-to-end. The server relies on "git http-backend" and is stopped when the repository is removed.
+func Serve(options ...ServerOption) Step {
+	return func(r *TestRepository) error {
+		if r.Origin == nil {
+			if err := Origin()(r); err != nil {
+				return err
+			}
+		}
+
+		gitPath, err := exec.LookPath("git")
+		if err != nil {
+			return fmt.Errorf("looking up git binary: %w", err)
+		}
+
+		// Pushes over HTTP are otherwise only accepted from authenticated users, which the backend does not know about.
+		cfg, err := r.Origin.Config()
+		if err != nil {
+			return fmt.Errorf("reading origin configuration: %w", err)
+		}
+
+		cfg.Raw.Section("http").SetOption("receivepack", "true")
+
+		if err = r.Origin.SetConfig(cfg); err != nil {
+			return fmt.Errorf("writing origin configuration: %w", err)
+		}
+
+		config := &serverConfig{}
+		for _, option := range options {
+			option(config)
+		}
+
+		repositoryPath := "/" + filepath.Base(r.Origin.Path)
+
+		backend := &cgi.Handler{
+			Path: gitPath,
+			Args: []string{"http-backend"},
+			Env: []string{
+				"GIT_PROJECT_ROOT=" + filepath.Dir(r.Origin.Path),
+				"GIT_HTTP_EXPORT_ALL=1",
+			},
+		}
+
+		var mu sync.Mutex
+
+		handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			mu.Lock()
+			fail := config.failures > 0
+			if fail {
+				config.failures--
+			}
+			mu.Unlock()
+
+			if fail {
+				http.Error(w, "service unavailable", http.StatusServiceUnavailable)
+				return
+			}
+
+			if config.username != "" || config.password != "" {
+				username, password, ok := req.BasicAuth()
+				if !ok || username != config.username || password != config.password {
+					w.Header().Set("WWW-Authenticate", `Basic realm="gittest"`)
+					http.Error(w, "unauthorized", http.StatusUnauthorized)
+					return
+				}
+			}
+
+			isPush := req.URL.Query().Get("service") == "git-receive-pack" || strings.HasSuffix(req.URL.Path, "/git-receive-pack")
+
+			if config.readOnly && isPush {
+				http.Error(w, "forbidden", http.StatusForbidden)
+				return
+			}
+
+			if req.URL.Path != repositoryPath && !strings.HasPrefix(req.URL.Path, repositoryPath+"/") {
+				http.NotFound(w, req)
+				return
+			}
+
+			backend.ServeHTTP(w, req)
+		})
+
+		server := httptest.NewServer(handler)
+
+		r.RemoteServer = server.Config
+		r.RemoteURL = server.URL + repositoryPath
+
+		return nil
+	}
+}
@@ -0,0 +1,48 @@
+package gittest
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestServer_Serve(t *testing.T) {
+	assert := assertion.New(t)
+
+	r, err := New(Commit("feat"), Serve(WithBasicAuth("user", "secret"), WithFailures(1)))
+	checkErr(t, "building repository", err)
+
+	t.Cleanup(func() {
+		_ = r.Remove()
+	})
+
+	assert.NotNil(r.Origin, "origin should have been created")
+
+	advertisementURL := r.RemoteURL + "/info/refs?service=git-upload-pack"
+
+	resp, err := http.Get(advertisementURL)
+	checkErr(t, "requesting references", err)
+	_ = resp.Body.Close()
+	assert.Equal(http.StatusServiceUnavailable, resp.StatusCode, "first request should fail")
+
+	resp, err = http.Get(advertisementURL)
+	checkErr(t, "requesting references", err)
+	_ = resp.Body.Close()
+	assert.Equal(http.StatusUnauthorized, resp.StatusCode, "unauthenticated request should be rejected")
+
+	req, err := http.NewRequest(http.MethodGet, advertisementURL, nil)
+	checkErr(t, "creating request", err)
+	req.SetBasicAuth("user", "secret")
+
+	resp, err = http.DefaultClient.Do(req)
+	checkErr(t, "requesting references", err)
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	checkErr(t, "reading references", err)
+
+	assert.Equal(http.StatusOK, resp.StatusCode)
+	assert.Contains(string(body), "refs/heads/master")
+}
@@ -0,0 +1,218 @@
+package gittest
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+const originName = "origin"
+
+var ErrNoOrigin = errors.New("repository has no origin, the Origin step must be applied first")
+
+// Step is an action applied to a TestRepository, so that a repository history can be described as a sequence of steps.
+type Step func(r *TestRepository) error
+
+// New creates a new TestRepository and applies the given steps to it.
+func New(steps ...Step) (*TestRepository, error) {
+	r, err := NewRepository()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = r.Apply(steps...); err != nil {
+		return nil, errors.Join(err, r.Remove())
+	}
+
+	return r, nil
+}
+
+// Apply applies the given steps to the repository, in order.
+func (r *TestRepository) Apply(steps ...Step) error {
+	for i, step := range steps {
+		if err := step(r); err != nil {
+			return fmt.Errorf("applying step %d: %w", i+1, err)
+		}
+	}
+
+	return nil
+}
+
+// Commit adds a commit with the given conventional commit type on the current branch.
+func Commit(commitType string) Step {
+	return func(r *TestRepository) error {
+		_, err := r.AddCommit(commitType)
+		return err
+	}
+}
+
+// CommitMessage adds a commit with the given message on the current branch.
+func CommitMessage(message string) Step {
+	return func(r *TestRepository) error {
+		_, err := r.AddCommitWithMessage(message)
+		return err
+	}
+}
+
+// CommitFile adds a commit with the given conventional commit type writing the given content to the file located at
+// the given path, e.g. to change a given project of a monorepo.
+func CommitFile(commitType, path, content string) Step {
+	return func(r *TestRepository) error {
+		_, err := r.AddCommitWithContent(commitType, path, content)
+		return err
+	}
+}
+
+// Merge adds a commit merging the given branch into the current one with the given message.
+func Merge(branch, message string) Step {
+	return func(r *TestRepository) error {
+		_, err := r.AddMergeCommit(branch, message)
+		return err
+	}
+}
+
+// Tag adds an annotated tag with the given name on the current commit.
+func Tag(name string) Step {
+	return func(r *TestRepository) error {
+		head, err := r.Head()
+		if err != nil {
+			return fmt.Errorf("fetching head: %w", err)
+		}
+
+		return r.AddTag(name, head.Hash())
+	}
+}
+
+// Branch creates a branch with the given name on the current commit and checks it out.
+func Branch(name string) Step {
+	return func(r *TestRepository) error {
+		return r.CheckoutBranch(name)
+	}
+}
+
+// Checkout checks out an existing branch.
+func Checkout(name string) Step {
+	return func(r *TestRepository) error {
+		worktree, err := r.Worktree()
+		if err != nil {
+			return fmt.Errorf("fetching worktree: %w", err)
+		}
+
+		return worktree.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName(name), Force: true})
+	}
+}
+
+// Origin creates a bare copy of the repository, branches and tags included, and configures it as the "origin" remote
+// of the repository. The path of the bare repository is stored in RemoteURL, so that it can be released as a remote
+// repository would be.
+func Origin() Step {
+	return func(r *TestRepository) error {
+		origin, err := r.BareClone()
+		if err != nil {
+			return fmt.Errorf("creating origin: %w", err)
+		}
+
+		_, err = r.CreateRemote(&config.RemoteConfig{Name: originName, URLs: []string{origin.Path}})
+		if err != nil {
+			return errors.Join(fmt.Errorf("configuring origin: %w", err), origin.Remove())
+		}
+
+		r.Origin = origin
+		r.RemoteURL = origin.Path
+
+		return nil
+	}
+}
+
+// Push pushes every branch and tag of the repository to its origin.
+func Push() Step {
+	return func(r *TestRepository) error {
+		if r.Origin == nil {
+			return ErrNoOrigin
+		}
+
+		return push(r.Repository, "+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*")
+	}
+}
+
+// Fetch fetches the branches and tags of the origin of the repository, e.g. after a release pushed a tag to it.
+func Fetch() Step {
+	return func(r *TestRepository) error {
+		if r.Origin == nil {
+			return ErrNoOrigin
+		}
+
+		err := r.Repository.Fetch(&git.FetchOptions{
+			RemoteName: originName,
+			RefSpecs:   []config.RefSpec{"+refs/heads/*:refs/remotes/origin/*", "+refs/tags/*:refs/tags/*"},
+			Progress:   io.Discard,
+		})
+		if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+			return fmt.Errorf("fetching origin: %w", err)
+		}
+
+		return nil
+	}
+}
+
+// RemoteCommit simulates another contributor pushing a commit with the given conventional commit type to a branch of
+// the origin, which the repository does not know about until it is fetched.
+func RemoteCommit(branch, commitType string) Step {
+	return func(r *TestRepository) (err error) {
+		if r.Origin == nil {
+			return ErrNoOrigin
+		}
+
+		path, err := os.MkdirTemp("", "gittest-*")
+		if err != nil {
+			return fmt.Errorf("creating temporary directory: %w", err)
+		}
+
+		defer func() {
+			err = errors.Join(err, os.RemoveAll(path))
+		}()
+
+		clone, err := git.PlainClone(path, false, &git.CloneOptions{
+			URL:           r.Origin.Path,
+			ReferenceName: plumbing.NewBranchReferenceName(branch),
+			SingleBranch:  true,
+			Progress:      io.Discard,
+		})
+		if err != nil {
+			return fmt.Errorf("cloning origin: %w", err)
+		}
+
+		// The contributor shares the clock of the repository so that commit dates keep increasing.
+		contributor := &TestRepository{Repository: clone, Path: path, Counter: r.Counter}
+
+		_, err = contributor.AddCommit(commitType)
+		if err != nil {
+			return err
+		}
+
+		r.Counter = contributor.Counter
+
+		refSpec := fmt.Sprintf("refs/heads/%s:refs/heads/%s", branch, branch)
+
+		return push(clone, refSpec)
+	}
+}
+
+func push(repository *git.Repository, refSpecs ...string) error {
+	specs := make([]config.RefSpec, len(refSpecs))
+	for i, refSpec := range refSpecs {
+		specs[i] = config.RefSpec(refSpec)
+	}
+
+	err := repository.Push(&git.PushOptions{RemoteName: originName, RefSpecs: specs, Progress: io.Discard})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("pushing to origin: %w", err)
+	}
+
+	return nil
+}
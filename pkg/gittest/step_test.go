@@ -0,0 +1,132 @@
+package gittest
+
+import (
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestStep_New(t *testing.T) {
+	assert := assertion.New(t)
+
+	r, err := New(
+		Commit("feat"),
+		Tag("v0.1.0"),
+		Branch("rc"),
+		CommitFile("fix", "foo/foo.txt", "foo"),
+		Checkout("master"),
+		Origin(),
+		RemoteCommit("rc", "feat"),
+	)
+	checkErr(t, "building repository", err)
+
+	t.Cleanup(func() {
+		_ = r.Remove()
+	})
+
+	_, err = r.Tag("v0.1.0")
+	assert.NoError(err, "tag should exist")
+
+	head, err := r.Head()
+	checkErr(t, "fetching head", err)
+	assert.Equal(plumbing.NewBranchReferenceName("master"), head.Name(), "master should be checked out")
+
+	localRC, err := r.Reference(plumbing.NewBranchReferenceName("rc"), true)
+	checkErr(t, "fetching local rc branch", err)
+
+	originRC, err := r.Origin.Reference(plumbing.NewBranchReferenceName("rc"), true)
+	checkErr(t, "fetching origin rc branch", err)
+
+	assert.NotEqual(localRC.Hash(), originRC.Hash(), "remote commit should only be pushed to origin")
+
+	remoteCommit, err := r.Origin.CommitObject(originRC.Hash())
+	checkErr(t, "fetching remote commit", err)
+	assert.Equal([]plumbing.Hash{localRC.Hash()}, remoteCommit.ParentHashes)
+
+	err = r.Apply(Fetch())
+	checkErr(t, "fetching origin", err)
+
+	fetchedRC, err := r.Reference(plumbing.NewRemoteReferenceName("origin", "rc"), true)
+	checkErr(t, "fetching remote-tracking rc branch", err)
+	assert.Equal(originRC.Hash(), fetchedRC.Hash())
+
+	err = r.Apply(Tag("v0.2.0"), Push())
+	checkErr(t, "pushing tag", err)
+
+	_, err = r.Origin.Tag("v0.2.0")
+	assert.NoError(err, "tag should have been pushed to origin")
+}
+
+func TestStep_CommitMessage(t *testing.T) {
+	assert := assertion.New(t)
+
+	r, err := New(CommitMessage("feat: add foo\n\nRefs: #42"))
+	checkErr(t, "building repository", err)
+
+	t.Cleanup(func() {
+		_ = r.Remove()
+	})
+
+	head, err := r.Head()
+	checkErr(t, "fetching head", err)
+
+	commit, err := r.CommitObject(head.Hash())
+	checkErr(t, "fetching head commit", err)
+
+	assert.Equal("feat: add foo\n\nRefs: #42", commit.Message)
+}
+
+func TestStep_Merge(t *testing.T) {
+	assert := assertion.New(t)
+
+	r, err := New(
+		Commit("chore"),
+		Branch("feature"),
+		Commit("feat"),
+		Checkout("master"),
+		Merge("feature", "Merge branch 'feature'"),
+	)
+	checkErr(t, "building repository", err)
+
+	t.Cleanup(func() {
+		_ = r.Remove()
+	})
+
+	feature, err := r.Reference("refs/heads/feature", true)
+	checkErr(t, "fetching feature branch", err)
+
+	head, err := r.Head()
+	checkErr(t, "fetching head", err)
+
+	assert.Equal("refs/heads/master", head.Name().String(), "the current branch should be kept")
+
+	commit, err := r.CommitObject(head.Hash())
+	checkErr(t, "fetching head commit", err)
+
+	assert.Equal("Merge branch 'feature'", commit.Message)
+	assert.Len(commit.ParentHashes, 2)
+	assert.Equal(feature.Hash(), commit.ParentHashes[1])
+
+	worktree, err := r.Worktree()
+	checkErr(t, "fetching worktree", err)
+
+	status, err := worktree.Status()
+	checkErr(t, "fetching worktree status", err)
+
+	assert.True(status.IsClean(), "the worktree should match the merge commit")
+}
+
+func TestStep_NoOrigin(t *testing.T) {
+	assert := assertion.New(t)
+
+	_, err := New(Commit("feat"), Push())
+	assert.ErrorIs(err, ErrNoOrigin)
+}
+
+func checkErr(t *testing.T, msg string, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %s", msg, err)
+	}
+}
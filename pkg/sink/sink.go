@@ -0,0 +1,97 @@
+// Package sink provides the registry of the writers to which the outcome of every release is reported, such as the
+// GitHub Actions outputs.
+//
+// Writers are registered by name and enabled by listing their name in the "outputs" configuration, so that several of
+// them can be used in a single run. Programs embedding the release command can register their own writers:
+//
+//	type slackWriter struct{}
+//
+//	func (slackWriter) Name() string { return "slack" }
+//
+//	func (slackWriter) Write(release sink.Release, options sink.Options) error {
+//		// Post the release to a channel.
+//	}
+//
+//	func init() {
+//		sink.Register(slackWriter{})
+//	}
+package sink
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+var ErrUnknownWriter = errors.New("unknown output writer")
+
+var (
+	registry   = map[string]Writer{}
+	registryMu sync.RWMutex
+)
+
+func init() {
+	Register(GitHubWriter{})
+	Register(ChannelsWriter{})
+}
+
+// Release is the outcome of a release of a branch, or of a project of a monorepo, reported even when no new version
+// is released, Version then being the latest one.
+type Release struct {
+	Version     string
+	Branch      string
+	TagPrefix   string
+	Project     string
+	Environment string
+	NewRelease  bool
+}
+
+// Options configures where writers write.
+type Options struct {
+	// Dir is the directory in which the writers producing a file per release, such as channel files, write them.
+	Dir string
+}
+
+// Writer writes the outcome of releases to a destination, such as a file read by the following steps of a pipeline.
+type Writer interface {
+	Name() string
+	// Write reports a release, once per branch and project of a run.
+	Write(release Release, options Options) error
+}
+
+// Register makes a writer available by its name, replacing any writer previously registered under that name.
+func Register(writer Writer) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	registry[writer.Name()] = writer
+}
+
+// Get returns the writer registered under the given name.
+func Get(name string) (Writer, error) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	writer, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownWriter, name)
+	}
+
+	return writer, nil
+}
+
+// Names returns the sorted names of the registered writers.
+func Names() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
@@ -0,0 +1,90 @@
+package sink
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+type recordingWriter struct {
+	releases *[]Release
+}
+
+func (recordingWriter) Name() string {
+	return "recording"
+}
+
+func (w recordingWriter) Write(release Release, _ Options) error {
+	*w.releases = append(*w.releases, release)
+	return nil
+}
+
+func TestSink_Registry(t *testing.T) {
+	assert := assertion.New(t)
+
+	var releases []Release
+
+	Register(recordingWriter{releases: &releases})
+
+	t.Cleanup(func() {
+		registryMu.Lock()
+		delete(registry, "recording")
+		registryMu.Unlock()
+	})
+
+	assert.Equal([]string{"channels", "github", "recording"}, Names())
+
+	writer, err := Get("recording")
+	if err != nil {
+		t.Fatalf("getting writer: %s", err)
+	}
+
+	err = writer.Write(Release{Version: "1.2.3", Branch: "main", NewRelease: true}, Options{})
+	assert.NoError(err)
+	assert.Equal([]Release{{Version: "1.2.3", Branch: "main", NewRelease: true}}, releases)
+
+	_, err = Get("unknown")
+	assert.ErrorIs(err, ErrUnknownWriter)
+}
+
+func TestSink_GitHubWriter(t *testing.T) {
+	assert := assertion.New(t)
+
+	path := filepath.Join(t.TempDir(), "output")
+	t.Setenv("GITHUB_OUTPUT", path)
+
+	err := GitHubWriter{}.Write(Release{Version: "1.2.3", Branch: "main", TagPrefix: "v", Project: "foo", NewRelease: true}, Options{})
+	if err != nil {
+		t.Fatalf("writing output: %s", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading output: %s", err)
+	}
+
+	assert.Equal("\nMAIN_SEMVER=v1.2.3\nMAIN_NEW_RELEASE=true\nMAIN_PROJECT=foo\n", string(content))
+}
+
+func TestSink_ChannelsWriter(t *testing.T) {
+	assert := assertion.New(t)
+
+	dir := t.TempDir()
+
+	err := ChannelsWriter{}.Write(Release{Version: "1.2.3-rc.1", Branch: "release/rc", Project: "foo"}, Options{Dir: dir})
+	if err != nil {
+		t.Fatalf("writing output: %s", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "foo-release-rc"))
+	if err != nil {
+		t.Fatalf("reading channel file: %s", err)
+	}
+
+	assert.Equal("1.2.3-rc.1\n", string(content))
+
+	err = ChannelsWriter{}.Write(Release{Version: "1.2.3", Branch: "main"}, Options{})
+	assert.ErrorIs(err, ErrNoDir, "a channel file should not be written without a directory")
+}
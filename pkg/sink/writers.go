@@ -0,0 +1,49 @@
+package sink
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/s0ders/go-semver-release/v6/internal/ci"
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
+)
+
+var ErrNoDir = errors.New("no directory configured")
+
+// GitHubWriter appends the outcome of releases to the file given by the GITHUB_OUTPUT environment variable of GitHub
+// Actions runners, and does nothing when it is not set.
+type GitHubWriter struct{}
+
+func (GitHubWriter) Name() string {
+	return "github"
+}
+
+func (GitHubWriter) Write(release Release, _ Options) error {
+	version, err := semver.NewFromString(release.Version)
+	if err != nil {
+		return fmt.Errorf("parsing version: %w", err)
+	}
+
+	return ci.GenerateGitHubOutput(version, release.Branch, ci.WithNewRelease(release.NewRelease), ci.WithTagPrefix(release.TagPrefix), ci.WithProject(release.Project), ci.WithEnvironment(release.Environment))
+}
+
+// ChannelsWriter writes the latest version of every release channel to a file named after the channel inside the
+// directory given by the options.
+type ChannelsWriter struct{}
+
+func (ChannelsWriter) Name() string {
+	return "channels"
+}
+
+func (ChannelsWriter) Write(release Release, options Options) error {
+	if options.Dir == "" {
+		return ErrNoDir
+	}
+
+	version, err := semver.NewFromString(release.Version)
+	if err != nil {
+		return fmt.Errorf("parsing version: %w", err)
+	}
+
+	return ci.WriteChannelFile(options.Dir, version, release.Branch, release.Project)
+}